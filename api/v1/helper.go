@@ -28,11 +28,16 @@ import (
 
 const (
 	LASTNETWORKNAMESPACE        = "operator.sriovnetwork.openshift.io/last-network-namespace"
+	LASTNETWORKNAME             = "operator.sriovnetwork.openshift.io/last-network-name"
 	NETATTDEFFINALIZERNAME      = "netattdef.finalizers.sriovnetwork.openshift.io"
+	OwnerRefAnnotation          = "sriovnetwork.openshift.io/owner-ref"
 	POOLCONFIGFINALIZERNAME     = "poolconfig.finalizers.sriovnetwork.openshift.io"
 	OPERATORCONFIGFINALIZERNAME = "operatorconfig.finalizers.sriovnetwork.openshift.io"
 	ESwithModeLegacy            = "legacy"
 	ESwithModeSwitchDev         = "switchdev"
+	PfLinkStateAuto             = "auto"
+	PfLinkStateUp               = "up"
+	PfLinkStateDown             = "down"
 
 	SriovCniStateEnable  = "enable"
 	SriovCniStateDisable = "disable"
@@ -45,6 +50,8 @@ const (
 
 const invalidVfIndex = -1
 
+const mellanoxVendorID = "15b3"
+
 var ManifestsPath = "./bindata/manifests/cni-config"
 var log = logf.Log.WithName("sriovnetwork")
 
@@ -71,6 +78,20 @@ const (
 	SystemdConfigurationMode ConfigurationModeType = "systemd"
 )
 
+type LogFormatType string
+
+const (
+	LogFormatText LogFormatType = "text"
+	LogFormatJSON LogFormatType = "json"
+)
+
+type WebhookValidationModeType string
+
+const (
+	WebhookValidationModeEnforce WebhookValidationModeType = "enforce"
+	WebhookValidationModeWarn    WebhookValidationModeType = "warn"
+)
+
 func (e NetFilterType) String() string {
 	switch e {
 	case OpenstackNetworkID:
@@ -250,6 +271,20 @@ func GetEswitchModeFromSpec(ifaceSpec *Interface) string {
 	return ifaceSpec.EswitchMode
 }
 
+// ResolveRdmaMode returns the RDMA subsystem mode to configure on a node with the given interfaces,
+// the auto mode is resolved to exclusive if the node has Mellanox NICs and to shared otherwise
+func ResolveRdmaMode(rdmaMode string, ifaces InterfaceExts) string {
+	if rdmaMode != consts.RdmaSubsystemModeAuto {
+		return rdmaMode
+	}
+	for _, iface := range ifaces {
+		if iface.Vendor == mellanoxVendorID {
+			return consts.RdmaSubsystemModeExclusive
+		}
+	}
+	return consts.RdmaSubsystemModeShared
+}
+
 // GetEswitchModeFromStatus returns ESwitchMode from the interface status, returns legacy if not set
 func GetEswitchModeFromStatus(ifaceStatus *InterfaceExt) string {
 	if ifaceStatus.EswitchMode == "" {
@@ -258,6 +293,146 @@ func GetEswitchModeFromStatus(ifaceStatus *InterfaceExt) string {
 	return ifaceStatus.EswitchMode
 }
 
+// GetPfLinkStateFromSpec returns the desired PF admin link state from the interface spec, returns auto if not set
+func GetPfLinkStateFromSpec(ifaceSpec *Interface) string {
+	if ifaceSpec.PfLinkState == "" {
+		return PfLinkStateAuto
+	}
+	return ifaceSpec.PfLinkState
+}
+
+// NeedToUpdatePfLinkState returns true if the PF admin link state reported in the status doesn't match the spec
+func NeedToUpdatePfLinkState(ifaceSpec *Interface, ifaceStatus *InterfaceExt) bool {
+	if GetPfLinkStateFromSpec(ifaceSpec) == PfLinkStateDown {
+		return ifaceStatus.LinkAdminState == consts.LinkAdminStateUp
+	}
+	return ifaceStatus.LinkAdminState == consts.LinkAdminStateDown
+}
+
+// NeedToUpdateLinkSpeed returns true if the PF link speed reported in the status doesn't match the speed forced
+// in the spec, the speed of a PF without link is unknown and never reported as a mismatch
+func NeedToUpdateLinkSpeed(ifaceSpec *Interface, ifaceStatus *InterfaceExt) bool {
+	if ifaceSpec.LinkSpeedMbps == 0 {
+		return false
+	}
+	speed := GetLinkSpeedMbps(ifaceStatus)
+	if speed <= 0 {
+		return false
+	}
+	return speed != ifaceSpec.LinkSpeedMbps
+}
+
+// GetLinkSpeedMbps returns the PF link speed reported in the status in Mbps, 0 if the speed is unknown
+func GetLinkSpeedMbps(ifaceStatus *InterfaceExt) int {
+	speed, err := strconv.Atoi(strings.TrimSuffix(ifaceStatus.LinkSpeed, " Mb/s"))
+	if err != nil || speed <= 0 {
+		return 0
+	}
+	return speed
+}
+
+// ValidateVfTxRates returns an error if the minimum TX rate of the VF attributes is higher than the maximum one
+// or if a rate is higher than the link speed in Mbps, the link speed is not checked if it's 0
+func ValidateVfTxRates(attrs *VfAttributes, linkSpeedMbps int) error {
+	if attrs == nil {
+		return nil
+	}
+	if attrs.MinTxRate != nil && attrs.MaxTxRate != nil && *attrs.MaxTxRate > 0 && *attrs.MinTxRate > *attrs.MaxTxRate {
+		return fmt.Errorf("minTxRate(%d) is higher than maxTxRate(%d)", *attrs.MinTxRate, *attrs.MaxTxRate)
+	}
+	if linkSpeedMbps <= 0 {
+		return nil
+	}
+	if attrs.MaxTxRate != nil && *attrs.MaxTxRate > linkSpeedMbps {
+		return fmt.Errorf("maxTxRate(%d) is higher than the link speed of the PF(%d Mb/s)", *attrs.MaxTxRate, linkSpeedMbps)
+	}
+	if attrs.MinTxRate != nil && *attrs.MinTxRate > linkSpeedMbps {
+		return fmt.Errorf("minTxRate(%d) is higher than the link speed of the PF(%d Mb/s)", *attrs.MinTxRate, linkSpeedMbps)
+	}
+	return nil
+}
+
+// NeedToUpdateVlanFiltering returns true if the hardware VLAN filtering of the PF reported in the status doesn't
+// match the spec, a PF which doesn't report the VLAN filtering state is never reported as a mismatch
+func NeedToUpdateVlanFiltering(ifaceSpec *Interface, ifaceStatus *InterfaceExt) bool {
+	if ifaceSpec.VlanFiltering == nil || ifaceStatus.VlanFiltering == nil {
+		return false
+	}
+	return *ifaceSpec.VlanFiltering != *ifaceStatus.VlanFiltering
+}
+
+// NeedToUpdateVfNetdevs returns true if the promiscuous or the multicast mode of a VF netdev reported in the status
+// doesn't match its VF group, the promiscuous mode is only expected on the VFs in trust mode. The sysctls, RSS,
+// broadcast filter and MACsec configuration of the VF netdevs are not reported in the status and are applied
+// again with each configuration instead.
+func NeedToUpdateVfNetdevs(ifaceSpec *Interface, ifaceStatus *InterfaceExt) bool {
+	for _, vfStatus := range ifaceStatus.VFs {
+		// VFs bound to userspace drivers or moved to the network namespace of a pod have no name in the status
+		if vfStatus.Name == "" {
+			continue
+		}
+		for _, groupSpec := range ifaceSpec.VfGroups {
+			if !IndexInRange(vfStatus.VfID, groupSpec.VfRange) {
+				continue
+			}
+			if groupSpec.Promisc != nil {
+				trust, _ := desiredVfTrust(ifaceSpec, &groupSpec, vfStatus.VfID)
+				promisc := *groupSpec.Promisc && trust
+				if promisc != vfStatus.Promisc {
+					log.V(0).Info("NeedToUpdateVfNetdevs(): VF promiscuous mode needs update",
+						"vf", vfStatus.VfID, "desired", promisc, "current", vfStatus.Promisc)
+					return true
+				}
+			}
+			if groupSpec.VfFilters != nil && groupSpec.VfFilters.Multicast != nil &&
+				*groupSpec.VfFilters.Multicast != vfStatus.Multicast {
+				log.V(0).Info("NeedToUpdateVfNetdevs(): VF multicast mode needs update",
+					"vf", vfStatus.VfID, "desired", *groupSpec.VfFilters.Multicast, "current", vfStatus.Multicast)
+				return true
+			}
+			break
+		}
+	}
+	return false
+}
+
+// NeedToUpdateVfAttributes returns true if the VLAN or the trust mode of a VF reported in the status doesn't match
+// the attributes requested for its VF group, the VLAN is only managed on the VFs of externally managed PFs
+func NeedToUpdateVfAttributes(ifaceSpec *Interface, ifaceStatus *InterfaceExt) bool {
+	for _, vfStatus := range ifaceStatus.VFs {
+		for _, groupSpec := range ifaceSpec.VfGroups {
+			if !IndexInRange(vfStatus.VfID, groupSpec.VfRange) {
+				continue
+			}
+			if ifaceSpec.ExternallyManaged && groupSpec.VfAttributes != nil && groupSpec.VfAttributes.Vlan != vfStatus.Vlan {
+				log.V(0).Info("NeedToUpdateVfAttributes(): VF VLAN needs update",
+					"vf", vfStatus.VfID, "desired", groupSpec.VfAttributes.Vlan, "current", vfStatus.Vlan)
+				return true
+			}
+			if trust, managed := desiredVfTrust(ifaceSpec, &groupSpec, vfStatus.VfID); managed && trust != vfStatus.Trust {
+				log.V(0).Info("NeedToUpdateVfAttributes(): VF trust mode needs update",
+					"vf", vfStatus.VfID, "desired", trust, "current", vfStatus.Trust)
+				return true
+			}
+			break
+		}
+	}
+	return false
+}
+
+// desiredVfTrust returns the trust mode requested for the VF and false if the trust mode of the VF is not managed,
+// the per VF trust mode takes precedence over the trust mode of the VfAttributes which only apply to externally
+// managed PFs
+func desiredVfTrust(ifaceSpec *Interface, groupSpec *VfGroup, vfID int) (bool, bool) {
+	if trust, ok := groupSpec.VfTrust[strconv.Itoa(vfID)]; ok {
+		return trust, true
+	}
+	if ifaceSpec.ExternallyManaged && groupSpec.VfAttributes != nil {
+		return groupSpec.VfAttributes.Trust, true
+	}
+	return false, false
+}
+
 func NeedToUpdateSriov(ifaceSpec *Interface, ifaceStatus *InterfaceExt) bool {
 	if ifaceSpec.Mtu > 0 {
 		mtu := ifaceSpec.Mtu
@@ -272,13 +447,23 @@ func NeedToUpdateSriov(ifaceSpec *Interface, ifaceStatus *InterfaceExt) bool {
 		log.V(0).Info("NeedToUpdateSriov(): EswitchMode needs update", "desired", desiredEswitchMode, "current", currentEswitchMode)
 		return true
 	}
+	if ifaceSpec.EswitchInlineMode != "" && ifaceSpec.EswitchInlineMode != ifaceStatus.EswitchInlineMode {
+		log.V(0).Info("NeedToUpdateSriov(): EswitchInlineMode needs update",
+			"desired", ifaceSpec.EswitchInlineMode, "current", ifaceStatus.EswitchInlineMode)
+		return true
+	}
 	if ifaceSpec.NumVfs != ifaceStatus.NumVfs {
 		log.V(0).Info("NeedToUpdateSriov(): NumVfs needs update", "desired", ifaceSpec.NumVfs, "current", ifaceStatus.NumVfs)
 		return true
 	}
 
-	if ifaceStatus.LinkAdminState == consts.LinkAdminStateDown {
-		log.V(0).Info("NeedToUpdateSriov(): PF link status needs update", "desired to include", "up", "current", ifaceStatus.LinkAdminState)
+	if NeedToUpdatePfLinkState(ifaceSpec, ifaceStatus) {
+		log.V(0).Info("NeedToUpdateSriov(): PF link status needs update",
+			"desired", GetPfLinkStateFromSpec(ifaceSpec), "current", ifaceStatus.LinkAdminState)
+		return true
+	}
+
+	if NeedToUpdateVfAttributes(ifaceSpec, ifaceStatus) {
 		return true
 	}
 
@@ -291,7 +476,13 @@ func NeedToUpdateSriov(ifaceSpec *Interface, ifaceStatus *InterfaceExt) bool {
 							"desired", groupSpec.DeviceType)
 						return true
 					}
-					if groupSpec.DeviceType != "" && groupSpec.DeviceType != consts.DeviceTypeNetDevice {
+					if groupSpec.VfDriver != "" {
+						if groupSpec.VfDriver != vfStatus.Driver {
+							log.V(0).Info("NeedToUpdateSriov(): Driver needs update",
+								"desired", groupSpec.VfDriver, "current", vfStatus.Driver)
+							return true
+						}
+					} else if groupSpec.DeviceType != "" && groupSpec.DeviceType != consts.DeviceTypeNetDevice {
 						if groupSpec.DeviceType != vfStatus.Driver {
 							log.V(0).Info("NeedToUpdateSriov(): Driver needs update",
 								"desired", groupSpec.DeviceType, "current", vfStatus.Driver)
@@ -303,9 +494,20 @@ func NeedToUpdateSriov(ifaceSpec *Interface, ifaceStatus *InterfaceExt) bool {
 								"desired", groupSpec.DeviceType, "current", vfStatus.Driver)
 							return true
 						}
-						if vfStatus.Mtu != 0 && groupSpec.Mtu != 0 && vfStatus.Mtu != groupSpec.Mtu {
+						if vfStatus.Mtu != 0 && groupSpec.GetVfMtu() != 0 && vfStatus.Mtu != groupSpec.GetVfMtu() {
 							log.V(0).Info("NeedToUpdateSriov(): VF MTU needs update",
-								"vf", vfStatus.VfID, "desired", groupSpec.Mtu, "current", vfStatus.Mtu)
+								"vf", vfStatus.VfID, "desired", groupSpec.GetVfMtu(), "current", vfStatus.Mtu)
+							return true
+						}
+						// VFs moved to the network namespace of a pod have no name in the status
+						if vfStatus.Name != "" && ifaceSpec.VfNamePrefix != "" && vfStatus.Name != ifaceSpec.GetVfName(vfStatus.VfID) {
+							log.V(0).Info("NeedToUpdateSriov(): VF name needs update",
+								"vf", vfStatus.VfID, "desired", ifaceSpec.GetVfName(vfStatus.VfID), "current", vfStatus.Name)
+							return true
+						}
+						if vfStatus.NumQueues != 0 && groupSpec.NumVfQueues != 0 && vfStatus.NumQueues != groupSpec.NumVfQueues {
+							log.V(0).Info("NeedToUpdateSriov(): VF number of queues needs update",
+								"vf", vfStatus.VfID, "desired", groupSpec.NumVfQueues, "current", vfStatus.NumQueues)
 							return true
 						}
 
@@ -339,6 +541,30 @@ func NeedToUpdateSriov(ifaceSpec *Interface, ifaceStatus *InterfaceExt) bool {
 	return false
 }
 
+// NeedToUpdateSriovExceptInPlaceVfConfig returns true if the interface needs to be configured for another reason
+// than the MTU of the VFs of the groups requesting a VF MTU or the VLAN and trust mode of the VFs, which are set
+// on the existing VFs without reconfiguring the PF
+func NeedToUpdateSriovExceptInPlaceVfConfig(ifaceSpec *Interface, ifaceStatus *InterfaceExt) bool {
+	status := ifaceStatus.DeepCopy()
+	for i := range status.VFs {
+		for _, groupSpec := range ifaceSpec.VfGroups {
+			if IndexInRange(status.VFs[i].VfID, groupSpec.VfRange) {
+				if groupSpec.VfMtu > 0 && status.VFs[i].Mtu != 0 {
+					status.VFs[i].Mtu = groupSpec.VfMtu
+				}
+				if ifaceSpec.ExternallyManaged && groupSpec.VfAttributes != nil {
+					status.VFs[i].Vlan = groupSpec.VfAttributes.Vlan
+				}
+				if trust, managed := desiredVfTrust(ifaceSpec, &groupSpec, status.VFs[i].VfID); managed {
+					status.VFs[i].Trust = trust
+				}
+				break
+			}
+		}
+	}
+	return NeedToUpdateSriov(ifaceSpec, status)
+}
+
 type ByPriority []SriovNetworkNodePolicy
 
 func (a ByPriority) Len() int {
@@ -376,6 +602,20 @@ func StringInArray(val string, array []string) bool {
 	return false
 }
 
+// StringMatchInArray returns true if val is equal to one of the entries in array
+// or matches one of them as a shell-style glob pattern (see filepath.Match).
+func StringMatchInArray(val string, array []string) bool {
+	for i := range array {
+		if array[i] == val {
+			return true
+		}
+		if matched, err := filepath.Match(array[i], val); err == nil && matched {
+			return true
+		}
+	}
+	return false
+}
+
 func RemoveString(s string, slice []string) (result []string, found bool) {
 	if len(slice) != 0 {
 		for _, item := range slice {
@@ -409,15 +649,23 @@ func (p *SriovNetworkNodePolicy) Apply(state *SriovNetworkNodeState, equalPriori
 		if s.Selected(&iface) {
 			log.Info("Update interface", "name:", iface.Name)
 			result := Interface{
-				PciAddress:        iface.PciAddress,
-				Mtu:               p.Spec.Mtu,
-				Name:              iface.Name,
-				LinkType:          p.Spec.LinkType,
-				EswitchMode:       p.Spec.EswitchMode,
-				NumVfs:            p.Spec.NumVfs,
-				ExternallyManaged: p.Spec.ExternallyManaged,
+				PciAddress:                  iface.PciAddress,
+				Mtu:                         p.Spec.Mtu,
+				Name:                        iface.Name,
+				LinkType:                    p.Spec.LinkType,
+				EswitchMode:                 p.Spec.EswitchMode,
+				EswitchInlineMode:           p.Spec.EswitchInlineMode,
+				NumVfs:                      p.GetNumVfs(&iface),
+				ExternallyManaged:           p.Spec.ExternallyManaged,
+				PfLinkState:                 p.Spec.PfLinkState,
+				LinkAutoNeg:                 p.Spec.LinkAutoNeg,
+				LinkSpeedMbps:               p.Spec.LinkSpeedMbps,
+				VlanFiltering:               p.Spec.VlanFiltering,
+				AllowPrimaryInterface:       p.Spec.AllowPrimaryInterface,
+				KeepDefaultRepresentorNames: p.Spec.KeepDefaultRepresentorNames,
+				VfNamePrefix:                p.Spec.VfNamePrefix,
 			}
-			if p.Spec.NumVfs > 0 {
+			if result.NumVfs > 0 {
 				group, err := p.generatePfNameVfGroup(&iface)
 				if err != nil {
 					return err
@@ -441,6 +689,15 @@ func (p *SriovNetworkNodePolicy) Apply(state *SriovNetworkNodeState, equalPriori
 	return nil
 }
 
+// GetNumVfs returns the number of VFs the policy requests for the PF.
+// When MaxNumVfs is set the number is clamped to the VFs supported by the PF.
+func (p *SriovNetworkNodePolicy) GetNumVfs(iface *InterfaceExt) int {
+	if p.Spec.MaxNumVfs > 0 {
+		return min(p.Spec.MaxNumVfs, iface.TotalVfs)
+	}
+	return p.Spec.NumVfs
+}
+
 // ApplyBridgeConfig applies bridge configuration from the policy to the provided state
 func (p *SriovNetworkNodePolicy) ApplyBridgeConfig(state *SriovNetworkNodeState) error {
 	if p.Spec.NicSelector.IsEmpty() {
@@ -482,8 +739,11 @@ func (p *SriovNetworkNodePolicy) ApplyBridgeConfig(state *SriovNetworkNodeState)
 					PciAddress: iface.PciAddress,
 					Name:       iface.Name,
 					Interface:  p.Spec.Bridge.OVS.Uplink.Interface,
+					Port:       *p.Spec.Bridge.OVS.Uplink.Port.DeepCopy(),
 				}},
 			}
+			// OVS reports trunks as a set, keep them sorted to compare them with the current state
+			slices.Sort(ovsBridge.Uplinks[0].Port.Trunks)
 			if p.Spec.Mtu > 0 {
 				mtu := p.Spec.Mtu
 				ovsBridge.Uplinks[0].Interface.MTURequest = &mtu
@@ -533,6 +793,40 @@ func (iface Interface) mergeConfigs(input *Interface, equalPriority bool) {
 	if input.NumVfs < iface.NumVfs {
 		input.NumVfs = iface.NumVfs
 	}
+	if input.PfLinkState == "" {
+		input.PfLinkState = iface.PfLinkState
+	}
+	if input.LinkAutoNeg == nil {
+		input.LinkAutoNeg = iface.LinkAutoNeg
+	}
+	if input.LinkSpeedMbps == 0 {
+		input.LinkSpeedMbps = iface.LinkSpeedMbps
+	}
+	if input.VlanFiltering == nil {
+		input.VlanFiltering = iface.VlanFiltering
+	}
+	input.AllowPrimaryInterface = input.AllowPrimaryInterface || iface.AllowPrimaryInterface
+	input.KeepDefaultRepresentorNames = input.KeepDefaultRepresentorNames || iface.KeepDefaultRepresentorNames
+	if input.VfNamePrefix == "" {
+		input.VfNamePrefix = iface.VfNamePrefix
+	}
+}
+
+// GetVfName returns the name given to the VF netdev by the VfNamePrefix of the interface,
+// an empty name is returned if the interface doesn't request a VF name prefix
+func (iface Interface) GetVfName(vfID int) string {
+	if iface.VfNamePrefix == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s%d", iface.VfNamePrefix, vfID)
+}
+
+// GetVfMtu returns the MTU of the VF netdevs of the group, VfMtu takes precedence over the MTU shared with the PF
+func (gr VfGroup) GetVfMtu() int {
+	if gr.VfMtu > 0 {
+		return gr.VfMtu
+	}
+	return gr.Mtu
 }
 
 func (gr VfGroup) isVFRangeOverlapping(group VfGroup) bool {
@@ -555,6 +849,7 @@ func (p *SriovNetworkNodePolicy) generatePfNameVfGroup(iface *InterfaceExt) (*Vf
 	var err error
 	pfName := ""
 	var rngStart, rngEnd int
+	numVfs := p.GetNumVfs(iface)
 	found := false
 	for _, selector := range p.Spec.NicSelector.PfNames {
 		pfName, rngStart, rngEnd, err = ParseVfRange(selector)
@@ -562,17 +857,17 @@ func (p *SriovNetworkNodePolicy) generatePfNameVfGroup(iface *InterfaceExt) (*Vf
 			log.Error(err, "Unable to parse PF Name.")
 			return nil, err
 		}
-		if pfName == iface.Name {
+		if StringMatchInArray(iface.Name, []string{pfName}) {
 			found = true
 			if rngStart == invalidVfIndex && rngEnd == invalidVfIndex {
-				rngStart, rngEnd = 0, p.Spec.NumVfs-1
+				rngStart, rngEnd = 0, numVfs-1
 			}
 			break
 		}
 	}
 	if !found {
 		// assign the default vf index range if the pfName is not specified by the nicSelector
-		rngStart, rngEnd = 0, p.Spec.NumVfs-1
+		rngStart, rngEnd = 0, numVfs-1
 	}
 	rng := strconv.Itoa(rngStart) + "-" + strconv.Itoa(rngEnd)
 	return &VfGroup{
@@ -581,8 +876,18 @@ func (p *SriovNetworkNodePolicy) generatePfNameVfGroup(iface *InterfaceExt) (*Vf
 		VfRange:      rng,
 		PolicyName:   p.GetName(),
 		Mtu:          p.Spec.Mtu,
+		VfMtu:        p.Spec.VfMtu,
 		IsRdma:       p.Spec.IsRdma,
 		VdpaType:     p.Spec.VdpaType,
+		NumVfQueues:  p.Spec.NumVfQueues,
+		VfDriver:     p.Spec.VfDriver,
+		VfAttributes: p.Spec.VfAttributes,
+		VfSysctls:    p.Spec.VfSysctls,
+		VfTrust:      p.Spec.VfTrust,
+		Promisc:      p.Spec.Promisc,
+		Macsec:       p.Spec.Macsec,
+		VfRss:        p.Spec.VfRss,
+		VfFilters:    p.Spec.VfFilters,
 	}, nil
 }
 
@@ -651,7 +956,7 @@ func (selector *SriovNetworkNicSelector) Selected(iface *InterfaceExt) bool {
 	if selector.DeviceID != "" && selector.DeviceID != iface.DeviceID {
 		return false
 	}
-	if len(selector.RootDevices) > 0 && !StringInArray(iface.PciAddress, selector.RootDevices) {
+	if len(selector.RootDevices) > 0 && !StringMatchInArray(iface.PciAddress, selector.RootDevices) {
 		return false
 	}
 	if len(selector.PfNames) > 0 {
@@ -664,7 +969,7 @@ func (selector *SriovNetworkNicSelector) Selected(iface *InterfaceExt) bool {
 				pfNames = append(pfNames, p)
 			}
 		}
-		if !StringInArray(iface.Name, pfNames) {
+		if !StringMatchInArray(iface.Name, pfNames) {
 			return false
 		}
 	}
@@ -701,6 +1006,9 @@ func (cr *SriovIBNetwork) RenderNetAttDef() (*uns.Unstructured, error) {
 	// render RawCNIConfig manifests
 	data := render.MakeRenderData()
 	data.Data["CniType"] = "ib-sriov"
+	if cr.Spec.CniType != "" {
+		data.Data["CniType"] = cr.Spec.CniType
+	}
 	data.Data["SriovNetworkName"] = cr.Name
 	if cr.Spec.NetworkNamespace == "" {
 		data.Data["SriovNetworkNamespace"] = cr.Namespace
@@ -709,17 +1017,7 @@ func (cr *SriovIBNetwork) RenderNetAttDef() (*uns.Unstructured, error) {
 	}
 	data.Data["SriovCniResourceName"] = os.Getenv("RESOURCE_PREFIX") + "/" + cr.Spec.ResourceName
 
-	data.Data["StateConfigured"] = true
-	switch cr.Spec.LinkState {
-	case SriovCniStateEnable:
-		data.Data["SriovCniState"] = SriovCniStateEnable
-	case SriovCniStateDisable:
-		data.Data["SriovCniState"] = SriovCniStateDisable
-	case SriovCniStateAuto:
-		data.Data["SriovCniState"] = SriovCniStateAuto
-	default:
-		data.Data["StateConfigured"] = false
-	}
+	data.Data["SriovCniState"], data.Data["StateConfigured"] = getLinkState(cr.Spec.LinkState)
 
 	if cr.Spec.Capabilities == "" {
 		data.Data["CapabilitiesConfigured"] = false
@@ -744,6 +1042,7 @@ func (cr *SriovIBNetwork) RenderNetAttDef() (*uns.Unstructured, error) {
 	// logLevel and logFile are currently not supports by the ip-sriov-cni -> hardcode them to false.
 	data.Data["LogLevelConfigured"] = false
 	data.Data["LogFileConfigured"] = false
+	data.Data["PreferredPfConfigured"] = false
 
 	objs, err := render.RenderDir(filepath.Join(ManifestsPath, "sriov"), &data)
 	if err != nil {
@@ -761,6 +1060,21 @@ func (cr *SriovIBNetwork) NetworkNamespace() string {
 	return cr.Spec.NetworkNamespace
 }
 
+// NetAttDefName returns the name of the net-att-def generated for the network
+func (cr *SriovIBNetwork) NetAttDefName() string {
+	return cr.Name
+}
+
+// NetAttDefLabels returns the labels requested for the net-att-def generated for the network
+func (cr *SriovIBNetwork) NetAttDefLabels() map[string]string {
+	return cr.Spec.NadLabels
+}
+
+// NetAttDefAnnotations returns the annotations requested for the net-att-def generated for the network
+func (cr *SriovIBNetwork) NetAttDefAnnotations() map[string]string {
+	return cr.Spec.NadAnnotations
+}
+
 // RenderNetAttDef renders a net-att-def for sriov CNI
 func (cr *SriovNetwork) RenderNetAttDef() (*uns.Unstructured, error) {
 	logger := log.WithName("RenderNetAttDef")
@@ -769,16 +1083,23 @@ func (cr *SriovNetwork) RenderNetAttDef() (*uns.Unstructured, error) {
 	// render RawCNIConfig manifests
 	data := render.MakeRenderData()
 	data.Data["CniType"] = "sriov"
-	data.Data["SriovNetworkName"] = cr.Name
+	if cr.Spec.CniType != "" {
+		data.Data["CniType"] = cr.Spec.CniType
+	}
+	data.Data["SriovNetworkName"] = cr.NetAttDefName()
 	if cr.Spec.NetworkNamespace == "" {
 		data.Data["SriovNetworkNamespace"] = cr.Namespace
 	} else {
 		data.Data["SriovNetworkNamespace"] = cr.Spec.NetworkNamespace
 	}
 	data.Data["SriovCniResourceName"] = os.Getenv("RESOURCE_PREFIX") + "/" + cr.Spec.ResourceName
+	// vlan 0 is only rendered when explicitly requested, so the CNI can tell
+	// a VLAN to clear from a VLAN that is not configured
+	vlanConfigured := cr.Spec.Vlan != 0 || cr.Spec.ClearVlan
+	data.Data["VlanConfigured"] = vlanConfigured
 	data.Data["SriovCniVlan"] = cr.Spec.Vlan
 
-	if cr.Spec.VlanQoS <= 7 && cr.Spec.VlanQoS >= 0 {
+	if vlanConfigured && cr.Spec.VlanQoS <= 7 && cr.Spec.VlanQoS >= 0 {
 		data.Data["VlanQoSConfigured"] = true
 		data.Data["SriovCniVlanQoS"] = cr.Spec.VlanQoS
 	} else {
@@ -818,17 +1139,7 @@ func (cr *SriovNetwork) RenderNetAttDef() (*uns.Unstructured, error) {
 		data.Data["TrustConfigured"] = false
 	}
 
-	data.Data["StateConfigured"] = true
-	switch cr.Spec.LinkState {
-	case SriovCniStateEnable:
-		data.Data["SriovCniState"] = SriovCniStateEnable
-	case SriovCniStateDisable:
-		data.Data["SriovCniState"] = SriovCniStateDisable
-	case SriovCniStateAuto:
-		data.Data["SriovCniState"] = SriovCniStateAuto
-	default:
-		data.Data["StateConfigured"] = false
-	}
+	data.Data["SriovCniState"], data.Data["StateConfigured"] = getLinkState(cr.Spec.LinkState)
 
 	data.Data["MinTxRateConfigured"] = false
 	if cr.Spec.MinTxRate != nil {
@@ -863,6 +1174,9 @@ func (cr *SriovNetwork) RenderNetAttDef() (*uns.Unstructured, error) {
 	data.Data["LogFileConfigured"] = (cr.Spec.LogFile != "")
 	data.Data["LogFile"] = cr.Spec.LogFile
 
+	data.Data["PreferredPfConfigured"] = (cr.Spec.PreferredPf != "")
+	data.Data["PreferredPf"] = cr.Spec.PreferredPf
+
 	objs, err := render.RenderDir(filepath.Join(ManifestsPath, "sriov"), &data)
 	if err != nil {
 		return nil, err
@@ -879,6 +1193,29 @@ func (cr *SriovNetwork) NetworkNamespace() string {
 	return cr.Spec.NetworkNamespace
 }
 
+// NetAttDefName returns the name of the net-att-def generated for the network
+func (cr *SriovNetwork) NetAttDefName() string {
+	if cr.Spec.NetworkName != "" {
+		return cr.Spec.NetworkName
+	}
+	return cr.Name
+}
+
+// NetAttDefLabels returns the labels requested for the net-att-def generated for the network
+func (cr *SriovNetwork) NetAttDefLabels() map[string]string {
+	return cr.Spec.NadLabels
+}
+
+// NetAttDefAnnotations returns the annotations requested for the net-att-def generated for the network
+func (cr *SriovNetwork) NetAttDefAnnotations() map[string]string {
+	return cr.Spec.NadAnnotations
+}
+
+// StatusConditions returns the conditions reporting the state of the net-att-def generated for the network
+func (cr *SriovNetwork) StatusConditions() *[]metav1.Condition {
+	return &cr.Status.Conditions
+}
+
 // RenderNetAttDef renders a net-att-def for sriov CNI
 func (cr *OVSNetwork) RenderNetAttDef() (*uns.Unstructured, error) {
 	logger := log.WithName("RenderNetAttDef")
@@ -887,6 +1224,9 @@ func (cr *OVSNetwork) RenderNetAttDef() (*uns.Unstructured, error) {
 	// render RawCNIConfig manifests
 	data := render.MakeRenderData()
 	data.Data["CniType"] = "ovs"
+	if cr.Spec.CniType != "" {
+		data.Data["CniType"] = cr.Spec.CniType
+	}
 	data.Data["NetworkName"] = cr.Name
 	if cr.Spec.NetworkNamespace == "" {
 		data.Data["NetworkNamespace"] = cr.Namespace
@@ -912,6 +1252,7 @@ func (cr *OVSNetwork) RenderNetAttDef() (*uns.Unstructured, error) {
 		data.Data["Trunk"] = ""
 	}
 	data.Data["InterfaceType"] = cr.Spec.InterfaceType
+	data.Data["LinkState"], _ = getLinkState(cr.Spec.LinkState)
 
 	if cr.Spec.IPAM != "" {
 		data.Data["CniIpam"] = SriovCniIpam + ":" + strings.Join(strings.Fields(cr.Spec.IPAM), "")
@@ -936,11 +1277,37 @@ func (cr *OVSNetwork) RenderNetAttDef() (*uns.Unstructured, error) {
 	return objs[0], nil
 }
 
+// getLinkState returns the CNI link state for the provided value
+// and whether it is one of the supported states (enable|disable|auto)
+func getLinkState(linkState string) (string, bool) {
+	switch linkState {
+	case SriovCniStateEnable, SriovCniStateDisable, SriovCniStateAuto:
+		return linkState, true
+	default:
+		return "", false
+	}
+}
+
 // NetworkNamespace returns target network namespace for the network
 func (cr *OVSNetwork) NetworkNamespace() string {
 	return cr.Spec.NetworkNamespace
 }
 
+// NetAttDefName returns the name of the net-att-def generated for the network
+func (cr *OVSNetwork) NetAttDefName() string {
+	return cr.Name
+}
+
+// NetAttDefLabels returns the labels requested for the net-att-def generated for the network
+func (cr *OVSNetwork) NetAttDefLabels() map[string]string {
+	return cr.Spec.NadLabels
+}
+
+// NetAttDefAnnotations returns the annotations requested for the net-att-def generated for the network
+func (cr *OVSNetwork) NetAttDefAnnotations() map[string]string {
+	return cr.Spec.NadAnnotations
+}
+
 // NetFilterMatch -- parse netFilter and check for a match
 func NetFilterMatch(netFilter string, netValue string) (isMatch bool) {
 	logger := log.WithName("NetFilterMatch")
@@ -1011,6 +1378,16 @@ func NeedToUpdateBridges(bridgeSpec, bridgeStatus *Bridges) bool {
 	return !reflect.DeepEqual(bridgeSpec, bridgeStatus)
 }
 
+// GetDrainStartTime returns the time the node state was moved to the Draining state.
+// Return zero time instant if annotation is not found on the object or if it has a wrong format.
+func (s *SriovNetworkNodeState) GetDrainStartTime() time.Time {
+	t, err := time.Parse(time.RFC3339, s.GetAnnotations()[consts.NodeStateDrainStartTimeAnnotation])
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
+
 // SetKeepUntilTime sets an annotation to hold the "keep until time" for the node’s state.
 // The "keep until time" specifies the earliest time at which the state object can be removed
 // if the daemon's pod is not found on the node.
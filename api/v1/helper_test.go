@@ -155,6 +155,27 @@ func TestRendering(t *testing.T) {
 				},
 			},
 		},
+		{
+			tname: "vlan",
+			network: v1.SriovNetwork{
+				Spec: v1.SriovNetworkSpec{
+					NetworkNamespace: "testnamespace",
+					ResourceName:     "testresource",
+					Vlan:             100,
+					VlanQoS:          2,
+				},
+			},
+		},
+		{
+			tname: "clearvlan",
+			network: v1.SriovNetwork{
+				Spec: v1.SriovNetworkSpec{
+					NetworkNamespace: "testnamespace",
+					ResourceName:     "testresource",
+					ClearVlan:        true,
+				},
+			},
+		},
 		{
 			tname: "chained",
 			network: v1.SriovNetwork{
@@ -310,6 +331,19 @@ func TestOVSRendering(t *testing.T) {
 				},
 			},
 		},
+		{
+			tname: "linkstate",
+			network: v1.OVSNetwork{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test",
+				},
+				Spec: v1.OVSNetworkSpec{
+					NetworkNamespace: "testnamespace",
+					ResourceName:     "testresource",
+					LinkState:        "disable",
+				},
+			},
+		},
 	}
 	for _, tc := range testtable {
 		t.Run(tc.tname, func(t *testing.T) {
@@ -762,6 +796,85 @@ func TestSriovNetworkNodePolicyApply(t *testing.T) {
 			equalP:             false,
 			expectedInterfaces: nil,
 		},
+		{
+			tname:        "maxNumVfs exceeds TotalVfs",
+			currentState: newNodeState(),
+			policy: func() *v1.SriovNetworkNodePolicy {
+				p := newNodePolicy()
+				p.Spec.NumVfs = 0
+				p.Spec.MaxNumVfs = 128
+				return p
+			}(),
+			equalP: false,
+			expectedInterfaces: []v1.Interface{
+				{
+					Name:       "ens803f1",
+					NumVfs:     64,
+					PciAddress: "0000:86:00.1",
+					VfGroups: []v1.VfGroup{
+						{
+							DeviceType:   consts.DeviceTypeNetDevice,
+							ResourceName: "p1res",
+							VfRange:      "0-63",
+							PolicyName:   "p1",
+						},
+					},
+				},
+			},
+		},
+		{
+			tname:        "maxNumVfs lower than TotalVfs",
+			currentState: newNodeState(),
+			policy: func() *v1.SriovNetworkNodePolicy {
+				p := newNodePolicy()
+				p.Spec.NumVfs = 0
+				p.Spec.MaxNumVfs = 8
+				return p
+			}(),
+			equalP: false,
+			expectedInterfaces: []v1.Interface{
+				{
+					Name:       "ens803f1",
+					NumVfs:     8,
+					PciAddress: "0000:86:00.1",
+					VfGroups: []v1.VfGroup{
+						{
+							DeviceType:   consts.DeviceTypeNetDevice,
+							ResourceName: "p1res",
+							VfRange:      "0-7",
+							PolicyName:   "p1",
+						},
+					},
+				},
+			},
+		},
+		{
+			tname:        "explicit VF driver",
+			currentState: newNodeState(),
+			policy: func() *v1.SriovNetworkNodePolicy {
+				p := newNodePolicy()
+				p.Spec.DeviceType = consts.DeviceTypeVfioPci
+				p.Spec.VfDriver = "igb_uio"
+				return p
+			}(),
+			equalP: false,
+			expectedInterfaces: []v1.Interface{
+				{
+					Name:       "ens803f1",
+					NumVfs:     2,
+					PciAddress: "0000:86:00.1",
+					VfGroups: []v1.VfGroup{
+						{
+							DeviceType:   consts.DeviceTypeVfioPci,
+							VfDriver:     "igb_uio",
+							ResourceName: "p1res",
+							VfRange:      "0-1",
+							PolicyName:   "p1",
+						},
+					},
+				},
+			},
+		},
 		{
 			tname:        "bad pf partition",
 			currentState: newNodeState(),
@@ -929,6 +1042,72 @@ func TestGetEswitchModeFromSpec(t *testing.T) {
 	}
 }
 
+func TestSriovNetworkNicSelectorSelected(t *testing.T) {
+	ifaces := []v1.InterfaceExt{
+		{Name: "eth0", PciAddress: "0000:16:00.0"},
+		{Name: "eth1", PciAddress: "0000:16:00.1"},
+		{Name: "ens803f0", PciAddress: "0000:86:00.0"},
+		{Name: "ens803f1", PciAddress: "0000:86:00.1"},
+	}
+	testtable := []struct {
+		tname          string
+		selector       v1.SriovNetworkNicSelector
+		expectedResult []string
+	}{
+		{
+			tname:          "exact root device",
+			selector:       v1.SriovNetworkNicSelector{RootDevices: []string{"0000:86:00.1"}},
+			expectedResult: []string{"ens803f1"},
+		},
+		{
+			tname:          "root device glob",
+			selector:       v1.SriovNetworkNicSelector{RootDevices: []string{"0000:16:*"}},
+			expectedResult: []string{"eth0", "eth1"},
+		},
+		{
+			tname:          "exact pf name",
+			selector:       v1.SriovNetworkNicSelector{PfNames: []string{"ens803f0"}},
+			expectedResult: []string{"ens803f0"},
+		},
+		{
+			tname:          "pf name glob",
+			selector:       v1.SriovNetworkNicSelector{PfNames: []string{"eth*"}},
+			expectedResult: []string{"eth0", "eth1"},
+		},
+		{
+			tname:          "pf name glob with vf range",
+			selector:       v1.SriovNetworkNicSelector{PfNames: []string{"ens803f*#0-3"}},
+			expectedResult: []string{"ens803f0", "ens803f1"},
+		},
+		{
+			tname: "pf name and root device globs",
+			selector: v1.SriovNetworkNicSelector{
+				PfNames:     []string{"eth*"},
+				RootDevices: []string{"0000:16:*.1"},
+			},
+			expectedResult: []string{"eth1"},
+		},
+		{
+			tname:          "invalid glob falls back to exact match",
+			selector:       v1.SriovNetworkNicSelector{PfNames: []string{"eth["}},
+			expectedResult: nil,
+		},
+	}
+	for _, tc := range testtable {
+		t.Run(tc.tname, func(t *testing.T) {
+			var result []string
+			for i := range ifaces {
+				if tc.selector.Selected(&ifaces[i]) {
+					result = append(result, ifaces[i].Name)
+				}
+			}
+			if diff := cmp.Diff(tc.expectedResult, result); diff != "" {
+				t.Errorf("unexpected result (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestGetEswitchModeFromStatus(t *testing.T) {
 	testtable := []struct {
 		tname          string
@@ -1113,6 +1292,71 @@ func TestNeedToUpdateSriov(t *testing.T) {
 			},
 			want: false,
 		},
+		{
+			name: "VF name doesn't match the VF name prefix",
+			args: args{
+				ifaceSpec: &v1.Interface{
+					NumVfs:       2,
+					VfNamePrefix: "sriov",
+					VfGroups:     []v1.VfGroup{{VfRange: "0-1", DeviceType: consts.DeviceTypeNetDevice}},
+				},
+				ifaceStatus: &v1.InterfaceExt{
+					NumVfs: 2,
+					VFs: []v1.VirtualFunction{
+						{VfID: 0, Driver: "iavf", Name: "sriov0"},
+						{VfID: 1, Driver: "iavf", Name: "ens803f1v1"},
+					},
+				},
+			},
+			want: true,
+		},
+		{
+			name: "VF names match the VF name prefix",
+			args: args{
+				ifaceSpec: &v1.Interface{
+					NumVfs:       2,
+					VfNamePrefix: "sriov",
+					VfGroups:     []v1.VfGroup{{VfRange: "0-1", DeviceType: consts.DeviceTypeNetDevice}},
+				},
+				ifaceStatus: &v1.InterfaceExt{
+					NumVfs: 2,
+					VFs: []v1.VirtualFunction{
+						{VfID: 0, Driver: "iavf", Name: "sriov0"},
+						// the VF is in the network namespace of a pod
+						{VfID: 1, Driver: "iavf"},
+					},
+				},
+			},
+			want: false,
+		},
+		{
+			name: "VF not bound to the explicit VF driver",
+			args: args{
+				ifaceSpec: &v1.Interface{
+					NumVfs:   1,
+					VfGroups: []v1.VfGroup{{VfRange: "0-0", DeviceType: consts.DeviceTypeVfioPci, VfDriver: "igb_uio"}},
+				},
+				ifaceStatus: &v1.InterfaceExt{
+					NumVfs: 1,
+					VFs:    []v1.VirtualFunction{{VfID: 0, Driver: "vfio-pci"}},
+				},
+			},
+			want: true,
+		},
+		{
+			name: "VF bound to the explicit VF driver",
+			args: args{
+				ifaceSpec: &v1.Interface{
+					NumVfs:   1,
+					VfGroups: []v1.VfGroup{{VfRange: "0-0", DeviceType: consts.DeviceTypeVfioPci, VfDriver: "igb_uio"}},
+				},
+				ifaceStatus: &v1.InterfaceExt{
+					NumVfs: 1,
+					VFs:    []v1.VirtualFunction{{VfID: 0, Driver: "igb_uio"}},
+				},
+			},
+			want: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -1123,6 +1367,132 @@ func TestNeedToUpdateSriov(t *testing.T) {
 	}
 }
 
+func TestNeedToUpdateVfAttributes(t *testing.T) {
+	tests := []struct {
+		name        string
+		ifaceSpec   *v1.Interface
+		ifaceStatus *v1.InterfaceExt
+		want        bool
+	}{
+		{
+			name: "VLAN and trust mode match",
+			ifaceSpec: &v1.Interface{NumVfs: 1, ExternallyManaged: true, VfGroups: []v1.VfGroup{
+				{VfRange: "0-0", VfAttributes: &v1.VfAttributes{Vlan: 100, Trust: true}},
+			}},
+			ifaceStatus: &v1.InterfaceExt{NumVfs: 1, VFs: []v1.VirtualFunction{
+				{VfID: 0, Vlan: 100, Trust: true},
+			}},
+			want: false,
+		},
+		{
+			name: "VLAN drifted",
+			ifaceSpec: &v1.Interface{NumVfs: 1, ExternallyManaged: true, VfGroups: []v1.VfGroup{
+				{VfRange: "0-0", VfAttributes: &v1.VfAttributes{Vlan: 100}},
+			}},
+			ifaceStatus: &v1.InterfaceExt{NumVfs: 1, VFs: []v1.VirtualFunction{
+				{VfID: 0, Vlan: 200},
+			}},
+			want: true,
+		},
+		{
+			name: "VLAN ignored on PF not externally managed",
+			ifaceSpec: &v1.Interface{NumVfs: 1, VfGroups: []v1.VfGroup{
+				{VfRange: "0-0", VfAttributes: &v1.VfAttributes{Vlan: 100}},
+			}},
+			ifaceStatus: &v1.InterfaceExt{NumVfs: 1, VFs: []v1.VirtualFunction{
+				{VfID: 0},
+			}},
+			want: false,
+		},
+		{
+			name: "per VF trust mode drifted",
+			ifaceSpec: &v1.Interface{NumVfs: 2, VfGroups: []v1.VfGroup{
+				{VfRange: "0-1", VfTrust: map[string]bool{"1": true}},
+			}},
+			ifaceStatus: &v1.InterfaceExt{NumVfs: 2, VFs: []v1.VirtualFunction{
+				{VfID: 0},
+				{VfID: 1},
+			}},
+			want: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := v1.NeedToUpdateVfAttributes(tt.ifaceSpec, tt.ifaceStatus); got != tt.want {
+				t.Errorf("NeedToUpdateVfAttributes() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNeedToUpdateVfNetdevs(t *testing.T) {
+	enabled, disabled := true, false
+	tests := []struct {
+		name        string
+		ifaceSpec   *v1.Interface
+		ifaceStatus *v1.InterfaceExt
+		want        bool
+	}{
+		{
+			name: "promiscuous mode matches",
+			ifaceSpec: &v1.Interface{NumVfs: 1, VfGroups: []v1.VfGroup{
+				{VfRange: "0-0", Promisc: &enabled, VfTrust: map[string]bool{"0": true}},
+			}},
+			ifaceStatus: &v1.InterfaceExt{NumVfs: 1, VFs: []v1.VirtualFunction{
+				{VfID: 0, Name: "eth0v0", Promisc: true},
+			}},
+			want: false,
+		},
+		{
+			name: "promiscuous mode drifted",
+			ifaceSpec: &v1.Interface{NumVfs: 1, VfGroups: []v1.VfGroup{
+				{VfRange: "0-0", Promisc: &enabled, VfTrust: map[string]bool{"0": true}},
+			}},
+			ifaceStatus: &v1.InterfaceExt{NumVfs: 1, VFs: []v1.VirtualFunction{
+				{VfID: 0, Name: "eth0v0"},
+			}},
+			want: true,
+		},
+		{
+			name: "promiscuous mode not expected on untrusted VF",
+			ifaceSpec: &v1.Interface{NumVfs: 1, VfGroups: []v1.VfGroup{
+				{VfRange: "0-0", Promisc: &enabled},
+			}},
+			ifaceStatus: &v1.InterfaceExt{NumVfs: 1, VFs: []v1.VirtualFunction{
+				{VfID: 0, Name: "eth0v0"},
+			}},
+			want: false,
+		},
+		{
+			name: "multicast mode drifted",
+			ifaceSpec: &v1.Interface{NumVfs: 1, VfGroups: []v1.VfGroup{
+				{VfRange: "0-0", VfFilters: &v1.VfFilters{Multicast: &disabled}},
+			}},
+			ifaceStatus: &v1.InterfaceExt{NumVfs: 1, VFs: []v1.VirtualFunction{
+				{VfID: 0, Name: "eth0v0", Multicast: true},
+			}},
+			want: true,
+		},
+		{
+			name: "VF without netdev is ignored",
+			ifaceSpec: &v1.Interface{NumVfs: 1, VfGroups: []v1.VfGroup{
+				{VfRange: "0-0", VfFilters: &v1.VfFilters{Multicast: &enabled}},
+			}},
+			ifaceStatus: &v1.InterfaceExt{NumVfs: 1, VFs: []v1.VirtualFunction{
+				{VfID: 0},
+			}},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := v1.NeedToUpdateVfNetdevs(tt.ifaceSpec, tt.ifaceStatus); got != tt.want {
+				t.Errorf("NeedToUpdateVfNetdevs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestSriovNetworkNodePolicyApplyBridgeConfig(t *testing.T) {
 	testtable := []struct {
 		tname           string
@@ -1279,6 +1649,43 @@ func TestSriovNetworkNodePolicyApplyBridgeConfig(t *testing.T) {
 				},
 			}},
 		},
+		{
+			tname:        "single policy with uplink port VLANs",
+			currentState: newNodeState(),
+			policy: &v1.SriovNetworkNodePolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "p1",
+				},
+				Spec: v1.SriovNetworkNodePolicySpec{
+					DeviceType: consts.DeviceTypeNetDevice,
+					NicSelector: v1.SriovNetworkNicSelector{
+						RootDevices: []string{"0000:86:00.0"},
+					},
+					NodeSelector: map[string]string{
+						"feature.node.kubernetes.io/network-sriov.capable": "true",
+					},
+					NumVfs:       2,
+					Priority:     99,
+					EswitchMode:  "switchdev",
+					ResourceName: "p1res",
+					Bridge: v1.Bridge{OVS: &v1.OVSConfig{
+						Uplink: v1.OVSUplinkConfig{
+							Port: v1.OVSPortConfig{VlanMode: "trunk", Trunks: []int{200, 100}},
+						},
+					}},
+				},
+			},
+			expectedBridges: v1.Bridges{OVS: []v1.OVSConfigExt{
+				{
+					Name: "br-0000_86_00.0",
+					Uplinks: []v1.OVSUplinkConfigExt{{
+						Name:       "ens803f0",
+						PciAddress: "0000:86:00.0",
+						Port:       v1.OVSPortConfig{VlanMode: "trunk", Trunks: []int{100, 200}},
+					}},
+				},
+			}},
+		},
 		{
 			tname: "update bridge set by policy with lover priority",
 			currentState: &v1.SriovNetworkNodeState{
@@ -24,6 +24,11 @@ import (
 type OVSNetworkSpec struct {
 	// Namespace of the NetworkAttachmentDefinition custom resource
 	NetworkNamespace string `json:"networkNamespace,omitempty"`
+	// Labels added to the NetworkAttachmentDefinition custom resource
+	NadLabels map[string]string `json:"nadLabels,omitempty"`
+	// Annotations added to the NetworkAttachmentDefinition custom resource,
+	// the annotations set by the operator, e.g. the resource name, take precedence
+	NadAnnotations map[string]string `json:"nadAnnotations,omitempty"`
 	// OVS Network device plugin endpoint resource name
 	ResourceName string `json:"resourceName"`
 	// Capabilities to be configured for this network.
@@ -46,6 +51,13 @@ type OVSNetworkSpec struct {
 	Trunk []*TrunkConfig `json:"trunk,omitempty"`
 	// The type of interface on ovs.
 	InterfaceType string `json:"interfaceType,omitempty"`
+	// VF link state (enable|disable|auto)
+	// +kubebuilder:validation:Enum={"auto","enable","disable"}
+	LinkState string `json:"linkState,omitempty"`
+	// +kubebuilder:validation:Pattern=`^[a-zA-Z0-9][a-zA-Z0-9._-]*$`
+	// CniType overrides the name of the CNI plugin binary set as "type" in the generated
+	// NetworkAttachmentDefinition. Defaults to "ovs".
+	CniType string `json:"cniType,omitempty"`
 }
 
 // TrunkConfig contains configuration for bridge trunk
@@ -65,6 +77,7 @@ type TrunkConfig struct {
 type OVSNetworkStatus struct {
 }
 
+//+genclient
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
 
@@ -30,6 +30,11 @@ type SriovIBNetworkSpec struct {
 
 	// Namespace of the NetworkAttachmentDefinition custom resource
 	NetworkNamespace string `json:"networkNamespace,omitempty"`
+	// Labels added to the NetworkAttachmentDefinition custom resource
+	NadLabels map[string]string `json:"nadLabels,omitempty"`
+	// Annotations added to the NetworkAttachmentDefinition custom resource,
+	// the annotations set by the operator, e.g. the resource name, take precedence
+	NadAnnotations map[string]string `json:"nadAnnotations,omitempty"`
 	// SRIOV Network device plugin endpoint resource name
 	ResourceName string `json:"resourceName"`
 	//Capabilities to be configured for this network.
@@ -43,6 +48,10 @@ type SriovIBNetworkSpec struct {
 	// MetaPluginsConfig configuration to be used in order to chain metaplugins to the sriov interface returned
 	// by the operator.
 	MetaPluginsConfig string `json:"metaPlugins,omitempty"`
+	// +kubebuilder:validation:Pattern=`^[a-zA-Z0-9][a-zA-Z0-9._-]*$`
+	// CniType overrides the name of the CNI plugin binary set as "type" in the generated
+	// NetworkAttachmentDefinition. Defaults to "ib-sriov".
+	CniType string `json:"cniType,omitempty"`
 }
 
 // SriovIBNetworkStatus defines the observed state of SriovIBNetwork
@@ -51,6 +60,7 @@ type SriovIBNetworkStatus struct {
 	// Important: Run "make" to regenerate code after modifying this file
 }
 
+//+genclient
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
 
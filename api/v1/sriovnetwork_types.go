@@ -27,6 +27,16 @@ import (
 type SriovNetworkSpec struct {
 	// Namespace of the NetworkAttachmentDefinition custom resource
 	NetworkNamespace string `json:"networkNamespace,omitempty"`
+	// Labels added to the NetworkAttachmentDefinition custom resource
+	NadLabels map[string]string `json:"nadLabels,omitempty"`
+	// Annotations added to the NetworkAttachmentDefinition custom resource,
+	// the annotations set by the operator, e.g. the resource name, take precedence
+	NadAnnotations map[string]string `json:"nadAnnotations,omitempty"`
+	// NetworkName overrides the name of the NetworkAttachmentDefinition custom resource and of the CNI network,
+	// e.g. to match the references of existing workloads. Defaults to the name of the SriovNetwork.
+	// +kubebuilder:validation:MaxLength=253
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$`
+	NetworkName string `json:"networkName,omitempty"`
 	// SRIOV Network device plugin endpoint resource name
 	ResourceName string `json:"resourceName"`
 	//Capabilities to be configured for this network.
@@ -38,6 +48,9 @@ type SriovNetworkSpec struct {
 	// +kubebuilder:validation:Maximum=4096
 	// VLAN ID to assign for the VF. Defaults to 0.
 	Vlan int `json:"vlan,omitempty"`
+	// ClearVlan explicitly configures VLAN 0 on the VF to clear a previously set VLAN.
+	// When neither vlan nor clearVlan are set the VLAN of the VF is not configured by the CNI.
+	ClearVlan bool `json:"clearVlan,omitempty"`
 	// +kubebuilder:validation:Minimum=0
 	// +kubebuilder:validation:Maximum=7
 	// VLAN QoS ID to assign for the VF. Defaults to 0.
@@ -71,14 +84,33 @@ type SriovNetworkSpec struct {
 	// LogFile sets the log file of the SRIOV CNI plugin logs. If unset (default), this will log to stderr and thus
 	// to multus and container runtime logs.
 	LogFile string `json:"logFile,omitempty"`
+	// +kubebuilder:validation:Pattern=`^[a-zA-Z0-9][a-zA-Z0-9._-]*$`
+	// CniType overrides the name of the CNI plugin binary set as "type" in the generated
+	// NetworkAttachmentDefinition. Defaults to "sriov".
+	CniType string `json:"cniType,omitempty"`
+	// PreferredPf is the name of a PF providing the resource the VFs of the network should preferably
+	// be allocated from, e.g. for locality. It is rendered as a "preferredPf" hint in the CNI configuration.
+	PreferredPf string `json:"preferredPf,omitempty"`
 }
 
 // SriovNetworkStatus defines the observed state of SriovNetwork
 type SriovNetworkStatus struct {
-	// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
-	// Important: Run "make" to regenerate code after modifying this file
+	// Conditions represent the latest available observations of the NetworkAttachmentDefinition of the network
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
+const (
+	// ReasonNetworkNameConflict reason is used when the NetworkAttachmentDefinition of the network
+	// already exists and belongs to another network object
+	ReasonNetworkNameConflict = "NetworkNameConflict"
+	// ReasonResourceNotFound reason is used when no SriovNetworkNodePolicy exposes the resource of the network,
+	// e.g. after the resourceName of the policy changed
+	ReasonResourceNotFound = "ResourceNotFound"
+)
+
+//+genclient
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
 
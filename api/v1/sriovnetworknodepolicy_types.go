@@ -36,17 +36,33 @@ type SriovNetworkNodePolicySpec struct {
 	// +kubebuilder:validation:Minimum=1
 	// MTU of VF
 	Mtu int `json:"mtu,omitempty"`
+	// +kubebuilder:validation:Minimum=1
+	// MTU of the VF netdevs when it differs from the MTU of the PF, valid only for deviceType==netdevice.
+	// Can't exceed the MTU of the PF. Defaults to mtu.
+	VfMtu int `json:"vfMtu,omitempty"`
 	// +kubebuilder:validation:Minimum=0
 	// Number of VFs for each PF
 	NumVfs int `json:"numVfs"`
+	// +kubebuilder:validation:Minimum=1
+	// Maximum number of VFs for each PF, alternative to numVfs.
+	// The PF is configured with as many VFs as it supports up to this value.
+	MaxNumVfs int `json:"maxNumVfs,omitempty"`
 	// NicSelector selects the NICs to be configured
 	NicSelector SriovNetworkNicSelector `json:"nicSelector"`
 	// +kubebuilder:validation:Enum=netdevice;vfio-pci
 	// +kubebuilder:default=netdevice
 	// The driver type for configured VFs. Allowed value "netdevice", "vfio-pci". Defaults to netdevice.
 	DeviceType string `json:"deviceType,omitempty"`
+	// +kubebuilder:validation:Pattern=`^[a-zA-Z0-9_-]+$`
+	// Name of the kernel driver the VFs are bound to, e.g. "igb_uio". Takes precedence over the driver
+	// implied by deviceType. The driver must be available on the nodes. Can't be used together with vdpaType.
+	VfDriver string `json:"vfDriver,omitempty"`
 	// RDMA mode. Defaults to false.
 	IsRdma bool `json:"isRdma,omitempty"`
+	// +kubebuilder:validation:Minimum=1
+	// Number of combined queues of VF, valid only for deviceType==netdevice.
+	// The queues are left unchanged if the driver doesn't support it.
+	NumVfQueues int `json:"numVfQueues,omitempty"`
 	// mount vhost-net device. Defaults to false.
 	NeedVhostNet bool `json:"needVhostNet,omitempty"`
 	// +kubebuilder:validation:Enum=eth;ETH;ib;IB
@@ -55,6 +71,10 @@ type SriovNetworkNodePolicySpec struct {
 	// +kubebuilder:validation:Enum=legacy;switchdev
 	// NIC Device Mode. Allowed value "legacy","switchdev".
 	EswitchMode string `json:"eSwitchMode,omitempty"`
+	// +kubebuilder:validation:Enum=none;link;network;transport
+	// NIC eSwitch inline mode, the minimal inline header the VF traffic requires for offload.
+	// Allowed value "none", "link", "network", "transport". Left unchanged if not set.
+	EswitchInlineMode string `json:"eSwitchInlineMode,omitempty"`
 	// +kubebuilder:validation:Enum=virtio;vhost
 	// VDPA device type. Allowed value "virtio", "vhost"
 	VdpaType string `json:"vdpaType,omitempty"`
@@ -62,6 +82,51 @@ type SriovNetworkNodePolicySpec struct {
 	ExcludeTopology bool `json:"excludeTopology,omitempty"`
 	// don't create the virtual function only allocated them to the device plugin. Defaults to false.
 	ExternallyManaged bool `json:"externallyManaged,omitempty"`
+	// Allow the operator to change the number of VFs of a PF carrying the default route of the node.
+	// Defaults to false, the node is likely to lose its connectivity while the VFs are created.
+	AllowPrimaryInterface bool `json:"allowPrimaryInterface,omitempty"`
+	// Keep the names given by the kernel to the VF representors of the PFs in switchdev mode.
+	// Defaults to false, the representors are renamed after the PF and the VF index.
+	KeepDefaultRepresentorNames bool `json:"keepDefaultRepresentorNames,omitempty"`
+	// +kubebuilder:validation:Pattern=`^[a-zA-Z][a-zA-Z0-9_-]*$`
+	// +kubebuilder:validation:MaxLength=12
+	// Prefix of the names of the VF netdevs of the PFs, the VFs are named after the prefix and their index, e.g. "sriov0".
+	// When not set the VFs keep the names given by the kernel. Must not be shared with a policy selecting other PFs of a node.
+	VfNamePrefix string `json:"vfNamePrefix,omitempty"`
+	// +kubebuilder:validation:Enum=auto;up;down
+	// Administrative link state of the PF. Allowed value "auto", "up", "down".
+	// Defaults to "auto", the PF link is brought up by the operator. Unlike "up", bringing
+	// the PF link up in "auto" state drains the node as the whole PF is reconfigured.
+	PfLinkState string `json:"pfLinkState,omitempty"`
+	// Auto-negotiation of the PF link. When not set the auto-negotiation is left unchanged.
+	// Can't be enabled together with linkSpeedMbps.
+	LinkAutoNeg *bool `json:"linkAutoNeg,omitempty"`
+	// +kubebuilder:validation:Minimum=0
+	// Speed of the PF link in Mb/s, forcing a speed disables the auto-negotiation.
+	// When not set the speed is left unchanged.
+	LinkSpeedMbps int `json:"linkSpeedMbps,omitempty"`
+	// Hardware VLAN filtering (rx-vlan-filter) of the PF. When not set the VLAN filtering is left unchanged.
+	VlanFiltering *bool `json:"vlanFiltering,omitempty"`
+	// VF attributes configured by the operator on the VFs of the PF, valid only for externallyManaged==true.
+	// The number of VFs is never changed. When not set the VF attributes are left untouched.
+	VfAttributes *VfAttributes `json:"vfAttributes,omitempty"`
+	// Sysctls set on the netdevs of the VFs, e.g. "net.ipv4.conf.<if>.rp_filter": "2".
+	// The <if> element of the sysctl name is replaced by the name of the VF netdev.
+	VfSysctls map[string]string `json:"vfSysctls,omitempty"`
+	// Trust mode of individual VFs keyed by the VF index, e.g. "0": true.
+	// The VF indexes must belong to the VFs of the policy. Overrides vfAttributes.trust for these VFs.
+	VfTrust map[string]bool `json:"vfTrust,omitempty"`
+	// Promiscuous mode of the VF netdevs, valid only for deviceType==netdevice and trusted VFs.
+	// Only the VFs in trust mode are put in promiscuous mode. When not set the promiscuous mode is left unchanged.
+	Promisc *bool `json:"promisc,omitempty"`
+	// MACsec configured on the netdevs of the VFs, valid only for deviceType==netdevice.
+	Macsec *VfMacsec `json:"macsec,omitempty"`
+	// RSS configured on the netdevs of the VFs, valid only for deviceType==netdevice.
+	// VFs whose driver doesn't support RSS configuration are skipped.
+	VfRss *VfRss `json:"vfRss,omitempty"`
+	// Filters of the broadcast and multicast frames received by the netdevs of the VFs, valid only for deviceType==netdevice.
+	// Can't be used to drop frames together with promisc.
+	VfFilters *VfFilters `json:"vfFilters,omitempty"`
 	// contains bridge configuration for matching PFs,
 	// valid only for eSwitchMode==switchdev
 	Bridge Bridge `json:"bridge,omitempty"`
@@ -72,14 +137,74 @@ type SriovNetworkNicSelector struct {
 	Vendor string `json:"vendor,omitempty"`
 	// The device hex code of SR-IoV device. Allowed value "0d58", "1572", "158b", "1013", "1015", "1017", "101b".
 	DeviceID string `json:"deviceID,omitempty"`
-	// PCI address of SR-IoV PF.
+	// PCI address of SR-IoV PF. Shell-style glob patterns are supported, e.g. "0000:16:*".
 	RootDevices []string `json:"rootDevices,omitempty"`
-	// Name of SR-IoV PF.
+	// Name of SR-IoV PF. Shell-style glob patterns are supported, e.g. "eth*".
 	PfNames []string `json:"pfNames,omitempty"`
 	// Infrastructure Networking selection filter. Allowed value "openstack/NetworkID:xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx"
 	NetFilter string `json:"netFilter,omitempty"`
 }
 
+// VfAttributes contains VF level attributes configured through the PF
+type VfAttributes struct {
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=4095
+	// VLAN ID of the VF, 0 disables VLAN tagging
+	Vlan int `json:"vlan,omitempty"`
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=7
+	// VLAN QoS (priority) of the VF
+	VlanQoS int `json:"vlanQoS,omitempty"`
+	// +kubebuilder:validation:Enum={"802.1q","802.1Q", "802.1ad", "802.1AD"}
+	// VLAN proto of the VF, only applied together with the VLAN. Defaults to 802.1q.
+	VlanProto string `json:"vlanProto,omitempty"`
+	// Trust mode of the VF. Defaults to false.
+	Trust bool `json:"trust,omitempty"`
+	// MAC spoof checking of the VF, left unchanged if not set
+	SpoofChk *bool `json:"spoofChk,omitempty"`
+	// +kubebuilder:validation:Minimum=0
+	// Minimum TX rate of the VF in Mbps, 0 disables the minimum rate, left unchanged if not set.
+	// Must not be higher than maxTxRate
+	MinTxRate *int `json:"minTxRate,omitempty"`
+	// +kubebuilder:validation:Minimum=0
+	// Maximum TX rate of the VF in Mbps, 0 disables the rate limit, left unchanged if not set.
+	// Must not be higher than the link speed of the PF
+	MaxTxRate *int `json:"maxTxRate,omitempty"`
+}
+
+// VfMacsec contains the MACsec configuration of the VFs
+type VfMacsec struct {
+	// Name of the Secret in the operator namespace holding the MACsec key.
+	// The "key" entry contains the hex encoded 128 or 256 bit key of the transmit secure association,
+	// the optional "keyID" entry contains its hex encoded key identifier.
+	KeySecretName string `json:"keySecretName"`
+	// +kubebuilder:validation:Enum=encrypt;integrity
+	// MACsec encryption mode. Allowed value "encrypt", "integrity".
+	// Defaults to "encrypt", "integrity" only protects the integrity of the traffic.
+	EncryptionMode string `json:"encryptionMode,omitempty"`
+}
+
+// VfRss contains the receive side scaling configuration of the VFs
+type VfRss struct {
+	// +kubebuilder:validation:Pattern=`^([0-9a-fA-F]{2}:)*[0-9a-fA-F]{2}$`
+	// RSS hash key as colon separated hex bytes, e.g. "6d:5a:56:da". When not set the hash key is left unchanged.
+	HashKey string `json:"hashKey,omitempty"`
+	// +kubebuilder:validation:Minimum=1
+	// Number of receive queues the indirection table spreads the traffic over equally.
+	// When not set the indirection table is left unchanged.
+	IndirectionSize int `json:"indirectionSize,omitempty"`
+}
+
+// VfFilters contains the filters of the traffic received by the VFs
+type VfFilters struct {
+	// Broadcast set to false drops the broadcast frames received by the VF netdevs with an ethtool
+	// classification rule, the VF driver must support ntuple filters. When not set the filter is left unchanged.
+	Broadcast *bool `json:"broadcast,omitempty"`
+	// Multicast set to false disables the reception of the multicast frames by the VF netdevs,
+	// including the all-multicast mode. When not set the multicast mode is left unchanged.
+	Multicast *bool `json:"multicast,omitempty"`
+}
+
 // contains spec for the bridge
 type Bridge struct {
 	// contains configuration for the OVS bridge,
@@ -107,12 +232,33 @@ type OVSBridgeConfig struct {
 	ExternalIDs map[string]string `json:"externalIDs,omitempty"`
 	// additional options to inject to other_config field in the bridge table in OVSDB
 	OtherConfig map[string]string `json:"otherConfig,omitempty"`
+	// +kubebuilder:validation:Enum=standalone;secure
+	// configure fail_mode field in the Bridge table in OVSDB
+	FailMode string `json:"failMode,omitempty"`
+	// target of the OpenFlow controller of the bridge, e.g. "tcp:192.0.2.10:6653",
+	// configure the controller field in the Bridge table in OVSDB
+	Controller string `json:"controller,omitempty"`
 }
 
 // OVSUplinkConfig contains PF interface configuration for the bridge
 type OVSUplinkConfig struct {
 	// contains settings for PF interface in the OVS bridge
 	Interface OVSInterfaceConfig `json:"interface,omitempty"`
+	// contains settings for the port of the PF interface in the OVS bridge
+	Port OVSPortConfig `json:"port,omitempty"`
+}
+
+// OVSPortConfig contains some options from the Port table of the OVSDB for PF
+type OVSPortConfig struct {
+	// tag field in the Port table in OVSDB, VLAN of an access port or native VLAN of a trunk port
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=4095
+	Vlan *int `json:"vlan,omitempty"`
+	// vlan_mode field in the Port table in OVSDB
+	// +kubebuilder:validation:Enum=access;trunk;native-tagged;native-untagged
+	VlanMode string `json:"vlanMode,omitempty"`
+	// trunks field in the Port table in OVSDB, VLANs trunked by the port, all VLANs are trunked if empty
+	Trunks []int `json:"trunks,omitempty"`
 }
 
 // OVSInterfaceConfig contains some options from the Interface table of the OVSDB for PF
@@ -127,6 +273,14 @@ type OVSInterfaceConfig struct {
 	OtherConfig map[string]string `json:"otherConfig,omitempty"`
 	// mtu_request field in the Interface table in OVSDB
 	MTURequest *int `json:"mtuRequest,omitempty"`
+
+	// ingress_policing_rate field in the Interface table in OVSDB, maximum rate in kbps
+	// for the data received on the interface, 0 disables policing
+	// +kubebuilder:validation:Minimum=0
+	IngressPolicingRate int `json:"ingressPolicingRate,omitempty"`
+	// ingress_policing_burst field in the Interface table in OVSDB, maximum burst size in kb
+	// +kubebuilder:validation:Minimum=0
+	IngressPolicingBurst int `json:"ingressPolicingBurst,omitempty"`
 }
 
 // SriovNetworkNodePolicyStatus defines the observed state of SriovNetworkNodePolicy
@@ -135,6 +289,7 @@ type SriovNetworkNodePolicyStatus struct {
 	// Important: Run "make" to regenerate code after modifying this file
 }
 
+//+genclient
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
 
@@ -39,8 +39,28 @@ type Interface struct {
 	Name              string    `json:"name,omitempty"`
 	LinkType          string    `json:"linkType,omitempty"`
 	EswitchMode       string    `json:"eSwitchMode,omitempty"`
+	EswitchInlineMode string    `json:"eSwitchInlineMode,omitempty"`
 	VfGroups          []VfGroup `json:"vfGroups,omitempty"`
 	ExternallyManaged bool      `json:"externallyManaged,omitempty"`
+	PfLinkState       string    `json:"pfLinkState,omitempty"`
+	// LinkAutoNeg is the auto-negotiation of the PF link, left unchanged if not set
+	LinkAutoNeg *bool `json:"linkAutoNeg,omitempty"`
+	// LinkSpeedMbps is the forced speed of the PF link, left unchanged if not set
+	LinkSpeedMbps int `json:"linkSpeedMbps,omitempty"`
+	// VlanFiltering is the hardware VLAN filtering of the PF, left unchanged if not set
+	VlanFiltering *bool `json:"vlanFiltering,omitempty"`
+	// AllowPrimaryInterface allows changing the number of VFs of a PF carrying the default route of the node
+	AllowPrimaryInterface bool `json:"allowPrimaryInterface,omitempty"`
+
+	// ManageByNetworkManager opts the VFs of the PF out of the udev rule
+	// which prevents NetworkManager from managing them
+	ManageByNetworkManager bool `json:"manageByNetworkManager,omitempty"`
+	// KeepDefaultRepresentorNames opts the VF representors of the PF in switchdev mode out of the udev rule
+	// which renames them, the representors keep the names given by the kernel
+	KeepDefaultRepresentorNames bool `json:"keepDefaultRepresentorNames,omitempty"`
+	// VfNamePrefix is the prefix of the names given to the VF netdevs of the PF by an udev rule,
+	// the VFs keep the names given by the kernel if not set
+	VfNamePrefix string `json:"vfNamePrefix,omitempty"`
 }
 
 type VfGroup struct {
@@ -51,6 +71,26 @@ type VfGroup struct {
 	Mtu          int    `json:"mtu,omitempty"`
 	IsRdma       bool   `json:"isRdma,omitempty"`
 	VdpaType     string `json:"vdpaType,omitempty"`
+	NumVfQueues  int    `json:"numVfQueues,omitempty"`
+	// VfDriver is the name of the driver the VFs should be bound to.
+	// Takes precedence over the driver implied by DeviceType.
+	VfDriver string `json:"vfDriver,omitempty"`
+	// VfAttributes are only applied to VFs of externally managed PFs
+	VfAttributes *VfAttributes `json:"vfAttributes,omitempty"`
+	// VfSysctls are the sysctls set on the netdevs of the VFs
+	VfSysctls map[string]string `json:"vfSysctls,omitempty"`
+	// VfTrust is the trust mode of individual VFs keyed by the VF index
+	VfTrust map[string]bool `json:"vfTrust,omitempty"`
+	// Promisc is the promiscuous mode of the VF netdevs
+	Promisc *bool `json:"promisc,omitempty"`
+	// Macsec is the MACsec configuration of the VF netdevs
+	Macsec *VfMacsec `json:"macsec,omitempty"`
+	// VfMtu is the MTU of the VF netdevs, overrides Mtu for the VFs
+	VfMtu int `json:"vfMtu,omitempty"`
+	// VfRss is the RSS configuration of the VF netdevs
+	VfRss *VfRss `json:"vfRss,omitempty"`
+	// VfFilters are the broadcast and multicast filters of the VF netdevs
+	VfFilters *VfFilters `json:"vfFilters,omitempty"`
 }
 
 type InterfaceExt struct {
@@ -66,10 +106,18 @@ type InterfaceExt struct {
 	LinkSpeed         string            `json:"linkSpeed,omitempty"`
 	LinkType          string            `json:"linkType,omitempty"`
 	LinkAdminState    string            `json:"linkAdminState,omitempty"`
+	PciLinkSpeed      string            `json:"pciLinkSpeed,omitempty"`
+	PciLinkWidth      string            `json:"pciLinkWidth,omitempty"`
+	DriverVersion     string            `json:"driverVersion,omitempty"`
 	EswitchMode       string            `json:"eSwitchMode,omitempty"`
+	EswitchInlineMode string            `json:"eSwitchInlineMode,omitempty"`
 	ExternallyManaged bool              `json:"externallyManaged,omitempty"`
 	TotalVfs          int               `json:"totalvfs,omitempty"`
 	VFs               []VirtualFunction `json:"Vfs,omitempty"`
+	// NumVfsInUse is the number of VFs allocated to pods according to the kubelet device plugin checkpoint
+	NumVfsInUse int `json:"numVfsInUse,omitempty"`
+	// VlanFiltering is the state of the hardware VLAN filtering of the PF, not reported if the NIC doesn't support it
+	VlanFiltering *bool `json:"vlanFiltering,omitempty"`
 }
 type InterfaceExts []InterfaceExt
 
@@ -82,11 +130,18 @@ type VirtualFunction struct {
 	Vendor          string `json:"vendor,omitempty"`
 	DeviceID        string `json:"deviceID,omitempty"`
 	Vlan            int    `json:"Vlan,omitempty"`
+	Trust           bool   `json:"trust,omitempty"`
 	Mtu             int    `json:"mtu,omitempty"`
 	VfID            int    `json:"vfID"`
 	VdpaType        string `json:"vdpaType,omitempty"`
 	RepresentorName string `json:"representorName,omitempty"`
 	GUID            string `json:"guid,omitempty"`
+	NumQueues       int    `json:"numQueues,omitempty"`
+	Promisc         bool   `json:"promisc,omitempty"`
+	Multicast       bool   `json:"multicast,omitempty"`
+	// ConsumerPod is the namespace/name of the pod the VF is allocated to, reported on a best-effort basis
+	// when the reportVfConsumerPods feature gate is enabled
+	ConsumerPod string `json:"consumerPod,omitempty"`
 }
 
 // Bridges contains list of bridges
@@ -113,12 +168,31 @@ type OVSUplinkConfigExt struct {
 	Name string `json:"name,omitempty"`
 	// configuration from the Interface OVS table for the PF
 	Interface OVSInterfaceConfig `json:"interface,omitempty"`
+	// configuration from the Port OVS table for the PF
+	Port OVSPortConfig `json:"port,omitempty"`
 }
 
 type System struct {
-	// +kubebuilder:validation:Enum=shared;exclusive
-	//RDMA subsystem. Allowed value "shared", "exclusive".
+	// +kubebuilder:validation:Enum=shared;exclusive;auto
+	//RDMA subsystem. Allowed value "shared", "exclusive", "auto".
 	RdmaMode string `json:"rdmaMode,omitempty"`
+	// OVSDB socket path override for the node, if empty the config-daemon default is used
+	OVSDBSocketPath string `json:"ovsdbSocketPath,omitempty"`
+	// Maximum time in seconds the node can stay in the Draining state, 0 means no timeout
+	DrainTimeoutSeconds int `json:"drainTimeoutSeconds,omitempty"`
+	// +kubebuilder:validation:Enum=daemon;systemd
+	// Configuration mode override for the node, if empty the config-daemon default is used
+	ConfigurationMode ConfigurationModeType `json:"configurationMode,omitempty"`
+	// Kernel modules required by the configuration of the node, reported in the status only
+	KernelModules *KernelModules `json:"kernelModules,omitempty"`
+}
+
+// KernelModules reports which of the kernel modules required by the configuration of the node are loaded
+type KernelModules struct {
+	// Required kernel modules loaded on the node
+	Loaded []string `json:"loaded,omitempty"`
+	// Required kernel modules not loaded on the node
+	Missing []string `json:"missing,omitempty"`
 }
 
 // SriovNetworkNodeStateStatus defines the observed state of SriovNetworkNodeState
@@ -128,8 +202,51 @@ type SriovNetworkNodeStateStatus struct {
 	System        System        `json:"system,omitempty"`
 	SyncStatus    string        `json:"syncStatus,omitempty"`
 	LastSyncError string        `json:"lastSyncError,omitempty"`
+	// LastAppliedTime is the time the configuration was last applied successfully on the node
+	LastAppliedTime metav1.Time `json:"lastAppliedTime,omitempty"`
+	// ProgressMessage reports the progress of the configuration while it is applied
+	ProgressMessage string `json:"progressMessage,omitempty"`
+	// FeatureGates reports the state of the feature gates used by the config daemon of the node
+	FeatureGates map[string]bool `json:"featureGates,omitempty"`
+	// PendingKernelArgs lists the kernel arguments set by the configuration which differ from the running kernel
+	// and take effect after the next reboot, the arguments to remove are prefixed with "-"
+	PendingKernelArgs []string `json:"pendingKernelArgs,omitempty"`
+	// Conditions represent the latest available observations of the node state
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
+const (
+	// ConditionDegraded reports if the config daemon stopped applying the configuration of the node
+	ConditionDegraded = "Degraded"
+
+	// ReasonExternalConflict reason is used when the number of VFs of a PF is repeatedly changed outside of the operator
+	ReasonExternalConflict = "ExternalConflict"
+	// ReasonRebootBlocked reason is used when the configuration requires a reboot of a node which opted out of reboots
+	ReasonRebootBlocked = "RebootBlocked"
+	// ReasonPrimaryInterfaceProtected reason is used when the configuration changes the number of VFs
+	// of the PF carrying the default route of the node
+	ReasonPrimaryInterfaceProtected = "PrimaryInterfaceProtected"
+	// ReasonUnsupportedFirmware reason is used when the firmware or the driver of a NIC doesn't support SR-IOV
+	ReasonUnsupportedFirmware = "UnsupportedFirmware"
+	// ReasonHugepagesMissing reason is used when VFs are bound to vfio-pci on a node without hugepages
+	ReasonHugepagesMissing = "HugepagesMissing"
+	// ReasonKernelModuleMissing reason is used when a kernel module required by the configuration is not loaded
+	ReasonKernelModuleMissing = "KernelModuleMissing"
+	// ReasonMaxRetriesExceeded reason is used when the config daemon stopped retrying a configuration
+	// which failed to apply too many consecutive times
+	ReasonMaxRetriesExceeded = "MaxRetriesExceeded"
+	// ReasonVfTxRateInvalid reason is used when the TX rates of the VFs exceed the link speed of the PF
+	// or are rejected by the NIC
+	ReasonVfTxRateInvalid = "VfTxRateInvalid"
+	// ReasonOVSInterfaceError reason is used when an uplink of a managed OVS bridge is reported in error state by OVS
+	ReasonOVSInterfaceError = "OVSInterfaceError"
+	// ReasonKernelTooOld reason is used when the configuration requires a more recent kernel than the one running on the node
+	ReasonKernelTooOld = "KernelTooOld"
+)
+
+//+genclient
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
 //+kubebuilder:printcolumn:name="Sync Status",type=string,JSONPath=`.status.syncStatus`
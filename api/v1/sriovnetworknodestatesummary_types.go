@@ -0,0 +1,74 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// SriovNetworkNodeStateSummaryStatus defines the observed state of SriovNetworkNodeStateSummary
+type SriovNetworkNodeStateSummaryStatus struct {
+	// TotalNodes is the number of SriovNetworkNodeStates
+	TotalNodes int `json:"totalNodes"`
+	// SyncedNodes is the number of SriovNetworkNodeStates with the Succeeded sync status
+	SyncedNodes int `json:"syncedNodes"`
+	// Nodes contains the summary of the SriovNetworkNodeState of each node, sorted by node name
+	// +listType=map
+	// +listMapKey=name
+	Nodes []NodeStateSummary `json:"nodes,omitempty"`
+}
+
+// NodeStateSummary is a condensed view of the SriovNetworkNodeState of a node
+type NodeStateSummary struct {
+	// Name of the node
+	Name          string `json:"name"`
+	SyncStatus    string `json:"syncStatus,omitempty"`
+	LastSyncError string `json:"lastSyncError,omitempty"`
+	// MatchedPolicies are the names of the SriovNetworkNodePolicies configuring the node
+	MatchedPolicies []string `json:"matchedPolicies,omitempty"`
+	// PendingAction is the drain or reboot requested by the config daemon which is not completed yet
+	PendingAction string `json:"pendingAction,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:resource:scope=Cluster
+//+kubebuilder:printcolumn:name="Synced",type=integer,JSONPath=`.status.syncedNodes`
+//+kubebuilder:printcolumn:name="Total",type=integer,JSONPath=`.status.totalNodes`
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// SriovNetworkNodeStateSummary is the Schema for the sriovnetworknodestatesummaries API,
+// a read-only projection of the SriovNetworkNodeStates maintained by the operator
+type SriovNetworkNodeStateSummary struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Status SriovNetworkNodeStateSummaryStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// SriovNetworkNodeStateSummaryList contains a list of SriovNetworkNodeStateSummary
+type SriovNetworkNodeStateSummaryList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []SriovNetworkNodeStateSummary `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&SriovNetworkNodeStateSummary{}, &SriovNetworkNodeStateSummaryList{})
+}
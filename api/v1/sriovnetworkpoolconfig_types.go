@@ -22,9 +22,32 @@ type SriovNetworkPoolConfigSpec struct {
 	// even if maxUnavailable is greater than one.
 	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
 
-	// +kubebuilder:validation:Enum=shared;exclusive
-	// RDMA subsystem. Allowed value "shared", "exclusive".
+	// +kubebuilder:validation:Enum=shared;exclusive;auto
+	// RDMA subsystem. Allowed value "shared", "exclusive", "auto".
+	// "auto" selects exclusive on nodes with Mellanox NICs and shared on the other nodes.
 	RdmaMode string `json:"rdmaMode,omitempty"`
+
+	// +kubebuilder:validation:Pattern=`^(unix|tcp):.+`
+	// OVSDB socket path used by the config-daemon on the nodes of the pool, e.g. "unix:///run/openvswitch/db.sock".
+	// If not set the value of the config-daemon --ovs-socket-path flag is used.
+	OVSDBSocketPath string `json:"ovsdbSocketPath,omitempty"`
+
+	// +kubebuilder:validation:Minimum=0
+	// DrainTimeoutSeconds is the maximum time a node of the pool can stay in the Draining state.
+	// When it is exceeded the config-daemon reports the sync status as Failed. 0 means no timeout.
+	DrainTimeoutSeconds int `json:"drainTimeoutSeconds,omitempty"`
+
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$`
+	// ResourcePrefix overrides the operator resource prefix (e.g. "openshift.io") for the resources
+	// advertised by the device plugin on the nodes of the pool.
+	// The NetworkAttachmentDefinitions of the networks using these resources reference them with this prefix.
+	ResourcePrefix string `json:"resourcePrefix,omitempty"`
+
+	// +kubebuilder:validation:Enum=daemon;systemd
+	// ConfigurationMode overrides the configuration mode of the SriovOperatorConfig for the nodes of the pool,
+	// e.g. "systemd" for nodes with an immutable OS. Allowed value "daemon", "systemd".
+	// On OpenShift the systemd service is only deployed when the SriovOperatorConfig uses the systemd mode.
+	ConfigurationMode ConfigurationModeType `json:"configurationMode,omitempty"`
 }
 
 type OvsHardwareOffloadConfig struct {
@@ -36,10 +59,56 @@ type OvsHardwareOffloadConfig struct {
 	Name string `json:"name,omitempty"`
 }
 
+const (
+	// ConditionRdmaModeApplied reports if the RDMA mode of the pool is applied on all the nodes of the pool
+	ConditionRdmaModeApplied = "RdmaModeApplied"
+
+	// RdmaModeApplied reason is used when the RDMA mode is applied on all the nodes of the pool
+	RdmaModeApplied = "Applied"
+	// RdmaModePartiallyApplied reason is used when the RDMA mode is applied only on some nodes of the pool
+	RdmaModePartiallyApplied = "PartiallyApplied"
+	// RdmaModeNotApplied reason is used when the RDMA mode is not applied on any node of the pool
+	RdmaModeNotApplied = "NotApplied"
+
+	// ConditionReady reports if the configuration is applied on all the nodes of the pool
+	ConditionReady = "Ready"
+
+	// PoolNodesSynced reason is used when the SriovNetworkNodeStates of all the nodes of the pool are synced
+	PoolNodesSynced = "NodesSynced"
+	// PoolNodesNotSynced reason is used when the SriovNetworkNodeState of some nodes of the pool is not synced
+	PoolNodesNotSynced = "NodesNotSynced"
+
+	// ConditionSwitchdevApplied reports if the PFs configured in switchdev mode on the nodes of the pool are in switchdev mode
+	ConditionSwitchdevApplied = "SwitchdevApplied"
+
+	// SwitchdevApplied reason is used when all the PFs configured in switchdev mode are in switchdev mode
+	SwitchdevApplied = "Applied"
+	// SwitchdevPartiallyApplied reason is used when only some PFs configured in switchdev mode are in switchdev mode
+	SwitchdevPartiallyApplied = "PartiallyApplied"
+	// SwitchdevNotApplied reason is used when none of the PFs configured in switchdev mode is in switchdev mode
+	SwitchdevNotApplied = "NotApplied"
+)
+
+// EswitchModeCounts contains the number of PFs in each eSwitch mode
+type EswitchModeCounts struct {
+	// Legacy is the number of PFs in legacy mode
+	Legacy int `json:"legacy"`
+	// Switchdev is the number of PFs in switchdev mode
+	Switchdev int `json:"switchdev"`
+}
+
 // SriovNetworkPoolConfigStatus defines the observed state of SriovNetworkPoolConfig
 type SriovNetworkPoolConfigStatus struct {
+	// Conditions represent the latest available observations of the pool state
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// EswitchModes reports the number of PFs of the nodes of the pool in each eSwitch mode,
+	// not reported if the nodes of the pool have no PF
+	EswitchModes *EswitchModeCounts `json:"eswitchModes,omitempty"`
 }
 
+//+genclient
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
 
@@ -18,6 +18,7 @@ package v1
 
 import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 // EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
@@ -43,14 +44,27 @@ func (pns PluginNameSlice) ToStringSlice() []string {
 type SriovOperatorConfigSpec struct {
 	// NodeSelector selects the nodes to be configured
 	ConfigDaemonNodeSelector map[string]string `json:"configDaemonNodeSelector,omitempty"`
+	// PriorityClassName of the sriov-network-config-daemon and sriov-device-plugin pods.
+	// Default: system-node-critical
+	ConfigDaemonPriorityClassName string `json:"configDaemonPriorityClassName,omitempty"`
 	// Flag to control whether the network resource injector webhook shall be deployed
 	EnableInjector bool `json:"enableInjector,omitempty"`
 	// Flag to control whether the operator admission controller webhook shall be deployed
 	EnableOperatorWebhook bool `json:"enableOperatorWebhook,omitempty"`
+	// Flag to control how the operator admission controller webhook reports the non-critical validation failures,
+	// e.g. a SriovNetworkNodePolicy not selecting any node or NIC yet. Set to 'warn' to admit the objects with
+	// admission warnings instead of rejecting them, the invalid specs are always rejected.
+	// Default mode: enforce
+	// +kubebuilder:validation:Enum=enforce;warn
+	WebhookValidationMode WebhookValidationModeType `json:"webhookValidationMode,omitempty"`
 	// Flag to control the log verbose level of the operator. Set to '0' to show only the basic logs. And set to '2' to show all the available logs.
 	// +kubebuilder:validation:Minimum=0
 	// +kubebuilder:validation:Maximum=2
 	LogLevel int `json:"logLevel,omitempty"`
+	// Flag to control the log format of the sriov-network-config-daemon. Set to 'json' to emit structured JSON logs.
+	// Default format: text
+	// +kubebuilder:validation:Enum=text;json
+	LogFormat LogFormatType `json:"logFormat,omitempty"`
 	// Flag to disable nodes drain during debugging
 	DisableDrain bool `json:"disableDrain,omitempty"`
 	// Flag to enable OVS hardware offload. Set to 'true' to provision switchdev-configuration.service and enable OpenvSwitch hw-offload on nodes.
@@ -65,6 +79,35 @@ type SriovOperatorConfigSpec struct {
 	DisablePlugins PluginNameSlice `json:"disablePlugins,omitempty"`
 	// FeatureGates to enable experimental features
 	FeatureGates map[string]bool `json:"featureGates,omitempty"`
+	// AllowedNetworkNamespaces is a list of namespaces a SriovNetwork can target with networkNamespace.
+	// If empty, all namespaces are allowed.
+	AllowedNetworkNamespaces []string `json:"allowedNetworkNamespaces,omitempty"`
+	// DrainPodSelector restricts the pods evicted when a node is drained to the pods matching the selector,
+	// e.g. a DoesNotExist expression skips the pods with a given label. DaemonSet pods are never evicted.
+	// It has no effect when disableDrain is set as the nodes are not drained.
+	DrainPodSelector *metav1.LabelSelector `json:"drainPodSelector,omitempty"`
+	// MaxUnavailable is the cluster default of the number or percentage of nodes that can be drained
+	// in parallel, applied to the nodes that don't belong to any SriovNetworkPoolConfig.
+	// Nodes outside of the pools are drained one at a time when it is not set.
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+	// MetricsExporterPort is the port the sriov-network-metrics-exporter is scraped on.
+	// Default: the METRICS_EXPORTER_PORT of the operator
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	MetricsExporterPort int `json:"metricsExporterPort,omitempty"`
+	// MetricsExporterTLS configures the certificates used to serve the sriov-network-metrics-exporter metrics
+	MetricsExporterTLS *MetricsExporterTLSConfig `json:"metricsExporterTLS,omitempty"`
+}
+
+// MetricsExporterTLSConfig defines the TLS configuration of the sriov-network-metrics-exporter
+type MetricsExporterTLSConfig struct {
+	// CertSecretName is the name of a Secret in the operator namespace holding the tls.crt and tls.key
+	// serving certificate, replacing the default METRICS_EXPORTER_SECRET_NAME of the operator
+	// +kubebuilder:validation:MinLength=1
+	CertSecretName string `json:"certSecretName"`
+	// ClientCASecretName is the name of a Secret in the operator namespace holding a ca.crt.
+	// When set, the scrapers must present a client certificate signed by this CA (mTLS).
+	ClientCASecretName string `json:"clientCASecretName,omitempty"`
 }
 
 // SriovOperatorConfigStatus defines the observed state of SriovOperatorConfig
@@ -73,8 +116,25 @@ type SriovOperatorConfigStatus struct {
 	Injector string `json:"injector,omitempty"`
 	// Show the runtime status of the operator admission controller webhook
 	OperatorWebhook string `json:"operatorWebhook,omitempty"`
+	// Conditions represent the latest available observations of the operator state,
+	// the Ready condition is true when all the SriovNetworkPoolConfigs are ready,
+	// the Degraded condition is true when a secret referenced by the injector is missing
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
+const (
+	// PoolsReady reason is used when all the SriovNetworkPoolConfigs are ready
+	PoolsReady = "PoolsReady"
+	// PoolsNotReady reason is used when some SriovNetworkPoolConfigs are not ready
+	PoolsNotReady = "PoolsNotReady"
+	// InjectorSecretMissing reason is used when the secret holding the network resources injector
+	// certificates doesn't exist
+	InjectorSecretMissing = "InjectorSecretMissing"
+)
+
+//+genclient
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
 
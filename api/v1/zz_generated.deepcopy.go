@@ -89,13 +89,40 @@ func (in ByPriority) DeepCopy() ByPriority {
 	return *out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EswitchModeCounts) DeepCopyInto(out *EswitchModeCounts) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EswitchModeCounts.
+func (in *EswitchModeCounts) DeepCopy() *EswitchModeCounts {
+	if in == nil {
+		return nil
+	}
+	out := new(EswitchModeCounts)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Interface) DeepCopyInto(out *Interface) {
 	*out = *in
 	if in.VfGroups != nil {
 		in, out := &in.VfGroups, &out.VfGroups
 		*out = make([]VfGroup, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LinkAutoNeg != nil {
+		in, out := &in.LinkAutoNeg, &out.LinkAutoNeg
+		*out = new(bool)
+		**out = **in
+	}
+	if in.VlanFiltering != nil {
+		in, out := &in.VlanFiltering, &out.VlanFiltering
+		*out = new(bool)
+		**out = **in
 	}
 }
 
@@ -117,6 +144,11 @@ func (in *InterfaceExt) DeepCopyInto(out *InterfaceExt) {
 		*out = make([]VirtualFunction, len(*in))
 		copy(*out, *in)
 	}
+	if in.VlanFiltering != nil {
+		in, out := &in.VlanFiltering, &out.VlanFiltering
+		*out = new(bool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InterfaceExt.
@@ -171,6 +203,66 @@ func (in Interfaces) DeepCopy() Interfaces {
 	return *out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KernelModules) DeepCopyInto(out *KernelModules) {
+	*out = *in
+	if in.Loaded != nil {
+		in, out := &in.Loaded, &out.Loaded
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Missing != nil {
+		in, out := &in.Missing, &out.Missing
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KernelModules.
+func (in *KernelModules) DeepCopy() *KernelModules {
+	if in == nil {
+		return nil
+	}
+	out := new(KernelModules)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MetricsExporterTLSConfig) DeepCopyInto(out *MetricsExporterTLSConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetricsExporterTLSConfig.
+func (in *MetricsExporterTLSConfig) DeepCopy() *MetricsExporterTLSConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(MetricsExporterTLSConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeStateSummary) DeepCopyInto(out *NodeStateSummary) {
+	*out = *in
+	if in.MatchedPolicies != nil {
+		in, out := &in.MatchedPolicies, &out.MatchedPolicies
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeStateSummary.
+func (in *NodeStateSummary) DeepCopy() *NodeStateSummary {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeStateSummary)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OVSBridgeConfig) DeepCopyInto(out *OVSBridgeConfig) {
 	*out = *in
@@ -343,6 +435,20 @@ func (in *OVSNetworkList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OVSNetworkSpec) DeepCopyInto(out *OVSNetworkSpec) {
 	*out = *in
+	if in.NadLabels != nil {
+		in, out := &in.NadLabels, &out.NadLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.NadAnnotations != nil {
+		in, out := &in.NadAnnotations, &out.NadAnnotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	if in.Trunk != nil {
 		in, out := &in.Trunk, &out.Trunk
 		*out = make([]*TrunkConfig, len(*in))
@@ -381,10 +487,36 @@ func (in *OVSNetworkStatus) DeepCopy() *OVSNetworkStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OVSPortConfig) DeepCopyInto(out *OVSPortConfig) {
+	*out = *in
+	if in.Vlan != nil {
+		in, out := &in.Vlan, &out.Vlan
+		*out = new(int)
+		**out = **in
+	}
+	if in.Trunks != nil {
+		in, out := &in.Trunks, &out.Trunks
+		*out = make([]int, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OVSPortConfig.
+func (in *OVSPortConfig) DeepCopy() *OVSPortConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(OVSPortConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OVSUplinkConfig) DeepCopyInto(out *OVSUplinkConfig) {
 	*out = *in
 	in.Interface.DeepCopyInto(&out.Interface)
+	in.Port.DeepCopyInto(&out.Port)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OVSUplinkConfig.
@@ -401,6 +533,7 @@ func (in *OVSUplinkConfig) DeepCopy() *OVSUplinkConfig {
 func (in *OVSUplinkConfigExt) DeepCopyInto(out *OVSUplinkConfigExt) {
 	*out = *in
 	in.Interface.DeepCopyInto(&out.Interface)
+	in.Port.DeepCopyInto(&out.Port)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OVSUplinkConfigExt.
@@ -452,7 +585,7 @@ func (in *SriovIBNetwork) DeepCopyInto(out *SriovIBNetwork) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 	out.Status = in.Status
 }
 
@@ -509,6 +642,20 @@ func (in *SriovIBNetworkList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SriovIBNetworkSpec) DeepCopyInto(out *SriovIBNetworkSpec) {
 	*out = *in
+	if in.NadLabels != nil {
+		in, out := &in.NadLabels, &out.NadLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.NadAnnotations != nil {
+		in, out := &in.NadAnnotations, &out.NadAnnotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SriovIBNetworkSpec.
@@ -542,7 +689,7 @@ func (in *SriovNetwork) DeepCopyInto(out *SriovNetwork) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SriovNetwork.
@@ -690,6 +837,55 @@ func (in *SriovNetworkNodePolicySpec) DeepCopyInto(out *SriovNetworkNodePolicySp
 		}
 	}
 	in.NicSelector.DeepCopyInto(&out.NicSelector)
+	if in.LinkAutoNeg != nil {
+		in, out := &in.LinkAutoNeg, &out.LinkAutoNeg
+		*out = new(bool)
+		**out = **in
+	}
+	if in.VlanFiltering != nil {
+		in, out := &in.VlanFiltering, &out.VlanFiltering
+		*out = new(bool)
+		**out = **in
+	}
+	if in.VfAttributes != nil {
+		in, out := &in.VfAttributes, &out.VfAttributes
+		*out = new(VfAttributes)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.VfSysctls != nil {
+		in, out := &in.VfSysctls, &out.VfSysctls
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.VfTrust != nil {
+		in, out := &in.VfTrust, &out.VfTrust
+		*out = make(map[string]bool, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Promisc != nil {
+		in, out := &in.Promisc, &out.Promisc
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Macsec != nil {
+		in, out := &in.Macsec, &out.Macsec
+		*out = new(VfMacsec)
+		**out = **in
+	}
+	if in.VfRss != nil {
+		in, out := &in.VfRss, &out.VfRss
+		*out = new(VfRss)
+		**out = **in
+	}
+	if in.VfFilters != nil {
+		in, out := &in.VfFilters, &out.VfFilters
+		*out = new(VfFilters)
+		(*in).DeepCopyInto(*out)
+	}
 	in.Bridge.DeepCopyInto(&out.Bridge)
 }
 
@@ -788,7 +984,7 @@ func (in *SriovNetworkNodeStateSpec) DeepCopyInto(out *SriovNetworkNodeStateSpec
 		}
 	}
 	in.Bridges.DeepCopyInto(&out.Bridges)
-	out.System = in.System
+	in.System.DeepCopyInto(&out.System)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SriovNetworkNodeStateSpec.
@@ -812,7 +1008,27 @@ func (in *SriovNetworkNodeStateStatus) DeepCopyInto(out *SriovNetworkNodeStateSt
 		}
 	}
 	in.Bridges.DeepCopyInto(&out.Bridges)
-	out.System = in.System
+	in.System.DeepCopyInto(&out.System)
+	in.LastAppliedTime.DeepCopyInto(&out.LastAppliedTime)
+	if in.FeatureGates != nil {
+		in, out := &in.FeatureGates, &out.FeatureGates
+		*out = make(map[string]bool, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.PendingKernelArgs != nil {
+		in, out := &in.PendingKernelArgs, &out.PendingKernelArgs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SriovNetworkNodeStateStatus.
@@ -825,13 +1041,93 @@ func (in *SriovNetworkNodeStateStatus) DeepCopy() *SriovNetworkNodeStateStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SriovNetworkNodeStateSummary) DeepCopyInto(out *SriovNetworkNodeStateSummary) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SriovNetworkNodeStateSummary.
+func (in *SriovNetworkNodeStateSummary) DeepCopy() *SriovNetworkNodeStateSummary {
+	if in == nil {
+		return nil
+	}
+	out := new(SriovNetworkNodeStateSummary)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SriovNetworkNodeStateSummary) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SriovNetworkNodeStateSummaryList) DeepCopyInto(out *SriovNetworkNodeStateSummaryList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]SriovNetworkNodeStateSummary, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SriovNetworkNodeStateSummaryList.
+func (in *SriovNetworkNodeStateSummaryList) DeepCopy() *SriovNetworkNodeStateSummaryList {
+	if in == nil {
+		return nil
+	}
+	out := new(SriovNetworkNodeStateSummaryList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SriovNetworkNodeStateSummaryList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SriovNetworkNodeStateSummaryStatus) DeepCopyInto(out *SriovNetworkNodeStateSummaryStatus) {
+	*out = *in
+	if in.Nodes != nil {
+		in, out := &in.Nodes, &out.Nodes
+		*out = make([]NodeStateSummary, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SriovNetworkNodeStateSummaryStatus.
+func (in *SriovNetworkNodeStateSummaryStatus) DeepCopy() *SriovNetworkNodeStateSummaryStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SriovNetworkNodeStateSummaryStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SriovNetworkPoolConfig) DeepCopyInto(out *SriovNetworkPoolConfig) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SriovNetworkPoolConfig.
@@ -913,6 +1209,18 @@ func (in *SriovNetworkPoolConfigSpec) DeepCopy() *SriovNetworkPoolConfigSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SriovNetworkPoolConfigStatus) DeepCopyInto(out *SriovNetworkPoolConfigStatus) {
 	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.EswitchModes != nil {
+		in, out := &in.EswitchModes, &out.EswitchModes
+		*out = new(EswitchModeCounts)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SriovNetworkPoolConfigStatus.
@@ -928,6 +1236,20 @@ func (in *SriovNetworkPoolConfigStatus) DeepCopy() *SriovNetworkPoolConfigStatus
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SriovNetworkSpec) DeepCopyInto(out *SriovNetworkSpec) {
 	*out = *in
+	if in.NadLabels != nil {
+		in, out := &in.NadLabels, &out.NadLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.NadAnnotations != nil {
+		in, out := &in.NadAnnotations, &out.NadAnnotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	if in.MinTxRate != nil {
 		in, out := &in.MinTxRate, &out.MinTxRate
 		*out = new(int)
@@ -953,6 +1275,13 @@ func (in *SriovNetworkSpec) DeepCopy() *SriovNetworkSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SriovNetworkStatus) DeepCopyInto(out *SriovNetworkStatus) {
 	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SriovNetworkStatus.
@@ -971,7 +1300,7 @@ func (in *SriovOperatorConfig) DeepCopyInto(out *SriovOperatorConfig) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SriovOperatorConfig.
@@ -1046,6 +1375,26 @@ func (in *SriovOperatorConfigSpec) DeepCopyInto(out *SriovOperatorConfigSpec) {
 			(*out)[key] = val
 		}
 	}
+	if in.AllowedNetworkNamespaces != nil {
+		in, out := &in.AllowedNetworkNamespaces, &out.AllowedNetworkNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DrainPodSelector != nil {
+		in, out := &in.DrainPodSelector, &out.DrainPodSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MaxUnavailable != nil {
+		in, out := &in.MaxUnavailable, &out.MaxUnavailable
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.MetricsExporterTLS != nil {
+		in, out := &in.MetricsExporterTLS, &out.MetricsExporterTLS
+		*out = new(MetricsExporterTLSConfig)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SriovOperatorConfigSpec.
@@ -1061,6 +1410,13 @@ func (in *SriovOperatorConfigSpec) DeepCopy() *SriovOperatorConfigSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SriovOperatorConfigStatus) DeepCopyInto(out *SriovOperatorConfigStatus) {
 	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SriovOperatorConfigStatus.
@@ -1076,6 +1432,11 @@ func (in *SriovOperatorConfigStatus) DeepCopy() *SriovOperatorConfigStatus {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *System) DeepCopyInto(out *System) {
 	*out = *in
+	if in.KernelModules != nil {
+		in, out := &in.KernelModules, &out.KernelModules
+		*out = new(KernelModules)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new System.
@@ -1118,9 +1479,103 @@ func (in *TrunkConfig) DeepCopy() *TrunkConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VfAttributes) DeepCopyInto(out *VfAttributes) {
+	*out = *in
+	if in.SpoofChk != nil {
+		in, out := &in.SpoofChk, &out.SpoofChk
+		*out = new(bool)
+		**out = **in
+	}
+	if in.MinTxRate != nil {
+		in, out := &in.MinTxRate, &out.MinTxRate
+		*out = new(int)
+		**out = **in
+	}
+	if in.MaxTxRate != nil {
+		in, out := &in.MaxTxRate, &out.MaxTxRate
+		*out = new(int)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VfAttributes.
+func (in *VfAttributes) DeepCopy() *VfAttributes {
+	if in == nil {
+		return nil
+	}
+	out := new(VfAttributes)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VfFilters) DeepCopyInto(out *VfFilters) {
+	*out = *in
+	if in.Broadcast != nil {
+		in, out := &in.Broadcast, &out.Broadcast
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Multicast != nil {
+		in, out := &in.Multicast, &out.Multicast
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VfFilters.
+func (in *VfFilters) DeepCopy() *VfFilters {
+	if in == nil {
+		return nil
+	}
+	out := new(VfFilters)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VfGroup) DeepCopyInto(out *VfGroup) {
 	*out = *in
+	if in.VfAttributes != nil {
+		in, out := &in.VfAttributes, &out.VfAttributes
+		*out = new(VfAttributes)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.VfSysctls != nil {
+		in, out := &in.VfSysctls, &out.VfSysctls
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.VfTrust != nil {
+		in, out := &in.VfTrust, &out.VfTrust
+		*out = make(map[string]bool, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Promisc != nil {
+		in, out := &in.Promisc, &out.Promisc
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Macsec != nil {
+		in, out := &in.Macsec, &out.Macsec
+		*out = new(VfMacsec)
+		**out = **in
+	}
+	if in.VfRss != nil {
+		in, out := &in.VfRss, &out.VfRss
+		*out = new(VfRss)
+		**out = **in
+	}
+	if in.VfFilters != nil {
+		in, out := &in.VfFilters, &out.VfFilters
+		*out = new(VfFilters)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VfGroup.
@@ -1133,6 +1588,36 @@ func (in *VfGroup) DeepCopy() *VfGroup {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VfMacsec) DeepCopyInto(out *VfMacsec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VfMacsec.
+func (in *VfMacsec) DeepCopy() *VfMacsec {
+	if in == nil {
+		return nil
+	}
+	out := new(VfMacsec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VfRss) DeepCopyInto(out *VfRss) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VfRss.
+func (in *VfRss) DeepCopy() *VfRss {
+	if in == nil {
+		return nil
+	}
+	out := new(VfRss)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VirtualFunction) DeepCopyInto(out *VirtualFunction) {
 	*out = *in
@@ -21,9 +21,12 @@ import (
 	"net"
 	"net/url"
 	"os"
+	"os/signal"
 	"strings"
+	"syscall"
 	"time"
 
+	"github.com/go-logr/logr"
 	"github.com/spf13/cobra"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -34,6 +37,7 @@ import (
 	"k8s.io/client-go/util/connrotation"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
 
 	configv1 "github.com/openshift/api/config/v1"
 	mcfgv1 "github.com/openshift/machine-config-operator/pkg/apis/machineconfiguration.openshift.io/v1"
@@ -88,6 +92,12 @@ var (
 		parallelNicConfig     bool
 		manageSoftwareBridges bool
 		ovsSocketPath         string
+		logFormat             string
+		resyncPeriod          time.Duration
+		devicePluginRestart   time.Duration
+		metricsBindAddress    string
+		// minimum kernel version required to configure NICs in switchdev mode
+		switchdevMinKernelVersion string
 	}
 )
 
@@ -100,10 +110,18 @@ func init() {
 	startCmd.PersistentFlags().BoolVar(&startOpts.parallelNicConfig, "parallel-nic-config", false, "perform NIC configuration in parallel")
 	startCmd.PersistentFlags().BoolVar(&startOpts.manageSoftwareBridges, "manage-software-bridges", false, "enable management of software bridges")
 	startCmd.PersistentFlags().StringVar(&startOpts.ovsSocketPath, "ovs-socket-path", vars.OVSDBSocketPath, "path for OVSDB socket")
+	startCmd.PersistentFlags().DurationVar(&startOpts.resyncPeriod, "resync-period", vars.DaemonResyncPeriod, "interval at which the node state is re-processed to detect configuration drift")
+	startCmd.PersistentFlags().DurationVar(&startOpts.devicePluginRestart, "device-plugin-restart-interval", vars.DevicePluginRestartInterval, "minimum interval between two restarts of the device plugin when the VF configuration didn't change, only used with the blockDevicePluginUntilConfigured feature gate")
+	startCmd.PersistentFlags().StringVar(&startOpts.switchdevMinKernelVersion, "switchdev-min-kernel-version", vars.SwitchdevMinKernelVersion, "minimum kernel version required to configure NICs in switchdev mode, an empty value disables the check")
+	startCmd.PersistentFlags().StringVar(&startOpts.metricsBindAddress, "metrics-bind-address", "", "address the metrics of the config daemon are served on, e.g. \"127.0.0.1:9111\", an empty value disables the metrics endpoint")
+	startCmd.PersistentFlags().StringVar(&startOpts.logFormat, "log-format", string(sriovnetworkv1.LogFormatText), "log format, either \"text\" or \"json\"")
 }
 
 func runStartCmd(cmd *cobra.Command, args []string) error {
 	// init logger
+	if err := snolog.SetLogFormat(startOpts.logFormat); err != nil {
+		return err
+	}
 	snolog.InitLog()
 	setupLog := log.Log.WithName("sriov-network-config-daemon")
 
@@ -117,6 +135,17 @@ func runStartCmd(cmd *cobra.Command, args []string) error {
 	vars.ManageSoftwareBridges = startOpts.manageSoftwareBridges
 	vars.OVSDBSocketPath = startOpts.ovsSocketPath
 
+	if startOpts.resyncPeriod <= 0 {
+		return fmt.Errorf("resync-period must be a positive duration")
+	}
+	vars.DaemonResyncPeriod = startOpts.resyncPeriod
+
+	if startOpts.devicePluginRestart < 0 {
+		return fmt.Errorf("device-plugin-restart-interval must not be negative")
+	}
+	vars.DevicePluginRestartInterval = startOpts.devicePluginRestart
+	vars.SwitchdevMinKernelVersion = startOpts.switchdevMinKernelVersion
+
 	if startOpts.nodeName == "" {
 		name, ok := os.LookupEnv("NODE_NAME")
 		if !ok || name == "" {
@@ -248,6 +277,7 @@ func runStartCmd(cmd *cobra.Command, args []string) error {
 
 	setupLog.V(0).Info("starting node writer")
 	nodeWriter := daemon.NewNodeStateStatusWriter(writerclient,
+		kubeclient,
 		closeAllConns,
 		eventRecorder,
 		hostHelpers,
@@ -273,15 +303,8 @@ func runStartCmd(cmd *cobra.Command, args []string) error {
 
 	eventRecorder.SendEvent("ConfigDaemonStart", "Config Daemon starting")
 
-	// block the deamon process until nodeWriter finish first its run
-	err = nodeWriter.RunOnce()
-	if err != nil {
-		setupLog.Error(err, "failed to run writer")
-		return err
-	}
-	go nodeWriter.Run(stopCh, refreshCh, syncCh)
-
 	// Init feature gates once to prevent race conditions.
+	// The feature gates are read before the first run of the nodeWriter as they change the reported status.
 	defaultConfig := &sriovnetworkv1.SriovOperatorConfig{}
 	err = kClient.Get(context.Background(), types.NamespacedName{Namespace: vars.Namespace, Name: consts.DefaultConfigName}, defaultConfig)
 	if err != nil {
@@ -291,10 +314,27 @@ func runStartCmd(cmd *cobra.Command, args []string) error {
 	featureGates := featuregate.New()
 	featureGates.Init(defaultConfig.Spec.FeatureGates)
 	vars.MlxPluginFwReset = featureGates.IsEnabled(consts.MellanoxFirmwareResetFeatureGate)
+	vars.SkipRdma = featureGates.IsEnabled(consts.SkipRdmaFeatureGate)
 	log.Log.Info("Enabled featureGates", "featureGates", featureGates.String())
+	nodeWriter.FeatureGate = featureGates
+
+	// block the deamon process until nodeWriter finish first its run
+	err = nodeWriter.RunOnce()
+	if err != nil {
+		setupLog.Error(err, "failed to run writer")
+		return err
+	}
+	go nodeWriter.Run(stopCh, refreshCh, syncCh)
+
+	if vars.ManageSoftwareBridges {
+		metrics.Registry.MustRegister(daemon.NewOVSPortStatisticsCollector(hostHelpers))
+	}
+	if startOpts.metricsBindAddress != "" {
+		go daemon.ServeMetrics(startOpts.metricsBindAddress, stopCh)
+	}
 
 	setupLog.V(0).Info("Starting SriovNetworkConfigDaemon")
-	err = daemon.New(
+	dn := daemon.New(
 		kClient,
 		snclient,
 		kubeclient,
@@ -307,7 +347,9 @@ func runStartCmd(cmd *cobra.Command, args []string) error {
 		eventRecorder,
 		featureGates,
 		startOpts.disabledPlugins,
-	).Run(stopCh, exitCh)
+	)
+	handleTermination(dn, setupLog)
+	err = dn.Run(stopCh, exitCh)
 	if err != nil {
 		setupLog.Error(err, "failed to run daemon")
 	}
@@ -315,6 +357,25 @@ func runStartCmd(cmd *cobra.Command, args []string) error {
 	return err
 }
 
+// handleTermination records the configuration step in progress when the pod is terminated,
+// the next start of the daemon rolls back the interrupted configuration of the PF
+func handleTermination(dn *daemon.Daemon, setupLog logr.Logger) {
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, syscall.SIGTERM)
+	go func() {
+		<-sigCh
+		setupLog.Info("received SIGTERM, recording the configuration in progress")
+		if err := dn.PersistInProgress(); err != nil {
+			setupLog.Error(err, "failed to record the configuration in progress")
+		}
+		// terminate the process as the default SIGTERM handler does
+		signal.Reset(syscall.SIGTERM)
+		if err := syscall.Kill(os.Getpid(), syscall.SIGTERM); err != nil {
+			os.Exit(1)
+		}
+	}()
+}
+
 // updateDialer instruments a restconfig with a dial. the returned function allows forcefully closing all active connections.
 func updateDialer(clientConfig *rest.Config) (func(), error) {
 	if clientConfig.Transport != nil || clientConfig.Dial != nil {
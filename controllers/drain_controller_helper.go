@@ -88,8 +88,14 @@ func (dr *DrainReconcile) handleNodeDrainOrReboot(ctx context.Context,
 		}
 	}
 
+	podSelector, err := dr.getDrainPodSelector(ctx)
+	if err != nil {
+		reqLogger.Error(err, "failed to get the drain pod selector")
+		return ctrl.Result{}, err
+	}
+
 	// call the drain function that will also call drain to other platform providers like openshift
-	drained, err := dr.drainer.DrainNode(ctx, node, nodeDrainAnnotation == constants.RebootRequired)
+	drained, err := dr.drainer.DrainNode(ctx, node, nodeDrainAnnotation == constants.RebootRequired, podSelector)
 	if err != nil {
 		reqLogger.Error(err, "error trying to drain the node")
 		dr.recorder.Event(nodeNetworkState,
@@ -187,6 +193,13 @@ func (dr *DrainReconcile) tryDrainNode(ctx context.Context, node *corev1.Node) (
 		return nil, fmt.Errorf("failed to find sriov network node state for requested node")
 	}
 
+	// the start time is recorded first so the config-daemon always finds it once the node state is Draining
+	err = utils.AnnotateObject(ctx, currentSnns, constants.NodeStateDrainStartTimeAnnotation,
+		time.Now().Format(time.RFC3339), dr.Client)
+	if err != nil {
+		reqLogger.Error(err, "failed to annotate node state with the drain start time")
+		return nil, err
+	}
 	err = utils.AnnotateObject(ctx, currentSnns, constants.NodeStateDrainAnnotationCurrent, constants.Draining, dr.Client)
 	if err != nil {
 		reqLogger.Error(err, "failed to annotate node with annotation", "annotation", constants.Draining)
@@ -269,7 +282,12 @@ func (dr *DrainReconcile) findNodePoolConfig(ctx context.Context, node *corev1.N
 		return selectedNpcl[0], nodeList.Items, nil
 	} else {
 		// in this case we get all the nodes and remove the ones that already part of any pool
-		logger.V(1).Info("node doesn't belong to any pool, using default drain configuration with MaxUnavailable of one", "pool", *defaultPoolConfig)
+		poolConfig, err := dr.getDefaultPoolConfig(ctx)
+		if err != nil {
+			logger.Error(err, "failed to get the default drain configuration")
+			return nil, nil, err
+		}
+		logger.V(1).Info("node doesn't belong to any pool, using default drain configuration", "pool", *poolConfig)
 		nodeList := &corev1.NodeList{}
 		err = dr.List(ctx, nodeList)
 		if err != nil {
@@ -283,6 +301,46 @@ func (dr *DrainReconcile) findNodePoolConfig(ctx context.Context, node *corev1.N
 				defaultNodeLists = append(defaultNodeLists, nodeObj)
 			}
 		}
-		return defaultPoolConfig, defaultNodeLists, nil
+		return poolConfig, defaultNodeLists, nil
+	}
+}
+
+// getDefaultPoolConfig returns the drain configuration of the nodes that don't belong to any pool,
+// using the maxUnavailable of the default SriovOperatorConfig when set and one node otherwise.
+func (dr *DrainReconcile) getDefaultPoolConfig(ctx context.Context) (*sriovnetworkv1.SriovNetworkPoolConfig, error) {
+	config := &sriovnetworkv1.SriovOperatorConfig{}
+	err := dr.Get(ctx, client.ObjectKey{Name: constants.DefaultConfigName, Namespace: vars.Namespace}, config)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return defaultPoolConfig, nil
+		}
+		return nil, err
+	}
+	if config.Spec.MaxUnavailable == nil {
+		return defaultPoolConfig, nil
+	}
+	poolConfig := defaultPoolConfig.DeepCopy()
+	poolConfig.Spec.MaxUnavailable = config.Spec.MaxUnavailable
+	return poolConfig, nil
+}
+
+// getDrainPodSelector returns the label selector of the pods to evict when draining a node,
+// configured in the default SriovOperatorConfig. Empty string selects all the pods.
+func (dr *DrainReconcile) getDrainPodSelector(ctx context.Context) (string, error) {
+	config := &sriovnetworkv1.SriovOperatorConfig{}
+	err := dr.Get(ctx, client.ObjectKey{Name: constants.DefaultConfigName, Namespace: vars.Namespace}, config)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return "", nil
+		}
+		return "", err
+	}
+	if config.Spec.DrainPodSelector == nil {
+		return "", nil
+	}
+	selector, err := metav1.LabelSelectorAsSelector(config.Spec.DrainPodSelector)
+	if err != nil {
+		return "", fmt.Errorf("invalid drainPodSelector in SriovOperatorConfig: %v", err)
 	}
+	return selector.String(), nil
 }
@@ -3,6 +3,7 @@ package controllers
 import (
 	"context"
 	"sync"
+	"testing"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -11,11 +12,16 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/utils/pointer"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	mcfgv1 "github.com/openshift/machine-config-operator/pkg/apis/machineconfiguration.openshift.io/v1"
 
@@ -27,6 +33,99 @@ import (
 	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/vars"
 )
 
+// fakeDrainer records the pod selector of the drain requests
+type fakeDrainer struct {
+	podSelectors []string
+}
+
+func (d *fakeDrainer) DrainNode(_ context.Context, _ *corev1.Node, _ bool, podSelector string) (bool, error) {
+	d.podSelectors = append(d.podSelectors, podSelector)
+	return true, nil
+}
+
+func (d *fakeDrainer) CompleteDrainNode(_ context.Context, _ *corev1.Node) (bool, error) {
+	return true, nil
+}
+
+func TestDrainNodeWithPodSelector(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	config := &sriovnetworkv1.SriovOperatorConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: constants.DefaultConfigName, Namespace: vars.Namespace},
+		Spec: sriovnetworkv1.SriovOperatorConfigSpec{
+			DrainPodSelector: &metav1.LabelSelector{
+				MatchExpressions: []metav1.LabelSelectorRequirement{{
+					Key:      "drain.example.com/skip",
+					Operator: metav1.LabelSelectorOpDoesNotExist,
+				}},
+			},
+		},
+	}
+	node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node1"}}
+	nodeState := &sriovnetworkv1.SriovNetworkNodeState{ObjectMeta: metav1.ObjectMeta{Name: node.Name, Namespace: vars.Namespace}}
+
+	s := runtime.NewScheme()
+	utilruntime.Must(sriovnetworkv1.AddToScheme(s))
+	utilruntime.Must(corev1.AddToScheme(s))
+	drainer := &fakeDrainer{}
+	dr := &DrainReconcile{
+		Client:   fake.NewClientBuilder().WithScheme(s).WithObjects(config, node, nodeState).Build(),
+		Scheme:   s,
+		recorder: record.NewFakeRecorder(10),
+		drainer:  drainer,
+	}
+
+	logger := log.FromContext(context.Background())
+	_, err := dr.handleNodeDrainOrReboot(context.Background(), &logger, node, nodeState,
+		constants.DrainRequired, constants.Draining)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(drainer.podSelectors).To(Equal([]string{"!drain.example.com/skip"}))
+}
+
+func TestTryDrainNodeWithClusterDefaultMaxUnavailable(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	maxUnavailable := intstr.FromInt32(2)
+	config := &sriovnetworkv1.SriovOperatorConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: constants.DefaultConfigName, Namespace: vars.Namespace},
+		Spec:       sriovnetworkv1.SriovOperatorConfigSpec{MaxUnavailable: &maxUnavailable},
+	}
+	objs := []client.Object{config}
+	nodes := []*corev1.Node{}
+	for _, name := range []string{"node1", "node2", "node3"} {
+		node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: name}}
+		nodeState := &sriovnetworkv1.SriovNetworkNodeState{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: vars.Namespace,
+			Annotations: map[string]string{constants.NodeStateDrainAnnotationCurrent: constants.DrainIdle}}}
+		nodes = append(nodes, node)
+		objs = append(objs, node, nodeState)
+	}
+
+	s := runtime.NewScheme()
+	utilruntime.Must(sriovnetworkv1.AddToScheme(s))
+	utilruntime.Must(corev1.AddToScheme(s))
+	dr := &DrainReconcile{
+		Client:   fake.NewClientBuilder().WithScheme(s).WithObjects(objs...).Build(),
+		Scheme:   s,
+		recorder: record.NewFakeRecorder(10),
+	}
+
+	// the nodes don't belong to any pool, two of them can drain in parallel
+	for _, node := range nodes[:2] {
+		result, err := dr.tryDrainNode(context.Background(), node)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(result).To(BeNil())
+	}
+
+	result, err := dr.tryDrainNode(context.Background(), nodes[2])
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(result).ToNot(BeNil())
+	g.Expect(result.RequeueAfter).ToNot(BeZero())
+
+	nodeState := &sriovnetworkv1.SriovNetworkNodeState{}
+	g.Expect(dr.Get(context.Background(), client.ObjectKey{Name: "node3", Namespace: vars.Namespace}, nodeState)).To(Succeed())
+	g.Expect(utils.ObjectHasAnnotation(nodeState, constants.NodeStateDrainAnnotationCurrent, constants.DrainIdle)).To(BeTrue())
+}
+
 var _ = Describe("Drain Controller", Ordered, func() {
 
 	var cancel context.CancelFunc
@@ -292,6 +391,7 @@ var _ = Describe("Drain Controller", Ordered, func() {
 
 			simulateDaemonSetAnnotation(node2, constants.RebootRequired)
 			expectNodeStateAnnotation(nodeState2, constants.Draining)
+			Expect(nodeState2.GetDrainStartTime()).ToNot(BeZero())
 
 			simulateDaemonSetAnnotation(node1, constants.DrainRequired)
 			expectNodeStateAnnotation(nodeState1, constants.DrainComplete)
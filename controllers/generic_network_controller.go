@@ -18,34 +18,70 @@ package controllers
 
 import (
 	"context"
+	"fmt"
 	"reflect"
+	"strings"
+	"time"
 
 	netattdefv1 "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	uns "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/util/workqueue"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	sriovnetworkv1 "github.com/k8snetworkplumbingwg/sriov-network-operator/api/v1"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/consts"
 	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/utils"
 	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/vars"
 )
 
+// orphanedNetAttDefCleanupPeriod defines how often the network controllers look for
+// NetworkAttachmentDefinitions whose owning network object no longer exists
+var orphanedNetAttDefCleanupPeriod = consts.ResyncPeriod
+
+// netAttDefConflictRequeuePeriod defines how often a network whose NetworkAttachmentDefinition
+// belongs to another network is reconciled again, until the conflict is solved
+const netAttDefConflictRequeuePeriod = time.Minute
+
+// NetAttDefOwnerKindIndex is the cache index of the NetworkAttachmentDefinitions by the kind of
+// the network object they are generated from, see NetAttDefOwnerKind
+const NetAttDefOwnerKindIndex = "metadata.annotations.ownerRefKind"
+
+// resourceNameAnnotation is the NetworkAttachmentDefinition annotation referencing the device plugin resource
+const resourceNameAnnotation = "k8s.v1.cni.cncf.io/resourceName"
+
 type networkCRInstance interface {
 	client.Object
 	// renders NetAttDef from the network instance
 	RenderNetAttDef() (*uns.Unstructured, error)
 	// return name of the target namespace for the network
 	NetworkNamespace() string
+	// return name of the net-att-def generated for the network
+	NetAttDefName() string
+	// return the labels requested for the net-att-def generated for the network
+	NetAttDefLabels() map[string]string
+	// return the annotations requested for the net-att-def generated for the network
+	NetAttDefAnnotations() map[string]string
+}
+
+// networkCRWithConditions is implemented by the network objects reporting
+// the state of their net-att-def with status conditions
+type networkCRWithConditions interface {
+	networkCRInstance
+	StatusConditions() *[]metav1.Condition
 }
 
 // interface which controller should implement to be compatible with genericNetworkReconciler
@@ -133,21 +169,32 @@ func (r *genericNetworkReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 	if err != nil {
 		return reconcile.Result{}, err
 	}
+	setUserMetadata(netAttDef, instance)
+	r.setOwnerRefAnnotation(netAttDef, instance)
+	if err := r.setResourcePrefix(ctx, netAttDef); err != nil {
+		reqLogger.Error(err, "Couldn't get the resource prefix for the NetworkAttachmentDefinition", "Namespace", netAttDef.Namespace, "Name", netAttDef.Name)
+		return reconcile.Result{}, err
+	}
 	// format CNI config json in CR for easier readability
 	netAttDef.Spec.Config, err = formatJSON(netAttDef.Spec.Config)
 	if err != nil {
 		reqLogger.Error(err, "Couldn't process rendered NetworkAttachmentDefinition config", "Namespace", netAttDef.Namespace, "Name", netAttDef.Name)
 		return reconcile.Result{}, err
 	}
-	if lnns, ok := instance.GetAnnotations()[sriovnetworkv1.LASTNETWORKNAMESPACE]; ok && netAttDef.GetNamespace() != lnns {
+	lastName := instance.GetName()
+	if lnn, ok := instance.GetAnnotations()[sriovnetworkv1.LASTNETWORKNAME]; ok {
+		lastName = lnn
+	}
+	if lnns, ok := instance.GetAnnotations()[sriovnetworkv1.LASTNETWORKNAMESPACE]; ok &&
+		(netAttDef.GetNamespace() != lnns || netAttDef.GetName() != lastName) {
 		err = r.Delete(ctx, &netattdefv1.NetworkAttachmentDefinition{
 			ObjectMeta: metav1.ObjectMeta{
-				Name:      instance.GetName(),
+				Name:      lastName,
 				Namespace: lnns,
 			},
 		})
-		if err != nil {
-			reqLogger.Error(err, "Couldn't delete NetworkAttachmentDefinition CR", "Namespace", instance.GetName(), "Name", lnns)
+		if err != nil && !errors.IsNotFound(err) {
+			reqLogger.Error(err, "Couldn't delete NetworkAttachmentDefinition CR", "Namespace", lnns, "Name", lastName)
 			return reconcile.Result{}, err
 		}
 	}
@@ -174,13 +221,28 @@ func (r *genericNetworkReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 			if err != nil {
 				return reconcile.Result{}, err
 			}
+			err = utils.AnnotateObject(ctx, instance, sriovnetworkv1.LASTNETWORKNAME, netAttDef.Name, r.Client)
+			if err != nil {
+				return reconcile.Result{}, err
+			}
 		} else {
 			reqLogger.Error(err, "Couldn't get NetworkAttachmentDefinition CR", "Namespace", netAttDef.Namespace, "Name", netAttDef.Name)
 			return reconcile.Result{}, err
 		}
 	} else {
 		reqLogger.Info("NetworkAttachmentDefinition CR already exist")
-		if !reflect.DeepEqual(found.Spec, netAttDef.Spec) || !reflect.DeepEqual(found.GetAnnotations(), netAttDef.GetAnnotations()) {
+		if r.ownedByAnotherNetwork(found, instance) {
+			// never take over the net-att-def of another network, retry until the conflict is solved
+			conflict := fmt.Sprintf("NetworkAttachmentDefinition %s/%s already exists and belongs to %s",
+				found.Namespace, found.Name, found.GetAnnotations()[sriovnetworkv1.OwnerRefAnnotation])
+			reqLogger.Info("Couldn't take over NetworkAttachmentDefinition CR", "conflict", conflict)
+			if err := r.setDegradedCondition(ctx, instance, sriovnetworkv1.ReasonNetworkNameConflict, conflict); err != nil {
+				return reconcile.Result{}, err
+			}
+			return reconcile.Result{RequeueAfter: netAttDefConflictRequeuePeriod}, nil
+		}
+		if !reflect.DeepEqual(found.Spec, netAttDef.Spec) || !reflect.DeepEqual(found.GetAnnotations(), netAttDef.GetAnnotations()) ||
+			!reflect.DeepEqual(found.GetLabels(), netAttDef.GetLabels()) {
 			reqLogger.Info("Update NetworkAttachmentDefinition CR", "Namespace", netAttDef.Namespace, "Name", netAttDef.Name)
 			netAttDef.SetResourceVersion(found.GetResourceVersion())
 			err = r.Update(ctx, netAttDef)
@@ -191,7 +253,34 @@ func (r *genericNetworkReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 		}
 	}
 
-	return ctrl.Result{}, nil
+	// the net-att-def is kept while no policy exposes the resource of the network, the pods can't be scheduled
+	// until a policy exposes it again
+	missingResource, err := r.missingResourceMessage(ctx, netAttDef)
+	if err != nil {
+		reqLogger.Error(err, "Couldn't check the resource of the network")
+		return reconcile.Result{}, err
+	}
+	return ctrl.Result{}, r.setDegradedCondition(ctx, instance, sriovnetworkv1.ReasonResourceNotFound, missingResource)
+}
+
+// missingResourceMessage returns a message reporting that no policy exposes the resource requested by the net-att-def,
+// it returns an empty message if a policy exposes the resource or if the net-att-def doesn't request any resource
+func (r *genericNetworkReconciler) missingResourceMessage(ctx context.Context, netAttDef *netattdefv1.NetworkAttachmentDefinition) (string, error) {
+	resource, ok := netAttDef.GetAnnotations()[resourceNameAnnotation]
+	if !ok {
+		return "", nil
+	}
+	resourceName := resource[strings.LastIndex(resource, "/")+1:]
+	npl := &sriovnetworkv1.SriovNetworkNodePolicyList{}
+	if err := r.List(ctx, npl, client.InNamespace(vars.Namespace)); err != nil {
+		return "", err
+	}
+	for _, p := range npl.Items {
+		if p.Name != consts.DefaultPolicyName && p.Spec.ResourceName == resourceName {
+			return "", nil
+		}
+	}
+	return fmt.Sprintf("no SriovNetworkNodePolicy exposes the resource %s", resourceName), nil
 }
 
 // SetupWithManager sets up the controller with the Manager.
@@ -200,13 +289,138 @@ func (r *genericNetworkReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	namespaceHandler := handler.Funcs{
 		CreateFunc: r.namespaceHandlerCreate,
 	}
+	// Periodically remove NetworkAttachmentDefinitions left behind by deleted networks,
+	// e.g. when the finalizer was removed before the NetworkAttachmentDefinition was deleted.
+	err := mgr.Add(manager.RunnableFunc(func(ctx context.Context) error {
+		wait.UntilWithContext(ctx, r.cleanupOrphanedNetAttDefs, orphanedNetAttDefCleanupPeriod)
+		return nil
+	}))
+	if err != nil {
+		return err
+	}
+	// The resource prefix of the networks depends on the pools and on the policies exposing the resources.
+	resourcePrefixHandler := handler.EnqueueRequestsFromMapFunc(r.allNetworksRequests)
 	return ctrl.NewControllerManagedBy(mgr).
 		For(r.controller.GetObject()).
-		Watches(&netattdefv1.NetworkAttachmentDefinition{}, &handler.EnqueueRequestForObject{}).
+		Watches(&netattdefv1.NetworkAttachmentDefinition{}, handler.EnqueueRequestsFromMapFunc(r.netAttDefOwnerRequests)).
 		Watches(&corev1.Namespace{}, &namespaceHandler).
+		Watches(&sriovnetworkv1.SriovNetworkPoolConfig{}, resourcePrefixHandler).
+		Watches(&sriovnetworkv1.SriovNetworkNodePolicy{}, resourcePrefixHandler).
 		Complete(r.controller)
 }
 
+// allNetworksRequests returns a reconcile request for all the network objects handled by the controller,
+// the networks are listed in all the namespaces watched by the manager
+func (r *genericNetworkReconciler) allNetworksRequests(ctx context.Context, _ client.Object) []reconcile.Request {
+	logger := log.Log.WithName(r.controller.Name() + " reconciler")
+	networkList := r.controller.GetObjectList()
+	if err := r.List(ctx, networkList); err != nil {
+		logger.Info("Can't list networks", "error", err)
+		return nil
+	}
+	requests := []reconcile.Request{}
+	_ = meta.EachListItem(networkList, func(o runtime.Object) error {
+		obj := o.(client.Object)
+		requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{
+			Namespace: obj.GetNamespace(),
+			Name:      obj.GetName(),
+		}})
+		return nil
+	})
+	return requests
+}
+
+// netAttDefOwnerRequests returns a reconcile request for the network object the net-att-def was generated from
+func (r *genericNetworkReconciler) netAttDefOwnerRequests(_ context.Context, obj client.Object) []reconcile.Request {
+	netAttDef, ok := obj.(*netattdefv1.NetworkAttachmentDefinition)
+	if !ok {
+		return nil
+	}
+	owner, ok := r.parseOwnerRefAnnotation(netAttDef)
+	if !ok {
+		// net-att-defs generated before the owner-ref annotation are named after their network
+		owner = types.NamespacedName{Namespace: netAttDef.Namespace, Name: netAttDef.Name}
+	}
+	return []reconcile.Request{{NamespacedName: owner}}
+}
+
+// setDegradedCondition reports the issue of the net-att-def of the network with the Degraded condition,
+// an empty message clears the condition.
+// It's a no-op for the network kinds without status conditions.
+func (r *genericNetworkReconciler) setDegradedCondition(ctx context.Context, cr networkCRInstance, reason, message string) error {
+	withConditions, ok := cr.(networkCRWithConditions)
+	if !ok {
+		return nil
+	}
+	orig := withConditions.DeepCopyObject().(client.Object)
+	conditions := withConditions.StatusConditions()
+	before := append([]metav1.Condition{}, (*conditions)...)
+	if message == "" {
+		meta.RemoveStatusCondition(conditions, sriovnetworkv1.ConditionDegraded)
+	} else {
+		meta.SetStatusCondition(conditions, metav1.Condition{
+			Type:               sriovnetworkv1.ConditionDegraded,
+			Status:             metav1.ConditionTrue,
+			Reason:             reason,
+			Message:            message,
+			ObservedGeneration: cr.GetGeneration(),
+		})
+	}
+	if equality.Semantic.DeepEqual(before, *conditions) {
+		return nil
+	}
+	return r.Status().Patch(ctx, cr, client.MergeFrom(orig))
+}
+
+// ownedByAnotherNetwork returns true if the net-att-def CR was generated for another network object
+func (r *genericNetworkReconciler) ownedByAnotherNetwork(netAttDef *netattdefv1.NetworkAttachmentDefinition, cr networkCRInstance) bool {
+	owner, ok := netAttDef.GetAnnotations()[sriovnetworkv1.OwnerRefAnnotation]
+	return ok && owner != "" && owner != r.ownerRefAnnotationValue(cr)
+}
+
+// setUserMetadata adds the labels and annotations requested by the network object to the net-att-def CR,
+// without overriding the annotations rendered by the operator. The owner-ref annotation is set afterwards.
+func setUserMetadata(netAttDef *netattdefv1.NetworkAttachmentDefinition, cr networkCRInstance) {
+	if len(cr.NetAttDefLabels()) > 0 {
+		labels := netAttDef.GetLabels()
+		if labels == nil {
+			labels = map[string]string{}
+		}
+		for key, value := range cr.NetAttDefLabels() {
+			labels[key] = value
+		}
+		netAttDef.SetLabels(labels)
+	}
+	if len(cr.NetAttDefAnnotations()) > 0 {
+		annotations := netAttDef.GetAnnotations()
+		if annotations == nil {
+			annotations = map[string]string{}
+		}
+		for key, value := range cr.NetAttDefAnnotations() {
+			if _, ok := annotations[key]; !ok {
+				annotations[key] = value
+			}
+		}
+		netAttDef.SetAnnotations(annotations)
+	}
+}
+
+// setResourcePrefix updates the resource name annotation of the NetworkAttachmentDefinition
+// when the pools override the prefix the resource is advertised with, see getResourcePrefix
+func (r *genericNetworkReconciler) setResourcePrefix(ctx context.Context, netAttDef *netattdefv1.NetworkAttachmentDefinition) error {
+	resource, ok := netAttDef.GetAnnotations()[resourceNameAnnotation]
+	if !ok {
+		return nil
+	}
+	resourceName := resource[strings.LastIndex(resource, "/")+1:]
+	prefix, err := getResourcePrefix(ctx, r.Client, resourceName)
+	if err != nil || prefix == "" {
+		return err
+	}
+	netAttDef.Annotations[resourceNameAnnotation] = prefix + "/" + resourceName
+	return nil
+}
+
 func (r *genericNetworkReconciler) namespaceHandlerCreate(ctx context.Context, e event.CreateEvent, q workqueue.RateLimitingInterface) {
 	networkList := r.controller.GetObjectList()
 	err := r.List(ctx,
@@ -242,8 +456,18 @@ func (r *genericNetworkReconciler) deleteNetAttDef(ctx context.Context, cr netwo
 	if namespace == "" {
 		namespace = cr.GetNamespace()
 	}
-	instance := &netattdefv1.NetworkAttachmentDefinition{ObjectMeta: metav1.ObjectMeta{Name: cr.GetName(), Namespace: namespace}}
-	err := r.Delete(ctx, instance)
+	instance := &netattdefv1.NetworkAttachmentDefinition{}
+	err := r.Get(ctx, types.NamespacedName{Name: cr.NetAttDefName(), Namespace: namespace}, instance)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	if r.ownedByAnotherNetwork(instance, cr) {
+		return nil
+	}
+	err = r.Delete(ctx, instance)
 	if err != nil {
 		if errors.IsNotFound(err) {
 			return nil
@@ -252,3 +476,82 @@ func (r *genericNetworkReconciler) deleteNetAttDef(ctx context.Context, cr netwo
 	}
 	return nil
 }
+
+// ownerRefAnnotationPrefix returns the prefix of the owner-ref annotation value
+// for the network kind handled by the controller
+func (r *genericNetworkReconciler) ownerRefAnnotationPrefix() string {
+	return fmt.Sprintf("%s.%s", r.controller.Name(), sriovnetworkv1.GroupVersion.Group)
+}
+
+// setOwnerRefAnnotation marks the net-att-def CR as generated from the network object.
+// The value has the <Kind>.<Group>/<Namespace>/<Name> format.
+func (r *genericNetworkReconciler) setOwnerRefAnnotation(netAttDef *netattdefv1.NetworkAttachmentDefinition, cr networkCRInstance) {
+	annotations := netAttDef.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[sriovnetworkv1.OwnerRefAnnotation] = r.ownerRefAnnotationValue(cr)
+	netAttDef.SetAnnotations(annotations)
+}
+
+// ownerRefAnnotationValue returns the owner-ref annotation value referencing the network object
+func (r *genericNetworkReconciler) ownerRefAnnotationValue(cr networkCRInstance) string {
+	return fmt.Sprintf("%s/%s/%s", r.ownerRefAnnotationPrefix(), cr.GetNamespace(), cr.GetName())
+}
+
+// parseOwnerRefAnnotation returns the namespaced name of the network object referenced by
+// the owner-ref annotation of the net-att-def CR. The second return value is false if the
+// annotation is missing, malformed or references a network kind not handled by the controller.
+func (r *genericNetworkReconciler) parseOwnerRefAnnotation(netAttDef *netattdefv1.NetworkAttachmentDefinition) (types.NamespacedName, bool) {
+	value, ok := netAttDef.GetAnnotations()[sriovnetworkv1.OwnerRefAnnotation]
+	if !ok {
+		return types.NamespacedName{}, false
+	}
+	parts := strings.Split(value, "/")
+	if len(parts) != 3 || parts[0] != r.ownerRefAnnotationPrefix() || parts[1] == "" || parts[2] == "" {
+		return types.NamespacedName{}, false
+	}
+	return types.NamespacedName{Namespace: parts[1], Name: parts[2]}, true
+}
+
+// NetAttDefOwnerKind returns the <Kind>.<Group> prefix of the owner-ref annotation of the net-att-def CR,
+// it's used to index the net-att-defs generated by the network controllers
+func NetAttDefOwnerKind(o client.Object) []string {
+	value, ok := o.GetAnnotations()[sriovnetworkv1.OwnerRefAnnotation]
+	if !ok {
+		return nil
+	}
+	return []string{strings.Split(value, "/")[0]}
+}
+
+// cleanupOrphanedNetAttDefs deletes the net-att-def CRs generated by the controller
+// whose network object doesn't exist anymore
+func (r *genericNetworkReconciler) cleanupOrphanedNetAttDefs(ctx context.Context) {
+	logger := log.Log.WithName(r.controller.Name() + " reconciler")
+
+	netAttDefList := &netattdefv1.NetworkAttachmentDefinitionList{}
+	if err := r.List(ctx, netAttDefList, client.MatchingFields{NetAttDefOwnerKindIndex: r.ownerRefAnnotationPrefix()}); err != nil {
+		logger.Error(err, "Couldn't list NetworkAttachmentDefinition CRs")
+		return
+	}
+	for i := range netAttDefList.Items {
+		netAttDef := &netAttDefList.Items[i]
+		owner, ok := r.parseOwnerRefAnnotation(netAttDef)
+		if !ok {
+			continue
+		}
+		err := r.Get(ctx, owner, r.controller.GetObject())
+		if err == nil {
+			continue
+		}
+		if !errors.IsNotFound(err) {
+			logger.Error(err, "Couldn't get "+r.controller.Name(), "Namespace", owner.Namespace, "Name", owner.Name)
+			continue
+		}
+		logger.Info("delete orphaned NetworkAttachmentDefinition CR", "Namespace", netAttDef.Namespace, "Name", netAttDef.Name,
+			r.controller.Name(), owner)
+		if err := r.Delete(ctx, netAttDef); err != nil && !errors.IsNotFound(err) {
+			logger.Error(err, "Couldn't delete orphaned NetworkAttachmentDefinition CR", "Namespace", netAttDef.Namespace, "Name", netAttDef.Name)
+		}
+	}
+}
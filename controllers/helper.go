@@ -22,6 +22,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"sort"
 	"strings"
 
 	errs "github.com/pkg/errors"
@@ -46,6 +47,7 @@ import (
 	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/apply"
 	constants "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/consts"
 	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/render"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/utils"
 	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/vars"
 )
 
@@ -126,6 +128,12 @@ func (DrainStateAnnotationPredicate) Update(e event.UpdateEvent) bool {
 		return false
 	}
 
+	// an immediate drain attempt was requested
+	if !utils.ObjectHasAnnotationKey(e.ObjectOld, constants.NodeStateDrainNowAnnotation) &&
+		utils.ObjectHasAnnotationKey(e.ObjectNew, constants.NodeStateDrainNowAnnotation) {
+		return true
+	}
+
 	oldAnno, hasOldAnno := e.ObjectOld.GetLabels()[constants.NodeStateDrainAnnotationCurrent]
 	newAnno, hasNewAnno := e.ObjectNew.GetLabels()[constants.NodeStateDrainAnnotationCurrent]
 
@@ -176,6 +184,15 @@ func GetNodeSelectorForDevicePlugin(dc *sriovnetworkv1.SriovOperatorConfig) map[
 	return tmp.ConfigDaemonNodeSelector
 }
 
+// GetConfigDaemonPriorityClassName returns the priority class of the sriov-network-config-daemon
+// and sriov-device-plugin pods
+func GetConfigDaemonPriorityClassName(dc *sriovnetworkv1.SriovOperatorConfig) string {
+	if dc.Spec.ConfigDaemonPriorityClassName != "" {
+		return dc.Spec.ConfigDaemonPriorityClassName
+	}
+	return constants.DefaultConfigDaemonPriorityClassName
+}
+
 func syncPluginDaemonObjs(ctx context.Context,
 	client k8sclient.Client,
 	scheme *runtime.Scheme,
@@ -192,6 +209,7 @@ func syncPluginDaemonObjs(ctx context.Context,
 	data.Data["ImagePullSecrets"] = GetImagePullSecrets()
 	data.Data["NodeSelectorField"] = GetNodeSelectorForDevicePlugin(dc)
 	data.Data["UseCDI"] = dc.Spec.UseCDI
+	data.Data["PriorityClassName"] = GetConfigDaemonPriorityClassName(dc)
 	objs, err := renderDsForCR(constants.PluginPath, &data)
 	if err != nil {
 		logger.Error(err, "Fail to render SR-IoV manifests")
@@ -408,3 +426,98 @@ func findNodePoolConfig(ctx context.Context, node *corev1.Node, c k8sclient.Clie
 		return defaultPoolConfig, defaultNodeLists, nil
 	}
 }
+
+// getResourcePrefix returns the prefix overriding the global resource prefix for the resource, i.e. the
+// ResourcePrefix of the SriovNetworkPoolConfig of the nodes selected by the policies exposing the resource.
+// An empty string is returned if the global resource prefix is used and an error is returned if
+// the resource is advertised with different prefixes on different nodes.
+func getResourcePrefix(ctx context.Context, c k8sclient.Client, resourceName string) (string, error) {
+	npcl := &sriovnetworkv1.SriovNetworkPoolConfigList{}
+	if err := c.List(ctx, npcl); err != nil {
+		return "", err
+	}
+	pools := []sriovnetworkv1.SriovNetworkPoolConfig{}
+	prefixOverridden := false
+	for _, npc := range npcl.Items {
+		// we skip hw offload objects
+		if npc.Spec.OvsHardwareOffloadConfig.Name != "" {
+			continue
+		}
+		pools = append(pools, npc)
+		prefixOverridden = prefixOverridden || npc.Spec.ResourcePrefix != ""
+	}
+	if !prefixOverridden {
+		return "", nil
+	}
+
+	npl := &sriovnetworkv1.SriovNetworkNodePolicyList{}
+	if err := c.List(ctx, npl); err != nil {
+		return "", err
+	}
+	policies := []sriovnetworkv1.SriovNetworkNodePolicy{}
+	for _, p := range npl.Items {
+		if p.Name != constants.DefaultPolicyName && p.Spec.ResourceName == resourceName {
+			policies = append(policies, p)
+		}
+	}
+	if len(policies) == 0 {
+		return "", nil
+	}
+
+	nodeList := &corev1.NodeList{}
+	if err := c.List(ctx, nodeList); err != nil {
+		return "", err
+	}
+	prefixes := map[string]bool{}
+	for i := range nodeList.Items {
+		node := &nodeList.Items[i]
+		for _, p := range policies {
+			if !p.Selected(node) {
+				continue
+			}
+			prefix, err := poolResourcePrefix(pools, node)
+			if err != nil {
+				return "", err
+			}
+			prefixes[prefix] = true
+			break
+		}
+	}
+
+	found := []string{}
+	for prefix := range prefixes {
+		found = append(found, prefix)
+	}
+	switch len(found) {
+	case 0:
+		return "", nil
+	case 1:
+		return found[0], nil
+	}
+	for i := range found {
+		if found[i] == "" {
+			found[i] = vars.ResourcePrefix
+		}
+	}
+	sort.Strings(found)
+	return "", fmt.Errorf("resource %s is advertised with different prefixes %v", resourceName, found)
+}
+
+// poolResourcePrefix returns the resource prefix of the pool the node belongs to,
+// an empty string is returned if the pool doesn't override the global resource prefix
+func poolResourcePrefix(pools []sriovnetworkv1.SriovNetworkPoolConfig, node *corev1.Node) (string, error) {
+	for _, npc := range pools {
+		nodeSelector := npc.Spec.NodeSelector
+		if nodeSelector == nil {
+			nodeSelector = &metav1.LabelSelector{}
+		}
+		selector, err := metav1.LabelSelectorAsSelector(nodeSelector)
+		if err != nil {
+			return "", err
+		}
+		if selector.Matches(labels.Set(node.Labels)) {
+			return npc.Spec.ResourcePrefix, nil
+		}
+	}
+	return "", nil
+}
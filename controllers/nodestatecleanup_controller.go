@@ -0,0 +1,136 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	sriovnetworkv1 "github.com/k8snetworkplumbingwg/sriov-network-operator/api/v1"
+	constants "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/consts"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/vars"
+)
+
+// NodeStateCleanupReconciler handles the SriovNetworkNodeStates of the nodes removed from the cluster,
+// so the stale states don't skew the counts reported by the status controllers
+type NodeStateCleanupReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+}
+
+// nodeScopedAnnotations are the annotations of a SriovNetworkNodeState tracking requests and operations
+// on the node, they are meaningless once the node is removed
+var nodeScopedAnnotations = []string{
+	constants.NodeStateDrainNowAnnotation,
+	constants.NodeStateForceSystemdReapplyAnnotation,
+}
+
+//+kubebuilder:rbac:groups="",resources=nodes,verbs=get;list;watch
+//+kubebuilder:rbac:groups=sriovnetwork.openshift.io,resources=sriovnetworknodestates,verbs=get;list;watch;update;patch;delete
+
+// Reconcile cleans the node-scoped annotations of the SriovNetworkNodeState of a removed node and
+// deletes the state once the cleanup delay configured for the stale states has passed
+func (r *NodeStateCleanupReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx).WithValues("node", req.Name)
+	logger.V(2).Info("Reconciling")
+
+	node := &corev1.Node{}
+	err := r.Get(ctx, types.NamespacedName{Name: req.Name}, node)
+	if err == nil {
+		return reconcile.Result{}, nil
+	}
+	if !errors.IsNotFound(err) {
+		return reconcile.Result{}, err
+	}
+
+	nodeState := &sriovnetworkv1.SriovNetworkNodeState{}
+	err = r.Get(ctx, types.NamespacedName{Namespace: vars.Namespace, Name: req.Name}, nodeState)
+	if err != nil {
+		return reconcile.Result{}, client.IgnoreNotFound(err)
+	}
+	if err := r.cleanNodeScopedAnnotations(ctx, nodeState); err != nil {
+		return reconcile.Result{}, err
+	}
+	// the state is kept for the cleanup delay, a node reprovisioned with the same name keeps its state
+	keepFor, err := handleStaleNodeState(ctx, r.Client, nodeState)
+	if err != nil {
+		return reconcile.Result{}, fmt.Errorf("failed to handle the stale SriovNetworkNodeState %s: %v", nodeState.Name, err)
+	}
+	if keepFor > 0 {
+		logger.V(2).Info("Keeping the SriovNetworkNodeState of the removed node", "keepFor", keepFor)
+		return reconcile.Result{RequeueAfter: keepFor}, nil
+	}
+	return reconcile.Result{}, nil
+}
+
+// cleanNodeScopedAnnotations removes the node-scoped annotations from the state of a removed node
+// and resets its drain state, the node is not drained anymore once it is removed
+func (r *NodeStateCleanupReconciler) cleanNodeScopedAnnotations(ctx context.Context, nodeState *sriovnetworkv1.SriovNetworkNodeState) error {
+	original := nodeState.DeepCopy()
+	annotations := nodeState.GetAnnotations()
+	for _, key := range nodeScopedAnnotations {
+		delete(annotations, key)
+	}
+	if _, exist := annotations[constants.NodeStateDrainAnnotationCurrent]; exist {
+		annotations[constants.NodeStateDrainAnnotationCurrent] = constants.DrainIdle
+	}
+	if equality.Semantic.DeepEqual(original.GetAnnotations(), annotations) {
+		return nil
+	}
+	nodeState.SetAnnotations(annotations)
+	if err := r.Patch(ctx, nodeState, client.MergeFrom(original)); err != nil {
+		return fmt.Errorf("failed to clean the annotations of SriovNetworkNodeState %s: %v", nodeState.Name, err)
+	}
+	return nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *NodeStateCleanupReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	// the node states are checked when created, which includes the initial list on startup,
+	// to catch the nodes deleted while the operator was not running
+	nodeStatePredicates := builder.WithPredicates(predicate.Funcs{
+		CreateFunc:  func(_ event.CreateEvent) bool { return true },
+		UpdateFunc:  func(_ event.UpdateEvent) bool { return false },
+		DeleteFunc:  func(_ event.DeleteEvent) bool { return false },
+		GenericFunc: func(_ event.GenericEvent) bool { return false },
+	})
+	nodePredicates := builder.WithPredicates(predicate.Funcs{
+		CreateFunc:  func(_ event.CreateEvent) bool { return false },
+		UpdateFunc:  func(_ event.UpdateEvent) bool { return false },
+		DeleteFunc:  func(_ event.DeleteEvent) bool { return true },
+		GenericFunc: func(_ event.GenericEvent) bool { return false },
+	})
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&sriovnetworkv1.SriovNetworkNodeState{}, nodeStatePredicates).
+		Watches(&corev1.Node{}, &handler.EnqueueRequestForObject{}, nodePredicates).
+		Complete(r)
+}
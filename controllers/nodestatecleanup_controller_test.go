@@ -0,0 +1,143 @@
+package controllers
+
+import (
+	"context"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	sriovnetworkv1 "github.com/k8snetworkplumbingwg/sriov-network-operator/api/v1"
+	constants "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/consts"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/vars"
+)
+
+func TestNodeStateCleanupKeepsStateOfRemovedNode(t *testing.T) {
+	g := NewGomegaWithT(t)
+	t.Setenv("STALE_NODE_STATE_CLEANUP_DELAY_MINUTES", "5")
+
+	nodeState := &sriovnetworkv1.SriovNetworkNodeState{
+		ObjectMeta: metav1.ObjectMeta{Name: "removed-node", Namespace: vars.Namespace,
+			Annotations: map[string]string{
+				constants.NodeStateDrainAnnotationCurrent: constants.Draining,
+				constants.NodeStateDrainNowAnnotation:     "true",
+			}},
+	}
+	s := runtime.NewScheme()
+	utilruntime.Must(sriovnetworkv1.AddToScheme(s))
+	utilruntime.Must(corev1.AddToScheme(s))
+	r := &NodeStateCleanupReconciler{
+		Client: fake.NewClientBuilder().WithScheme(s).WithObjects(nodeState).Build(),
+		Scheme: s,
+	}
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: nodeState.Name}}
+
+	// the state is kept until the cleanup delay has passed
+	result, err := r.Reconcile(context.Background(), req)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(result.RequeueAfter).To(BeNumerically(">", 4*time.Minute))
+
+	current := &sriovnetworkv1.SriovNetworkNodeState{}
+	g.Expect(r.Get(context.Background(), types.NamespacedName{Name: nodeState.Name, Namespace: vars.Namespace}, current)).To(Succeed())
+	g.Expect(current.GetKeepUntilTime().IsZero()).To(BeFalse())
+	g.Expect(current.GetAnnotations()).ToNot(HaveKey(constants.NodeStateDrainNowAnnotation))
+	g.Expect(current.GetAnnotations()).To(HaveKeyWithValue(constants.NodeStateDrainAnnotationCurrent, constants.DrainIdle))
+
+	// the state is deleted once the keep until time is reached
+	current.SetKeepUntilTime(time.Now().Add(-time.Minute))
+	g.Expect(r.Update(context.Background(), current)).To(Succeed())
+	result, err = r.Reconcile(context.Background(), req)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(result.RequeueAfter).To(BeZero())
+	err = r.Get(context.Background(), types.NamespacedName{Name: nodeState.Name, Namespace: vars.Namespace}, current)
+	g.Expect(errors.IsNotFound(err)).To(BeTrue())
+}
+
+var _ = Describe("NodeStateCleanup controller", Ordered, func() {
+	var cancel context.CancelFunc
+	var ctx context.Context
+
+	BeforeAll(func() {
+		// disable stale state cleanup delay to check that the controller can cleanup state objects
+		DeferCleanup(os.Setenv, "STALE_NODE_STATE_CLEANUP_DELAY_MINUTES", os.Getenv("STALE_NODE_STATE_CLEANUP_DELAY_MINUTES"))
+		os.Setenv("STALE_NODE_STATE_CLEANUP_DELAY_MINUTES", "0")
+
+		By("Setup controller manager")
+		k8sManager, err := setupK8sManagerForTest()
+		Expect(err).ToNot(HaveOccurred())
+
+		err = (&NodeStateCleanupReconciler{
+			Client: k8sManager.GetClient(),
+			Scheme: k8sManager.GetScheme(),
+		}).SetupWithManager(k8sManager)
+		Expect(err).ToNot(HaveOccurred())
+
+		ctx, cancel = context.WithCancel(context.Background())
+
+		wg := sync.WaitGroup{}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer GinkgoRecover()
+			By("Start controller manager")
+			err := k8sManager.Start(ctx)
+			Expect(err).ToNot(HaveOccurred())
+		}()
+
+		DeferCleanup(func() {
+			By("Shutdown controller manager")
+			cancel()
+			wg.Wait()
+		})
+	})
+
+	Context("When is up", func() {
+		It("should delete the node state of a deleted node", func() {
+			node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "cleanup-node-0"}}
+			Expect(k8sClient.Create(ctx, node)).To(Succeed())
+			nodeState := &sriovnetworkv1.SriovNetworkNodeState{
+				ObjectMeta: metav1.ObjectMeta{Name: "cleanup-node-0", Namespace: testNamespace},
+			}
+			Expect(k8sClient.Create(ctx, nodeState)).To(Succeed())
+
+			// the node state of an existing node is kept
+			Consistently(func(g Gomega) {
+				g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: nodeState.Name, Namespace: testNamespace},
+					&sriovnetworkv1.SriovNetworkNodeState{})).To(Succeed())
+			}, "1s", "100ms").Should(Succeed())
+
+			Expect(k8sClient.Delete(ctx, node)).To(Succeed())
+
+			Eventually(func(g Gomega) {
+				err := k8sClient.Get(ctx, types.NamespacedName{Name: nodeState.Name, Namespace: testNamespace},
+					&sriovnetworkv1.SriovNetworkNodeState{})
+				g.Expect(errors.IsNotFound(err)).To(BeTrue())
+			}, "10s", "100ms").Should(Succeed())
+		})
+
+		It("should delete the node state created for a node which doesn't exist", func() {
+			nodeState := &sriovnetworkv1.SriovNetworkNodeState{
+				ObjectMeta: metav1.ObjectMeta{Name: "cleanup-node-1", Namespace: testNamespace},
+			}
+			Expect(k8sClient.Create(ctx, nodeState)).To(Succeed())
+
+			Eventually(func(g Gomega) {
+				err := k8sClient.Get(ctx, types.NamespacedName{Name: nodeState.Name, Namespace: testNamespace},
+					&sriovnetworkv1.SriovNetworkNodeState{})
+				g.Expect(errors.IsNotFound(err)).To(BeTrue())
+			}, "10s", "100ms").Should(Succeed())
+		})
+	})
+})
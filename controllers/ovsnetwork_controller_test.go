@@ -2,6 +2,7 @@ package controllers
 
 import (
 	"context"
+	"encoding/json"
 	"sync"
 	"time"
 
@@ -157,6 +158,49 @@ var _ = Describe("OVSNetwork Controller", Ordered, func() {
 				g.Expect(netAttDef.GetUID()).NotTo(Equal(origUID))
 			}, util.APITimeout, util.RetryInterval).Should(Succeed())
 		})
+		DescribeTable("link state",
+			func(linkState, expected string) {
+				netCR := getOvsNetworkCR()
+				netCR.Spec.LinkState = linkState
+
+				By("Create OVSNetwork CR")
+				Expect(k8sClient.Create(ctx, netCR)).NotTo(HaveOccurred())
+				DeferCleanup(func() { removeOVSNetwork(ctx, netCR) })
+
+				By("Check NetworkAttachmentDefinition is created with the link state")
+				Eventually(func(g Gomega) {
+					netAttDef := &netattdefv1.NetworkAttachmentDefinition{}
+					g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "test",
+						Namespace: testNamespace}, netAttDef)).NotTo(HaveOccurred())
+					g.Expect(netAttDef.Spec.Config).To(ContainSubstring(expected))
+				}, util.APITimeout, util.RetryInterval).Should(Succeed())
+			},
+			Entry("auto", "auto", `"link_state": "auto"`),
+			Entry("enable", "enable", `"link_state": "enable"`),
+			Entry("disable", "disable", `"link_state": "disable"`),
+		)
+		DescribeTable("CNI type",
+			func(cniType, expected string) {
+				netCR := getOvsNetworkCR()
+				netCR.Spec.CniType = cniType
+
+				By("Create OVSNetwork CR")
+				Expect(k8sClient.Create(ctx, netCR)).NotTo(HaveOccurred())
+				DeferCleanup(func() { removeOVSNetwork(ctx, netCR) })
+
+				By("Check NetworkAttachmentDefinition is created with the CNI type")
+				Eventually(func(g Gomega) {
+					netAttDef := &netattdefv1.NetworkAttachmentDefinition{}
+					g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "test",
+						Namespace: testNamespace}, netAttDef)).NotTo(HaveOccurred())
+					config := map[string]interface{}{}
+					g.Expect(json.Unmarshal([]byte(netAttDef.Spec.Config), &config)).NotTo(HaveOccurred())
+					g.Expect(config).To(HaveKeyWithValue("type", expected))
+				}, util.APITimeout, util.RetryInterval).Should(Succeed())
+			},
+			Entry("default", "", "ovs"),
+			Entry("override", "ovs-custom", "ovs-custom"),
+		)
 		It("namespace is not yet created", func() {
 			newNSName := "test-ns"
 			netCR := getOvsNetworkCR()
@@ -2,6 +2,7 @@ package controllers
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"strings"
@@ -10,6 +11,7 @@ import (
 
 	netattdefv1 "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"k8s.io/apimachinery/pkg/types"
@@ -96,6 +98,11 @@ var _ = Describe("SriovNetwork Controller", Ordered, func() {
 				LogLevel:     "debug",
 				LogFile:      "/tmp/tmpfile",
 			},
+			"test-6": {
+				ResourceName: "resource_1",
+				IPAM:         `{"type":"host-local","subnet":"10.56.217.0/24","rangeStart":"10.56.217.171","rangeEnd":"10.56.217.181","routes":[{"dst":"0.0.0.0/0"}],"gateway":"10.56.217.1"}`,
+				ClearVlan:    true,
+			},
 		}
 		sriovnets := util.GenerateSriovNetworkCRs(testNamespace, specs)
 		DescribeTable("should be possible to create/delete net-att-def",
@@ -135,6 +142,7 @@ var _ = Describe("SriovNetwork Controller", Ordered, func() {
 			Entry("with SpoofChk flag on", sriovnets["test-2"]),
 			Entry("with Trust flag on", sriovnets["test-3"]),
 			Entry("with LogLevel and LogFile", sriovnets["test-5"]),
+			Entry("with ClearVlan flag", sriovnets["test-6"]),
 		)
 
 		newSpecs := map[string]sriovnetworkv1.SriovNetworkSpec{
@@ -260,6 +268,62 @@ var _ = Describe("SriovNetwork Controller", Ordered, func() {
 			})
 		})
 
+		Context("When the pools override the resource prefix", func() {
+			It("should reference the resources with the prefix of their pool", func() {
+				for _, pool := range []string{"a", "b"} {
+					node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{
+						Name:   "prefix-node-" + pool,
+						Labels: map[string]string{"pool": pool},
+					}}
+					Expect(k8sClient.Create(ctx, node)).To(Succeed())
+					DeferCleanup(k8sClient.Delete, ctx, node)
+
+					poolConfig := &sriovnetworkv1.SriovNetworkPoolConfig{
+						ObjectMeta: metav1.ObjectMeta{Name: "prefix-pool-" + pool, Namespace: testNamespace},
+						Spec: sriovnetworkv1.SriovNetworkPoolConfigSpec{
+							NodeSelector:   &metav1.LabelSelector{MatchLabels: map[string]string{"pool": pool}},
+							ResourcePrefix: pool + ".example.com",
+						},
+					}
+					Expect(k8sClient.Create(ctx, poolConfig)).To(Succeed())
+					DeferCleanup(k8sClient.Delete, ctx, poolConfig)
+
+					policy := &sriovnetworkv1.SriovNetworkNodePolicy{
+						ObjectMeta: metav1.ObjectMeta{Name: "prefix-policy-" + pool, Namespace: testNamespace},
+						Spec: sriovnetworkv1.SriovNetworkNodePolicySpec{
+							ResourceName: "resource_prefix_" + pool,
+							NumVfs:       4,
+							NodeSelector: map[string]string{"pool": pool},
+							NicSelector:  sriovnetworkv1.SriovNetworkNicSelector{Vendor: "8086"},
+						},
+					}
+					Expect(k8sClient.Create(ctx, policy)).To(Succeed())
+					DeferCleanup(k8sClient.Delete, ctx, policy)
+				}
+
+				for _, pool := range []string{"a", "b"} {
+					cr := &sriovnetworkv1.SriovNetwork{
+						ObjectMeta: metav1.ObjectMeta{Name: "prefix-network-" + pool, Namespace: testNamespace},
+						Spec: sriovnetworkv1.SriovNetworkSpec{
+							ResourceName: "resource_prefix_" + pool,
+							IPAM:         `{"type":"dhcp"}`,
+						},
+					}
+					Expect(k8sClient.Create(ctx, cr)).To(Succeed())
+					DeferCleanup(k8sClient.Delete, ctx, cr)
+				}
+
+				for _, pool := range []string{"a", "b"} {
+					Eventually(func(g Gomega) {
+						netAttDef := &netattdefv1.NetworkAttachmentDefinition{}
+						g.Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: testNamespace, Name: "prefix-network-" + pool}, netAttDef)).To(Succeed())
+						g.Expect(netAttDef.GetAnnotations()).To(HaveKeyWithValue("k8s.v1.cni.cncf.io/resourceName",
+							pool+".example.com/resource_prefix_"+pool))
+					}, util.APITimeout, util.RetryInterval).Should(Succeed())
+				}
+			})
+		})
+
 		Context("When the target NetworkNamespace doesn't exists", func() {
 			It("should create the NetAttachDef when the namespace is created", func() {
 				cr := sriovnetworkv1.SriovNetwork{
@@ -314,6 +378,60 @@ var _ = Describe("SriovNetwork Controller", Ordered, func() {
 			})
 		})
 
+		It("should render the CNI type override", func() {
+			cr := sriovnetworkv1.SriovNetwork{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-cnitype",
+					Namespace: testNamespace,
+				},
+				Spec: sriovnetworkv1.SriovNetworkSpec{
+					ResourceName:     "resource_1",
+					IPAM:             `{"type":"dhcp"}`,
+					NetworkNamespace: "default",
+					CniType:          "sriov-custom",
+				},
+			}
+
+			err := k8sClient.Create(ctx, &cr)
+			Expect(err).NotTo(HaveOccurred())
+			DeferCleanup(k8sClient.Delete, ctx, &cr)
+
+			netAttDef := &netattdefv1.NetworkAttachmentDefinition{}
+			err = util.WaitForNamespacedObject(netAttDef, k8sClient, "default", cr.GetName(), util.RetryInterval, util.Timeout)
+			Expect(err).NotTo(HaveOccurred())
+
+			config := map[string]interface{}{}
+			Expect(json.Unmarshal([]byte(netAttDef.Spec.Config), &config)).NotTo(HaveOccurred())
+			Expect(config).To(HaveKeyWithValue("type", "sriov-custom"))
+		})
+
+		It("should render the preferred PF hint", func() {
+			cr := sriovnetworkv1.SriovNetwork{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-preferredpf",
+					Namespace: testNamespace,
+				},
+				Spec: sriovnetworkv1.SriovNetworkSpec{
+					ResourceName:     "resource_1",
+					IPAM:             `{"type":"dhcp"}`,
+					NetworkNamespace: "default",
+					PreferredPf:      "ens1f0",
+				},
+			}
+
+			err := k8sClient.Create(ctx, &cr)
+			Expect(err).NotTo(HaveOccurred())
+			DeferCleanup(k8sClient.Delete, ctx, &cr)
+
+			netAttDef := &netattdefv1.NetworkAttachmentDefinition{}
+			err = util.WaitForNamespacedObject(netAttDef, k8sClient, "default", cr.GetName(), util.RetryInterval, util.Timeout)
+			Expect(err).NotTo(HaveOccurred())
+
+			config := map[string]interface{}{}
+			Expect(json.Unmarshal([]byte(netAttDef.Spec.Config), &config)).NotTo(HaveOccurred())
+			Expect(config).To(HaveKeyWithValue("preferredPf", "ens1f0"))
+		})
+
 		It("should preserve user defined annotations", func() {
 			cr := sriovnetworkv1.SriovNetwork{
 				ObjectMeta: metav1.ObjectMeta{
@@ -342,12 +460,288 @@ var _ = Describe("SriovNetwork Controller", Ordered, func() {
 				MustPassRepeatedly(10).
 				Should(Succeed())
 		})
+
+		It("should add the requested labels and annotations to the net-att-def", func() {
+			cr := sriovnetworkv1.SriovNetwork{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-nadmetadata",
+					Namespace: testNamespace,
+				},
+				Spec: sriovnetworkv1.SriovNetworkSpec{
+					ResourceName:     "resource_1",
+					NetworkNamespace: "default",
+					NadLabels:        map[string]string{"app.kubernetes.io/part-of": "telco"},
+					NadAnnotations: map[string]string{
+						"example.com/team":                "networking",
+						"k8s.v1.cni.cncf.io/resourceName": "example.com/other_resource",
+						sriovnetworkv1.OwnerRefAnnotation: "user-netattdef",
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, &cr)).To(Succeed())
+			DeferCleanup(k8sClient.Delete, ctx, &cr)
+
+			netAttDef := &netattdefv1.NetworkAttachmentDefinition{}
+			err := util.WaitForNamespacedObject(netAttDef, k8sClient, "default", cr.GetName(), util.RetryInterval, util.Timeout)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(netAttDef.GetLabels()).To(HaveKeyWithValue("app.kubernetes.io/part-of", "telco"))
+			Expect(netAttDef.GetAnnotations()).To(HaveKeyWithValue("example.com/team", "networking"))
+			// the annotations managed by the operator are preserved
+			Expect(netAttDef.GetAnnotations()).To(HaveKeyWithValue("k8s.v1.cni.cncf.io/resourceName", "openshift.io/resource_1"))
+			Expect(netAttDef.GetAnnotations()).To(HaveKeyWithValue(sriovnetworkv1.OwnerRefAnnotation,
+				"SriovNetwork.sriovnetwork.openshift.io/"+testNamespace+"/test-nadmetadata"))
+
+			By("updating the labels of the net-att-def when they change")
+			Expect(retry.RetryOnConflict(retry.DefaultRetry, func() error {
+				network := &sriovnetworkv1.SriovNetwork{}
+				if err := k8sClient.Get(ctx, types.NamespacedName{Name: cr.GetName(), Namespace: testNamespace}, network); err != nil {
+					return err
+				}
+				network.Spec.NadLabels = map[string]string{"app.kubernetes.io/part-of": "core"}
+				return k8sClient.Update(ctx, network)
+			})).To(Succeed())
+			Eventually(func(g Gomega) {
+				netAttDef := &netattdefv1.NetworkAttachmentDefinition{}
+				g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: cr.GetName(), Namespace: "default"}, netAttDef)).To(Succeed())
+				g.Expect(netAttDef.GetLabels()).To(HaveKeyWithValue("app.kubernetes.io/part-of", "core"))
+			}, util.Timeout, util.RetryInterval).Should(Succeed())
+		})
+
+		Context("When the NetworkName is set", func() {
+			It("should generate the net-att-def with the custom name", func() {
+				cr := sriovnetworkv1.SriovNetwork{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "test-networkname",
+						Namespace: testNamespace,
+					},
+					Spec: sriovnetworkv1.SriovNetworkSpec{
+						ResourceName:     "resource_1",
+						NetworkNamespace: "default",
+						NetworkName:      "legacy-network",
+					},
+				}
+				Expect(k8sClient.Create(ctx, &cr)).To(Succeed())
+
+				netAttDef := &netattdefv1.NetworkAttachmentDefinition{}
+				err := util.WaitForNamespacedObject(netAttDef, k8sClient, "default", "legacy-network", util.RetryInterval, util.Timeout)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(netAttDef.GetAnnotations()).To(HaveKeyWithValue(sriovnetworkv1.OwnerRefAnnotation,
+					"SriovNetwork.sriovnetwork.openshift.io/"+testNamespace+"/test-networkname"))
+				config := map[string]interface{}{}
+				Expect(json.Unmarshal([]byte(netAttDef.Spec.Config), &config)).To(Succeed())
+				Expect(config).To(HaveKeyWithValue("name", "legacy-network"))
+
+				err = k8sClient.Get(ctx, types.NamespacedName{Name: cr.GetName(), Namespace: "default"}, &netattdefv1.NetworkAttachmentDefinition{})
+				Expect(errors.IsNotFound(err)).To(BeTrue())
+
+				By("regenerating the net-att-def when it is removed")
+				Expect(k8sClient.Delete(ctx, netAttDef)).To(Succeed())
+				err = util.WaitForNamespacedObject(&netattdefv1.NetworkAttachmentDefinition{}, k8sClient, "default", "legacy-network", util.RetryInterval, util.Timeout)
+				Expect(err).NotTo(HaveOccurred())
+
+				By("renaming the net-att-def when the NetworkName changes")
+				Expect(retry.RetryOnConflict(retry.DefaultRetry, func() error {
+					network := &sriovnetworkv1.SriovNetwork{}
+					if err := k8sClient.Get(ctx, types.NamespacedName{Name: cr.GetName(), Namespace: testNamespace}, network); err != nil {
+						return err
+					}
+					network.Spec.NetworkName = "renamed-network"
+					return k8sClient.Update(ctx, network)
+				})).To(Succeed())
+				err = util.WaitForNamespacedObject(&netattdefv1.NetworkAttachmentDefinition{}, k8sClient, "default", "renamed-network", util.RetryInterval, util.Timeout)
+				Expect(err).NotTo(HaveOccurred())
+				err = util.WaitForNamespacedObjectDeleted(&netattdefv1.NetworkAttachmentDefinition{}, k8sClient, "default", "legacy-network", util.RetryInterval, util.Timeout)
+				Expect(err).NotTo(HaveOccurred())
+
+				By("deleting the net-att-def with the SriovNetwork")
+				Expect(k8sClient.Delete(ctx, &cr)).To(Succeed())
+				err = util.WaitForNamespacedObjectDeleted(&netattdefv1.NetworkAttachmentDefinition{}, k8sClient, "default", "renamed-network", util.RetryInterval, util.Timeout)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("should report a conflict with the net-att-def of another network as Degraded", func() {
+				other := &netattdefv1.NetworkAttachmentDefinition{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "taken-network",
+						Namespace: "default",
+						Annotations: map[string]string{
+							sriovnetworkv1.OwnerRefAnnotation: "OVSNetwork.sriovnetwork.openshift.io/" + testNamespace + "/taken-network",
+						},
+					},
+					Spec: netattdefv1.NetworkAttachmentDefinitionSpec{Config: emptyCurls},
+				}
+				Expect(k8sClient.Create(ctx, other)).To(Succeed())
+				DeferCleanup(k8sClient.Delete, ctx, other)
+
+				cr := sriovnetworkv1.SriovNetwork{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "test-networkname-conflict",
+						Namespace: testNamespace,
+					},
+					Spec: sriovnetworkv1.SriovNetworkSpec{
+						ResourceName:     "resource_1",
+						NetworkNamespace: "default",
+						NetworkName:      "taken-network",
+					},
+				}
+				Expect(k8sClient.Create(ctx, &cr)).To(Succeed())
+
+				Eventually(func(g Gomega) {
+					network := &sriovnetworkv1.SriovNetwork{}
+					g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: cr.GetName(), Namespace: testNamespace}, network)).To(Succeed())
+					g.Expect(network.Status.Conditions).To(ContainElement(And(
+						HaveField("Type", sriovnetworkv1.ConditionDegraded),
+						HaveField("Status", metav1.ConditionTrue),
+						HaveField("Reason", sriovnetworkv1.ReasonNetworkNameConflict),
+					)))
+				}, util.Timeout, util.RetryInterval).Should(Succeed())
+
+				netAttDef := &netattdefv1.NetworkAttachmentDefinition{}
+				Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "taken-network", Namespace: "default"}, netAttDef)).To(Succeed())
+				Expect(netAttDef.Spec.Config).To(Equal(emptyCurls))
+				Expect(netAttDef.GetAnnotations()).To(Equal(other.GetAnnotations()))
+
+				By("keeping the net-att-def of the other network when the SriovNetwork is deleted")
+				Expect(k8sClient.Delete(ctx, &cr)).To(Succeed())
+				err := util.WaitForNamespacedObjectDeleted(&sriovnetworkv1.SriovNetwork{}, k8sClient, testNamespace, cr.GetName(), util.RetryInterval, util.Timeout)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "taken-network", Namespace: "default"}, &netattdefv1.NetworkAttachmentDefinition{})).To(Succeed())
+			})
+
+			It("should report a resource not exposed by any policy as Degraded", func() {
+				cr := sriovnetworkv1.SriovNetwork{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "test-missing-resource",
+						Namespace: testNamespace,
+					},
+					Spec: sriovnetworkv1.SriovNetworkSpec{
+						ResourceName:     "renamed_resource",
+						NetworkNamespace: "default",
+					},
+				}
+				Expect(k8sClient.Create(ctx, &cr)).To(Succeed())
+				DeferCleanup(k8sClient.Delete, ctx, &cr)
+
+				Eventually(func(g Gomega) {
+					network := &sriovnetworkv1.SriovNetwork{}
+					g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: cr.GetName(), Namespace: testNamespace}, network)).To(Succeed())
+					g.Expect(network.Status.Conditions).To(ContainElement(And(
+						HaveField("Type", sriovnetworkv1.ConditionDegraded),
+						HaveField("Status", metav1.ConditionTrue),
+						HaveField("Reason", sriovnetworkv1.ReasonResourceNotFound),
+						HaveField("Message", ContainSubstring("renamed_resource")),
+					)))
+				}, util.Timeout, util.RetryInterval).Should(Succeed())
+				err := util.WaitForNamespacedObject(&netattdefv1.NetworkAttachmentDefinition{}, k8sClient, "default", cr.GetName(), util.RetryInterval, util.Timeout)
+				Expect(err).NotTo(HaveOccurred())
+
+				By("clearing the condition when a policy exposes the resource")
+				policy := &sriovnetworkv1.SriovNetworkNodePolicy{
+					ObjectMeta: metav1.ObjectMeta{Name: "renamed-resource-policy", Namespace: testNamespace},
+					Spec: sriovnetworkv1.SriovNetworkNodePolicySpec{
+						ResourceName: "renamed_resource",
+						NumVfs:       1,
+						NodeSelector: map[string]string{"node-role.kubernetes.io/worker": ""},
+						NicSelector:  sriovnetworkv1.SriovNetworkNicSelector{Vendor: "8086"},
+					},
+				}
+				Expect(k8sClient.Create(ctx, policy)).To(Succeed())
+				DeferCleanup(k8sClient.Delete, ctx, policy)
+
+				Eventually(func(g Gomega) {
+					network := &sriovnetworkv1.SriovNetwork{}
+					g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: cr.GetName(), Namespace: testNamespace}, network)).To(Succeed())
+					g.Expect(network.Status.Conditions).ToNot(ContainElement(HaveField("Type", sriovnetworkv1.ConditionDegraded)))
+				}, util.Timeout, util.RetryInterval).Should(Succeed())
+			})
+		})
+	})
+})
+
+var _ = Describe("SriovNetwork Controller orphaned NetworkAttachmentDefinitions cleanup", Ordered, func() {
+	var cancel context.CancelFunc
+	var ctx context.Context
+
+	BeforeAll(func() {
+		period := orphanedNetAttDefCleanupPeriod
+		orphanedNetAttDefCleanupPeriod = 500 * time.Millisecond
+		DeferCleanup(func() { orphanedNetAttDefCleanupPeriod = period })
+
+		By("Setup controller manager")
+		k8sManager, err := setupK8sManagerForTest()
+		Expect(err).ToNot(HaveOccurred())
+
+		err = (&SriovNetworkReconciler{
+			Client: k8sManager.GetClient(),
+			Scheme: k8sManager.GetScheme(),
+		}).SetupWithManager(k8sManager)
+		Expect(err).ToNot(HaveOccurred())
+
+		ctx, cancel = context.WithCancel(context.Background())
+
+		wg := sync.WaitGroup{}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer GinkgoRecover()
+			By("Start controller manager")
+			err := k8sManager.Start(ctx)
+			Expect(err).ToNot(HaveOccurred())
+		}()
+
+		DeferCleanup(func() {
+			By("Shutdown controller manager")
+			cancel()
+			wg.Wait()
+		})
+	})
+
+	It("should delete net-att-def CRs whose SriovNetwork doesn't exist", func() {
+		orphaned := &netattdefv1.NetworkAttachmentDefinition{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "orphaned-netattdef",
+				Namespace: "default",
+				Annotations: map[string]string{
+					sriovnetworkv1.OwnerRefAnnotation: "SriovNetwork.sriovnetwork.openshift.io/" + testNamespace + "/orphaned-netattdef",
+				},
+			},
+			Spec: netattdefv1.NetworkAttachmentDefinitionSpec{Config: emptyCurls},
+		}
+		Expect(k8sClient.Create(ctx, orphaned)).To(Succeed())
+
+		Eventually(func(g Gomega) {
+			err := k8sClient.Get(ctx, types.NamespacedName{Name: orphaned.Name, Namespace: orphaned.Namespace}, &netattdefv1.NetworkAttachmentDefinition{})
+			g.Expect(err).To(HaveOccurred())
+			g.Expect(errors.IsNotFound(err)).To(BeTrue())
+		}, util.Timeout, util.RetryInterval).Should(Succeed())
+	})
+
+	It("should keep net-att-def CRs not generated by the operator", func() {
+		userDefined := &netattdefv1.NetworkAttachmentDefinition{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "user-netattdef",
+				Namespace: "default",
+				Annotations: map[string]string{
+					sriovnetworkv1.OwnerRefAnnotation: "user-netattdef",
+				},
+			},
+			Spec: netattdefv1.NetworkAttachmentDefinitionSpec{Config: emptyCurls},
+		}
+		Expect(k8sClient.Create(ctx, userDefined)).To(Succeed())
+		DeferCleanup(k8sClient.Delete, ctx, userDefined)
+
+		Consistently(func(g Gomega) {
+			err := k8sClient.Get(ctx, types.NamespacedName{Name: userDefined.Name, Namespace: userDefined.Namespace}, &netattdefv1.NetworkAttachmentDefinition{})
+			g.Expect(err).ToNot(HaveOccurred())
+		}, 3*time.Second, util.RetryInterval).Should(Succeed())
 	})
 })
 
 func generateExpectedNetConfig(cr *sriovnetworkv1.SriovNetwork) string {
 	spoofchk := ""
 	trust := ""
+	vlan := ""
+	vlanQoS := ""
 	vlanProto := ""
 	logLevel := `"logLevel":"info",`
 	logFile := ""
@@ -370,7 +764,10 @@ func generateExpectedNetConfig(cr *sriovnetworkv1.SriovNetwork) string {
 	if cr.Spec.IPAM != "" {
 		ipam = cr.Spec.IPAM
 	}
-	vlanQoS := cr.Spec.VlanQoS
+	if cr.Spec.Vlan != 0 || cr.Spec.ClearVlan {
+		vlan = fmt.Sprintf(`"vlan":%d,`, cr.Spec.Vlan)
+		vlanQoS = fmt.Sprintf(`"vlanQoS":%d,`, cr.Spec.VlanQoS)
+	}
 
 	if cr.Spec.VlanProto != "" {
 		vlanProto = fmt.Sprintf(`"vlanProto": "%s",`, cr.Spec.VlanProto)
@@ -383,8 +780,8 @@ func generateExpectedNetConfig(cr *sriovnetworkv1.SriovNetwork) string {
 	}
 
 	configStr, err := formatJSON(fmt.Sprintf(
-		`{ "cniVersion":"1.0.0", "name":"%s","type":"sriov","vlan":%d,%s%s"vlanQoS":%d,%s%s%s%s"ipam":%s }`,
-		cr.GetName(), cr.Spec.Vlan, spoofchk, trust, vlanQoS, vlanProto, state, logLevel, logFile, ipam))
+		`{ "cniVersion":"1.0.0", "name":"%s","type":"sriov",%s%s%s%s%s%s%s%s"ipam":%s }`,
+		cr.GetName(), vlan, spoofchk, trust, vlanQoS, vlanProto, state, logLevel, logFile, ipam))
 	if err != nil {
 		panic(err)
 	}
@@ -21,10 +21,12 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"path/filepath"
 	"reflect"
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
@@ -144,12 +146,10 @@ func (r *SriovNetworkNodePolicyReconciler) Reconcile(ctx context.Context, req ct
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *SriovNetworkNodePolicyReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	qHandler := func(q workqueue.RateLimitingInterface) {
-		q.AddAfter(reconcile.Request{NamespacedName: types.NamespacedName{
-			Namespace: "",
-			Name:      nodePolicySyncEventName,
-		}}, time.Second)
-	}
+	// every change restarts the delay, the changes received until the delay expires
+	// are rendered to the SriovNetworkNodeStates at once
+	debouncer := &nodePolicySyncDebouncer{delay: getNodePolicySyncDelay()}
+	qHandler := debouncer.trigger
 
 	delayedEventHandler := handler.Funcs{
 		CreateFunc: func(ctx context.Context, e event.CreateEvent, q workqueue.RateLimitingInterface) {
@@ -209,6 +209,46 @@ func (r *SriovNetworkNodePolicyReconciler) SetupWithManager(mgr ctrl.Manager) er
 		Complete(r)
 }
 
+// nodePolicySyncDebouncer adds the sync event to the workqueue once no change was received for the delay
+type nodePolicySyncDebouncer struct {
+	delay time.Duration
+	mu    sync.Mutex
+	timer *time.Timer
+}
+
+// trigger (re)starts the delay before the sync event is added to the workqueue
+func (d *nodePolicySyncDebouncer) trigger(q workqueue.RateLimitingInterface) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.timer != nil {
+		d.timer.Stop()
+	}
+	d.timer = time.AfterFunc(d.delay, func() {
+		q.Add(reconcile.Request{NamespacedName: types.NamespacedName{
+			Namespace: "",
+			Name:      nodePolicySyncEventName,
+		}})
+	})
+}
+
+// getNodePolicySyncDelay returns the delay to wait before syncing the SriovNetworkNodeStates after a change,
+// the delay is read from the NODE_POLICY_SYNC_DELAY env variable (e.g. "5s")
+func getNodePolicySyncDelay() time.Duration {
+	logger := log.Log.WithName("getNodePolicySyncDelay")
+
+	envValue, found := os.LookupEnv("NODE_POLICY_SYNC_DELAY")
+	if !found {
+		return constants.DefaultNodePolicySyncDelay
+	}
+	delay, err := time.ParseDuration(envValue)
+	if err != nil || delay < 0 {
+		logger.Error(err, "invalid value in NODE_POLICY_SYNC_DELAY env variable, use default delay",
+			"delay", constants.DefaultNodePolicySyncDelay)
+		return constants.DefaultNodePolicySyncDelay
+	}
+	return delay
+}
+
 func (r *SriovNetworkNodePolicyReconciler) syncDevicePluginConfigMap(ctx context.Context, dc *sriovnetworkv1.SriovOperatorConfig,
 	pl *sriovnetworkv1.SriovNetworkNodePolicyList, nl *corev1.NodeList) error {
 	logger := log.Log.WithName("syncDevicePluginConfigMap")
@@ -307,6 +347,9 @@ func (r *SriovNetworkNodePolicyReconciler) syncAllSriovNetworkNodeStates(ctx con
 		}
 		if netPoolConfig != nil {
 			ns.Spec.System.RdmaMode = netPoolConfig.Spec.RdmaMode
+			ns.Spec.System.OVSDBSocketPath = netPoolConfig.Spec.OVSDBSocketPath
+			ns.Spec.System.DrainTimeoutSeconds = netPoolConfig.Spec.DrainTimeoutSeconds
+			ns.Spec.System.ConfigurationMode = netPoolConfig.Spec.ConfigurationMode
 		}
 		j, _ := json.Marshal(ns)
 		logger.V(2).Info("SriovNetworkNodeState CR", "content", j)
@@ -314,6 +357,11 @@ func (r *SriovNetworkNodePolicyReconciler) syncAllSriovNetworkNodeStates(ctx con
 			logger.Error(err, "Fail to sync", "SriovNetworkNodeState", ns.Name)
 			return err
 		}
+		if err := utils.AnnotateObject(ctx, &node, constants.NodeMatchedPoliciesAnnotation,
+			strings.Join(matchedPolicyNames(npl, &node), ","), r.Client); err != nil {
+			logger.Error(err, "Fail to annotate node with the matched policies", "node", node.Name)
+			return err
+		}
 	}
 	logger.V(1).Info("Remove SriovNetworkNodeState custom resource for unselected node")
 	nsList := &sriovnetworkv1.SriovNetworkNodeStateList{}
@@ -340,7 +388,12 @@ func (r *SriovNetworkNodePolicyReconciler) syncAllSriovNetworkNodeStates(ctx con
 					logger.Error(err, "Fail to remove device plugin label from node", "node", ns.Name)
 					return err
 				}
-				if err := r.handleStaleNodeState(ctx, &ns); err != nil {
+				err = utils.RemoveAnnotationFromNode(ctx, ns.Name, constants.NodeMatchedPoliciesAnnotation, r.Client)
+				if err != nil {
+					logger.Error(err, "Fail to remove the matched policies annotation from node", "node", ns.Name)
+					return err
+				}
+				if _, err := handleStaleNodeState(ctx, r.Client, &ns); err != nil {
 					return err
 				}
 			}
@@ -353,8 +406,9 @@ func (r *SriovNetworkNodePolicyReconciler) syncAllSriovNetworkNodeStates(ctx con
 // If the CR has the "keep until time" annotation, indicating the earliest time the state object can be removed,
 // this function will compare it to the current time to determine if deletion is permissible and do deletion if allowed.
 // If the annotation is absent, the function will create one with a timestamp in future, using either the default or a configured offset.
-// If STALE_NODE_STATE_CLEANUP_DELAY_MINUTES env variable is set to 0, removes the CR immediately
-func (r *SriovNetworkNodePolicyReconciler) handleStaleNodeState(ctx context.Context, ns *sriovnetworkv1.SriovNetworkNodeState) error {
+// If STALE_NODE_STATE_CLEANUP_DELAY_MINUTES env variable is set to 0, removes the CR immediately.
+// Returns the time left before the CR can be removed, zero once it is deleted.
+func handleStaleNodeState(ctx context.Context, c client.Client, ns *sriovnetworkv1.SriovNetworkNodeState) (time.Duration, error) {
 	logger := log.Log.WithName("handleStaleNodeState")
 
 	var delayMinutes int
@@ -380,23 +434,23 @@ func (r *SriovNetworkNodePolicyReconciler) handleStaleNodeState(ctx context.Cont
 			logger.V(2).Info("SriovNetworkNodeState has no matching node, configure cleanup delay for the state object",
 				"nodeStateName", ns.Name, "delay", delayMinutes, "keepUntilTime", keepUntilTime.String())
 			ns.SetKeepUntilTime(keepUntilTime)
-			if err := r.Update(ctx, ns); err != nil {
+			if err := c.Update(ctx, ns); err != nil {
 				logger.Error(err, "Fail to update SriovNetworkNodeState CR", "name", ns.GetName())
-				return err
+				return 0, err
 			}
-			return nil
+			return keepUntilTime.Sub(now), nil
 		}
 		if now.Before(keepUntilTime) {
-			return nil
+			return keepUntilTime.Sub(now), nil
 		}
 	}
 	// remove the object if delayMinutes is 0 or if keepUntilTime is already passed
 	logger.Info("Deleting SriovNetworkNodeState as node with that name doesn't exist", "nodeStateName", ns.Name)
-	if err := r.Delete(ctx, ns, &client.DeleteOptions{}); err != nil {
+	if err := c.Delete(ctx, ns, &client.DeleteOptions{}); err != nil {
 		logger.Error(err, "Fail to delete SriovNetworkNodeState CR", "name", ns.GetName())
-		return err
+		return 0, err
 	}
-	return nil
+	return 0, nil
 }
 
 func (r *SriovNetworkNodePolicyReconciler) syncSriovNetworkNodeState(ctx context.Context,
@@ -489,6 +543,22 @@ func (r *SriovNetworkNodePolicyReconciler) syncSriovNetworkNodeState(ctx context
 	return nil
 }
 
+// matchedPolicyNames returns the sorted names of the policies that select the node
+func matchedPolicyNames(npl *sriovnetworkv1.SriovNetworkNodePolicyList, node *corev1.Node) []string {
+	names := []string{}
+	for _, p := range npl.Items {
+		// default policy is deprecated and ignored
+		if p.Name == constants.DefaultPolicyName {
+			continue
+		}
+		if p.Selected(node) {
+			names = append(names, p.Name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
 func (r *SriovNetworkNodePolicyReconciler) renderDevicePluginConfigData(ctx context.Context, pl *sriovnetworkv1.SriovNetworkNodePolicyList, node *corev1.Node) (dptypes.ResourceConfList, error) {
 	logger := log.Log.WithName("renderDevicePluginConfigData")
 	logger.V(1).Info("Start to render device plugin config data", "node", node.Name)
@@ -527,6 +597,19 @@ func (r *SriovNetworkNodePolicyReconciler) renderDevicePluginConfigData(ctx cont
 			logger.V(1).Info("Add resource", "Resource", *rc)
 		}
 	}
+
+	if len(rcl.ResourceList) > 0 {
+		netPoolConfig, _, err := findNodePoolConfig(ctx, node, r.Client)
+		if err != nil {
+			logger.Error(err, "failed to get SriovNetworkPoolConfig for the node", "node", node.Name)
+			return rcl, err
+		}
+		if netPoolConfig != nil && netPoolConfig.Spec.ResourcePrefix != "" {
+			for i := range rcl.ResourceList {
+				rcl.ResourceList[i].ResourcePrefix = netPoolConfig.Spec.ResourcePrefix
+			}
+		}
+	}
 	return rcl, nil
 }
 
@@ -556,7 +639,7 @@ func createDevicePluginResource(
 	}
 	if p.Spec.NicSelector.DeviceID != "" {
 		var deviceID string
-		if p.Spec.NumVfs == 0 {
+		if p.Spec.NumVfs == 0 && p.Spec.MaxNumVfs == 0 {
 			deviceID = p.Spec.NicSelector.DeviceID
 		} else {
 			deviceID = sriovnetworkv1.GetVfDeviceID(p.Spec.NicSelector.DeviceID)
@@ -567,10 +650,11 @@ func createDevicePluginResource(
 		}
 	}
 	if len(p.Spec.NicSelector.PfNames) > 0 {
-		netDeviceSelectors.PfNames = append(netDeviceSelectors.PfNames, p.Spec.NicSelector.PfNames...)
+		netDeviceSelectors.PfNames = sriovnetworkv1.UniqueAppend(netDeviceSelectors.PfNames,
+			expandSelectorPatterns(p.Spec.NicSelector.PfNames, nodeState, func(iface *sriovnetworkv1.InterfaceExt) string { return iface.Name })...)
 	}
 	// vfio-pci device link type is not detectable
-	if p.Spec.DeviceType != constants.DeviceTypeVfioPci {
+	if p.Spec.DeviceType != constants.DeviceTypeVfioPci && p.Spec.VfDriver != constants.DeviceTypeVfioPci {
 		if p.Spec.LinkType != "" {
 			linkType := constants.LinkTypeEthernet
 			if strings.EqualFold(p.Spec.LinkType, constants.LinkTypeIB) {
@@ -580,10 +664,14 @@ func createDevicePluginResource(
 		}
 	}
 	if len(p.Spec.NicSelector.RootDevices) > 0 {
-		netDeviceSelectors.RootDevices = append(netDeviceSelectors.RootDevices, p.Spec.NicSelector.RootDevices...)
-	}
-	// Removed driver constraint for "netdevice" DeviceType
-	if p.Spec.DeviceType == constants.DeviceTypeVfioPci {
+		netDeviceSelectors.RootDevices = sriovnetworkv1.UniqueAppend(netDeviceSelectors.RootDevices,
+			expandSelectorPatterns(p.Spec.NicSelector.RootDevices, nodeState, func(iface *sriovnetworkv1.InterfaceExt) string { return iface.PciAddress })...)
+	}
+	if p.Spec.VfDriver != "" {
+		// the VFs are bound to the driver requested by the policy instead of the one implied by the DeviceType
+		netDeviceSelectors.Drivers = sriovnetworkv1.UniqueAppend(netDeviceSelectors.Drivers, p.Spec.VfDriver)
+	} else if p.Spec.DeviceType == constants.DeviceTypeVfioPci {
+		// Removed driver constraint for "netdevice" DeviceType
 		netDeviceSelectors.Drivers = append(netDeviceSelectors.Drivers, p.Spec.DeviceType)
 	}
 	// Enable the selection of devices using NetFilter
@@ -627,7 +715,7 @@ func updateDevicePluginResource(
 	}
 	if p.Spec.NicSelector.DeviceID != "" {
 		var deviceID string
-		if p.Spec.NumVfs == 0 {
+		if p.Spec.NumVfs == 0 && p.Spec.MaxNumVfs == 0 {
 			deviceID = p.Spec.NicSelector.DeviceID
 		} else {
 			deviceID = sriovnetworkv1.GetVfDeviceID(p.Spec.NicSelector.DeviceID)
@@ -638,10 +726,11 @@ func updateDevicePluginResource(
 		}
 	}
 	if len(p.Spec.NicSelector.PfNames) > 0 {
-		netDeviceSelectors.PfNames = sriovnetworkv1.UniqueAppend(netDeviceSelectors.PfNames, p.Spec.NicSelector.PfNames...)
+		netDeviceSelectors.PfNames = sriovnetworkv1.UniqueAppend(netDeviceSelectors.PfNames,
+			expandSelectorPatterns(p.Spec.NicSelector.PfNames, nodeState, func(iface *sriovnetworkv1.InterfaceExt) string { return iface.Name })...)
 	}
 	// vfio-pci device link type is not detectable
-	if p.Spec.DeviceType != constants.DeviceTypeVfioPci {
+	if p.Spec.DeviceType != constants.DeviceTypeVfioPci && p.Spec.VfDriver != constants.DeviceTypeVfioPci {
 		if p.Spec.LinkType != "" {
 			linkType := constants.LinkTypeEthernet
 			if strings.EqualFold(p.Spec.LinkType, constants.LinkTypeIB) {
@@ -653,10 +742,14 @@ func updateDevicePluginResource(
 		}
 	}
 	if len(p.Spec.NicSelector.RootDevices) > 0 {
-		netDeviceSelectors.RootDevices = sriovnetworkv1.UniqueAppend(netDeviceSelectors.RootDevices, p.Spec.NicSelector.RootDevices...)
-	}
-	// Removed driver constraint for "netdevice" DeviceType
-	if p.Spec.DeviceType == constants.DeviceTypeVfioPci {
+		netDeviceSelectors.RootDevices = sriovnetworkv1.UniqueAppend(netDeviceSelectors.RootDevices,
+			expandSelectorPatterns(p.Spec.NicSelector.RootDevices, nodeState, func(iface *sriovnetworkv1.InterfaceExt) string { return iface.PciAddress })...)
+	}
+	if p.Spec.VfDriver != "" {
+		// the VFs are bound to the driver requested by the policy instead of the one implied by the DeviceType
+		netDeviceSelectors.Drivers = sriovnetworkv1.UniqueAppend(netDeviceSelectors.Drivers, p.Spec.VfDriver)
+	} else if p.Spec.DeviceType == constants.DeviceTypeVfioPci {
+		// Removed driver constraint for "netdevice" DeviceType
 		netDeviceSelectors.Drivers = sriovnetworkv1.UniqueAppend(netDeviceSelectors.Drivers, p.Spec.DeviceType)
 	}
 	// Enable the selection of devices using NetFilter
@@ -681,3 +774,34 @@ func updateDevicePluginResource(
 
 	return nil
 }
+
+// expandSelectorPatterns replaces the glob patterns of the pfNames or rootDevices selectors by the matching
+// values of the interfaces of the node, as the device plugin only matches the selectors exactly.
+// The VF range of a pattern is kept for each match, a pattern matching no interface is kept as is.
+func expandSelectorPatterns(selectors []string, nodeState *sriovnetworkv1.SriovNetworkNodeState,
+	value func(*sriovnetworkv1.InterfaceExt) string) []string {
+	expanded := []string{}
+	for _, selector := range selectors {
+		pattern, vfRange := sriovnetworkv1.SplitDeviceFromRange(selector)
+		if !strings.ContainsAny(pattern, "*?[") {
+			expanded = sriovnetworkv1.UniqueAppend(expanded, selector)
+			continue
+		}
+		matches := []string{}
+		for i := range nodeState.Status.Interfaces {
+			v := value(&nodeState.Status.Interfaces[i])
+			if matched, err := filepath.Match(pattern, v); err != nil || !matched {
+				continue
+			}
+			if vfRange != "" {
+				v += "#" + vfRange
+			}
+			matches = sriovnetworkv1.UniqueAppend(matches, v)
+		}
+		if len(matches) == 0 {
+			matches = append(matches, selector)
+		}
+		expanded = sriovnetworkv1.UniqueAppend(expanded, matches...)
+	}
+	return expanded
+}
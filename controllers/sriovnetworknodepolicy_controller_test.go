@@ -19,8 +19,11 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/client-go/util/workqueue"
 	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	sriovnetworkv1 "github.com/k8snetworkplumbingwg/sriov-network-operator/api/v1"
 	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/consts"
@@ -102,6 +105,86 @@ func TestRenderDevicePluginConfigData(t *testing.T) {
 				},
 			},
 		},
+		{
+			tname: "testNicSelectors",
+			policy: sriovnetworkv1.SriovNetworkNodePolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: "nic-selectors"},
+				Spec: sriovnetworkv1.SriovNetworkNodePolicySpec{
+					ResourceName: "resourceName",
+					DeviceType:   consts.DeviceTypeNetDevice,
+					LinkType:     "ib",
+					NicSelector: sriovnetworkv1.SriovNetworkNicSelector{
+						Vendor:      "15b3",
+						PfNames:     []string{"ib0#0-3", "ib1"},
+						RootDevices: []string{"0000:86:00.0", "0000:86:00.1"},
+					},
+				},
+			},
+			expResource: dptypes.ResourceConfList{
+				ResourceList: []dptypes.ResourceConfig{
+					{
+						ResourceName: "resourceName",
+						Selectors: mustMarshallSelector(t, &dptypes.NetDeviceSelectors{
+							DeviceSelectors: dptypes.DeviceSelectors{Vendors: []string{"15b3"}},
+							PfNames:         []string{"ib0#0-3", "ib1"},
+							RootDevices:     []string{"0000:86:00.0", "0000:86:00.1"},
+							LinkTypes:       []string{consts.LinkTypeInfiniband},
+						}),
+					},
+				},
+			},
+		},
+		{
+			tname: "testNicSelectorPatterns",
+			policy: sriovnetworkv1.SriovNetworkNodePolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: "nic-selector-patterns"},
+				Spec: sriovnetworkv1.SriovNetworkNodePolicySpec{
+					ResourceName: "resourceName",
+					DeviceType:   consts.DeviceTypeNetDevice,
+					NicSelector: sriovnetworkv1.SriovNetworkNicSelector{
+						PfNames:     []string{"ens1*#0-3", "eth*"},
+						RootDevices: []string{"0000:86:00.*", "0000:3b:00.0"},
+					},
+				},
+			},
+			expResource: dptypes.ResourceConfList{
+				ResourceList: []dptypes.ResourceConfig{
+					{
+						ResourceName: "resourceName",
+						Selectors: mustMarshallSelector(t, &dptypes.NetDeviceSelectors{
+							PfNames:     []string{"ens1f0#0-3", "ens1f1#0-3", "eth*"},
+							RootDevices: []string{"0000:86:00.0", "0000:86:00.1", "0000:3b:00.0"},
+						}),
+					},
+				},
+			},
+		},
+		{
+			tname: "testVfDriver",
+			policy: sriovnetworkv1.SriovNetworkNodePolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: "vf-driver"},
+				Spec: sriovnetworkv1.SriovNetworkNodePolicySpec{
+					ResourceName: "resourceName",
+					DeviceType:   consts.DeviceTypeNetDevice,
+					LinkType:     "eth",
+					VfDriver:     consts.DeviceTypeVfioPci,
+					NicSelector: sriovnetworkv1.SriovNetworkNicSelector{
+						RootDevices: []string{"0000:3b:00.0"},
+					},
+				},
+			},
+			expResource: dptypes.ResourceConfList{
+				ResourceList: []dptypes.ResourceConfig{
+					{
+						ResourceName: "resourceName",
+						Selectors: mustMarshallSelector(t, &dptypes.NetDeviceSelectors{
+							DeviceSelectors: dptypes.DeviceSelectors{Drivers: []string{consts.DeviceTypeVfioPci}},
+							RootDevices:     []string{"0000:3b:00.0"},
+						}),
+					},
+				},
+			},
+		},
 	}
 
 	reconciler := SriovNetworkNodePolicyReconciler{
@@ -109,7 +192,16 @@ func TestRenderDevicePluginConfigData(t *testing.T) {
 	}
 
 	node := corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node1"}}
-	nodeState := sriovnetworkv1.SriovNetworkNodeState{ObjectMeta: metav1.ObjectMeta{Name: node.Name, Namespace: vars.Namespace}}
+	nodeState := sriovnetworkv1.SriovNetworkNodeState{
+		ObjectMeta: metav1.ObjectMeta{Name: node.Name, Namespace: vars.Namespace},
+		Status: sriovnetworkv1.SriovNetworkNodeStateStatus{
+			Interfaces: sriovnetworkv1.InterfaceExts{
+				{Name: "ens1f0", PciAddress: "0000:86:00.0"},
+				{Name: "ens1f1", PciAddress: "0000:86:00.1"},
+				{Name: "eno1", PciAddress: "0000:3b:00.0"},
+			},
+		},
+	}
 
 	scheme := runtime.NewScheme()
 	utilruntime.Must(sriovnetworkv1.AddToScheme(scheme))
@@ -141,6 +233,9 @@ var _ = Describe("SriovnetworkNodePolicy controller", Ordered, func() {
 		// disable stale state cleanup delay to check that the controller can cleanup state objects
 		DeferCleanup(os.Setenv, "STALE_NODE_STATE_CLEANUP_DELAY_MINUTES", os.Getenv("STALE_NODE_STATE_CLEANUP_DELAY_MINUTES"))
 		os.Setenv("STALE_NODE_STATE_CLEANUP_DELAY_MINUTES", "0")
+		// use a sync delay large enough to coalesce the quick edits done by the tests
+		DeferCleanup(os.Setenv, "NODE_POLICY_SYNC_DELAY", os.Getenv("NODE_POLICY_SYNC_DELAY"))
+		os.Setenv("NODE_POLICY_SYNC_DELAY", "2s")
 
 		By("Create SriovOperatorConfig controller k8s objs")
 		config := makeDefaultSriovOpConfig()
@@ -265,6 +360,272 @@ var _ = Describe("SriovnetworkNodePolicy controller", Ordered, func() {
 		})
 	})
 
+	Context("matched policies annotation", func() {
+		It("should update the annotation when the policy node selector changes", func() {
+			node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{
+				Name: "node0",
+				Labels: map[string]string{
+					"node-role.kubernetes.io/worker": "",
+					"kubernetes.io/os":               "linux",
+					"zone":                           "a",
+				},
+			}}
+			Expect(k8sClient.Create(ctx, node)).To(Succeed())
+
+			policyA := &sriovnetworkv1.SriovNetworkNodePolicy{}
+			policyA.SetNamespace(testNamespace)
+			policyA.SetName("policy-a")
+			policyA.Spec = sriovnetworkv1.SriovNetworkNodePolicySpec{
+				NumVfs:       5,
+				NodeSelector: map[string]string{"zone": "a"},
+				NicSelector:  sriovnetworkv1.SriovNetworkNicSelector{Vendor: "8086"},
+				Priority:     20,
+			}
+			Expect(k8sClient.Create(ctx, policyA)).To(Succeed())
+
+			policyB := policyA.DeepCopy()
+			policyB.SetName("policy-b")
+			policyB.Spec.NodeSelector = map[string]string{"zone": "b"}
+			Expect(k8sClient.Create(ctx, policyB)).To(Succeed())
+
+			Eventually(func(g Gomega) {
+				g.Expect(k8sClient.Get(ctx, k8sclient.ObjectKey{Name: node.Name}, node)).To(Succeed())
+				g.Expect(node.Annotations).To(HaveKeyWithValue(consts.NodeMatchedPoliciesAnnotation, "policy-a"))
+			}, time.Minute, time.Second).Should(Succeed())
+
+			Expect(k8sClient.Get(ctx, k8sclient.ObjectKey{Name: policyB.Name, Namespace: testNamespace}, policyB)).To(Succeed())
+			policyB.Spec.NodeSelector = map[string]string{"zone": "a"}
+			Expect(k8sClient.Update(ctx, policyB)).To(Succeed())
+
+			Eventually(func(g Gomega) {
+				g.Expect(k8sClient.Get(ctx, k8sclient.ObjectKey{Name: node.Name}, node)).To(Succeed())
+				g.Expect(node.Annotations).To(HaveKeyWithValue(consts.NodeMatchedPoliciesAnnotation, "policy-a,policy-b"))
+			}, time.Minute, time.Second).Should(Succeed())
+
+			Expect(k8sClient.Get(ctx, k8sclient.ObjectKey{Name: policyA.Name, Namespace: testNamespace}, policyA)).To(Succeed())
+			policyA.Spec.NodeSelector = map[string]string{"zone": "b"}
+			Expect(k8sClient.Update(ctx, policyA)).To(Succeed())
+
+			Eventually(func(g Gomega) {
+				g.Expect(k8sClient.Get(ctx, k8sclient.ObjectKey{Name: node.Name}, node)).To(Succeed())
+				g.Expect(node.Annotations).To(HaveKeyWithValue(consts.NodeMatchedPoliciesAnnotation, "policy-b"))
+			}, time.Minute, time.Second).Should(Succeed())
+
+			By("removing the annotation when the node is no longer selected")
+			delete(node.Labels, "node-role.kubernetes.io/worker")
+			Expect(k8sClient.Update(ctx, node)).To(Succeed())
+
+			Eventually(func(g Gomega) {
+				g.Expect(k8sClient.Get(ctx, k8sclient.ObjectKey{Name: node.Name}, node)).To(Succeed())
+				g.Expect(node.Annotations).ToNot(HaveKey(consts.NodeMatchedPoliciesAnnotation))
+			}, time.Minute, time.Second).Should(Succeed())
+		})
+	})
+
+	Context("resource prefix", func() {
+		It("should use the resource prefix of the pool in the device plugin config", func() {
+			for _, pool := range []string{"a", "b"} {
+				node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{
+					Name: "node-" + pool,
+					Labels: map[string]string{
+						"node-role.kubernetes.io/worker": "",
+						"kubernetes.io/os":               "linux",
+						"pool":                           pool,
+					},
+				}}
+				Expect(k8sClient.Create(ctx, node)).To(Succeed())
+
+				nodeState := &sriovnetworkv1.SriovNetworkNodeState{}
+				Eventually(func(g Gomega) {
+					err := k8sClient.Get(ctx, k8sclient.ObjectKey{Name: node.Name, Namespace: testNamespace}, nodeState)
+					g.Expect(err).ToNot(HaveOccurred())
+				}, time.Minute, time.Second).Should(Succeed())
+				nodeState.Status.Interfaces = sriovnetworkv1.InterfaceExts{
+					sriovnetworkv1.InterfaceExt{
+						Vendor:     "8086",
+						Driver:     "i40e",
+						Mtu:        1500,
+						Name:       "ens803f0",
+						PciAddress: "0000:86:00.0",
+						NumVfs:     0,
+						TotalVfs:   64,
+					},
+				}
+				Expect(k8sClient.Status().Update(ctx, nodeState)).To(Succeed())
+
+				poolConfig := &sriovnetworkv1.SriovNetworkPoolConfig{}
+				poolConfig.SetNamespace(testNamespace)
+				poolConfig.SetName("pool-" + pool)
+				poolConfig.Spec = sriovnetworkv1.SriovNetworkPoolConfigSpec{
+					NodeSelector:   &metav1.LabelSelector{MatchLabels: map[string]string{"pool": pool}},
+					ResourcePrefix: pool + ".example.com",
+				}
+				Expect(k8sClient.Create(ctx, poolConfig)).To(Succeed())
+				DeferCleanup(k8sClient.Delete, context.Background(), poolConfig)
+			}
+
+			policy := &sriovnetworkv1.SriovNetworkNodePolicy{}
+			policy.SetNamespace(testNamespace)
+			policy.SetName("some-policy")
+			policy.Spec = sriovnetworkv1.SriovNetworkNodePolicySpec{
+				ResourceName: "resource_1",
+				NumVfs:       5,
+				NodeSelector: map[string]string{"node-role.kubernetes.io/worker": ""},
+				NicSelector:  sriovnetworkv1.SriovNetworkNicSelector{Vendor: "8086"},
+				Priority:     20,
+			}
+			Expect(k8sClient.Create(ctx, policy)).To(Succeed())
+
+			Eventually(func(g Gomega) {
+				cm := &corev1.ConfigMap{}
+				g.Expect(k8sClient.Get(ctx, k8sclient.ObjectKey{Name: consts.ConfigMapName, Namespace: testNamespace}, cm)).To(Succeed())
+				for _, pool := range []string{"a", "b"} {
+					rcl := dptypes.ResourceConfList{}
+					g.Expect(json.Unmarshal([]byte(cm.Data["node-"+pool]), &rcl)).To(Succeed())
+					g.Expect(rcl.ResourceList).To(HaveLen(1))
+					g.Expect(rcl.ResourceList[0].ResourceName).To(Equal("resource_1"))
+					g.Expect(rcl.ResourceList[0].ResourcePrefix).To(Equal(pool + ".example.com"))
+				}
+			}, time.Minute, time.Second).Should(Succeed())
+		})
+	})
+
+	Context("resource name", func() {
+		It("should replace the resource in the device plugin config when the resourceName of the policy changes", func() {
+			node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{
+				Name: "node-rename",
+				Labels: map[string]string{
+					"node-role.kubernetes.io/worker": "",
+					"kubernetes.io/os":               "linux",
+				},
+			}}
+			Expect(k8sClient.Create(ctx, node)).To(Succeed())
+
+			nodeState := &sriovnetworkv1.SriovNetworkNodeState{}
+			Eventually(func(g Gomega) {
+				err := k8sClient.Get(ctx, k8sclient.ObjectKey{Name: node.Name, Namespace: testNamespace}, nodeState)
+				g.Expect(err).ToNot(HaveOccurred())
+			}, time.Minute, time.Second).Should(Succeed())
+			nodeState.Status.Interfaces = sriovnetworkv1.InterfaceExts{
+				sriovnetworkv1.InterfaceExt{
+					Vendor:     "8086",
+					Driver:     "i40e",
+					Mtu:        1500,
+					Name:       "ens803f0",
+					PciAddress: "0000:86:00.0",
+					NumVfs:     0,
+					TotalVfs:   64,
+				},
+			}
+			Expect(k8sClient.Status().Update(ctx, nodeState)).To(Succeed())
+
+			policy := &sriovnetworkv1.SriovNetworkNodePolicy{}
+			policy.SetNamespace(testNamespace)
+			policy.SetName("renamed-policy")
+			policy.Spec = sriovnetworkv1.SriovNetworkNodePolicySpec{
+				ResourceName: "old_resource",
+				NumVfs:       5,
+				NodeSelector: map[string]string{"node-role.kubernetes.io/worker": ""},
+				NicSelector:  sriovnetworkv1.SriovNetworkNicSelector{Vendor: "8086"},
+				Priority:     20,
+			}
+			Expect(k8sClient.Create(ctx, policy)).To(Succeed())
+
+			resourceNames := func(g Gomega) []string {
+				cm := &corev1.ConfigMap{}
+				g.Expect(k8sClient.Get(ctx, k8sclient.ObjectKey{Name: consts.ConfigMapName, Namespace: testNamespace}, cm)).To(Succeed())
+				rcl := dptypes.ResourceConfList{}
+				g.Expect(json.Unmarshal([]byte(cm.Data[node.Name]), &rcl)).To(Succeed())
+				names := []string{}
+				for _, rc := range rcl.ResourceList {
+					names = append(names, rc.ResourceName)
+				}
+				return names
+			}
+			Eventually(func(g Gomega) {
+				g.Expect(resourceNames(g)).To(ConsistOf("old_resource"))
+			}, time.Minute, time.Second).Should(Succeed())
+
+			Expect(retry.RetryOnConflict(retry.DefaultRetry, func() error {
+				if err := k8sClient.Get(ctx, k8sclient.ObjectKeyFromObject(policy), policy); err != nil {
+					return err
+				}
+				policy.Spec.ResourceName = "new_resource"
+				return k8sClient.Update(ctx, policy)
+			})).To(Succeed())
+
+			Eventually(func(g Gomega) {
+				g.Expect(resourceNames(g)).To(ConsistOf("new_resource"))
+			}, time.Minute, time.Second).Should(Succeed())
+		})
+	})
+
+	Context("sync delay", func() {
+		It("should coalesce quick policy edits in a single SriovNetworkNodeState update", func() {
+			node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{
+				Name: "node0",
+				Labels: map[string]string{"kubernetes.io/os": "linux",
+					"node-role.kubernetes.io/worker": ""},
+			}}
+			Expect(k8sClient.Create(ctx, node)).To(Succeed())
+
+			nodeState := &sriovnetworkv1.SriovNetworkNodeState{}
+			Eventually(func(g Gomega) {
+				err := k8sClient.Get(ctx, k8sclient.ObjectKey{Name: "node0", Namespace: testNamespace}, nodeState)
+				g.Expect(err).ToNot(HaveOccurred())
+			}, time.Minute, time.Second).Should(Succeed())
+
+			nodeState.Status.Interfaces = sriovnetworkv1.InterfaceExts{
+				sriovnetworkv1.InterfaceExt{
+					Vendor:     "8086",
+					Driver:     "i40e",
+					Mtu:        1500,
+					Name:       "ens803f0",
+					PciAddress: "0000:86:00.0",
+					NumVfs:     0,
+					TotalVfs:   64,
+				},
+			}
+			Expect(k8sClient.Status().Update(ctx, nodeState)).To(Succeed())
+
+			policy := &sriovnetworkv1.SriovNetworkNodePolicy{}
+			policy.SetNamespace(testNamespace)
+			policy.SetName("some-policy")
+			policy.Spec = sriovnetworkv1.SriovNetworkNodePolicySpec{
+				NumVfs:       5,
+				NodeSelector: map[string]string{"node-role.kubernetes.io/worker": ""},
+				NicSelector:  sriovnetworkv1.SriovNetworkNicSelector{Vendor: "8086"},
+				Priority:     20,
+			}
+			Expect(k8sClient.Create(ctx, policy)).To(Succeed())
+
+			Eventually(func(g Gomega) {
+				g.Expect(k8sClient.Get(ctx, k8sclient.ObjectKey{Name: node.Name, Namespace: testNamespace}, nodeState)).To(Succeed())
+				g.Expect(nodeState.Spec.Interfaces).To(HaveLen(1))
+				g.Expect(nodeState.Spec.Interfaces[0].NumVfs).To(Equal(5))
+			}, time.Minute, time.Second).Should(Succeed())
+			generation := nodeState.Generation
+
+			for _, numVfs := range []int{6, 7, 8} {
+				Expect(k8sClient.Get(ctx, k8sclient.ObjectKey{Name: policy.Name, Namespace: testNamespace}, policy)).To(Succeed())
+				policy.Spec.NumVfs = numVfs
+				Expect(k8sClient.Update(ctx, policy)).To(Succeed())
+			}
+
+			Eventually(func(g Gomega) {
+				g.Expect(k8sClient.Get(ctx, k8sclient.ObjectKey{Name: node.Name, Namespace: testNamespace}, nodeState)).To(Succeed())
+				g.Expect(nodeState.Spec.Interfaces).To(HaveLen(1))
+				g.Expect(nodeState.Spec.Interfaces[0].NumVfs).To(Equal(8))
+			}, time.Minute, time.Second).Should(Succeed())
+			Expect(nodeState.Generation).To(Equal(generation + 1))
+
+			Consistently(func(g Gomega) {
+				g.Expect(k8sClient.Get(ctx, k8sclient.ObjectKey{Name: node.Name, Namespace: testNamespace}, nodeState)).To(Succeed())
+				g.Expect(nodeState.Generation).To(Equal(generation + 1))
+			}, 5*time.Second, time.Second).Should(Succeed())
+		})
+	})
+
 	Context("RdmaMode", func() {
 		BeforeEach(func() {
 			Expect(
@@ -324,6 +685,47 @@ var _ = Describe("SriovnetworkNodePolicy controller", Ordered, func() {
 
 		})
 	})
+
+	Context("ConfigurationMode", func() {
+		BeforeEach(func() {
+			Expect(
+				k8sClient.DeleteAllOf(context.Background(), &sriovnetworkv1.SriovNetworkPoolConfig{}, k8sclient.InNamespace(vars.Namespace)),
+			).ToNot(HaveOccurred())
+		})
+
+		It("field of a pool forcing the systemd mode is written to the SriovNetworkNodeState", func() {
+			node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{
+				Name: "node-immutable-os",
+				Labels: map[string]string{
+					"node-role.kubernetes.io/worker": "",
+					"kubernetes.io/os":               "linux",
+					"immutable-os":                   "",
+				},
+			}}
+			Expect(k8sClient.Create(ctx, node)).To(Succeed())
+			DeferCleanup(k8sClient.Delete, ctx, node)
+
+			poolConfig := &sriovnetworkv1.SriovNetworkPoolConfig{}
+			poolConfig.SetNamespace(testNamespace)
+			poolConfig.SetName("immutable-os-workers")
+			poolConfig.Spec = sriovnetworkv1.SriovNetworkPoolConfigSpec{
+				NodeSelector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{
+						"immutable-os": "",
+					},
+				},
+				ConfigurationMode: sriovnetworkv1.SystemdConfigurationMode,
+			}
+			Expect(k8sClient.Create(ctx, poolConfig)).To(Succeed())
+
+			nodeState := &sriovnetworkv1.SriovNetworkNodeState{}
+			Eventually(func(g Gomega) {
+				err := k8sClient.Get(context.Background(), k8sclient.ObjectKey{Name: node.Name, Namespace: testNamespace}, nodeState)
+				g.Expect(err).ToNot(HaveOccurred())
+				g.Expect(nodeState.Spec.System.ConfigurationMode).To(Equal(sriovnetworkv1.SystemdConfigurationMode))
+			}).WithPolling(time.Second).WithTimeout(time.Minute).Should(Succeed())
+		})
+	})
 })
 
 var _ = Describe("SriovNetworkNodePolicyReconciler", Ordered, func() {
@@ -343,14 +745,14 @@ var _ = Describe("SriovNetworkNodePolicyReconciler", Ordered, func() {
 		})
 		It("should set default delay", func() {
 			nodeState := nodeState.DeepCopy()
-			Expect(r.handleStaleNodeState(ctx, nodeState)).NotTo(HaveOccurred())
+			Expect(handleStaleNodeState(ctx, r.Client, nodeState)).Error().NotTo(HaveOccurred())
 			Expect(r.Get(ctx, types.NamespacedName{Name: nodeState.Name}, nodeState)).NotTo(HaveOccurred())
 			Expect(time.Now().UTC().Before(nodeState.GetKeepUntilTime())).To(BeTrue())
 		})
 		It("should remove CR if wait time expired", func() {
 			nodeState := nodeState.DeepCopy()
 			nodeState.SetKeepUntilTime(time.Now().UTC().Add(-time.Minute))
-			Expect(r.handleStaleNodeState(ctx, nodeState)).NotTo(HaveOccurred())
+			Expect(handleStaleNodeState(ctx, r.Client, nodeState)).Error().NotTo(HaveOccurred())
 			Expect(errors.IsNotFound(r.Get(ctx, types.NamespacedName{Name: nodeState.Name}, nodeState))).To(BeTrue())
 		})
 		It("should keep existing wait time if already set", func() {
@@ -358,7 +760,7 @@ var _ = Describe("SriovNetworkNodePolicyReconciler", Ordered, func() {
 			nodeState.SetKeepUntilTime(time.Now().UTC().Add(time.Minute))
 			testTime := nodeState.GetKeepUntilTime()
 			r.Update(ctx, nodeState)
-			Expect(r.handleStaleNodeState(ctx, nodeState)).NotTo(HaveOccurred())
+			Expect(handleStaleNodeState(ctx, r.Client, nodeState)).Error().NotTo(HaveOccurred())
 			Expect(r.Get(ctx, types.NamespacedName{Name: nodeState.Name}, nodeState)).NotTo(HaveOccurred())
 			Expect(nodeState.GetKeepUntilTime()).To(Equal(testTime))
 		})
@@ -366,7 +768,7 @@ var _ = Describe("SriovNetworkNodePolicyReconciler", Ordered, func() {
 			DeferCleanup(os.Setenv, "STALE_NODE_STATE_CLEANUP_DELAY_MINUTES", os.Getenv("STALE_NODE_STATE_CLEANUP_DELAY_MINUTES"))
 			os.Setenv("STALE_NODE_STATE_CLEANUP_DELAY_MINUTES", "60")
 			nodeState := nodeState.DeepCopy()
-			Expect(r.handleStaleNodeState(ctx, nodeState)).NotTo(HaveOccurred())
+			Expect(handleStaleNodeState(ctx, r.Client, nodeState)).Error().NotTo(HaveOccurred())
 			Expect(r.Get(ctx, types.NamespacedName{Name: nodeState.Name}, nodeState)).NotTo(HaveOccurred())
 			Expect(time.Until(nodeState.GetKeepUntilTime()) > 30*time.Minute).To(BeTrue())
 		})
@@ -374,7 +776,7 @@ var _ = Describe("SriovNetworkNodePolicyReconciler", Ordered, func() {
 			DeferCleanup(os.Setenv, "STALE_NODE_STATE_CLEANUP_DELAY_MINUTES", os.Getenv("STALE_NODE_STATE_CLEANUP_DELAY_MINUTES"))
 			os.Setenv("STALE_NODE_STATE_CLEANUP_DELAY_MINUTES", "-20")
 			nodeState := nodeState.DeepCopy()
-			Expect(r.handleStaleNodeState(ctx, nodeState)).NotTo(HaveOccurred())
+			Expect(handleStaleNodeState(ctx, r.Client, nodeState)).Error().NotTo(HaveOccurred())
 			Expect(r.Get(ctx, types.NamespacedName{Name: nodeState.Name}, nodeState)).NotTo(HaveOccurred())
 			Expect(time.Until(nodeState.GetKeepUntilTime()) > 20*time.Minute).To(BeTrue())
 		})
@@ -382,8 +784,42 @@ var _ = Describe("SriovNetworkNodePolicyReconciler", Ordered, func() {
 			DeferCleanup(os.Setenv, "STALE_NODE_STATE_CLEANUP_DELAY_MINUTES", os.Getenv("STALE_NODE_STATE_CLEANUP_DELAY_MINUTES"))
 			os.Setenv("STALE_NODE_STATE_CLEANUP_DELAY_MINUTES", "0")
 			nodeState := nodeState.DeepCopy()
-			Expect(r.handleStaleNodeState(ctx, nodeState)).NotTo(HaveOccurred())
+			Expect(handleStaleNodeState(ctx, r.Client, nodeState)).Error().NotTo(HaveOccurred())
 			Expect(errors.IsNotFound(r.Get(ctx, types.NamespacedName{Name: nodeState.Name}, nodeState))).To(BeTrue())
 		})
 	})
+	Context("nodePolicySyncDebouncer", func() {
+		It("should restart the delay on every change", func() {
+			q := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+			DeferCleanup(q.ShutDown)
+			debouncer := &nodePolicySyncDebouncer{delay: 500 * time.Millisecond}
+
+			for i := 0; i < 3; i++ {
+				debouncer.trigger(q)
+				time.Sleep(300 * time.Millisecond)
+			}
+			Expect(q.Len()).To(BeZero())
+
+			Eventually(q.Len, time.Second, 50*time.Millisecond).Should(Equal(1))
+			item, _ := q.Get()
+			Expect(item).To(Equal(reconcile.Request{NamespacedName: types.NamespacedName{Name: nodePolicySyncEventName}}))
+			q.Done(item)
+			Consistently(q.Len, time.Second, 100*time.Millisecond).Should(BeZero())
+		})
+	})
+	Context("getNodePolicySyncDelay", func() {
+		BeforeEach(func() {
+			DeferCleanup(os.Setenv, "NODE_POLICY_SYNC_DELAY", os.Getenv("NODE_POLICY_SYNC_DELAY"))
+		})
+		It("should use the configured delay", func() {
+			os.Setenv("NODE_POLICY_SYNC_DELAY", "10s")
+			Expect(getNodePolicySyncDelay()).To(Equal(10 * time.Second))
+		})
+		It("should use the default delay for invalid values", func() {
+			os.Setenv("NODE_POLICY_SYNC_DELAY", "-1s")
+			Expect(getNodePolicySyncDelay()).To(Equal(consts.DefaultNodePolicySyncDelay))
+			os.Setenv("NODE_POLICY_SYNC_DELAY", "ten")
+			Expect(getNodePolicySyncDelay()).To(Equal(consts.DefaultNodePolicySyncDelay))
+		})
+	})
 })
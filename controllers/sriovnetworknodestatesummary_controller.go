@@ -0,0 +1,110 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	sriovnetworkv1 "github.com/k8snetworkplumbingwg/sriov-network-operator/api/v1"
+	constants "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/consts"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/vars"
+)
+
+// SriovNetworkNodeStateSummaryReconciler maintains the default SriovNetworkNodeStateSummary,
+// a condensed view of all the SriovNetworkNodeStates for cluster-wide dashboards
+type SriovNetworkNodeStateSummaryReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+}
+
+//+kubebuilder:rbac:groups=sriovnetwork.openshift.io,resources=sriovnetworknodestatesummaries,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=sriovnetwork.openshift.io,resources=sriovnetworknodestatesummaries/status,verbs=get;update;patch
+
+// Reconcile rebuilds the status of the default SriovNetworkNodeStateSummary from the SriovNetworkNodeStates
+func (r *SriovNetworkNodeStateSummaryReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx).WithValues("sriovnetworknodestatesummary", req.Name)
+	logger.V(2).Info("Reconciling")
+
+	summary := &sriovnetworkv1.SriovNetworkNodeStateSummary{}
+	err := r.Get(ctx, types.NamespacedName{Name: constants.DefaultConfigName}, summary)
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			return reconcile.Result{}, err
+		}
+		logger.Info("creating the default SriovNetworkNodeStateSummary")
+		summary.Name = constants.DefaultConfigName
+		if err := r.Create(ctx, summary); err != nil {
+			return reconcile.Result{}, fmt.Errorf("failed to create SriovNetworkNodeStateSummary: %v", err)
+		}
+	}
+
+	nodeStateList := &sriovnetworkv1.SriovNetworkNodeStateList{}
+	if err := r.List(ctx, nodeStateList, client.InNamespace(vars.Namespace)); err != nil {
+		return reconcile.Result{}, fmt.Errorf("failed to list SriovNetworkNodeStates: %v", err)
+	}
+
+	status := sriovnetworkv1.SriovNetworkNodeStateSummaryStatus{TotalNodes: len(nodeStateList.Items)}
+	for i := range nodeStateList.Items {
+		nodeSummary := summarizeNodeState(&nodeStateList.Items[i])
+		if nodeSummary.SyncStatus == constants.SyncStatusSucceeded {
+			status.SyncedNodes++
+		}
+		status.Nodes = append(status.Nodes, nodeSummary)
+	}
+	sort.Slice(status.Nodes, func(i, j int) bool { return status.Nodes[i].Name < status.Nodes[j].Name })
+
+	if equality.Semantic.DeepEqual(status, summary.Status) {
+		return reconcile.Result{}, nil
+	}
+	summary.Status = status
+	return reconcile.Result{}, r.Status().Update(ctx, summary)
+}
+
+// summarizeNodeState returns the condensed view of a SriovNetworkNodeState
+func summarizeNodeState(nodeState *sriovnetworkv1.SriovNetworkNodeState) sriovnetworkv1.NodeStateSummary {
+	nodeSummary := sriovnetworkv1.NodeStateSummary{
+		Name:          nodeState.Name,
+		SyncStatus:    nodeState.Status.SyncStatus,
+		LastSyncError: nodeState.Status.LastSyncError,
+	}
+
+	policies := map[string]bool{}
+	for _, iface := range nodeState.Spec.Interfaces {
+		for _, group := range iface.VfGroups {
+			if group.PolicyName != "" && !policies[group.PolicyName] {
+				policies[group.PolicyName] = true
+				nodeSummary.MatchedPolicies = append(nodeSummary.MatchedPolicies, group.PolicyName)
+			}
+		}
+	}
+	sort.Strings(nodeSummary.MatchedPolicies)
+
+	// the config daemon resets the desired state to Idle once the drain or reboot is done
+	desiredState := nodeState.GetAnnotations()[constants.NodeStateDrainAnnotation]
+	if desiredState != "" && desiredState != constants.DrainIdle {
+		nodeSummary.PendingAction = desiredState
+	}
+	return nodeSummary
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *SriovNetworkNodeStateSummaryReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	// all the node states are summarized in the default summary
+	enqueueDefault := handler.EnqueueRequestsFromMapFunc(func(_ context.Context, _ client.Object) []reconcile.Request {
+		return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: constants.DefaultConfigName}}}
+	})
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&sriovnetworkv1.SriovNetworkNodeStateSummary{}).
+		Watches(&sriovnetworkv1.SriovNetworkNodeState{}, enqueueDefault).
+		Complete(r)
+}
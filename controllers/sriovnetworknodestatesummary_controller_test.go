@@ -0,0 +1,127 @@
+package controllers
+
+import (
+	"context"
+	"sync"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	sriovnetworkv1 "github.com/k8snetworkplumbingwg/sriov-network-operator/api/v1"
+	constants "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/consts"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/test/util"
+)
+
+var _ = Describe("SriovNetworkNodeStateSummary controller", Ordered, func() {
+	var cancel context.CancelFunc
+	var ctx context.Context
+
+	BeforeAll(func() {
+		By("Setup controller manager")
+		k8sManager, err := setupK8sManagerForTest()
+		Expect(err).ToNot(HaveOccurred())
+
+		err = (&SriovNetworkNodeStateSummaryReconciler{
+			Client: k8sManager.GetClient(),
+			Scheme: k8sManager.GetScheme(),
+		}).SetupWithManager(k8sManager)
+		Expect(err).ToNot(HaveOccurred())
+
+		ctx, cancel = context.WithCancel(context.Background())
+
+		wg := sync.WaitGroup{}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer GinkgoRecover()
+			By("Start controller manager")
+			err := k8sManager.Start(ctx)
+			Expect(err).ToNot(HaveOccurred())
+		}()
+
+		DeferCleanup(func() {
+			By("Shutdown controller manager")
+			cancel()
+			wg.Wait()
+		})
+	})
+
+	Context("When is up", func() {
+		It("should summarize the status of the node states", func() {
+			nodeState0 := &sriovnetworkv1.SriovNetworkNodeState{
+				ObjectMeta: metav1.ObjectMeta{Name: "summary-node-0", Namespace: testNamespace},
+				Spec: sriovnetworkv1.SriovNetworkNodeStateSpec{
+					Interfaces: sriovnetworkv1.Interfaces{{
+						PciAddress: "0000:86:00.0",
+						NumVfs:     4,
+						VfGroups: []sriovnetworkv1.VfGroup{
+							{PolicyName: "policy-b", VfRange: "2-3"},
+							{PolicyName: "policy-a", VfRange: "0-1"},
+						},
+					}},
+				},
+			}
+			nodeState1 := &sriovnetworkv1.SriovNetworkNodeState{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "summary-node-1",
+					Namespace:   testNamespace,
+					Annotations: map[string]string{constants.NodeStateDrainAnnotation: constants.DrainRequired},
+				},
+			}
+			for _, nodeState := range []*sriovnetworkv1.SriovNetworkNodeState{nodeState0, nodeState1} {
+				Expect(k8sClient.Create(ctx, nodeState)).To(Succeed())
+				DeferCleanup(k8sClient.Delete, ctx, nodeState)
+			}
+
+			nodeState0.Status.SyncStatus = constants.SyncStatusSucceeded
+			Expect(k8sClient.Status().Update(ctx, nodeState0)).To(Succeed())
+			nodeState1.Status.SyncStatus = constants.SyncStatusInProgress
+			nodeState1.Status.LastSyncError = "failed to configure PF"
+			Expect(k8sClient.Status().Update(ctx, nodeState1)).To(Succeed())
+
+			getNodeSummary := func(summary *sriovnetworkv1.SriovNetworkNodeStateSummary, name string) *sriovnetworkv1.NodeStateSummary {
+				for i := range summary.Status.Nodes {
+					if summary.Status.Nodes[i].Name == name {
+						return &summary.Status.Nodes[i]
+					}
+				}
+				return nil
+			}
+
+			Eventually(func(g Gomega) {
+				summary := &sriovnetworkv1.SriovNetworkNodeStateSummary{}
+				g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: constants.DefaultConfigName}, summary)).To(Succeed())
+
+				g.Expect(getNodeSummary(summary, "summary-node-0")).To(Equal(&sriovnetworkv1.NodeStateSummary{
+					Name:            "summary-node-0",
+					SyncStatus:      constants.SyncStatusSucceeded,
+					MatchedPolicies: []string{"policy-a", "policy-b"},
+				}))
+				g.Expect(getNodeSummary(summary, "summary-node-1")).To(Equal(&sriovnetworkv1.NodeStateSummary{
+					Name:          "summary-node-1",
+					SyncStatus:    constants.SyncStatusInProgress,
+					LastSyncError: "failed to configure PF",
+					PendingAction: constants.DrainRequired,
+				}))
+			}, util.APITimeout, util.RetryInterval).Should(Succeed())
+
+			By("updating the summary when a node state changes")
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: nodeState1.Name, Namespace: testNamespace}, nodeState1)).To(Succeed())
+			nodeState1.Status.SyncStatus = constants.SyncStatusSucceeded
+			nodeState1.Status.LastSyncError = ""
+			Expect(k8sClient.Status().Update(ctx, nodeState1)).To(Succeed())
+
+			Eventually(func(g Gomega) {
+				summary := &sriovnetworkv1.SriovNetworkNodeStateSummary{}
+				g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: constants.DefaultConfigName}, summary)).To(Succeed())
+				nodeSummary := getNodeSummary(summary, "summary-node-1")
+				g.Expect(nodeSummary).ToNot(BeNil())
+				g.Expect(nodeSummary.SyncStatus).To(Equal(constants.SyncStatusSucceeded))
+				g.Expect(nodeSummary.LastSyncError).To(BeEmpty())
+			}, util.APITimeout, util.RetryInterval).Should(Succeed())
+		})
+	})
+})
@@ -5,14 +5,20 @@ import (
 	"encoding/json"
 	"fmt"
 	"reflect"
+	"slices"
 
 	mcfgv1 "github.com/openshift/machine-config-operator/pkg/apis/machineconfiguration.openshift.io/v1"
 
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
@@ -75,6 +81,9 @@ func (r *SriovNetworkPoolConfigReconciler) Reconcile(ctx context.Context, req ct
 
 	// we don't need a finalizer for pools that doesn't use the ovs hardware offload feature
 	if instance.Spec.OvsHardwareOffloadConfig.Name == "" {
+		if err := r.syncConditions(ctx, instance); err != nil {
+			return reconcile.Result{}, err
+		}
 		return ctrl.Result{}, nil
 	}
 
@@ -98,6 +107,9 @@ func (r *SriovNetworkPoolConfigReconciler) Reconcile(ctx context.Context, req ct
 				logger.Info("Ignoring request to enable HWOL (running on Hypershift)")
 			}
 		}
+		if err := r.syncConditions(ctx, instance); err != nil {
+			return reconcile.Result{}, err
+		}
 	} else {
 		// The object is being deleted
 		if sriovnetworkv1.StringInArray(sriovnetworkv1.POOLCONFIGFINALIZERNAME, instance.ObjectMeta.Finalizers) {
@@ -129,9 +141,170 @@ func (r *SriovNetworkPoolConfigReconciler) Reconcile(ctx context.Context, req ct
 func (r *SriovNetworkPoolConfigReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&sriovnetworkv1.SriovNetworkPoolConfig{}).
+		// the conditions of the pool depend on the status of the node states
+		Watches(&sriovnetworkv1.SriovNetworkNodeState{}, handler.EnqueueRequestsFromMapFunc(r.enqueueAllPoolConfigs)).
 		Complete(r)
 }
 
+func (r *SriovNetworkPoolConfigReconciler) enqueueAllPoolConfigs(ctx context.Context, _ client.Object) []reconcile.Request {
+	npcl := &sriovnetworkv1.SriovNetworkPoolConfigList{}
+	if err := r.List(ctx, npcl); err != nil {
+		log.Log.WithName("SriovNetworkPoolConfig reconciler").Error(err, "failed to list sriovNetworkPoolConfig")
+		return nil
+	}
+	requests := make([]reconcile.Request, 0, len(npcl.Items))
+	for _, npc := range npcl.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: npc.Namespace, Name: npc.Name}})
+	}
+	return requests
+}
+
+// syncConditions sets the conditions of the pool based on the SriovNetworkNodeStates of the nodes in the pool:
+// * RdmaModeApplied reports the RDMA mode of the nodes, only when the pool configures it
+// * Ready reports if the SriovNetworkNodeStates of all the nodes are synced
+// * SwitchdevApplied reports if the PFs configured in switchdev mode are in switchdev mode, only when some PFs are
+// the number of PFs in each eSwitch mode is reported together with the conditions
+func (r *SriovNetworkPoolConfigReconciler) syncConditions(ctx context.Context, npc *sriovnetworkv1.SriovNetworkPoolConfig) error {
+	nodeSelector := npc.Spec.NodeSelector
+	if nodeSelector == nil {
+		nodeSelector = &metav1.LabelSelector{}
+	}
+	selector, err := metav1.LabelSelectorAsSelector(nodeSelector)
+	if err != nil {
+		return fmt.Errorf("failed to create label selector from nodeSelector: %v", err)
+	}
+	nodeList := &corev1.NodeList{}
+	if err := r.List(ctx, nodeList, &client.ListOptions{LabelSelector: selector}); err != nil {
+		return fmt.Errorf("failed to list nodes of the pool: %v", err)
+	}
+
+	nodeStates := []*sriovnetworkv1.SriovNetworkNodeState{}
+	for _, node := range nodeList.Items {
+		nodeState := &sriovnetworkv1.SriovNetworkNodeState{}
+		err := r.Get(ctx, types.NamespacedName{Namespace: vars.Namespace, Name: node.Name}, nodeState)
+		if err != nil {
+			if errors.IsNotFound(err) {
+				continue
+			}
+			return fmt.Errorf("failed to get SriovNetworkNodeState %s: %v", node.Name, err)
+		}
+		nodeStates = append(nodeStates, nodeState)
+	}
+
+	conditions := append([]metav1.Condition{}, npc.Status.Conditions...)
+	if npc.Spec.RdmaMode == "" {
+		meta.RemoveStatusCondition(&conditions, sriovnetworkv1.ConditionRdmaModeApplied)
+	} else {
+		applied := 0
+		for _, nodeState := range nodeStates {
+			// the auto mode is resolved per node based on its NICs
+			rdmaMode := sriovnetworkv1.ResolveRdmaMode(npc.Spec.RdmaMode, nodeState.Status.Interfaces)
+			if nodeState.Status.System.RdmaMode == rdmaMode {
+				applied++
+			}
+		}
+
+		condition := metav1.Condition{
+			Type:               sriovnetworkv1.ConditionRdmaModeApplied,
+			Status:             metav1.ConditionTrue,
+			Reason:             sriovnetworkv1.RdmaModeApplied,
+			Message:            fmt.Sprintf("RDMA mode %s applied on %d/%d nodes", npc.Spec.RdmaMode, applied, len(nodeList.Items)),
+			ObservedGeneration: npc.Generation,
+		}
+		if applied < len(nodeList.Items) {
+			condition.Status = metav1.ConditionFalse
+			condition.Reason = sriovnetworkv1.RdmaModePartiallyApplied
+			if applied == 0 {
+				condition.Reason = sriovnetworkv1.RdmaModeNotApplied
+			}
+		}
+		meta.SetStatusCondition(&conditions, condition)
+	}
+
+	synced := 0
+	for _, nodeState := range nodeStates {
+		if nodeState.Status.SyncStatus == constants.SyncStatusSucceeded {
+			synced++
+		}
+	}
+	readyCondition := metav1.Condition{
+		Type:               sriovnetworkv1.ConditionReady,
+		Status:             metav1.ConditionTrue,
+		Reason:             sriovnetworkv1.PoolNodesSynced,
+		Message:            fmt.Sprintf("%d/%d nodes synced", synced, len(nodeStates)),
+		ObservedGeneration: npc.Generation,
+	}
+	if synced < len(nodeStates) {
+		readyCondition.Status = metav1.ConditionFalse
+		readyCondition.Reason = sriovnetworkv1.PoolNodesNotSynced
+	}
+	meta.SetStatusCondition(&conditions, readyCondition)
+
+	eswitchModes, switchdevCondition := getPoolEswitchModes(npc, nodeStates)
+	if switchdevCondition == nil {
+		meta.RemoveStatusCondition(&conditions, sriovnetworkv1.ConditionSwitchdevApplied)
+	} else {
+		meta.SetStatusCondition(&conditions, *switchdevCondition)
+	}
+
+	if equality.Semantic.DeepEqual(conditions, npc.Status.Conditions) &&
+		equality.Semantic.DeepEqual(eswitchModes, npc.Status.EswitchModes) {
+		return nil
+	}
+	npc.Status.Conditions = conditions
+	npc.Status.EswitchModes = eswitchModes
+	return r.Status().Update(ctx, npc)
+}
+
+// getPoolEswitchModes counts the PFs of the node states in each eSwitch mode, and returns the SwitchdevApplied condition
+// of the pool if some PFs are configured in switchdev mode, the PFs without a reported eSwitch mode are in legacy mode
+func getPoolEswitchModes(npc *sriovnetworkv1.SriovNetworkPoolConfig,
+	nodeStates []*sriovnetworkv1.SriovNetworkNodeState) (*sriovnetworkv1.EswitchModeCounts, *metav1.Condition) {
+	counts := &sriovnetworkv1.EswitchModeCounts{}
+	requested, applied := 0, 0
+	for _, nodeState := range nodeStates {
+		for _, ifaceStatus := range nodeState.Status.Interfaces {
+			if ifaceStatus.EswitchMode == sriovnetworkv1.ESwithModeSwitchDev {
+				counts.Switchdev++
+			} else {
+				counts.Legacy++
+			}
+		}
+		for _, iface := range nodeState.Spec.Interfaces {
+			if iface.EswitchMode != sriovnetworkv1.ESwithModeSwitchDev {
+				continue
+			}
+			requested++
+			if slices.ContainsFunc(nodeState.Status.Interfaces, func(ifaceStatus sriovnetworkv1.InterfaceExt) bool {
+				return ifaceStatus.PciAddress == iface.PciAddress && ifaceStatus.EswitchMode == sriovnetworkv1.ESwithModeSwitchDev
+			}) {
+				applied++
+			}
+		}
+	}
+	if counts.Legacy == 0 && counts.Switchdev == 0 {
+		counts = nil
+	}
+	if requested == 0 {
+		return counts, nil
+	}
+	condition := &metav1.Condition{
+		Type:               sriovnetworkv1.ConditionSwitchdevApplied,
+		Status:             metav1.ConditionTrue,
+		Reason:             sriovnetworkv1.SwitchdevApplied,
+		Message:            fmt.Sprintf("switchdev mode applied on %d/%d PFs", applied, requested),
+		ObservedGeneration: npc.Generation,
+	}
+	if applied < requested {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = sriovnetworkv1.SwitchdevPartiallyApplied
+		if applied == 0 {
+			condition.Reason = sriovnetworkv1.SwitchdevNotApplied
+		}
+	}
+	return counts, condition
+}
+
 func (r *SriovNetworkPoolConfigReconciler) syncOvsHardwareOffloadMachineConfigs(ctx context.Context, nc *sriovnetworkv1.SriovNetworkPoolConfig, deletion bool) error {
 	logger := log.Log.WithName("syncOvsHardwareOffloadMachineConfigs")
 
@@ -2,9 +2,12 @@ package controllers
 
 import (
 	"context"
+	"fmt"
 	"sync"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 
@@ -133,5 +136,193 @@ var _ = Describe("SriovNetworkPoolConfig controller", Ordered, func() {
 				return nil
 			}, util.APITimeout, util.RetryInterval).Should(Succeed())
 		})
+
+		It("should report the RDMA mode application progress", func() {
+			nodeStates := map[string]*sriovnetworkv1.SriovNetworkNodeState{}
+			for _, name := range []string{"rdma-node-0", "rdma-node-1"} {
+				node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{
+					Name:   name,
+					Labels: map[string]string{"rdma-pool": ""},
+				}}
+				Expect(k8sClient.Create(ctx, node)).To(Succeed())
+				DeferCleanup(k8sClient.Delete, ctx, node)
+
+				nodeState := &sriovnetworkv1.SriovNetworkNodeState{ObjectMeta: metav1.ObjectMeta{
+					Name:      name,
+					Namespace: testNamespace,
+				}}
+				Expect(k8sClient.Create(ctx, nodeState)).To(Succeed())
+				DeferCleanup(k8sClient.Delete, ctx, nodeState)
+				nodeStates[name] = nodeState
+			}
+
+			setNodeRdmaMode := func(name, rdmaMode string) {
+				nodeState := nodeStates[name]
+				Expect(k8sClient.Get(ctx, types.NamespacedName{Name: name, Namespace: testNamespace}, nodeState)).To(Succeed())
+				nodeState.Status.System.RdmaMode = rdmaMode
+				Expect(k8sClient.Status().Update(ctx, nodeState)).To(Succeed())
+			}
+			setNodeRdmaMode("rdma-node-0", "exclusive")
+			setNodeRdmaMode("rdma-node-1", "shared")
+
+			config := &sriovnetworkv1.SriovNetworkPoolConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: "rdma-pool", Namespace: testNamespace},
+				Spec: sriovnetworkv1.SriovNetworkPoolConfigSpec{
+					RdmaMode: "exclusive",
+					NodeSelector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{"rdma-pool": ""},
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, config)).To(Succeed())
+			DeferCleanup(k8sClient.Delete, ctx, config)
+
+			assertCondition := func(status metav1.ConditionStatus, reason, message string) {
+				EventuallyWithOffset(1, func(g Gomega) {
+					found := &sriovnetworkv1.SriovNetworkPoolConfig{}
+					g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: config.Name, Namespace: testNamespace}, found)).To(Succeed())
+					condition := meta.FindStatusCondition(found.Status.Conditions, sriovnetworkv1.ConditionRdmaModeApplied)
+					g.Expect(condition).ToNot(BeNil())
+					g.Expect(condition.Status).To(Equal(status))
+					g.Expect(condition.Reason).To(Equal(reason))
+					g.Expect(condition.Message).To(Equal(message))
+				}, util.APITimeout, util.RetryInterval).Should(Succeed())
+			}
+
+			assertCondition(metav1.ConditionFalse, sriovnetworkv1.RdmaModePartiallyApplied, "RDMA mode exclusive applied on 1/2 nodes")
+
+			setNodeRdmaMode("rdma-node-1", "exclusive")
+			assertCondition(metav1.ConditionTrue, sriovnetworkv1.RdmaModeApplied, "RDMA mode exclusive applied on 2/2 nodes")
+		})
+
+		It("should report the pool as ready when the node states of all the nodes are synced", func() {
+			nodeStates := map[string]*sriovnetworkv1.SriovNetworkNodeState{}
+			for _, name := range []string{"ready-node-0", "ready-node-1"} {
+				node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{
+					Name:   name,
+					Labels: map[string]string{"ready-pool": ""},
+				}}
+				Expect(k8sClient.Create(ctx, node)).To(Succeed())
+				DeferCleanup(k8sClient.Delete, ctx, node)
+
+				nodeState := &sriovnetworkv1.SriovNetworkNodeState{ObjectMeta: metav1.ObjectMeta{
+					Name:      name,
+					Namespace: testNamespace,
+				}}
+				Expect(k8sClient.Create(ctx, nodeState)).To(Succeed())
+				DeferCleanup(k8sClient.Delete, ctx, nodeState)
+				nodeStates[name] = nodeState
+			}
+
+			setNodeSyncStatus := func(name, syncStatus string) {
+				nodeState := nodeStates[name]
+				Expect(k8sClient.Get(ctx, types.NamespacedName{Name: name, Namespace: testNamespace}, nodeState)).To(Succeed())
+				nodeState.Status.SyncStatus = syncStatus
+				Expect(k8sClient.Status().Update(ctx, nodeState)).To(Succeed())
+			}
+			setNodeSyncStatus("ready-node-0", constants.SyncStatusSucceeded)
+			setNodeSyncStatus("ready-node-1", constants.SyncStatusInProgress)
+
+			config := &sriovnetworkv1.SriovNetworkPoolConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: "ready-pool", Namespace: testNamespace},
+				Spec: sriovnetworkv1.SriovNetworkPoolConfigSpec{
+					NodeSelector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{"ready-pool": ""},
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, config)).To(Succeed())
+			DeferCleanup(k8sClient.Delete, ctx, config)
+
+			assertCondition := func(status metav1.ConditionStatus, reason, message string) {
+				EventuallyWithOffset(1, func(g Gomega) {
+					found := &sriovnetworkv1.SriovNetworkPoolConfig{}
+					g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: config.Name, Namespace: testNamespace}, found)).To(Succeed())
+					condition := meta.FindStatusCondition(found.Status.Conditions, sriovnetworkv1.ConditionReady)
+					g.Expect(condition).ToNot(BeNil())
+					g.Expect(condition.Status).To(Equal(status))
+					g.Expect(condition.Reason).To(Equal(reason))
+					g.Expect(condition.Message).To(Equal(message))
+				}, util.APITimeout, util.RetryInterval).Should(Succeed())
+			}
+
+			assertCondition(metav1.ConditionFalse, sriovnetworkv1.PoolNodesNotSynced, "1/2 nodes synced")
+
+			setNodeSyncStatus("ready-node-1", constants.SyncStatusSucceeded)
+			assertCondition(metav1.ConditionTrue, sriovnetworkv1.PoolNodesSynced, "2/2 nodes synced")
+		})
+
+		It("should report the eSwitch modes of the PFs and the switchdev rollout progress", func() {
+			nodeStates := map[string]*sriovnetworkv1.SriovNetworkNodeState{}
+			for _, name := range []string{"switchdev-node-0", "switchdev-node-1"} {
+				node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{
+					Name:   name,
+					Labels: map[string]string{"switchdev-pool": ""},
+				}}
+				Expect(k8sClient.Create(ctx, node)).To(Succeed())
+				DeferCleanup(k8sClient.Delete, ctx, node)
+
+				nodeState := &sriovnetworkv1.SriovNetworkNodeState{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      name,
+						Namespace: testNamespace,
+					},
+					Spec: sriovnetworkv1.SriovNetworkNodeStateSpec{
+						Interfaces: sriovnetworkv1.Interfaces{
+							{PciAddress: "0000:d8:00.0", NumVfs: 4, EswitchMode: sriovnetworkv1.ESwithModeSwitchDev},
+						},
+					},
+				}
+				Expect(k8sClient.Create(ctx, nodeState)).To(Succeed())
+				DeferCleanup(k8sClient.Delete, ctx, nodeState)
+				nodeStates[name] = nodeState
+			}
+
+			setNodeEswitchModes := func(name string, modes ...string) {
+				nodeState := nodeStates[name]
+				Expect(k8sClient.Get(ctx, types.NamespacedName{Name: name, Namespace: testNamespace}, nodeState)).To(Succeed())
+				nodeState.Status.Interfaces = nil
+				for i, mode := range modes {
+					nodeState.Status.Interfaces = append(nodeState.Status.Interfaces, sriovnetworkv1.InterfaceExt{
+						PciAddress:  fmt.Sprintf("0000:d8:00.%d", i),
+						EswitchMode: mode,
+					})
+				}
+				Expect(k8sClient.Status().Update(ctx, nodeState)).To(Succeed())
+			}
+			setNodeEswitchModes("switchdev-node-0", sriovnetworkv1.ESwithModeSwitchDev, sriovnetworkv1.ESwithModeLegacy)
+			setNodeEswitchModes("switchdev-node-1", sriovnetworkv1.ESwithModeLegacy, "")
+
+			config := &sriovnetworkv1.SriovNetworkPoolConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: "switchdev-pool", Namespace: testNamespace},
+				Spec: sriovnetworkv1.SriovNetworkPoolConfigSpec{
+					NodeSelector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{"switchdev-pool": ""},
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, config)).To(Succeed())
+			DeferCleanup(k8sClient.Delete, ctx, config)
+
+			assertStatus := func(counts sriovnetworkv1.EswitchModeCounts, status metav1.ConditionStatus, reason, message string) {
+				EventuallyWithOffset(1, func(g Gomega) {
+					found := &sriovnetworkv1.SriovNetworkPoolConfig{}
+					g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: config.Name, Namespace: testNamespace}, found)).To(Succeed())
+					g.Expect(found.Status.EswitchModes).To(Equal(&counts))
+					condition := meta.FindStatusCondition(found.Status.Conditions, sriovnetworkv1.ConditionSwitchdevApplied)
+					g.Expect(condition).ToNot(BeNil())
+					g.Expect(condition.Status).To(Equal(status))
+					g.Expect(condition.Reason).To(Equal(reason))
+					g.Expect(condition.Message).To(Equal(message))
+				}, util.APITimeout, util.RetryInterval).Should(Succeed())
+			}
+
+			assertStatus(sriovnetworkv1.EswitchModeCounts{Legacy: 3, Switchdev: 1},
+				metav1.ConditionFalse, sriovnetworkv1.SwitchdevPartiallyApplied, "switchdev mode applied on 1/2 PFs")
+
+			setNodeEswitchModes("switchdev-node-1", sriovnetworkv1.ESwithModeSwitchDev, "")
+			assertStatus(sriovnetworkv1.EswitchModeCounts{Legacy: 2, Switchdev: 2},
+				metav1.ConditionTrue, sriovnetworkv1.SwitchdevApplied, "switchdev mode applied on 2/2 PFs")
+		})
 	})
 })
@@ -21,12 +21,17 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"slices"
 	"sort"
+	"strconv"
 	"strings"
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	uns "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -36,6 +41,7 @@ import (
 	ctrl_builder "sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
@@ -130,8 +136,13 @@ func (r *SriovOperatorConfigReconciler) Reconcile(ctx context.Context, req ctrl.
 	// it will remain in the same order and not trigger a pod recreation
 	sort.Sort(sriovnetworkv1.ByPriority(policyList.Items))
 
+	missingSecret, err := r.missingInjectorSecret(ctx, defaultConfig)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
 	// Render and sync webhook objects
-	if err = r.syncWebhookObjs(ctx, defaultConfig); err != nil {
+	if err = r.syncWebhookObjs(ctx, defaultConfig, missingSecret == ""); err != nil {
 		return reconcile.Result{}, err
 	}
 
@@ -160,6 +171,10 @@ func (r *SriovOperatorConfigReconciler) Reconcile(ctx context.Context, req ctrl.
 		}
 	}
 
+	if err = r.syncStatusConditions(ctx, defaultConfig, missingSecret); err != nil {
+		return reconcile.Result{}, err
+	}
+
 	logger.Info("Reconcile SriovOperatorConfig completed successfully")
 	return reconcile.Result{RequeueAfter: consts.ResyncPeriod}, nil
 }
@@ -181,9 +196,100 @@ func (r *SriovOperatorConfigReconciler) SetupWithManager(mgr ctrl.Manager) error
 		For(&sriovnetworkv1.SriovOperatorConfig{}, ctrl_builder.WithPredicates(defaultConfigPredicate())).
 		Owns(&appsv1.DaemonSet{}).
 		Owns(&corev1.ConfigMap{}).
+		// the Ready condition depends on the status of the pools
+		Watches(&sriovnetworkv1.SriovNetworkPoolConfig{}, handler.EnqueueRequestsFromMapFunc(
+			func(_ context.Context, _ client.Object) []reconcile.Request {
+				return []reconcile.Request{{NamespacedName: types.NamespacedName{
+					Namespace: vars.Namespace, Name: consts.DefaultConfigName}}}
+			})).
+		// the injector is held back until the secret holding its certificates exists
+		Watches(&corev1.Secret{}, handler.EnqueueRequestsFromMapFunc(
+			func(_ context.Context, _ client.Object) []reconcile.Request {
+				return []reconcile.Request{{NamespacedName: types.NamespacedName{
+					Namespace: vars.Namespace, Name: consts.DefaultConfigName}}}
+			}), ctrl_builder.WithPredicates(predicate.NewPredicateFuncs(func(object client.Object) bool {
+			return object.GetName() == os.Getenv("ADMISSION_CONTROLLERS_CERTIFICATES_INJECTOR_SECRET_NAME") &&
+				object.GetNamespace() == vars.Namespace
+		}))).
 		Complete(r)
 }
 
+// missingInjectorSecret returns the name of the secret holding the network resources injector
+// certificates when the injector is enabled but the secret doesn't exist yet.
+// On OpenShift the secret is generated by the service CA once the injector service is created,
+// so it is not checked there.
+func (r *SriovOperatorConfigReconciler) missingInjectorSecret(ctx context.Context, dc *sriovnetworkv1.SriovOperatorConfig) (string, error) {
+	if !dc.Spec.EnableInjector || r.PlatformHelper.IsOpenshiftCluster() {
+		return "", nil
+	}
+
+	secretName := os.Getenv("ADMISSION_CONTROLLERS_CERTIFICATES_INJECTOR_SECRET_NAME")
+	if secretName == "" {
+		return "", nil
+	}
+
+	err := r.Get(ctx, types.NamespacedName{Namespace: vars.Namespace, Name: secretName}, &corev1.Secret{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return secretName, nil
+		}
+		return "", fmt.Errorf("failed to get injector secret %s: %v", secretName, err)
+	}
+	return "", nil
+}
+
+// syncStatusConditions sets the conditions of the SriovOperatorConfig,
+// the operator is ready when all the SriovNetworkPoolConfigs are ready
+// and degraded when a secret referenced by the injector is missing
+func (r *SriovOperatorConfigReconciler) syncStatusConditions(ctx context.Context, dc *sriovnetworkv1.SriovOperatorConfig, missingSecret string) error {
+	poolList := &sriovnetworkv1.SriovNetworkPoolConfigList{}
+	if err := r.List(ctx, poolList); err != nil {
+		return fmt.Errorf("failed to list SriovNetworkPoolConfigs: %v", err)
+	}
+
+	notReady := []string{}
+	for _, pool := range poolList.Items {
+		readyCondition := meta.FindStatusCondition(pool.Status.Conditions, sriovnetworkv1.ConditionReady)
+		if readyCondition == nil || readyCondition.Status != metav1.ConditionTrue || readyCondition.ObservedGeneration != pool.Generation {
+			notReady = append(notReady, pool.Name)
+		}
+	}
+	sort.Strings(notReady)
+
+	condition := metav1.Condition{
+		Type:               sriovnetworkv1.ConditionReady,
+		Status:             metav1.ConditionTrue,
+		Reason:             sriovnetworkv1.PoolsReady,
+		Message:            fmt.Sprintf("%d/%d SriovNetworkPoolConfigs ready", len(poolList.Items)-len(notReady), len(poolList.Items)),
+		ObservedGeneration: dc.Generation,
+	}
+	if len(notReady) > 0 {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = sriovnetworkv1.PoolsNotReady
+		condition.Message = fmt.Sprintf("%s, not ready: %s", condition.Message, strings.Join(notReady, ", "))
+	}
+
+	conditions := append([]metav1.Condition{}, dc.Status.Conditions...)
+	meta.SetStatusCondition(&conditions, condition)
+	if missingSecret != "" {
+		meta.SetStatusCondition(&conditions, metav1.Condition{
+			Type:   sriovnetworkv1.ConditionDegraded,
+			Status: metav1.ConditionTrue,
+			Reason: sriovnetworkv1.InjectorSecretMissing,
+			Message: fmt.Sprintf("network resources injector is not deployed: secret %s/%s referenced for its certificates does not exist",
+				vars.Namespace, missingSecret),
+			ObservedGeneration: dc.Generation,
+		})
+	} else {
+		meta.RemoveStatusCondition(&conditions, sriovnetworkv1.ConditionDegraded)
+	}
+	if equality.Semantic.DeepEqual(conditions, dc.Status.Conditions) {
+		return nil
+	}
+	dc.Status.Conditions = conditions
+	return r.Status().Update(ctx, dc)
+}
+
 func (r *SriovOperatorConfigReconciler) syncConfigDaemonSet(ctx context.Context, dc *sriovnetworkv1.SriovOperatorConfig) error {
 	logger := log.Log.WithName("syncConfigDaemonset")
 	logger.V(1).Info("Start to sync config daemonset")
@@ -198,14 +304,27 @@ func (r *SriovOperatorConfigReconciler) syncConfigDaemonSet(ctx context.Context,
 	data.Data["ReleaseVersion"] = os.Getenv("RELEASEVERSION")
 	data.Data["ClusterType"] = vars.ClusterType
 	data.Data["DevMode"] = os.Getenv("DEV_MODE")
+	data.Data["MetricsBindAddress"] = os.Getenv("CONFIG_DAEMON_METRICS_BIND_ADDRESS")
 	data.Data["ImagePullSecrets"] = GetImagePullSecrets()
 	if dc.Spec.ConfigurationMode == sriovnetworkv1.SystemdConfigurationMode {
 		data.Data["UsedSystemdMode"] = true
 	} else {
 		data.Data["UsedSystemdMode"] = false
 	}
+	// the binary used by the systemd service is also copied to the nodes of the pools overriding the mode
+	poolList := &sriovnetworkv1.SriovNetworkPoolConfigList{}
+	if err := r.List(ctx, poolList); err != nil {
+		return fmt.Errorf("failed to list SriovNetworkPoolConfigs: %v", err)
+	}
+	data.Data["SystemdModeInPools"] = slices.ContainsFunc(poolList.Items, func(pool sriovnetworkv1.SriovNetworkPoolConfig) bool {
+		return pool.Spec.ConfigurationMode == sriovnetworkv1.SystemdConfigurationMode
+	})
 	data.Data["ParallelNicConfig"] = r.FeatureGate.IsEnabled(consts.ParallelNicConfigFeatureGate)
 	data.Data["ManageSoftwareBridges"] = r.FeatureGate.IsEnabled(consts.ManageSoftwareBridgesFeatureGate)
+	data.Data["PriorityClassName"] = GetConfigDaemonPriorityClassName(dc)
+	if dc.Spec.LogFormat != "" {
+		data.Data["LogFormat"] = string(dc.Spec.LogFormat)
+	}
 
 	envCniBinPath := os.Getenv("SRIOV_CNI_BIN_PATH")
 	if envCniBinPath == "" {
@@ -255,6 +374,17 @@ func (r *SriovOperatorConfigReconciler) syncMetricsExporter(ctx context.Context,
 	data.Data["MetricsExporterPort"] = os.Getenv("METRICS_EXPORTER_PORT")
 	data.Data["MetricsExporterKubeRbacProxyImage"] = os.Getenv("METRICS_EXPORTER_KUBE_RBAC_PROXY_IMAGE")
 	data.Data["IsOpenshift"] = r.PlatformHelper.IsOpenshiftCluster()
+	// the service serving certificate is only generated for the default secret
+	data.Data["MetricsExporterCustomCert"] = false
+	data.Data["MetricsExporterClientCASecretName"] = ""
+	if dc.Spec.MetricsExporterPort != 0 {
+		data.Data["MetricsExporterPort"] = strconv.Itoa(dc.Spec.MetricsExporterPort)
+	}
+	if dc.Spec.MetricsExporterTLS != nil {
+		data.Data["MetricsExporterSecretName"] = dc.Spec.MetricsExporterTLS.CertSecretName
+		data.Data["MetricsExporterCustomCert"] = true
+		data.Data["MetricsExporterClientCASecretName"] = dc.Spec.MetricsExporterTLS.ClientCASecretName
+	}
 
 	data.Data["IsPrometheusOperatorInstalled"] = strings.ToLower(os.Getenv("METRICS_EXPORTER_PROMETHEUS_OPERATOR_ENABLED")) == trueString
 	data.Data["PrometheusOperatorDeployRules"] = strings.ToLower(os.Getenv("METRICS_EXPORTER_PROMETHEUS_DEPLOY_RULES")) == trueString
@@ -292,7 +422,7 @@ func (r *SriovOperatorConfigReconciler) syncMetricsExporter(ctx context.Context,
 	return nil
 }
 
-func (r *SriovOperatorConfigReconciler) syncWebhookObjs(ctx context.Context, dc *sriovnetworkv1.SriovOperatorConfig) error {
+func (r *SriovOperatorConfigReconciler) syncWebhookObjs(ctx context.Context, dc *sriovnetworkv1.SriovOperatorConfig, deployInjector bool) error {
 	logger := log.Log.WithName("syncWebhookObjs")
 	logger.V(1).Info("Start to sync webhook objects")
 
@@ -341,6 +471,11 @@ func (r *SriovOperatorConfigReconciler) syncWebhookObjs(ctx context.Context, dc
 			logger.Info("Set 'SriovOperatorConfig.Spec.EnableInjector' to true(bool).")
 			continue
 		}
+		// Hold the injector back until the secret holding its certificates exists
+		if !deployInjector && path == consts.InjectorWebHookPath {
+			logger.Info("SR-IOV Admission Controller certificates secret is missing, skipping deployment.")
+			continue
+		}
 		// Delete operator webhook
 		if !dc.Spec.EnableOperatorWebhook && path == consts.OperatorWebHookPath {
 			for _, obj := range objs {
@@ -12,6 +12,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -52,6 +53,9 @@ var _ = Describe("SriovOperatorConfig controller", Ordered, func() {
 		}
 		Expect(k8sClient.Create(context.Background(), somePolicy)).ToNot(HaveOccurred())
 
+		injectorSecret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: "network-resources-injector-cert"}}
+		Expect(k8sClient.Create(context.Background(), injectorSecret)).ToNot(HaveOccurred())
+
 		// setup controller manager
 		By("Setup controller manager")
 		k8sManager, err := setupK8sManagerForTest()
@@ -351,6 +355,36 @@ var _ = Describe("SriovOperatorConfig controller", Ordered, func() {
 			}, util.APITimeout, util.RetryInterval).Should(Equal(secondNodeSelector))
 		})
 
+		It("should render the priority class of sriov-network-config-daemon and sriov-device-plugin", func() {
+			assertPriorityClassName := func(priorityClassName string) {
+				for _, name := range []string{"sriov-network-config-daemon", "sriov-device-plugin"} {
+					EventuallyWithOffset(1, func(g Gomega) {
+						daemonSet := &appsv1.DaemonSet{}
+						err := k8sClient.Get(ctx, types.NamespacedName{Name: name, Namespace: testNamespace}, daemonSet)
+						g.Expect(err).ToNot(HaveOccurred())
+						g.Expect(daemonSet.Spec.Template.Spec.PriorityClassName).To(Equal(priorityClassName))
+					}, util.APITimeout, util.RetryInterval).Should(Succeed())
+				}
+			}
+
+			By("using the default priority class")
+			assertPriorityClassName(consts.DefaultConfigDaemonPriorityClassName)
+
+			By("specifying the configDaemonPriorityClassName")
+			config := &sriovnetworkv1.SriovOperatorConfig{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: testNamespace, Name: "default"}, config)).NotTo(HaveOccurred())
+			config.Spec.ConfigDaemonPriorityClassName = "custom-priority"
+			Expect(k8sClient.Update(ctx, config)).To(Succeed())
+			DeferCleanup(func() {
+				config := &sriovnetworkv1.SriovOperatorConfig{}
+				Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: testNamespace, Name: "default"}, config)).NotTo(HaveOccurred())
+				config.Spec.ConfigDaemonPriorityClassName = ""
+				Expect(k8sClient.Update(ctx, config)).To(Succeed())
+			})
+
+			assertPriorityClassName("custom-priority")
+		})
+
 		It("should not render disable-plugins cmdline flag of sriov-network-config-daemon if disablePlugin not provided in spec", func() {
 			config := &sriovnetworkv1.SriovOperatorConfig{}
 			Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: testNamespace, Name: "default"}, config)).NotTo(HaveOccurred())
@@ -468,6 +502,57 @@ var _ = Describe("SriovOperatorConfig controller", Ordered, func() {
 					}, time.Minute, time.Second).Should(Succeed())
 				})
 
+				It("should deploy the sriov-network-metrics-exporter using the Spec.MetricsExporterPort and Spec.MetricsExporterTLS fields", func() {
+					config := &sriovnetworkv1.SriovOperatorConfig{}
+					Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: testNamespace, Name: "default"}, config)).To(Succeed())
+					config.Spec.MetricsExporterPort = 9443
+					config.Spec.MetricsExporterTLS = &sriovnetworkv1.MetricsExporterTLSConfig{
+						CertSecretName:     "custom-metrics-cert",
+						ClientCASecretName: "custom-metrics-client-ca",
+					}
+					Expect(k8sClient.Update(ctx, config)).To(Succeed())
+					DeferCleanup(func() {
+						config := &sriovnetworkv1.SriovOperatorConfig{}
+						Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: testNamespace, Name: "default"}, config)).To(Succeed())
+						config.Spec.MetricsExporterPort = 0
+						config.Spec.MetricsExporterTLS = nil
+						Expect(k8sClient.Update(ctx, config)).To(Succeed())
+					})
+
+					Eventually(func(g Gomega) {
+						service := &corev1.Service{}
+						err := k8sClient.Get(ctx, types.NamespacedName{Namespace: testNamespace, Name: "sriov-network-metrics-exporter-service"}, service)
+						g.Expect(err).ToNot(HaveOccurred())
+						g.Expect(service.Spec.Ports).To(HaveLen(1))
+						g.Expect(service.Spec.Ports[0].Port).To(Equal(int32(9443)))
+						g.Expect(service.Spec.Ports[0].TargetPort.IntValue()).To(Equal(9443))
+
+						daemonSet := &appsv1.DaemonSet{}
+						err = k8sClient.Get(ctx, types.NamespacedName{Namespace: testNamespace, Name: "sriov-network-metrics-exporter"}, daemonSet)
+						g.Expect(err).ToNot(HaveOccurred())
+						secretNames := map[string]string{}
+						for _, volume := range daemonSet.Spec.Template.Spec.Volumes {
+							if volume.Secret != nil {
+								secretNames[volume.Name] = volume.Secret.SecretName
+							}
+						}
+						g.Expect(secretNames).To(Equal(map[string]string{
+							"metrics-certs":     "custom-metrics-cert",
+							"metrics-client-ca": "custom-metrics-client-ca",
+						}))
+
+						var proxy *corev1.Container
+						for i := range daemonSet.Spec.Template.Spec.Containers {
+							if daemonSet.Spec.Template.Spec.Containers[i].Name == "kube-rbac-proxy" {
+								proxy = &daemonSet.Spec.Template.Spec.Containers[i]
+							}
+						}
+						g.Expect(proxy).ToNot(BeNil())
+						g.Expect(proxy.Args).To(ContainElement("--client-ca-file=/etc/metrics-client-ca/ca.crt"))
+						g.Expect(proxy.Args).To(ContainElement("--secure-listen-address=[$(HOST_IP)]:9443"))
+					}, util.APITimeout, util.RetryInterval).Should(Succeed())
+				})
+
 				It("should deploy extra configuration when the Prometheus operator is installed", func() {
 					DeferCleanup(os.Setenv, "METRICS_EXPORTER_PROMETHEUS_OPERATOR_ENABLED", os.Getenv("METRICS_EXPORTER_PROMETHEUS_OPERATOR_ENABLED"))
 					os.Setenv("METRICS_EXPORTER_PROMETHEUS_OPERATOR_ENABLED", "true")
@@ -503,6 +588,107 @@ var _ = Describe("SriovOperatorConfig controller", Ordered, func() {
 		// removed during the reconciliation loop. This is important when dealing with OpenShift certificate mangement:
 		// https://docs.openshift.com/container-platform/4.15/security/certificates/service-serving-certificate.html
 		// and when CertManager is used
+		It("should not report the operator as ready until all the SriovNetworkPoolConfigs are ready", func() {
+			setPoolReady := func(name string, status metav1.ConditionStatus) {
+				pool := &sriovnetworkv1.SriovNetworkPoolConfig{}
+				Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: testNamespace, Name: name}, pool)).To(Succeed())
+				meta.SetStatusCondition(&pool.Status.Conditions, metav1.Condition{
+					Type:               sriovnetworkv1.ConditionReady,
+					Status:             status,
+					Reason:             sriovnetworkv1.PoolNodesSynced,
+					ObservedGeneration: pool.Generation,
+				})
+				Expect(k8sClient.Status().Update(ctx, pool)).To(Succeed())
+			}
+
+			for _, name := range []string{"pool-ready", "pool-not-ready"} {
+				pool := &sriovnetworkv1.SriovNetworkPoolConfig{ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: name}}
+				Expect(k8sClient.Create(ctx, pool)).To(Succeed())
+				DeferCleanup(k8sClient.Delete, ctx, pool)
+			}
+			setPoolReady("pool-ready", metav1.ConditionTrue)
+			setPoolReady("pool-not-ready", metav1.ConditionFalse)
+
+			Eventually(func(g Gomega) {
+				config := &sriovnetworkv1.SriovOperatorConfig{}
+				g.Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: testNamespace, Name: "default"}, config)).To(Succeed())
+				condition := meta.FindStatusCondition(config.Status.Conditions, sriovnetworkv1.ConditionReady)
+				g.Expect(condition).ToNot(BeNil())
+				g.Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+				g.Expect(condition.Reason).To(Equal(sriovnetworkv1.PoolsNotReady))
+				g.Expect(condition.Message).To(ContainSubstring("pool-not-ready"))
+				g.Expect(condition.Message).ToNot(ContainSubstring("pool-ready"))
+			}, util.APITimeout, util.RetryInterval).Should(Succeed())
+
+			Consistently(func(g Gomega) {
+				config := &sriovnetworkv1.SriovOperatorConfig{}
+				g.Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: testNamespace, Name: "default"}, config)).To(Succeed())
+				g.Expect(meta.IsStatusConditionFalse(config.Status.Conditions, sriovnetworkv1.ConditionReady)).To(BeTrue())
+			}, "2s", "200ms").Should(Succeed())
+
+			setPoolReady("pool-not-ready", metav1.ConditionTrue)
+
+			Eventually(func(g Gomega) {
+				config := &sriovnetworkv1.SriovOperatorConfig{}
+				g.Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: testNamespace, Name: "default"}, config)).To(Succeed())
+				condition := meta.FindStatusCondition(config.Status.Conditions, sriovnetworkv1.ConditionReady)
+				g.Expect(condition).ToNot(BeNil())
+				g.Expect(condition.Message).ToNot(ContainSubstring("pool-not-ready"))
+			}, util.APITimeout, util.RetryInterval).Should(Succeed())
+		})
+
+		It("should not deploy the network-resources-injector and report the operator as degraded when its secret is missing", func() {
+			injectorSecret := &corev1.Secret{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: testNamespace, Name: "network-resources-injector-cert"}, injectorSecret)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, injectorSecret)).To(Succeed())
+			DeferCleanup(func() {
+				err := k8sClient.Create(ctx, &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: "network-resources-injector-cert"}})
+				if !errors.IsAlreadyExists(err) {
+					Expect(err).ToNot(HaveOccurred())
+				}
+			})
+
+			By("redeploying the injector without its secret")
+			config := &sriovnetworkv1.SriovOperatorConfig{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: testNamespace, Name: "default"}, config)).To(Succeed())
+			config.Spec.EnableInjector = false
+			Expect(k8sClient.Update(ctx, config)).To(Succeed())
+
+			err := util.WaitForNamespacedObjectDeleted(&appsv1.DaemonSet{}, k8sClient, testNamespace, "network-resources-injector", util.RetryInterval, util.APITimeout)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: testNamespace, Name: "default"}, config)).To(Succeed())
+			config.Spec.EnableInjector = true
+			Expect(k8sClient.Update(ctx, config)).To(Succeed())
+
+			Eventually(func(g Gomega) {
+				config := &sriovnetworkv1.SriovOperatorConfig{}
+				g.Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: testNamespace, Name: "default"}, config)).To(Succeed())
+				condition := meta.FindStatusCondition(config.Status.Conditions, sriovnetworkv1.ConditionDegraded)
+				g.Expect(condition).ToNot(BeNil())
+				g.Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+				g.Expect(condition.Reason).To(Equal(sriovnetworkv1.InjectorSecretMissing))
+				g.Expect(condition.Message).To(ContainSubstring("network-resources-injector-cert"))
+			}, util.APITimeout, util.RetryInterval).Should(Succeed())
+
+			Consistently(func(g Gomega) {
+				err := k8sClient.Get(ctx, types.NamespacedName{Namespace: testNamespace, Name: "network-resources-injector"}, &appsv1.DaemonSet{})
+				g.Expect(errors.IsNotFound(err)).To(BeTrue())
+			}, "2s", "200ms").Should(Succeed())
+
+			By("creating the missing secret")
+			Expect(k8sClient.Create(ctx, &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: "network-resources-injector-cert"}})).To(Succeed())
+
+			err = util.WaitForNamespacedObject(&appsv1.DaemonSet{}, k8sClient, testNamespace, "network-resources-injector", util.RetryInterval, util.APITimeout)
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(func(g Gomega) {
+				config := &sriovnetworkv1.SriovOperatorConfig{}
+				g.Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: testNamespace, Name: "default"}, config)).To(Succeed())
+				g.Expect(meta.FindStatusCondition(config.Status.Conditions, sriovnetworkv1.ConditionDegraded)).To(BeNil())
+			}, util.APITimeout, util.RetryInterval).Should(Succeed())
+		})
+
 		It("should not remove the field Spec.ClientConfig.CABundle from webhook configuration when reconciling", func() {
 			validateCfg := &admv1.ValidatingWebhookConfiguration{}
 			err := util.WaitForNamespacedObject(validateCfg, k8sClient, testNamespace, "sriov-operator-webhook-config", util.RetryInterval, util.APITimeout*3)
@@ -82,6 +82,8 @@ func setupK8sManagerForTest() (manager.Manager, error) {
 		return []string{o.(*sriovnetworkv1.OVSNetwork).Spec.NetworkNamespace}
 	})
 
+	k8sManager.GetCache().IndexField(context.Background(), &netattdefv1.NetworkAttachmentDefinition{}, NetAttDefOwnerKindIndex, NetAttDefOwnerKind)
+
 	return k8sManager, nil
 }
 
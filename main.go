@@ -172,6 +172,12 @@ func main() {
 		os.Exit(1)
 	}
 
+	err = mgrGlobal.GetCache().IndexField(context.Background(), &netattdefv1.NetworkAttachmentDefinition{}, controllers.NetAttDefOwnerKindIndex, controllers.NetAttDefOwnerKind)
+	if err != nil {
+		setupLog.Error(err, "unable to create index field for cache")
+		os.Exit(1)
+	}
+
 	if err := initNicIDMap(); err != nil {
 		setupLog.Error(err, "unable to init NicIdMap")
 		os.Exit(1)
@@ -235,6 +241,20 @@ func main() {
 		setupLog.Error(err, "unable to create controller", "controller", "SriovNetworkPoolConfig")
 		os.Exit(1)
 	}
+	if err = (&controllers.SriovNetworkNodeStateSummaryReconciler{
+		Client: mgr.GetClient(),
+		Scheme: mgr.GetScheme(),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "SriovNetworkNodeStateSummary")
+		os.Exit(1)
+	}
+	if err = (&controllers.NodeStateCleanupReconciler{
+		Client: mgr.GetClient(),
+		Scheme: mgr.GetScheme(),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "NodeStateCleanup")
+		os.Exit(1)
+	}
 
 	// we need a client that doesn't use the local cache for the objects
 	drainKClient, err := client.New(restConfig, client.Options{
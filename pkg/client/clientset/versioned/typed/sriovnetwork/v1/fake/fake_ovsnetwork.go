@@ -0,0 +1,126 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+
+	sriovnetworkv1 "github.com/k8snetworkplumbingwg/sriov-network-operator/api/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeOVSNetworks implements OVSNetworkInterface
+type FakeOVSNetworks struct {
+	Fake *FakeSriovnetworkV1
+	ns   string
+}
+
+var ovsnetworksResource = schema.GroupVersionResource{Group: "sriovnetwork.openshift.io", Version: "v1", Resource: "ovsnetworks"}
+
+var ovsnetworksKind = schema.GroupVersionKind{Group: "sriovnetwork.openshift.io", Version: "v1", Kind: "OVSNetwork"}
+
+// Get takes name of the oVSNetwork, and returns the corresponding oVSNetwork object, and an error if there is any.
+func (c *FakeOVSNetworks) Get(ctx context.Context, name string, options v1.GetOptions) (result *sriovnetworkv1.OVSNetwork, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewGetAction(ovsnetworksResource, c.ns, name), &sriovnetworkv1.OVSNetwork{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*sriovnetworkv1.OVSNetwork), err
+}
+
+// List takes label and field selectors, and returns the list of OVSNetworks that match those selectors.
+func (c *FakeOVSNetworks) List(ctx context.Context, opts v1.ListOptions) (result *sriovnetworkv1.OVSNetworkList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewListAction(ovsnetworksResource, ovsnetworksKind, c.ns, opts), &sriovnetworkv1.OVSNetworkList{})
+
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &sriovnetworkv1.OVSNetworkList{ListMeta: obj.(*sriovnetworkv1.OVSNetworkList).ListMeta}
+	for _, item := range obj.(*sriovnetworkv1.OVSNetworkList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested oVSNetworks.
+func (c *FakeOVSNetworks) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchAction(ovsnetworksResource, c.ns, opts))
+
+}
+
+// Create takes the representation of a oVSNetwork and creates it.  Returns the server's representation of the oVSNetwork, and an error, if there is any.
+func (c *FakeOVSNetworks) Create(ctx context.Context, oVSNetwork *sriovnetworkv1.OVSNetwork, opts v1.CreateOptions) (result *sriovnetworkv1.OVSNetwork, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateAction(ovsnetworksResource, c.ns, oVSNetwork), &sriovnetworkv1.OVSNetwork{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*sriovnetworkv1.OVSNetwork), err
+}
+
+// Update takes the representation of a oVSNetwork and updates it. Returns the server's representation of the oVSNetwork, and an error, if there is any.
+func (c *FakeOVSNetworks) Update(ctx context.Context, oVSNetwork *sriovnetworkv1.OVSNetwork, opts v1.UpdateOptions) (result *sriovnetworkv1.OVSNetwork, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateAction(ovsnetworksResource, c.ns, oVSNetwork), &sriovnetworkv1.OVSNetwork{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*sriovnetworkv1.OVSNetwork), err
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeOVSNetworks) UpdateStatus(ctx context.Context, oVSNetwork *sriovnetworkv1.OVSNetwork, opts v1.UpdateOptions) (*sriovnetworkv1.OVSNetwork, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateSubresourceAction(ovsnetworksResource, "status", c.ns, oVSNetwork), &sriovnetworkv1.OVSNetwork{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*sriovnetworkv1.OVSNetwork), err
+}
+
+// Delete takes name of the oVSNetwork and deletes it. Returns an error if one occurs.
+func (c *FakeOVSNetworks) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteAction(ovsnetworksResource, c.ns, name), &sriovnetworkv1.OVSNetwork{})
+
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeOVSNetworks) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewDeleteCollectionAction(ovsnetworksResource, c.ns, listOpts)
+
+	_, err := c.Fake.Invokes(action, &sriovnetworkv1.OVSNetworkList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched oVSNetwork.
+func (c *FakeOVSNetworks) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *sriovnetworkv1.OVSNetwork, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(ovsnetworksResource, c.ns, name, pt, data, subresources...), &sriovnetworkv1.OVSNetwork{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*sriovnetworkv1.OVSNetwork), err
+}
@@ -12,6 +12,14 @@ type FakeSriovnetworkV1 struct {
 	*testing.Fake
 }
 
+func (c *FakeSriovnetworkV1) OVSNetworks(namespace string) v1.OVSNetworkInterface {
+	return &FakeOVSNetworks{c, namespace}
+}
+
+func (c *FakeSriovnetworkV1) SriovIBNetworks(namespace string) v1.SriovIBNetworkInterface {
+	return &FakeSriovIBNetworks{c, namespace}
+}
+
 func (c *FakeSriovnetworkV1) SriovNetworks(namespace string) v1.SriovNetworkInterface {
 	return &FakeSriovNetworks{c, namespace}
 }
@@ -24,6 +32,10 @@ func (c *FakeSriovnetworkV1) SriovNetworkNodeStates(namespace string) v1.SriovNe
 	return &FakeSriovNetworkNodeStates{c, namespace}
 }
 
+func (c *FakeSriovnetworkV1) SriovNetworkPoolConfigs(namespace string) v1.SriovNetworkPoolConfigInterface {
+	return &FakeSriovNetworkPoolConfigs{c, namespace}
+}
+
 func (c *FakeSriovnetworkV1) SriovOperatorConfigs(namespace string) v1.SriovOperatorConfigInterface {
 	return &FakeSriovOperatorConfigs{c, namespace}
 }
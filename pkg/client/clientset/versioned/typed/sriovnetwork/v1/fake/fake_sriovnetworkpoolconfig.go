@@ -0,0 +1,126 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+
+	sriovnetworkv1 "github.com/k8snetworkplumbingwg/sriov-network-operator/api/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeSriovNetworkPoolConfigs implements SriovNetworkPoolConfigInterface
+type FakeSriovNetworkPoolConfigs struct {
+	Fake *FakeSriovnetworkV1
+	ns   string
+}
+
+var sriovnetworkpoolconfigsResource = schema.GroupVersionResource{Group: "sriovnetwork.openshift.io", Version: "v1", Resource: "sriovnetworkpoolconfigs"}
+
+var sriovnetworkpoolconfigsKind = schema.GroupVersionKind{Group: "sriovnetwork.openshift.io", Version: "v1", Kind: "SriovNetworkPoolConfig"}
+
+// Get takes name of the sriovNetworkPoolConfig, and returns the corresponding sriovNetworkPoolConfig object, and an error if there is any.
+func (c *FakeSriovNetworkPoolConfigs) Get(ctx context.Context, name string, options v1.GetOptions) (result *sriovnetworkv1.SriovNetworkPoolConfig, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewGetAction(sriovnetworkpoolconfigsResource, c.ns, name), &sriovnetworkv1.SriovNetworkPoolConfig{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*sriovnetworkv1.SriovNetworkPoolConfig), err
+}
+
+// List takes label and field selectors, and returns the list of SriovNetworkPoolConfigs that match those selectors.
+func (c *FakeSriovNetworkPoolConfigs) List(ctx context.Context, opts v1.ListOptions) (result *sriovnetworkv1.SriovNetworkPoolConfigList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewListAction(sriovnetworkpoolconfigsResource, sriovnetworkpoolconfigsKind, c.ns, opts), &sriovnetworkv1.SriovNetworkPoolConfigList{})
+
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &sriovnetworkv1.SriovNetworkPoolConfigList{ListMeta: obj.(*sriovnetworkv1.SriovNetworkPoolConfigList).ListMeta}
+	for _, item := range obj.(*sriovnetworkv1.SriovNetworkPoolConfigList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested sriovNetworkPoolConfigs.
+func (c *FakeSriovNetworkPoolConfigs) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchAction(sriovnetworkpoolconfigsResource, c.ns, opts))
+
+}
+
+// Create takes the representation of a sriovNetworkPoolConfig and creates it.  Returns the server's representation of the sriovNetworkPoolConfig, and an error, if there is any.
+func (c *FakeSriovNetworkPoolConfigs) Create(ctx context.Context, sriovNetworkPoolConfig *sriovnetworkv1.SriovNetworkPoolConfig, opts v1.CreateOptions) (result *sriovnetworkv1.SriovNetworkPoolConfig, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateAction(sriovnetworkpoolconfigsResource, c.ns, sriovNetworkPoolConfig), &sriovnetworkv1.SriovNetworkPoolConfig{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*sriovnetworkv1.SriovNetworkPoolConfig), err
+}
+
+// Update takes the representation of a sriovNetworkPoolConfig and updates it. Returns the server's representation of the sriovNetworkPoolConfig, and an error, if there is any.
+func (c *FakeSriovNetworkPoolConfigs) Update(ctx context.Context, sriovNetworkPoolConfig *sriovnetworkv1.SriovNetworkPoolConfig, opts v1.UpdateOptions) (result *sriovnetworkv1.SriovNetworkPoolConfig, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateAction(sriovnetworkpoolconfigsResource, c.ns, sriovNetworkPoolConfig), &sriovnetworkv1.SriovNetworkPoolConfig{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*sriovnetworkv1.SriovNetworkPoolConfig), err
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeSriovNetworkPoolConfigs) UpdateStatus(ctx context.Context, sriovNetworkPoolConfig *sriovnetworkv1.SriovNetworkPoolConfig, opts v1.UpdateOptions) (*sriovnetworkv1.SriovNetworkPoolConfig, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateSubresourceAction(sriovnetworkpoolconfigsResource, "status", c.ns, sriovNetworkPoolConfig), &sriovnetworkv1.SriovNetworkPoolConfig{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*sriovnetworkv1.SriovNetworkPoolConfig), err
+}
+
+// Delete takes name of the sriovNetworkPoolConfig and deletes it. Returns an error if one occurs.
+func (c *FakeSriovNetworkPoolConfigs) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteAction(sriovnetworkpoolconfigsResource, c.ns, name), &sriovnetworkv1.SriovNetworkPoolConfig{})
+
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeSriovNetworkPoolConfigs) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewDeleteCollectionAction(sriovnetworkpoolconfigsResource, c.ns, listOpts)
+
+	_, err := c.Fake.Invokes(action, &sriovnetworkv1.SriovNetworkPoolConfigList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched sriovNetworkPoolConfig.
+func (c *FakeSriovNetworkPoolConfigs) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *sriovnetworkv1.SriovNetworkPoolConfig, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(sriovnetworkpoolconfigsResource, c.ns, name, pt, data, subresources...), &sriovnetworkv1.SriovNetworkPoolConfig{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*sriovnetworkv1.SriovNetworkPoolConfig), err
+}
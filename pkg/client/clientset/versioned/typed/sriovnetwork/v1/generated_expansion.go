@@ -2,10 +2,16 @@
 
 package v1
 
+type OVSNetworkExpansion interface{}
+
+type SriovIBNetworkExpansion interface{}
+
 type SriovNetworkExpansion interface{}
 
 type SriovNetworkNodePolicyExpansion interface{}
 
 type SriovNetworkNodeStateExpansion interface{}
 
+type SriovNetworkPoolConfigExpansion interface{}
+
 type SriovOperatorConfigExpansion interface{}
@@ -10,9 +10,12 @@ import (
 
 type SriovnetworkV1Interface interface {
 	RESTClient() rest.Interface
+	OVSNetworksGetter
+	SriovIBNetworksGetter
 	SriovNetworksGetter
 	SriovNetworkNodePoliciesGetter
 	SriovNetworkNodeStatesGetter
+	SriovNetworkPoolConfigsGetter
 	SriovOperatorConfigsGetter
 }
 
@@ -21,6 +24,14 @@ type SriovnetworkV1Client struct {
 	restClient rest.Interface
 }
 
+func (c *SriovnetworkV1Client) OVSNetworks(namespace string) OVSNetworkInterface {
+	return newOVSNetworks(c, namespace)
+}
+
+func (c *SriovnetworkV1Client) SriovIBNetworks(namespace string) SriovIBNetworkInterface {
+	return newSriovIBNetworks(c, namespace)
+}
+
 func (c *SriovnetworkV1Client) SriovNetworks(namespace string) SriovNetworkInterface {
 	return newSriovNetworks(c, namespace)
 }
@@ -33,6 +44,10 @@ func (c *SriovnetworkV1Client) SriovNetworkNodeStates(namespace string) SriovNet
 	return newSriovNetworkNodeStates(c, namespace)
 }
 
+func (c *SriovnetworkV1Client) SriovNetworkPoolConfigs(namespace string) SriovNetworkPoolConfigInterface {
+	return newSriovNetworkPoolConfigs(c, namespace)
+}
+
 func (c *SriovnetworkV1Client) SriovOperatorConfigs(namespace string) SriovOperatorConfigInterface {
 	return newSriovOperatorConfigs(c, namespace)
 }
@@ -0,0 +1,179 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	"context"
+	"time"
+
+	v1 "github.com/k8snetworkplumbingwg/sriov-network-operator/api/v1"
+	scheme "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/client/clientset/versioned/scheme"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	rest "k8s.io/client-go/rest"
+)
+
+// SriovNetworkPoolConfigsGetter has a method to return a SriovNetworkPoolConfigInterface.
+// A group's client should implement this interface.
+type SriovNetworkPoolConfigsGetter interface {
+	SriovNetworkPoolConfigs(namespace string) SriovNetworkPoolConfigInterface
+}
+
+// SriovNetworkPoolConfigInterface has methods to work with SriovNetworkPoolConfig resources.
+type SriovNetworkPoolConfigInterface interface {
+	Create(ctx context.Context, sriovNetworkPoolConfig *v1.SriovNetworkPoolConfig, opts metav1.CreateOptions) (*v1.SriovNetworkPoolConfig, error)
+	Update(ctx context.Context, sriovNetworkPoolConfig *v1.SriovNetworkPoolConfig, opts metav1.UpdateOptions) (*v1.SriovNetworkPoolConfig, error)
+	UpdateStatus(ctx context.Context, sriovNetworkPoolConfig *v1.SriovNetworkPoolConfig, opts metav1.UpdateOptions) (*v1.SriovNetworkPoolConfig, error)
+	Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts metav1.DeleteOptions, listOpts metav1.ListOptions) error
+	Get(ctx context.Context, name string, opts metav1.GetOptions) (*v1.SriovNetworkPoolConfig, error)
+	List(ctx context.Context, opts metav1.ListOptions) (*v1.SriovNetworkPoolConfigList, error)
+	Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (result *v1.SriovNetworkPoolConfig, err error)
+	SriovNetworkPoolConfigExpansion
+}
+
+// sriovNetworkPoolConfigs implements SriovNetworkPoolConfigInterface
+type sriovNetworkPoolConfigs struct {
+	client rest.Interface
+	ns     string
+}
+
+// newSriovNetworkPoolConfigs returns a SriovNetworkPoolConfigs
+func newSriovNetworkPoolConfigs(c *SriovnetworkV1Client, namespace string) *sriovNetworkPoolConfigs {
+	return &sriovNetworkPoolConfigs{
+		client: c.RESTClient(),
+		ns:     namespace,
+	}
+}
+
+// Get takes name of the sriovNetworkPoolConfig, and returns the corresponding sriovNetworkPoolConfig object, and an error if there is any.
+func (c *sriovNetworkPoolConfigs) Get(ctx context.Context, name string, options metav1.GetOptions) (result *v1.SriovNetworkPoolConfig, err error) {
+	result = &v1.SriovNetworkPoolConfig{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("sriovnetworkpoolconfigs").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// List takes label and field selectors, and returns the list of SriovNetworkPoolConfigs that match those selectors.
+func (c *sriovNetworkPoolConfigs) List(ctx context.Context, opts metav1.ListOptions) (result *v1.SriovNetworkPoolConfigList, err error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	result = &v1.SriovNetworkPoolConfigList{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("sriovnetworkpoolconfigs").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Watch returns a watch.Interface that watches the requested sriovNetworkPoolConfigs.
+func (c *sriovNetworkPoolConfigs) Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	opts.Watch = true
+	return c.client.Get().
+		Namespace(c.ns).
+		Resource("sriovnetworkpoolconfigs").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Watch(ctx)
+}
+
+// Create takes the representation of a sriovNetworkPoolConfig and creates it.  Returns the server's representation of the sriovNetworkPoolConfig, and an error, if there is any.
+func (c *sriovNetworkPoolConfigs) Create(ctx context.Context, sriovNetworkPoolConfig *v1.SriovNetworkPoolConfig, opts metav1.CreateOptions) (result *v1.SriovNetworkPoolConfig, err error) {
+	result = &v1.SriovNetworkPoolConfig{}
+	err = c.client.Post().
+		Namespace(c.ns).
+		Resource("sriovnetworkpoolconfigs").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(sriovNetworkPoolConfig).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Update takes the representation of a sriovNetworkPoolConfig and updates it. Returns the server's representation of the sriovNetworkPoolConfig, and an error, if there is any.
+func (c *sriovNetworkPoolConfigs) Update(ctx context.Context, sriovNetworkPoolConfig *v1.SriovNetworkPoolConfig, opts metav1.UpdateOptions) (result *v1.SriovNetworkPoolConfig, err error) {
+	result = &v1.SriovNetworkPoolConfig{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("sriovnetworkpoolconfigs").
+		Name(sriovNetworkPoolConfig.Name).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(sriovNetworkPoolConfig).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *sriovNetworkPoolConfigs) UpdateStatus(ctx context.Context, sriovNetworkPoolConfig *v1.SriovNetworkPoolConfig, opts metav1.UpdateOptions) (result *v1.SriovNetworkPoolConfig, err error) {
+	result = &v1.SriovNetworkPoolConfig{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("sriovnetworkpoolconfigs").
+		Name(sriovNetworkPoolConfig.Name).
+		SubResource("status").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(sriovNetworkPoolConfig).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Delete takes name of the sriovNetworkPoolConfig and deletes it. Returns an error if one occurs.
+func (c *sriovNetworkPoolConfigs) Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error {
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("sriovnetworkpoolconfigs").
+		Name(name).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *sriovNetworkPoolConfigs) DeleteCollection(ctx context.Context, opts metav1.DeleteOptions, listOpts metav1.ListOptions) error {
+	var timeout time.Duration
+	if listOpts.TimeoutSeconds != nil {
+		timeout = time.Duration(*listOpts.TimeoutSeconds) * time.Second
+	}
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("sriovnetworkpoolconfigs").
+		VersionedParams(&listOpts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// Patch applies the patch and returns the patched sriovNetworkPoolConfig.
+func (c *sriovNetworkPoolConfigs) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (result *v1.SriovNetworkPoolConfig, err error) {
+	result = &v1.SriovNetworkPoolConfig{}
+	err = c.client.Patch(pt).
+		Namespace(c.ns).
+		Resource("sriovnetworkpoolconfigs").
+		Name(name).
+		SubResource(subresources...).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
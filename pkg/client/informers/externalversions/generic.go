@@ -38,12 +38,18 @@ func (f *genericInformer) Lister() cache.GenericLister {
 func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource) (GenericInformer, error) {
 	switch resource {
 	// Group=sriovnetwork, Version=v1
+	case v1.SchemeGroupVersion.WithResource("ovsnetworks"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Sriovnetwork().V1().OVSNetworks().Informer()}, nil
+	case v1.SchemeGroupVersion.WithResource("sriovibnetworks"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Sriovnetwork().V1().SriovIBNetworks().Informer()}, nil
 	case v1.SchemeGroupVersion.WithResource("sriovnetworks"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Sriovnetwork().V1().SriovNetworks().Informer()}, nil
 	case v1.SchemeGroupVersion.WithResource("sriovnetworknodepolicies"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Sriovnetwork().V1().SriovNetworkNodePolicies().Informer()}, nil
 	case v1.SchemeGroupVersion.WithResource("sriovnetworknodestates"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Sriovnetwork().V1().SriovNetworkNodeStates().Informer()}, nil
+	case v1.SchemeGroupVersion.WithResource("sriovnetworkpoolconfigs"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Sriovnetwork().V1().SriovNetworkPoolConfigs().Informer()}, nil
 	case v1.SchemeGroupVersion.WithResource("sriovoperatorconfigs"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Sriovnetwork().V1().SriovOperatorConfigs().Informer()}, nil
 
@@ -8,12 +8,18 @@ import (
 
 // Interface provides access to all the informers in this group version.
 type Interface interface {
+	// OVSNetworks returns a OVSNetworkInformer.
+	OVSNetworks() OVSNetworkInformer
+	// SriovIBNetworks returns a SriovIBNetworkInformer.
+	SriovIBNetworks() SriovIBNetworkInformer
 	// SriovNetworks returns a SriovNetworkInformer.
 	SriovNetworks() SriovNetworkInformer
 	// SriovNetworkNodePolicies returns a SriovNetworkNodePolicyInformer.
 	SriovNetworkNodePolicies() SriovNetworkNodePolicyInformer
 	// SriovNetworkNodeStates returns a SriovNetworkNodeStateInformer.
 	SriovNetworkNodeStates() SriovNetworkNodeStateInformer
+	// SriovNetworkPoolConfigs returns a SriovNetworkPoolConfigInformer.
+	SriovNetworkPoolConfigs() SriovNetworkPoolConfigInformer
 	// SriovOperatorConfigs returns a SriovOperatorConfigInformer.
 	SriovOperatorConfigs() SriovOperatorConfigInformer
 }
@@ -29,6 +35,16 @@ func New(f internalinterfaces.SharedInformerFactory, namespace string, tweakList
 	return &version{factory: f, namespace: namespace, tweakListOptions: tweakListOptions}
 }
 
+// OVSNetworks returns a OVSNetworkInformer.
+func (v *version) OVSNetworks() OVSNetworkInformer {
+	return &oVSNetworkInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// SriovIBNetworks returns a SriovIBNetworkInformer.
+func (v *version) SriovIBNetworks() SriovIBNetworkInformer {
+	return &sriovIBNetworkInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // SriovNetworks returns a SriovNetworkInformer.
 func (v *version) SriovNetworks() SriovNetworkInformer {
 	return &sriovNetworkInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
@@ -44,6 +60,11 @@ func (v *version) SriovNetworkNodeStates() SriovNetworkNodeStateInformer {
 	return &sriovNetworkNodeStateInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
 
+// SriovNetworkPoolConfigs returns a SriovNetworkPoolConfigInformer.
+func (v *version) SriovNetworkPoolConfigs() SriovNetworkPoolConfigInformer {
+	return &sriovNetworkPoolConfigInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // SriovOperatorConfigs returns a SriovOperatorConfigInformer.
 func (v *version) SriovOperatorConfigs() SriovOperatorConfigInformer {
 	return &sriovOperatorConfigInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
@@ -0,0 +1,75 @@
+// Code generated by informer-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	"context"
+	time "time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+	watch "k8s.io/apimachinery/pkg/watch"
+	cache "k8s.io/client-go/tools/cache"
+
+	sriovnetworkv1 "github.com/k8snetworkplumbingwg/sriov-network-operator/api/v1"
+	versioned "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/client/clientset/versioned"
+	internalinterfaces "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/client/informers/externalversions/internalinterfaces"
+	v1 "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/client/listers/sriovnetwork/v1"
+)
+
+// OVSNetworkInformer provides access to a shared informer and lister for
+// OVSNetworks.
+type OVSNetworkInformer interface {
+	Informer() cache.SharedIndexInformer
+	Lister() v1.OVSNetworkLister
+}
+
+type oVSNetworkInformer struct {
+	factory          internalinterfaces.SharedInformerFactory
+	tweakListOptions internalinterfaces.TweakListOptionsFunc
+	namespace        string
+}
+
+// NewOVSNetworkInformer constructs a new informer for OVSNetwork type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewOVSNetworkInformer(client versioned.Interface, namespace string, resyncPeriod time.Duration, indexers cache.Indexers) cache.SharedIndexInformer {
+	return NewFilteredOVSNetworkInformer(client, namespace, resyncPeriod, indexers, nil)
+}
+
+// NewFilteredOVSNetworkInformer constructs a new informer for OVSNetwork type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewFilteredOVSNetworkInformer(client versioned.Interface, namespace string, resyncPeriod time.Duration, indexers cache.Indexers, tweakListOptions internalinterfaces.TweakListOptionsFunc) cache.SharedIndexInformer {
+	return cache.NewSharedIndexInformer(
+		&cache.ListWatch{
+			ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.SriovnetworkV1().OVSNetworks(namespace).List(context.TODO(), options)
+			},
+			WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.SriovnetworkV1().OVSNetworks(namespace).Watch(context.TODO(), options)
+			},
+		},
+		&sriovnetworkv1.OVSNetwork{},
+		resyncPeriod,
+		indexers,
+	)
+}
+
+func (f *oVSNetworkInformer) defaultInformer(client versioned.Interface, resyncPeriod time.Duration) cache.SharedIndexInformer {
+	return NewFilteredOVSNetworkInformer(client, f.namespace, resyncPeriod, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}, f.tweakListOptions)
+}
+
+func (f *oVSNetworkInformer) Informer() cache.SharedIndexInformer {
+	return f.factory.InformerFor(&sriovnetworkv1.OVSNetwork{}, f.defaultInformer)
+}
+
+func (f *oVSNetworkInformer) Lister() v1.OVSNetworkLister {
+	return v1.NewOVSNetworkLister(f.Informer().GetIndexer())
+}
@@ -0,0 +1,75 @@
+// Code generated by informer-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	"context"
+	time "time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+	watch "k8s.io/apimachinery/pkg/watch"
+	cache "k8s.io/client-go/tools/cache"
+
+	sriovnetworkv1 "github.com/k8snetworkplumbingwg/sriov-network-operator/api/v1"
+	versioned "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/client/clientset/versioned"
+	internalinterfaces "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/client/informers/externalversions/internalinterfaces"
+	v1 "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/client/listers/sriovnetwork/v1"
+)
+
+// SriovIBNetworkInformer provides access to a shared informer and lister for
+// SriovIBNetworks.
+type SriovIBNetworkInformer interface {
+	Informer() cache.SharedIndexInformer
+	Lister() v1.SriovIBNetworkLister
+}
+
+type sriovIBNetworkInformer struct {
+	factory          internalinterfaces.SharedInformerFactory
+	tweakListOptions internalinterfaces.TweakListOptionsFunc
+	namespace        string
+}
+
+// NewSriovIBNetworkInformer constructs a new informer for SriovIBNetwork type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewSriovIBNetworkInformer(client versioned.Interface, namespace string, resyncPeriod time.Duration, indexers cache.Indexers) cache.SharedIndexInformer {
+	return NewFilteredSriovIBNetworkInformer(client, namespace, resyncPeriod, indexers, nil)
+}
+
+// NewFilteredSriovIBNetworkInformer constructs a new informer for SriovIBNetwork type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewFilteredSriovIBNetworkInformer(client versioned.Interface, namespace string, resyncPeriod time.Duration, indexers cache.Indexers, tweakListOptions internalinterfaces.TweakListOptionsFunc) cache.SharedIndexInformer {
+	return cache.NewSharedIndexInformer(
+		&cache.ListWatch{
+			ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.SriovnetworkV1().SriovIBNetworks(namespace).List(context.TODO(), options)
+			},
+			WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.SriovnetworkV1().SriovIBNetworks(namespace).Watch(context.TODO(), options)
+			},
+		},
+		&sriovnetworkv1.SriovIBNetwork{},
+		resyncPeriod,
+		indexers,
+	)
+}
+
+func (f *sriovIBNetworkInformer) defaultInformer(client versioned.Interface, resyncPeriod time.Duration) cache.SharedIndexInformer {
+	return NewFilteredSriovIBNetworkInformer(client, f.namespace, resyncPeriod, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}, f.tweakListOptions)
+}
+
+func (f *sriovIBNetworkInformer) Informer() cache.SharedIndexInformer {
+	return f.factory.InformerFor(&sriovnetworkv1.SriovIBNetwork{}, f.defaultInformer)
+}
+
+func (f *sriovIBNetworkInformer) Lister() v1.SriovIBNetworkLister {
+	return v1.NewSriovIBNetworkLister(f.Informer().GetIndexer())
+}
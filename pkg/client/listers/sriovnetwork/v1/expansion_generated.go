@@ -2,6 +2,22 @@
 
 package v1
 
+// OVSNetworkListerExpansion allows custom methods to be added to
+// OVSNetworkLister.
+type OVSNetworkListerExpansion interface{}
+
+// OVSNetworkNamespaceListerExpansion allows custom methods to be added to
+// OVSNetworkNamespaceLister.
+type OVSNetworkNamespaceListerExpansion interface{}
+
+// SriovIBNetworkListerExpansion allows custom methods to be added to
+// SriovIBNetworkLister.
+type SriovIBNetworkListerExpansion interface{}
+
+// SriovIBNetworkNamespaceListerExpansion allows custom methods to be added to
+// SriovIBNetworkNamespaceLister.
+type SriovIBNetworkNamespaceListerExpansion interface{}
+
 // SriovNetworkListerExpansion allows custom methods to be added to
 // SriovNetworkLister.
 type SriovNetworkListerExpansion interface{}
@@ -26,6 +42,14 @@ type SriovNetworkNodeStateListerExpansion interface{}
 // SriovNetworkNodeStateNamespaceLister.
 type SriovNetworkNodeStateNamespaceListerExpansion interface{}
 
+// SriovNetworkPoolConfigListerExpansion allows custom methods to be added to
+// SriovNetworkPoolConfigLister.
+type SriovNetworkPoolConfigListerExpansion interface{}
+
+// SriovNetworkPoolConfigNamespaceListerExpansion allows custom methods to be added to
+// SriovNetworkPoolConfigNamespaceLister.
+type SriovNetworkPoolConfigNamespaceListerExpansion interface{}
+
 // SriovOperatorConfigListerExpansion allows custom methods to be added to
 // SriovOperatorConfigLister.
 type SriovOperatorConfigListerExpansion interface{}
@@ -0,0 +1,84 @@
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+
+	v1 "github.com/k8snetworkplumbingwg/sriov-network-operator/api/v1"
+)
+
+// OVSNetworkLister helps list OVSNetworks.
+// All objects returned here must be treated as read-only.
+type OVSNetworkLister interface {
+	// List lists all OVSNetworks in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1.OVSNetwork, err error)
+	// OVSNetworks returns an object that can list and get OVSNetworks.
+	OVSNetworks(namespace string) OVSNetworkNamespaceLister
+	OVSNetworkListerExpansion
+}
+
+// oVSNetworkLister implements the OVSNetworkLister interface.
+type oVSNetworkLister struct {
+	indexer cache.Indexer
+}
+
+// NewOVSNetworkLister returns a new OVSNetworkLister.
+func NewOVSNetworkLister(indexer cache.Indexer) OVSNetworkLister {
+	return &oVSNetworkLister{indexer: indexer}
+}
+
+// List lists all OVSNetworks in the indexer.
+func (s *oVSNetworkLister) List(selector labels.Selector) (ret []*v1.OVSNetwork, err error) {
+	err = cache.ListAll(s.indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1.OVSNetwork))
+	})
+	return ret, err
+}
+
+// OVSNetworks returns an object that can list and get OVSNetworks.
+func (s *oVSNetworkLister) OVSNetworks(namespace string) OVSNetworkNamespaceLister {
+	return oVSNetworkNamespaceLister{indexer: s.indexer, namespace: namespace}
+}
+
+// OVSNetworkNamespaceLister helps list and get OVSNetworks.
+// All objects returned here must be treated as read-only.
+type OVSNetworkNamespaceLister interface {
+	// List lists all OVSNetworks in the indexer for a given namespace.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1.OVSNetwork, err error)
+	// Get retrieves the OVSNetwork from the indexer for a given namespace and name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*v1.OVSNetwork, error)
+	OVSNetworkNamespaceListerExpansion
+}
+
+// oVSNetworkNamespaceLister implements the OVSNetworkNamespaceLister
+// interface.
+type oVSNetworkNamespaceLister struct {
+	indexer   cache.Indexer
+	namespace string
+}
+
+// List lists all OVSNetworks in the indexer for a given namespace.
+func (s oVSNetworkNamespaceLister) List(selector labels.Selector) (ret []*v1.OVSNetwork, err error) {
+	err = cache.ListAllByNamespace(s.indexer, s.namespace, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1.OVSNetwork))
+	})
+	return ret, err
+}
+
+// Get retrieves the OVSNetwork from the indexer for a given namespace and name.
+func (s oVSNetworkNamespaceLister) Get(name string) (*v1.OVSNetwork, error) {
+	obj, exists, err := s.indexer.GetByKey(s.namespace + "/" + name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.NewNotFound(v1.Resource("ovsnetwork"), name)
+	}
+	return obj.(*v1.OVSNetwork), nil
+}
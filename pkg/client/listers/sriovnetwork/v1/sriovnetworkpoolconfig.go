@@ -0,0 +1,84 @@
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+
+	v1 "github.com/k8snetworkplumbingwg/sriov-network-operator/api/v1"
+)
+
+// SriovNetworkPoolConfigLister helps list SriovNetworkPoolConfigs.
+// All objects returned here must be treated as read-only.
+type SriovNetworkPoolConfigLister interface {
+	// List lists all SriovNetworkPoolConfigs in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1.SriovNetworkPoolConfig, err error)
+	// SriovNetworkPoolConfigs returns an object that can list and get SriovNetworkPoolConfigs.
+	SriovNetworkPoolConfigs(namespace string) SriovNetworkPoolConfigNamespaceLister
+	SriovNetworkPoolConfigListerExpansion
+}
+
+// sriovNetworkPoolConfigLister implements the SriovNetworkPoolConfigLister interface.
+type sriovNetworkPoolConfigLister struct {
+	indexer cache.Indexer
+}
+
+// NewSriovNetworkPoolConfigLister returns a new SriovNetworkPoolConfigLister.
+func NewSriovNetworkPoolConfigLister(indexer cache.Indexer) SriovNetworkPoolConfigLister {
+	return &sriovNetworkPoolConfigLister{indexer: indexer}
+}
+
+// List lists all SriovNetworkPoolConfigs in the indexer.
+func (s *sriovNetworkPoolConfigLister) List(selector labels.Selector) (ret []*v1.SriovNetworkPoolConfig, err error) {
+	err = cache.ListAll(s.indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1.SriovNetworkPoolConfig))
+	})
+	return ret, err
+}
+
+// SriovNetworkPoolConfigs returns an object that can list and get SriovNetworkPoolConfigs.
+func (s *sriovNetworkPoolConfigLister) SriovNetworkPoolConfigs(namespace string) SriovNetworkPoolConfigNamespaceLister {
+	return sriovNetworkPoolConfigNamespaceLister{indexer: s.indexer, namespace: namespace}
+}
+
+// SriovNetworkPoolConfigNamespaceLister helps list and get SriovNetworkPoolConfigs.
+// All objects returned here must be treated as read-only.
+type SriovNetworkPoolConfigNamespaceLister interface {
+	// List lists all SriovNetworkPoolConfigs in the indexer for a given namespace.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1.SriovNetworkPoolConfig, err error)
+	// Get retrieves the SriovNetworkPoolConfig from the indexer for a given namespace and name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*v1.SriovNetworkPoolConfig, error)
+	SriovNetworkPoolConfigNamespaceListerExpansion
+}
+
+// sriovNetworkPoolConfigNamespaceLister implements the SriovNetworkPoolConfigNamespaceLister
+// interface.
+type sriovNetworkPoolConfigNamespaceLister struct {
+	indexer   cache.Indexer
+	namespace string
+}
+
+// List lists all SriovNetworkPoolConfigs in the indexer for a given namespace.
+func (s sriovNetworkPoolConfigNamespaceLister) List(selector labels.Selector) (ret []*v1.SriovNetworkPoolConfig, err error) {
+	err = cache.ListAllByNamespace(s.indexer, s.namespace, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1.SriovNetworkPoolConfig))
+	})
+	return ret, err
+}
+
+// Get retrieves the SriovNetworkPoolConfig from the indexer for a given namespace and name.
+func (s sriovNetworkPoolConfigNamespaceLister) Get(name string) (*v1.SriovNetworkPoolConfig, error) {
+	obj, exists, err := s.indexer.GetByKey(s.namespace + "/" + name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.NewNotFound(v1.Resource("sriovnetworkpoolconfig"), name)
+	}
+	return obj.(*v1.SriovNetworkPoolConfig), nil
+}
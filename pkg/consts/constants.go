@@ -56,6 +56,7 @@ const (
 
 	RdmaSubsystemModeShared    = "shared"
 	RdmaSubsystemModeExclusive = "exclusive"
+	RdmaSubsystemModeAuto      = "auto"
 
 	ClusterTypeOpenshift  = "openshift"
 	ClusterTypeKubernetes = "kubernetes"
@@ -65,6 +66,8 @@ const (
 	SriovSwitchDevConfPath     = SriovConfBasePath + "/sriov_config.json"
 	SriovHostSwitchDevConfPath = Host + SriovSwitchDevConfPath
 	ManagedOVSBridgesPath      = SriovConfBasePath + "/managed-ovs-bridges.json"
+	// KubeletDevicePluginCheckpoint is the kubelet checkpoint of the devices allocated to the pods by the device plugins
+	KubeletDevicePluginCheckpoint = "/var/lib/kubelet/device-plugins/kubelet_internal_checkpoint"
 
 	MachineConfigPoolPausedAnnotation       = "sriovnetwork.openshift.io/state"
 	MachineConfigPoolPausedAnnotationIdle   = "Idle"
@@ -74,6 +77,8 @@ const (
 	SriovDevicePluginLabelEnabled  = "Enabled"
 	SriovDevicePluginLabelDisabled = "Disabled"
 
+	DefaultConfigDaemonPriorityClassName = "system-node-critical"
+
 	NodeDrainAnnotation             = "sriovnetwork.openshift.io/state"
 	NodeStateDrainAnnotation        = "sriovnetwork.openshift.io/desired-state"
 	NodeStateDrainAnnotationCurrent = "sriovnetwork.openshift.io/current-state"
@@ -87,6 +92,10 @@ const (
 	SyncStatusFailed     = "Failed"
 	SyncStatusInProgress = "InProgress"
 
+	ReasonDrainTimeout = "DrainTimeout"
+	// ReasonDrainDecision is the reason of the event summarizing the drain and reboot decision of a sync
+	ReasonDrainDecision = "DrainDecision"
+
 	DrainDeleted = "Deleted"
 	DrainEvicted = "Evicted"
 
@@ -97,9 +106,29 @@ const (
 	// The "keep until time" specifies the earliest time at which the state object can be removed
 	// if the daemon's pod is not found on the node.
 	NodeStateKeepUntilAnnotation = "sriovnetwork.openshift.io/keep-state-until"
+	// NodeStateDrainStartTimeAnnotation contains the time the operator moved the SriovNetworkNodeState to the Draining
+	// state, the config-daemon reports the drain timeout from it so the timer survives the daemon restarts
+	NodeStateDrainStartTimeAnnotation = "sriovnetwork.openshift.io/drain-start-time"
+	// NodeMatchedPoliciesAnnotation contains a comma separated list of the SriovNetworkNodePolicies which select the node
+	NodeMatchedPoliciesAnnotation = "sriovnetwork.openshift.io/matched-policies"
+	// NodeNoRebootAnnotation set to "true" on a node prevents the config-daemon from rebooting it,
+	// configuration changes which require a reboot are not applied on the node
+	NodeNoRebootAnnotation = "sriovnetwork.openshift.io/no-reboot"
+	// NodeStateDrainNowAnnotation set on a SriovNetworkNodeState triggers an immediate sync and drain attempt
+	// instead of waiting for the next requeue, the retries and the drain timeout of the generation are reset and the
+	// drain is not deferred for a node cordoned by another controller, the config-daemon removes it once handled
+	NodeStateDrainNowAnnotation = "sriovnetwork.openshift.io/drain-now"
+	// NodeStateForceSystemdReapplyAnnotation set on a SriovNetworkNodeState in systemd mode makes the sriov-config
+	// service apply the configuration again on the next boot, the node is rebooted even if the configuration didn't change
+	NodeStateForceSystemdReapplyAnnotation = "sriovnetwork.openshift.io/force-systemd-reapply"
+	// ForceDeleteAnnotation allows to delete the default SriovOperatorConfig while SriovNetworkNodePolicies still exist
+	ForceDeleteAnnotation = "sriovnetwork.openshift.io/force-delete"
 	// DefaultNodeStateCleanupDelayMinutes contains default delay before removing stale SriovNetworkNodeState CRs
 	// (the CRs that no longer have a corresponding node with the daemon).
 	DefaultNodeStateCleanupDelayMinutes = 30
+	// DefaultNodePolicySyncDelay contains default delay without any new change before rendering the SriovNetworkNodeState specs,
+	// every change restarts the delay so successive changes are coalesced in a single update of the SriovNetworkNodeStates.
+	DefaultNodePolicySyncDelay = time.Second
 
 	CheckpointFileName = "sno-initial-node-state.json"
 	Unknown            = "Unknown"
@@ -108,13 +137,30 @@ const (
 	SysBusPciDevices      = SysBus + "/pci/devices"
 	SysBusPciDrivers      = SysBus + "/pci/drivers"
 	SysBusPciDriversProbe = SysBus + "/pci/drivers_probe"
+	SysKernelMmHugepages  = "/sys/kernel/mm/hugepages"
 	SysClassNet           = "/sys/class/net"
 	ProcKernelCmdLine     = "/proc/cmdline"
+	ProcKernelOsRelease   = "/proc/sys/kernel/osrelease"
+	ProcSys               = "/proc/sys"
 	NetClass              = 0x02
 	NumVfsFile            = "sriov_numvfs"
+	CurrentLinkSpeedFile  = "current_link_speed"
+	CurrentLinkWidthFile  = "current_link_width"
 	BusPci                = "pci"
 	BusVdpa               = "vdpa"
 
+	// SysctlInterfacePlaceholder is replaced by the name of the network interface in per-interface sysctl names
+	SysctlInterfacePlaceholder = "<if>"
+
+	// MACsec encryption modes of the VFs
+	MacsecEncryptionModeEncrypt   = "encrypt"
+	MacsecEncryptionModeIntegrity = "integrity"
+	// MacsecSecretKey and MacsecSecretKeyID are the entries of the Secret referenced by the MACsec configuration
+	MacsecSecretKey   = "key"
+	MacsecSecretKeyID = "keyID"
+	// MacsecDefaultKeyID is the key identifier used when the Secret doesn't provide one
+	MacsecDefaultKeyID = "00"
+
 	UdevFolder          = "/etc/udev"
 	HostUdevFolder      = Host + UdevFolder
 	UdevRulesFolder     = UdevFolder + "/rules.d"
@@ -124,6 +170,8 @@ const (
 	// nolint:goconst
 	PFNameUdevRule = `SUBSYSTEM=="net", ACTION=="add", DRIVERS=="?*", KERNELS=="%s", NAME="%s"`
 	// nolint:goconst
+	VfNameUdevRule = `SUBSYSTEM=="net", ACTION=="add", DRIVERS=="?*", KERNELS=="%s", NAME="%s"`
+	// nolint:goconst
 	NMUdevRule = `SUBSYSTEM=="net", ` +
 		`ACTION=="add|change|move", ` +
 		`ATTRS{device}=="%s", ` +
@@ -160,6 +208,34 @@ const (
 	// MellanoxFirmwareResetFeatureGate: enables the firmware reset via mstfwreset before a reboot
 	MellanoxFirmwareResetFeatureGate = "mellanoxFirmwareReset"
 
+	// BlockDevicePluginUntilConfiguredFeatureGate: the device plugin only serves the resources discovered once the
+	// node is configured, the config-daemon restarts it at the end of each successful sync even if the VF configuration
+	// was not changed, so a resource list read while the node was being configured is never kept
+	BlockDevicePluginUntilConfiguredFeatureGate = "blockDevicePluginUntilConfigured"
+
+	// DisableDevicePluginRestartFeatureGate: never restart the device plugin from the config-daemon,
+	// the device plugin lifecycle is managed externally. Takes precedence over BlockDevicePluginUntilConfiguredFeatureGate
+	DisableDevicePluginRestartFeatureGate = "disableDevicePluginRestart"
+
+	// VfioHugepagesAdvisoryFeatureGate: only log a warning instead of degrading the node when VFs are bound to vfio-pci
+	// on a node without hugepages
+	VfioHugepagesAdvisoryFeatureGate = "vfioHugepagesAdvisory"
+
+	// SriovNetworkTrustSpoofChkOffByDefaultFeatureGate: explicitly set trust and spoofChk to "off" on SriovNetwork creation
+	// if they are not set, so the VF configuration doesn't depend on the driver defaults
+	SriovNetworkTrustSpoofChkOffByDefaultFeatureGate = "sriovNetworkTrustSpoofChkOffByDefault"
+
+	// ReportVfConsumerPodsFeatureGate: report the pod each VF is allocated to in the SriovNetworkNodeState status,
+	// this requires reading the kubelet device plugin checkpoint and listing the pods of the node on each status poll
+	ReportVfConsumerPodsFeatureGate = "reportVfConsumerPods"
+
+	// SkipRdmaFeatureGate: bypass the RDMA checks, discovery and configuration on clusters without RDMA hardware
+	SkipRdmaFeatureGate = "skipRdma"
+
+	// LinkEventsRefreshFeatureGate: refresh the node state status and reconcile it when a link of a managed PF or VF
+	// changes on the host, disabled by default due to the cost of processing the netlink events
+	LinkEventsRefreshFeatureGate = "linkEventsRefresh"
+
 	// The path to the file on the host filesystem that contains the IB GUID distribution for IB VFs
 	InfinibandGUIDConfigFilePath = SriovConfBasePath + "/infiniband/guids"
 )
@@ -2,20 +2,28 @@ package daemon
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math/rand"
 	"reflect"
+	"slices"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"golang.org/x/time/rate"
-	"k8s.io/apimachinery/pkg/api/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/retry"
 	"k8s.io/client-go/util/workqueue"
+	"k8s.io/utils/clock"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
@@ -25,9 +33,11 @@ import (
 	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/consts"
 	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/featuregate"
 	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/helper"
+	hostTypes "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/host/types"
 	snolog "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/log"
 	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/platforms"
 	plugin "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/plugins"
+	genericplugin "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/plugins/generic"
 	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/systemd"
 	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/utils"
 	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/vars"
@@ -40,11 +50,29 @@ const (
 	// maxUpdateBackoff is the maximum time to react to a change as we back off
 	// in the face of errors.
 	maxUpdateBackoff = 60 * time.Second
+	// numVfsConflictThreshold is the number of times the daemon restores the number of VFs
+	// of a PF changed outside of the operator within numVfsConflictWindow before it stops
+	// fighting with the external actor.
+	numVfsConflictThreshold = 3
+	numVfsConflictWindow    = 10 * time.Minute
 )
 
+// maxSyncFailures is the number of consecutive failed syncs of a generation of the node state
+// after which the daemon stops retrying it, to avoid useless drain and reboot cycles
+var maxSyncFailures = 5
+
 type Message struct {
 	syncStatus    string
 	lastSyncError string
+	// degradedReason is the reason of the Degraded condition reported together with the lastSyncError,
+	// the condition is not reported if it's empty
+	degradedReason string
+	// kernelModules are the kernel modules required by the configuration, not reported if nil
+	kernelModules *sriovnetworkv1.KernelModules
+	// appliedTime is the time the configuration was applied successfully, not reported if zero
+	appliedTime time.Time
+	// pendingKernelArgs are the kernel arguments applied by the next reboot, not reported if nil
+	pendingKernelArgs []string
 }
 
 type Daemon struct {
@@ -85,6 +113,39 @@ type Daemon struct {
 	eventRecorder *EventRecorder
 
 	featureGate featuregate.FeatureGate
+
+	// OVSDB socket path configured for the daemon, used when the node state doesn't override it
+	defaultOVSDBSocketPath string
+	// configuration mode of the daemon, used when the node state doesn't override it
+	defaultUsingSystemdMode bool
+
+	clock clock.Clock
+	// error reported when the drain timeout is exceeded, empty if the timeout was not exceeded
+	drainTimeoutError string
+	// times the number of VFs of each PF was restored after being changed outside of the operator
+	numVfsRestores map[string][]time.Time
+	// error reported when the number of VFs conflicts with an external actor, empty if there is no conflict
+	numVfsConflictError string
+	// time of the last restart of the device plugin pod
+	lastDevicePluginRestart time.Time
+	// number of consecutive failed syncs of the syncFailuresGeneration of the node state
+	syncFailures           int
+	syncFailuresGeneration int64
+	// error reported when the sync failed maxSyncFailures consecutive times, empty if the sync is retried
+	maxRetriesError string
+	// kernel modules required by the last applied configuration
+	kernelModules *sriovnetworkv1.KernelModules
+	// configuration step in progress, recorded in the checkpoint file when the daemon is terminated
+	inProgress   *ApplyInProgress
+	inProgressMu sync.Mutex
+	// closed to stop the watcher of the link events, nil if the watcher is not running
+	linkWatcherStopCh chan struct{}
+	// links of the configured PFs and of their VFs, the events of the other links are ignored
+	managedLinks           map[string]bool
+	managedLinksGeneration int64
+	managedLinksMu         sync.Mutex
+	// set when a managed link changed, the status is refreshed before the next sync
+	linkChanged atomic.Bool
 }
 
 func New(
@@ -119,7 +180,28 @@ func New(
 		eventRecorder:   er,
 		featureGate:     featureGates,
 		disabledPlugins: disabledPlugins,
+		clock:           clock.RealClock{},
 		mu:              &sync.Mutex{},
+		numVfsRestores:  map[string][]time.Time{},
+
+		defaultOVSDBSocketPath:  vars.OVSDBSocketPath,
+		defaultUsingSystemdMode: vars.UsingSystemdMode,
+	}
+}
+
+// prepareHost checks and enables the kernel features used by the VFs when running in daemon mode,
+// the RDMA checks are skipped on clusters without RDMA hardware
+func (dn *Daemon) prepareHost() {
+	if vars.SkipRdma {
+		log.Log.V(0).Info("prepareHost(): skipping the RDMA checks")
+	} else {
+		dn.HostHelpers.CheckRDMAEnabled()
+	}
+	dn.HostHelpers.TryEnableTun()
+	dn.HostHelpers.TryEnableVhostNet()
+	err := systemd.CleanSriovFilesFromHost(vars.ClusterType == consts.ClusterTypeOpenshift)
+	if err != nil {
+		log.Log.Error(err, "failed to remove all the systemd sriov files")
 	}
 }
 
@@ -135,13 +217,7 @@ func (dn *Daemon) Run(stopCh <-chan struct{}, exitCh <-chan error) error {
 
 	if !vars.UsingSystemdMode {
 		log.Log.V(0).Info("Run(): daemon running in daemon mode")
-		dn.HostHelpers.CheckRDMAEnabled()
-		dn.HostHelpers.TryEnableTun()
-		dn.HostHelpers.TryEnableVhostNet()
-		err := systemd.CleanSriovFilesFromHost(vars.ClusterType == consts.ClusterTypeOpenshift)
-		if err != nil {
-			log.Log.Error(err, "failed to remove all the systemd sriov files")
-		}
+		dn.prepareHost()
 	} else {
 		log.Log.V(0).Info("Run(): daemon running in systemd mode")
 	}
@@ -150,6 +226,10 @@ func (dn *Daemon) Run(stopCh <-chan struct{}, exitCh <-chan error) error {
 	defer utilruntime.HandleCrash()
 	defer dn.workqueue.ShutDown()
 
+	if err := dn.rollbackInterruptedApply(); err != nil {
+		log.Log.Error(err, "failed to roll back the interrupted configuration")
+	}
+
 	if err := dn.prepareNMUdevRule(); err != nil {
 		log.Log.Error(err, "failed to prepare udev files to disable network manager on requested VFs")
 	}
@@ -160,7 +240,7 @@ func (dn *Daemon) Run(stopCh <-chan struct{}, exitCh <-chan error) error {
 	var timeout int64 = 5
 	var metadataKey = "metadata.name"
 	informerFactory := sninformer.NewFilteredSharedInformerFactory(dn.sriovClient,
-		time.Second*15,
+		vars.DaemonResyncPeriod,
 		vars.Namespace,
 		func(lo *metav1.ListOptions) {
 			lo.FieldSelector = metadataKey + "=" + vars.NodeName
@@ -234,6 +314,10 @@ func (dn *Daemon) enqueueNodeState(obj interface{}) {
 		return
 	}
 	key := ns.GetGeneration()
+	// a drain-now request is processed right away, without the backoff of the previous failed syncs
+	if utils.ObjectHasAnnotationKey(ns, consts.NodeStateDrainNowAnnotation) {
+		dn.workqueue.Forget(key)
+	}
 	dn.workqueue.Add(key)
 }
 
@@ -264,11 +348,22 @@ func (dn *Daemon) processNextWorkItem() bool {
 		err := dn.nodeStateSyncHandler()
 		if err != nil {
 			// Ereport error message, and put the item back to work queue for retry.
-			dn.refreshCh <- Message{
+			msg := Message{
 				syncStatus:    consts.SyncStatusFailed,
 				lastSyncError: err.Error(),
+				kernelModules: dn.kernelModules,
 			}
+			var degradedErr *plugin.DegradedError
+			var ovsIfaceErr *hostTypes.OVSInterfaceError
+			if errors.As(err, &degradedErr) {
+				msg.degradedReason = degradedErr.Reason
+			} else if errors.As(err, &ovsIfaceErr) {
+				msg.degradedReason = sriovnetworkv1.ReasonOVSInterfaceError
+				dn.eventRecorder.SendEvent(sriovnetworkv1.ReasonOVSInterfaceError, ovsIfaceErr.Error())
+			}
+			dn.refreshCh <- msg
 			<-dn.syncCh
+			dn.recordSyncFailure(err)
 			dn.workqueue.AddRateLimited(key)
 			return fmt.Errorf("error syncing: %s, requeuing", err.Error())
 		}
@@ -312,6 +407,10 @@ func (dn *Daemon) operatorConfigChangeHandler(old, new interface{}) {
 	}
 
 	vars.MlxPluginFwReset = dn.featureGate.IsEnabled(consts.MellanoxFirmwareResetFeatureGate)
+	vars.VfioHugepagesAdvisory = dn.featureGate.IsEnabled(consts.VfioHugepagesAdvisoryFeatureGate)
+	vars.ReportVfConsumerPods = dn.featureGate.IsEnabled(consts.ReportVfConsumerPodsFeatureGate)
+	vars.SkipRdma = dn.featureGate.IsEnabled(consts.SkipRdmaFeatureGate)
+	dn.updateLinkWatcher()
 }
 
 func (dn *Daemon) nodeStateSyncHandler() error {
@@ -323,12 +422,55 @@ func (dn *Daemon) nodeStateSyncHandler() error {
 		log.Log.Error(err, "nodeStateSyncHandler(): Failed to fetch node state", "name", vars.NodeName)
 		return err
 	}
+	if dn.linkChanged.Swap(false) {
+		// refresh the status with the current state of the links before checking for changes
+		dn.refreshCh <- Message{
+			syncStatus:    dn.desiredNodeState.Status.SyncStatus,
+			lastSyncError: dn.desiredNodeState.Status.LastSyncError,
+		}
+		<-dn.syncCh
+		dn.desiredNodeState, err = dn.sriovClient.SriovnetworkV1().SriovNetworkNodeStates(vars.Namespace).Get(context.Background(), vars.NodeName, metav1.GetOptions{})
+		if err != nil {
+			log.Log.Error(err, "nodeStateSyncHandler(): Failed to fetch node state", "name", vars.NodeName)
+			return err
+		}
+	}
+	dn.setManagedLinks(dn.desiredNodeState)
 	latest := dn.desiredNodeState.GetGeneration()
 	log.Log.V(0).Info("nodeStateSyncHandler(): new generation", "generation", latest)
 
+	drainNow := dn.isDrainNowRequested()
+	if drainNow {
+		// an immediate drain restarts the retries of the generation and the drain timer
+		log.Log.Info("nodeStateSyncHandler(): immediate drain requested", "annotation", consts.NodeStateDrainNowAnnotation)
+		dn.resetDrainTimeout()
+		defer dn.clearDrainNowRequest(dn.desiredNodeState.DeepCopy())
+	}
+
+	if dn.syncFailuresGeneration != latest || drainNow {
+		// a new generation of the node state is retried again
+		dn.resetSyncFailures(latest)
+	} else if dn.checkMaxRetriesExceeded() {
+		return nil
+	}
+
+	dn.updateOVSDBSocketPath()
+	if err := dn.updateConfigurationMode(); err != nil {
+		log.Log.Error(err, "nodeStateSyncHandler(): failed to update the configuration mode")
+		return err
+	}
+
+	forceSystemdReapply := dn.isSystemdReapplyForced()
+	if forceSystemdReapply {
+		log.Log.Info("nodeStateSyncHandler(): systemd reapply requested", "annotation", consts.NodeStateForceSystemdReapplyAnnotation)
+	}
+
 	// load plugins if it has not loaded
 	if len(dn.loadedPlugins) == 0 {
-		dn.loadedPlugins, err = loadPlugins(dn.desiredNodeState, dn.HostHelpers, dn.disabledPlugins)
+		dn.loadedPlugins, err = loadPlugins(dn.desiredNodeState, dn.HostHelpers, dn.disabledPlugins,
+			genericplugin.WithProgressUpdater(dn.updateProgressMessage),
+			genericplugin.WithPfInProgressUpdater(dn.setPfInProgress),
+			genericplugin.WithSecretGetter(dn.getSecret))
 		if err != nil {
 			log.Log.Error(err, "nodeStateSyncHandler(): failed to enable vendor plugins")
 			return err
@@ -336,9 +478,12 @@ func (dn *Daemon) nodeStateSyncHandler() error {
 	}
 
 	skipReconciliation := true
+	// result of the plugins status check done to decide if the reconciliation can be skipped,
+	// nil if the status was not checked
+	var statusChanged *bool
 	// if the operator complete the drain operator we should continue the configuration
 	if !dn.isDrainCompleted() {
-		if vars.UsingSystemdMode && dn.currentNodeState.GetGeneration() == latest {
+		if vars.UsingSystemdMode && dn.currentNodeState.GetGeneration() == latest && !forceSystemdReapply {
 			serviceEnabled, err := dn.HostHelpers.IsServiceEnabled(systemd.SriovServicePath)
 			if err != nil {
 				log.Log.Error(err, "nodeStateSyncHandler(): failed to check if sriov-config service exist on host")
@@ -377,10 +522,18 @@ func (dn *Daemon) nodeStateSyncHandler() error {
 			}
 		}
 
-		skipReconciliation, err = dn.shouldSkipReconciliation(dn.desiredNodeState)
+		skipReconciliation, statusChanged, err = dn.shouldSkipReconciliation(dn.desiredNodeState)
 		if err != nil {
 			return err
 		}
+		skipReconciliation = skipReconciliation && !forceSystemdReapply
+	}
+
+	if dn.currentNodeState.GetGeneration() != latest {
+		// a new generation of the node state restarts the conflict detection
+		dn.resetNumVfsConflict()
+	} else if !skipReconciliation && dn.checkNumVfsConflict(dn.desiredNodeState) {
+		return nil
 	}
 
 	// we are done with the configuration just return here
@@ -390,9 +543,17 @@ func (dn *Daemon) nodeStateSyncHandler() error {
 		return nil
 	}
 
-	dn.refreshCh <- Message{
-		syncStatus:    consts.SyncStatusInProgress,
-		lastSyncError: "",
+	if dn.drainTimeoutError != "" {
+		// keep reporting the drain timeout until the drain completes
+		dn.refreshCh <- Message{
+			syncStatus:    consts.SyncStatusFailed,
+			lastSyncError: dn.drainTimeoutError,
+		}
+	} else {
+		dn.refreshCh <- Message{
+			syncStatus:    consts.SyncStatusInProgress,
+			lastSyncError: "",
+		}
 	}
 	// wait for writer to refresh status then pull again the latest node state
 	<-dn.syncCh
@@ -407,8 +568,18 @@ func (dn *Daemon) nodeStateSyncHandler() error {
 	}
 	dn.desiredNodeState.Status = updatedState.Status
 
+	// check if the VF configuration is going to change, we use this to decide
+	// if the device plugin must be restarted at the end of the sync
+	vfConfigChanged, err := dn.isVFConfigurationChanged(statusChanged)
+	if err != nil {
+		return err
+	}
+
 	reqReboot := false
 	reqDrain := false
+	// names of the plugins (or systemd) which required to drain or reboot the node
+	drainOrigins := []string{}
+	rebootOrigins := []string{}
 
 	// check if any of the plugins required to drain or reboot the node
 	for k, p := range dn.loadedPlugins {
@@ -418,7 +589,9 @@ func (dn *Daemon) nodeStateSyncHandler() error {
 		} else {
 			log.Log.V(0).Info("nodeStateSyncHandler(): calling OnNodeStateChange for an updated node state")
 		}
+		start := time.Now()
 		d, r, err = p.OnNodeStateChange(dn.desiredNodeState)
+		observePluginDuration(k, pluginPhaseOnNodeStateChange, start)
 		if err != nil {
 			log.Log.Error(err, "nodeStateSyncHandler(): OnNodeStateChange plugin error", "plugin-name", k)
 			return err
@@ -426,30 +599,41 @@ func (dn *Daemon) nodeStateSyncHandler() error {
 		log.Log.V(0).Info("nodeStateSyncHandler(): OnNodeStateChange result", "plugin", k, "drain-required", d, "reboot-required", r)
 		reqDrain = reqDrain || d
 		reqReboot = reqReboot || r
+		if d {
+			drainOrigins = append(drainOrigins, k)
+		}
+		if r {
+			rebootOrigins = append(rebootOrigins, k)
+		}
+	}
+
+	// report the kernel arguments before the node is drained and rebooted to apply them
+	pendingKernelArgs := dn.getPendingKernelArgs()
+	if !slices.Equal(pendingKernelArgs, dn.desiredNodeState.Status.PendingKernelArgs) {
+		log.Log.Info("nodeStateSyncHandler(): pending kernel arguments changed", "pending-kernel-args", pendingKernelArgs)
+		dn.refreshCh <- Message{
+			syncStatus:        dn.desiredNodeState.Status.SyncStatus,
+			lastSyncError:     dn.desiredNodeState.Status.LastSyncError,
+			pendingKernelArgs: pendingKernelArgs,
+		}
+		<-dn.syncCh
 	}
 
 	// When running using systemd check if the applied configuration is the latest one
 	// or there is a new config we need to apply
 	// When using systemd configuration we write the file
 	if vars.UsingSystemdMode {
-		log.Log.V(0).Info("nodeStateSyncHandler(): writing systemd config file to host")
-		systemdConfModified, err := systemd.WriteConfFile(dn.desiredNodeState)
+		systemdConfModified, err := dn.writeSystemdConfigFile()
 		if err != nil {
-			log.Log.Error(err, "nodeStateSyncHandler(): failed to write configuration file for systemd mode")
 			return err
 		}
-		if systemdConfModified {
-			// remove existing result file to make sure that we will not use outdated result, e.g. in case if
-			// systemd service was not triggered for some reason
-			err = systemd.RemoveSriovResult()
-			if err != nil {
-				log.Log.Error(err, "nodeStateSyncHandler(): failed to remove result file for systemd mode")
-				return err
-			}
-		}
 		reqDrain = reqDrain || systemdConfModified
 		// require reboot if drain needed for systemd mode
 		reqReboot = reqReboot || systemdConfModified || reqDrain
+		if systemdConfModified {
+			drainOrigins = append(drainOrigins, "systemd")
+			rebootOrigins = append(rebootOrigins, "systemd")
+		}
 		log.Log.V(0).Info("nodeStateSyncHandler(): systemd mode WriteConfFile results",
 			"drain-required", reqDrain, "reboot-required", reqReboot, "disable-drain", dn.disableDrain)
 
@@ -462,6 +646,28 @@ func (dn *Daemon) nodeStateSyncHandler() error {
 
 	log.Log.V(0).Info("nodeStateSyncHandler(): aggregated daemon",
 		"drain-required", reqDrain, "reboot-required", reqReboot, "disable-drain", dn.disableDrain)
+	dn.eventRecorder.SendEvent(consts.ReasonDrainDecision,
+		drainDecisionSummary(reqDrain, reqReboot, drainOrigins, rebootOrigins, dn.disableDrain))
+
+	if reqReboot {
+		rebootBlocked, err := dn.isRebootBlocked()
+		if err != nil {
+			return err
+		}
+		if rebootBlocked {
+			errMsg := fmt.Sprintf("%s: the configuration requires a reboot but the node has the %s annotation, "+
+				"the configuration is not applied", sriovnetworkv1.ReasonRebootBlocked, consts.NodeNoRebootAnnotation)
+			log.Log.Info("nodeStateSyncHandler(): skip configuration", "error", errMsg)
+			dn.eventRecorder.SendEvent(sriovnetworkv1.ReasonRebootBlocked, errMsg)
+			dn.refreshCh <- Message{
+				syncStatus:     consts.SyncStatusFailed,
+				lastSyncError:  errMsg,
+				degradedReason: sriovnetworkv1.ReasonRebootBlocked,
+			}
+			<-dn.syncCh
+			return nil
+		}
+	}
 
 	// handle drain only if the plugin request drain, or we are already in a draining request state
 	if reqDrain || !utils.ObjectHasAnnotation(dn.desiredNodeState,
@@ -481,7 +687,11 @@ func (dn *Daemon) nodeStateSyncHandler() error {
 	for k, p := range dn.loadedPlugins {
 		// Skip both the general and virtual plugin apply them last
 		if k != GenericPluginName && k != VirtualPluginName {
+			dn.setStepInProgress("apply " + k)
+			start := time.Now()
 			err := p.Apply()
+			observePluginDuration(k, pluginPhaseApply, start)
+			dn.setStepInProgress("")
 			if err != nil {
 				log.Log.Error(err, "nodeStateSyncHandler(): plugin Apply failed", "plugin-name", k)
 				return err
@@ -496,7 +706,11 @@ func (dn *Daemon) nodeStateSyncHandler() error {
 		selectedPlugin, ok := dn.loadedPlugins[GenericPluginName]
 		if ok {
 			// Apply generic plugin last
+			dn.setStepInProgress("apply " + GenericPluginName)
+			start := time.Now()
 			err = selectedPlugin.Apply()
+			observePluginDuration(GenericPluginName, pluginPhaseApply, start)
+			dn.setStepInProgress("")
 			if err != nil {
 				log.Log.Error(err, "nodeStateSyncHandler(): generic plugin fail to apply")
 				return err
@@ -507,7 +721,11 @@ func (dn *Daemon) nodeStateSyncHandler() error {
 		selectedPlugin, ok = dn.loadedPlugins[VirtualPluginName]
 		if ok {
 			// Apply virtual plugin last
+			dn.setStepInProgress("apply " + VirtualPluginName)
+			start := time.Now()
 			err = selectedPlugin.Apply()
+			observePluginDuration(VirtualPluginName, pluginPhaseApply, start)
+			dn.setStepInProgress("")
 			if err != nil {
 				log.Log.Error(err, "nodeStateSyncHandler(): virtual plugin failed to apply")
 				return err
@@ -518,17 +736,32 @@ func (dn *Daemon) nodeStateSyncHandler() error {
 	if reqReboot {
 		log.Log.Info("nodeStateSyncHandler(): reboot node")
 		dn.eventRecorder.SendEvent("RebootNode", "Reboot node has been initiated")
+		if forceSystemdReapply {
+			dn.clearSystemdReapplyRequest(dn.desiredNodeState.DeepCopy())
+		}
 		dn.rebootNode()
 		return nil
 	}
 
-	// restart device plugin pod
-	log.Log.Info("nodeStateSyncHandler(): restart device plugin pod")
-	if err := dn.restartDevicePluginPod(); err != nil {
-		log.Log.Error(err, "nodeStateSyncHandler(): fail to restart device plugin pod")
+	if err := dn.checkKernelModules(); err != nil {
+		log.Log.Error(err, "nodeStateSyncHandler(): required kernel modules are not loaded")
 		return err
 	}
 
+	// restart device plugin pod only if the VF configuration was changed
+	if dn.featureGate.IsEnabled(consts.DisableDevicePluginRestartFeatureGate) {
+		log.Log.Info("nodeStateSyncHandler(): device plugin restart disabled by feature gate, skip device plugin pod restart",
+			"feature-gate", consts.DisableDevicePluginRestartFeatureGate, "vf-config-changed", vfConfigChanged)
+	} else if vfConfigChanged || dn.featureGate.IsEnabled(consts.BlockDevicePluginUntilConfiguredFeatureGate) {
+		log.Log.Info("nodeStateSyncHandler(): restart device plugin pod")
+		if err := dn.restartDevicePluginPod(vfConfigChanged); err != nil {
+			log.Log.Error(err, "nodeStateSyncHandler(): fail to restart device plugin pod")
+			return err
+		}
+	} else {
+		log.Log.Info("nodeStateSyncHandler(): VF configuration not changed, skip device plugin pod restart")
+	}
+
 	log.Log.Info("nodeStateSyncHandler(): apply 'Idle' annotation for node")
 	err = utils.AnnotateNode(context.Background(), vars.NodeName, consts.NodeDrainAnnotation, consts.DrainIdle, dn.client)
 	if err != nil {
@@ -544,24 +777,29 @@ func (dn *Daemon) nodeStateSyncHandler() error {
 	}
 
 	log.Log.Info("nodeStateSyncHandler(): sync succeeded")
+	dn.syncFailures = 0
 	dn.currentNodeState = dn.desiredNodeState.DeepCopy()
+	msg := Message{
+		syncStatus:    consts.SyncStatusSucceeded,
+		lastSyncError: "",
+		kernelModules: dn.kernelModules,
+	}
 	if vars.UsingSystemdMode {
-		dn.refreshCh <- Message{
-			syncStatus:    sriovResult.SyncStatus,
-			lastSyncError: sriovResult.LastSyncError,
-		}
-	} else {
-		dn.refreshCh <- Message{
-			syncStatus:    consts.SyncStatusSucceeded,
-			lastSyncError: "",
-		}
+		msg.syncStatus = sriovResult.SyncStatus
+		msg.lastSyncError = sriovResult.LastSyncError
+	}
+	if msg.syncStatus == consts.SyncStatusSucceeded {
+		msg.appliedTime = dn.clock.Now()
 	}
+	dn.refreshCh <- msg
 	// wait for writer to refresh the status
 	<-dn.syncCh
 	return nil
 }
 
-func (dn *Daemon) shouldSkipReconciliation(latestState *sriovnetworkv1.SriovNetworkNodeState) (bool, error) {
+// shouldSkipReconciliation returns true if the node state doesn't need to be applied, the result of the plugins
+// status check is also returned so it's not repeated during the sync, nil if the status was not checked
+func (dn *Daemon) shouldSkipReconciliation(latestState *sriovnetworkv1.SriovNetworkNodeState) (bool, *bool, error) {
 	log.Log.V(0).Info("shouldSkipReconciliation()")
 	var err error
 
@@ -570,7 +808,7 @@ func (dn *Daemon) shouldSkipReconciliation(latestState *sriovnetworkv1.SriovNetw
 		err = dn.HostHelpers.ClearPCIAddressFolder()
 		if err != nil {
 			log.Log.Error(err, "failed to clear the PCI address configuration")
-			return false, err
+			return false, nil, err
 		}
 
 		log.Log.V(0).Info(
@@ -584,23 +822,18 @@ func (dn *Daemon) shouldSkipReconciliation(latestState *sriovnetworkv1.SriovNetw
 			// wait for writer to refresh status
 			<-dn.syncCh
 		}
-		return true, nil
+		return true, nil, nil
 	}
 
 	// Verify changes in the status of the SriovNetworkNodeState CR.
 	if dn.currentNodeState.GetGeneration() == latestState.GetGeneration() {
 		log.Log.V(0).Info("shouldSkipReconciliation() verifying status change")
-		for _, p := range dn.loadedPlugins {
-			// Verify changes in the status of the SriovNetworkNodeState CR.
-			log.Log.V(0).Info("shouldSkipReconciliation(): verifying status change for plugin", "pluginName", p.Name())
-			changed, err := p.CheckStatusChanges(latestState)
-			if err != nil {
-				return false, err
-			}
-			if changed {
-				log.Log.V(0).Info("shouldSkipReconciliation(): plugin require change", "pluginName", p.Name())
-				return false, nil
-			}
+		changed, err := dn.checkStatusChanges(latestState)
+		if err != nil {
+			return false, nil, err
+		}
+		if changed {
+			return false, &changed, nil
 		}
 
 		log.Log.V(0).Info("shouldSkipReconciliation(): Interface not changed")
@@ -614,24 +847,233 @@ func (dn *Daemon) shouldSkipReconciliation(latestState *sriovnetworkv1.SriovNetw
 			<-dn.syncCh
 		}
 
-		return true, nil
+		return true, &changed, nil
 	}
 
+	return false, nil, nil
+}
+
+// checkStatusChanges returns true if any plugin detects that the host is not in sync with the node state
+func (dn *Daemon) checkStatusChanges(latestState *sriovnetworkv1.SriovNetworkNodeState) (bool, error) {
+	for k, p := range dn.loadedPlugins {
+		// Verify changes in the status of the SriovNetworkNodeState CR.
+		log.Log.V(0).Info("checkStatusChanges(): verifying status change for plugin", "pluginName", p.Name())
+		start := time.Now()
+		changed, err := p.CheckStatusChanges(latestState)
+		observePluginDuration(k, pluginPhaseCheckStatusChanges, start)
+		if err != nil {
+			log.Log.Error(err, "checkStatusChanges(): failed to check status changes", "plugin-name", p.Name())
+			return false, err
+		}
+		if changed {
+			log.Log.V(0).Info("checkStatusChanges(): plugin require change", "pluginName", p.Name())
+			return true, nil
+		}
+	}
 	return false, nil
 }
 
+// updateOVSDBSocketPath sets the OVSDB socket path to the one from the node state spec,
+// or to the daemon default if the node state doesn't override it
+func (dn *Daemon) updateOVSDBSocketPath() {
+	socketPath := dn.defaultOVSDBSocketPath
+	if dn.desiredNodeState.Spec.System.OVSDBSocketPath != "" {
+		socketPath = dn.desiredNodeState.Spec.System.OVSDBSocketPath
+	}
+	if vars.OVSDBSocketPath != socketPath {
+		log.Log.Info("updateOVSDBSocketPath(): update OVSDB socket path", "old", vars.OVSDBSocketPath, "new", socketPath)
+		vars.OVSDBSocketPath = socketPath
+	}
+}
+
+// updateConfigurationMode switches between the daemon and the systemd mode according to the node state spec,
+// or to the daemon default if the node state doesn't override it. The plugins are reloaded on a switch so they
+// are set up for the new mode, the systemd services are then installed by the k8s plugin on kubernetes clusters
+// while on openshift they must be already deployed by the machine config of the operator
+func (dn *Daemon) updateConfigurationMode() error {
+	usingSystemdMode := dn.defaultUsingSystemdMode
+	switch dn.desiredNodeState.Spec.System.ConfigurationMode {
+	case sriovnetworkv1.SystemdConfigurationMode:
+		usingSystemdMode = true
+	case sriovnetworkv1.DaemonConfigurationMode:
+		usingSystemdMode = false
+	}
+	if vars.UsingSystemdMode == usingSystemdMode {
+		return nil
+	}
+	if usingSystemdMode && vars.ClusterType == consts.ClusterTypeOpenshift {
+		serviceEnabled, err := dn.HostHelpers.IsServiceEnabled(systemd.SriovServicePath)
+		if err != nil {
+			log.Log.Error(err, "updateConfigurationMode(): failed to check if sriov-config service exist on host")
+			return err
+		}
+		postNetworkServiceEnabled, err := dn.HostHelpers.IsServiceEnabled(systemd.SriovPostNetworkServicePath)
+		if err != nil {
+			log.Log.Error(err, "updateConfigurationMode(): failed to check if sriov-config-post-network service exist on host")
+			return err
+		}
+		if !(serviceEnabled && postNetworkServiceEnabled) {
+			return fmt.Errorf("can't switch to the systemd configuration mode, some sriov systemd services are not available on node: "+
+				"sriov-config available:%t, sriov-config-post-network available:%t", serviceEnabled, postNetworkServiceEnabled)
+		}
+	}
+	log.Log.Info("updateConfigurationMode(): update configuration mode", "systemd", usingSystemdMode)
+	vars.UsingSystemdMode = usingSystemdMode
+	dn.loadedPlugins = nil
+	if !usingSystemdMode {
+		// the host is only prepared on start in daemon mode, this also removes the systemd service files
+		dn.prepareHost()
+	}
+	return nil
+}
+
+// updateProgressMessage writes the configuration progress message to the node state status,
+// errors are only logged as the progress message is informative
+func (dn *Daemon) updateProgressMessage(message string) {
+	log.Log.V(2).Info("updateProgressMessage(): update progress message", "message", message)
+	err := retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		nodeState, err := dn.sriovClient.SriovnetworkV1().SriovNetworkNodeStates(vars.Namespace).Get(context.Background(), vars.NodeName, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		if nodeState.Status.ProgressMessage == message {
+			return nil
+		}
+		nodeState.Status.ProgressMessage = message
+		_, err = dn.sriovClient.SriovnetworkV1().SriovNetworkNodeStates(vars.Namespace).UpdateStatus(context.Background(), nodeState, metav1.UpdateOptions{})
+		return err
+	})
+	if err != nil {
+		log.Log.Error(err, "updateProgressMessage(): failed to update progress message")
+	}
+}
+
+// setStepInProgress records the configuration step the daemon is running, an empty step clears the record
+func (dn *Daemon) setStepInProgress(step string) {
+	dn.inProgressMu.Lock()
+	defer dn.inProgressMu.Unlock()
+	if step == "" {
+		dn.inProgress = nil
+		return
+	}
+	dn.inProgress = &ApplyInProgress{Step: step}
+}
+
+// setPfInProgress records the PF being configured by the step in progress
+func (dn *Daemon) setPfInProgress(pciAddress string) {
+	dn.inProgressMu.Lock()
+	defer dn.inProgressMu.Unlock()
+	if dn.inProgress != nil {
+		dn.inProgress.PciAddress = pciAddress
+	}
+}
+
+// PersistInProgress records the configuration step in progress in the checkpoint file, it's called when the
+// daemon is terminated so the next start can roll back the interrupted configuration of the PF
+func (dn *Daemon) PersistInProgress() error {
+	dn.inProgressMu.Lock()
+	defer dn.inProgressMu.Unlock()
+	if dn.inProgress == nil {
+		return nil
+	}
+	log.Log.Info("PersistInProgress(): record the interrupted configuration step",
+		"step", dn.inProgress.Step, "device", dn.inProgress.PciAddress)
+	return writeInProgressCheckpoint(dn.inProgress)
+}
+
+// rollbackInterruptedApply resets the PF whose configuration was interrupted by the previous termination of
+// the daemon, its VFs may be partially configured, the next sync recreates them instead of skipping the PF
+func (dn *Daemon) rollbackInterruptedApply() error {
+	inProgress, err := popInProgressCheckpoint()
+	if err != nil || inProgress == nil {
+		return err
+	}
+	log.Log.Info("rollbackInterruptedApply(): previous configuration was interrupted",
+		"step", inProgress.Step, "device", inProgress.PciAddress)
+	dn.eventRecorder.SendEvent("InterruptedApply",
+		fmt.Sprintf("Configuration step %q was interrupted, device: %q", inProgress.Step, inProgress.PciAddress))
+	if inProgress.PciAddress == "" {
+		return nil
+	}
+
+	pfStatus, exist, err := dn.HostHelpers.LoadPfsStatus(inProgress.PciAddress)
+	if err != nil {
+		return err
+	}
+	if exist && pfStatus.ExternallyManaged {
+		log.Log.Info("rollbackInterruptedApply(): PF is externally managed, skip the reset", "device", inProgress.PciAddress)
+		return nil
+	}
+	if !vars.UsingSystemdMode {
+		exit, err := dn.HostHelpers.Chroot(consts.Host)
+		if err != nil {
+			return err
+		}
+		defer exit()
+	}
+	ifaceStatuses, err := dn.HostHelpers.DiscoverSriovDevices(dn.HostHelpers)
+	if err != nil {
+		return err
+	}
+	for _, ifaceStatus := range ifaceStatuses {
+		if ifaceStatus.PciAddress != inProgress.PciAddress {
+			continue
+		}
+		if err := dn.HostHelpers.ResetSriovDevice(ifaceStatus); err != nil {
+			return fmt.Errorf("failed to reset the interrupted configuration of %s: %v", inProgress.PciAddress, err)
+		}
+		return dn.HostHelpers.RemovePfAppliedStatus(inProgress.PciAddress)
+	}
+	return nil
+}
+
+// getSecret returns the data of the Secret in the operator namespace, it's used by the plugins
+// to read the Secrets referenced by the configuration
+func (dn *Daemon) getSecret(name string) (map[string][]byte, error) {
+	secret, err := dn.kubeClient.CoreV1().Secrets(vars.Namespace).Get(context.Background(), name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return secret.Data, nil
+}
+
+// isVFConfigurationChanged returns true if applying the desired node state is going to change the VF configuration
+// on the host. This is the case when the daemon didn't apply any configuration yet, when the interfaces
+// in the spec changed from the last applied configuration or when any plugin detects that the host is not in sync with the spec.
+// The plugins status is only checked if statusChanged, the result of an earlier check during the sync, is nil
+func (dn *Daemon) isVFConfigurationChanged(statusChanged *bool) (bool, error) {
+	if dn.currentNodeState.GetName() == "" {
+		log.Log.V(2).Info("isVFConfigurationChanged(): no configuration was applied yet")
+		return true, nil
+	}
+
+	if !equality.Semantic.DeepEqual(dn.currentNodeState.Spec.Interfaces, dn.desiredNodeState.Spec.Interfaces) {
+		log.Log.V(2).Info("isVFConfigurationChanged(): interfaces spec changed")
+		return true, nil
+	}
+
+	if statusChanged != nil {
+		return *statusChanged, nil
+	}
+	return dn.checkStatusChanges(dn.desiredNodeState)
+}
+
 // handleDrain: adds the right annotation to the node and nodeState object
 // returns true if we need to finish the reconcile loop and wait for a new object
 func (dn *Daemon) handleDrain(reqReboot bool) (bool, error) {
 	// done with the drain we can continue with the configuration
 	if utils.ObjectHasAnnotation(dn.desiredNodeState, consts.NodeStateDrainAnnotationCurrent, consts.DrainComplete) {
 		log.Log.Info("handleDrain(): the node complete the draining")
+		dn.resetDrainTimeout()
 		return false, nil
 	}
 
 	// the operator is still draining the node so we reconcile
 	if utils.ObjectHasAnnotation(dn.desiredNodeState, consts.NodeStateDrainAnnotationCurrent, consts.Draining) {
 		log.Log.Info("handleDrain(): the node is still draining")
+		if !dn.isDrainNowRequested() {
+			dn.checkDrainTimeout()
+		}
 		return true, nil
 	}
 
@@ -641,9 +1083,19 @@ func (dn *Daemon) handleDrain(reqReboot bool) (bool, error) {
 		return false, nil
 	}
 
+	// another controller is draining the node, wait for it to release the node instead of draining it twice
+	cordoned, err := dn.isCordonedExternally()
+	if err != nil {
+		return false, err
+	}
+	if cordoned && !dn.isDrainNowRequested() {
+		log.Log.Info("handleDrain(): the node is cordoned by another controller, deferring the drain until it is uncordoned")
+		return true, nil
+	}
+
 	if reqReboot {
 		log.Log.Info("handleDrain(): apply 'Reboot_Required' annotation for node")
-		err := utils.AnnotateNode(context.Background(), vars.NodeName, consts.NodeDrainAnnotation, consts.RebootRequired, dn.client)
+		err = utils.AnnotateNode(context.Background(), vars.NodeName, consts.NodeDrainAnnotation, consts.RebootRequired, dn.client)
 		if err != nil {
 			log.Log.Error(err, "applyDrainRequired(): Failed to annotate node")
 			return false, err
@@ -660,7 +1112,7 @@ func (dn *Daemon) handleDrain(reqReboot bool) (bool, error) {
 		return true, nil
 	}
 	log.Log.Info("handleDrain(): apply 'Drain_Required' annotation for node")
-	err := utils.AnnotateNode(context.Background(), vars.NodeName, consts.NodeDrainAnnotation, consts.DrainRequired, dn.client)
+	err = utils.AnnotateNode(context.Background(), vars.NodeName, consts.NodeDrainAnnotation, consts.DrainRequired, dn.client)
 	if err != nil {
 		log.Log.Error(err, "handleDrain(): Failed to annotate node")
 		return false, err
@@ -677,9 +1129,19 @@ func (dn *Daemon) handleDrain(reqReboot bool) (bool, error) {
 	return true, nil
 }
 
-func (dn *Daemon) restartDevicePluginPod() error {
+// restartDevicePluginPod deletes the device plugin pods of the node, the restart is skipped if the VF configuration
+// didn't change and the pods were restarted less than vars.DevicePluginRestartInterval ago. A change of the VF
+// configuration always restarts the pods so the interval only limits the restarts requested on every sync by the
+// blockDevicePluginUntilConfigured feature gate
+func (dn *Daemon) restartDevicePluginPod(vfConfigChanged bool) error {
 	dn.mu.Lock()
 	defer dn.mu.Unlock()
+	if !vfConfigChanged && !dn.lastDevicePluginRestart.IsZero() &&
+		dn.clock.Since(dn.lastDevicePluginRestart) < vars.DevicePluginRestartInterval {
+		log.Log.Info("restartDevicePluginPod(): device plugin pod restarted recently, skip restart",
+			"last-restart", dn.lastDevicePluginRestart, "interval", vars.DevicePluginRestartInterval)
+		return nil
+	}
 	log.Log.V(2).Info("restartDevicePluginPod(): try to restart device plugin pod")
 
 	pods, err := dn.kubeClient.CoreV1().Pods(vars.Namespace).List(context.Background(), metav1.ListOptions{
@@ -688,7 +1150,7 @@ func (dn *Daemon) restartDevicePluginPod() error {
 		ResourceVersion: "0",
 	})
 	if err != nil {
-		if errors.IsNotFound(err) {
+		if apierrors.IsNotFound(err) {
 			log.Log.Info("restartDevicePluginPod(): device plugin pod exited")
 			return nil
 		}
@@ -705,7 +1167,7 @@ func (dn *Daemon) restartDevicePluginPod() error {
 		podToDelete := pod.Name
 		log.Log.V(2).Info("restartDevicePluginPod(): Found device plugin pod, deleting it", "pod-name", podToDelete)
 		err = dn.kubeClient.CoreV1().Pods(vars.Namespace).Delete(context.Background(), podToDelete, metav1.DeleteOptions{})
-		if errors.IsNotFound(err) {
+		if apierrors.IsNotFound(err) {
 			log.Log.Info("restartDevicePluginPod(): pod to delete not found")
 			continue
 		}
@@ -716,7 +1178,7 @@ func (dn *Daemon) restartDevicePluginPod() error {
 
 		if err := wait.PollImmediateUntil(3*time.Second, func() (bool, error) {
 			_, err := dn.kubeClient.CoreV1().Pods(vars.Namespace).Get(context.Background(), podToDelete, metav1.GetOptions{})
-			if errors.IsNotFound(err) {
+			if apierrors.IsNotFound(err) {
 				log.Log.Info("restartDevicePluginPod(): device plugin pod exited")
 				return true, nil
 			}
@@ -733,9 +1195,74 @@ func (dn *Daemon) restartDevicePluginPod() error {
 		}
 	}
 
+	dn.lastDevicePluginRestart = dn.clock.Now()
 	return nil
 }
 
+// checkKernelModules records which of the kernel modules required by the desired configuration
+// are loaded, and returns a DegradedError if some of them are missing
+func (dn *Daemon) checkKernelModules() error {
+	modules := &sriovnetworkv1.KernelModules{}
+	for _, module := range requiredKernelModules(dn.desiredNodeState) {
+		loaded, err := dn.HostHelpers.IsKernelModuleLoaded(module)
+		if err != nil {
+			log.Log.Error(err, "checkKernelModules(): failed to check if kernel module is loaded", "module", module)
+			return err
+		}
+		if loaded {
+			modules.Loaded = append(modules.Loaded, module)
+		} else {
+			modules.Missing = append(modules.Missing, module)
+		}
+	}
+	dn.kernelModules = modules
+
+	if len(modules.Missing) > 0 {
+		return &plugin.DegradedError{
+			Reason:  sriovnetworkv1.ReasonKernelModuleMissing,
+			Message: fmt.Sprintf("required kernel modules are not loaded: %s", strings.Join(modules.Missing, ",")),
+		}
+	}
+	return nil
+}
+
+// requiredKernelModules returns the sorted names of the kernel modules needed by the configuration of the node:
+// the drivers of the configured PFs and the drivers the VFs are bound to
+func requiredKernelModules(nodeState *sriovnetworkv1.SriovNetworkNodeState) []string {
+	modules := []string{}
+	addModule := func(name string) {
+		// lsmod reports the module names with underscores
+		name = strings.ReplaceAll(name, "-", "_")
+		if name != "" && !slices.Contains(modules, name) {
+			modules = append(modules, name)
+		}
+	}
+
+	for _, iface := range nodeState.Spec.Interfaces {
+		for _, ifaceStatus := range nodeState.Status.Interfaces {
+			if ifaceStatus.PciAddress == iface.PciAddress {
+				addModule(ifaceStatus.Driver)
+			}
+		}
+		for _, group := range iface.VfGroups {
+			switch {
+			case group.VfDriver != "":
+				addModule(group.VfDriver)
+			case group.DeviceType == consts.DeviceTypeVfioPci:
+				addModule(consts.DeviceTypeVfioPci)
+			}
+			switch group.VdpaType {
+			case consts.VdpaTypeVirtio:
+				addModule("virtio_vdpa")
+			case consts.VdpaTypeVhost:
+				addModule("vhost_vdpa")
+			}
+		}
+	}
+	slices.Sort(modules)
+	return modules
+}
+
 func (dn *Daemon) rebootNode() {
 	log.Log.Info("rebootNode(): trigger node reboot")
 	exit, err := dn.HostHelpers.Chroot(consts.Host)
@@ -774,6 +1301,236 @@ func (dn *Daemon) prepareNMUdevRule() error {
 	return dn.HostHelpers.PrepareNMUdevRule(supportedVfIds)
 }
 
+// checkDrainTimeout reports the sync status as failed once the node is in the Draining state
+// for longer than the drain timeout configured for the node pool, the start of the drain is
+// read from the node state so the timer is kept across the restarts of the daemon
+func (dn *Daemon) checkDrainTimeout() {
+	timeout := time.Duration(dn.desiredNodeState.Spec.System.DrainTimeoutSeconds) * time.Second
+	if timeout == 0 || dn.drainTimeoutError != "" {
+		return
+	}
+	drainStartTime := dn.desiredNodeState.GetDrainStartTime()
+	if drainStartTime.IsZero() {
+		log.Log.V(2).Info("checkDrainTimeout(): drain start time not recorded yet")
+		return
+	}
+	if dn.clock.Since(drainStartTime) <= timeout {
+		return
+	}
+
+	dn.drainTimeoutError = fmt.Sprintf("%s: node is in the %s state for more than %s",
+		consts.ReasonDrainTimeout, consts.Draining, timeout)
+	log.Log.Info("checkDrainTimeout(): drain timeout exceeded", "timeout", timeout, "start", drainStartTime)
+	dn.eventRecorder.SendEvent(consts.ReasonDrainTimeout, dn.drainTimeoutError)
+	dn.refreshCh <- Message{
+		syncStatus:    consts.SyncStatusFailed,
+		lastSyncError: dn.drainTimeoutError,
+	}
+	<-dn.syncCh
+}
+
+// resetDrainTimeout clears the drain timeout error once the drain completes
+func (dn *Daemon) resetDrainTimeout() {
+	dn.drainTimeoutError = ""
+}
+
+// checkNumVfsConflict records the PFs whose number of VFs was changed outside of the operator since the last sync.
+// It returns true and reports the Degraded condition when the number of VFs of a PF was restored more than
+// numVfsConflictThreshold times within numVfsConflictWindow, the daemon then stops restoring it until the
+// generation of the node state changes.
+func (dn *Daemon) checkNumVfsConflict(latestState *sriovnetworkv1.SriovNetworkNodeState) bool {
+	if dn.numVfsConflictError == "" {
+		now := dn.clock.Now()
+		for _, iface := range dn.currentNodeState.Spec.Interfaces {
+			if iface.ExternallyManaged {
+				continue
+			}
+			for _, ifaceStatus := range latestState.Status.Interfaces {
+				if ifaceStatus.PciAddress != iface.PciAddress || ifaceStatus.NumVfs == iface.NumVfs {
+					continue
+				}
+				restores := slices.DeleteFunc(dn.numVfsRestores[iface.PciAddress], func(t time.Time) bool {
+					return now.Sub(t) > numVfsConflictWindow
+				})
+				dn.numVfsRestores[iface.PciAddress] = append(restores, now)
+				log.Log.Info("checkNumVfsConflict(): number of VFs changed outside of the operator",
+					"device", iface.PciAddress, "desired", iface.NumVfs, "current", ifaceStatus.NumVfs,
+					"restores", len(dn.numVfsRestores[iface.PciAddress]))
+				if len(dn.numVfsRestores[iface.PciAddress]) > numVfsConflictThreshold {
+					dn.numVfsConflictError = fmt.Sprintf("%s: number of VFs of PF %s was changed outside of the operator more than %d times in %s, "+
+						"stop configuring it until the node state is updated", sriovnetworkv1.ReasonExternalConflict,
+						iface.PciAddress, numVfsConflictThreshold, numVfsConflictWindow)
+					dn.eventRecorder.SendEvent(sriovnetworkv1.ReasonExternalConflict, dn.numVfsConflictError)
+				}
+			}
+		}
+		if dn.numVfsConflictError == "" {
+			return false
+		}
+	}
+
+	log.Log.Info("checkNumVfsConflict(): skip configuration", "error", dn.numVfsConflictError)
+	dn.refreshCh <- Message{
+		syncStatus:     consts.SyncStatusFailed,
+		lastSyncError:  dn.numVfsConflictError,
+		degradedReason: sriovnetworkv1.ReasonExternalConflict,
+	}
+	<-dn.syncCh
+	return true
+}
+
+// recordSyncFailure counts the consecutive failed syncs of the generation of the node state,
+// the generation is not retried anymore once maxSyncFailures is reached
+func (dn *Daemon) recordSyncFailure(err error) {
+	dn.syncFailures++
+	if dn.syncFailures < maxSyncFailures || dn.maxRetriesError != "" {
+		return
+	}
+	dn.maxRetriesError = fmt.Sprintf("%s: applying the configuration failed %d consecutive times, "+
+		"stop retrying until the node state is updated, last error: %v", sriovnetworkv1.ReasonMaxRetriesExceeded, dn.syncFailures, err)
+	dn.eventRecorder.SendEvent(sriovnetworkv1.ReasonMaxRetriesExceeded, dn.maxRetriesError)
+}
+
+// checkMaxRetriesExceeded returns true and reports the configuration as degraded
+// if the generation of the node state failed too many times to be retried
+func (dn *Daemon) checkMaxRetriesExceeded() bool {
+	if dn.maxRetriesError == "" {
+		return false
+	}
+	log.Log.Info("checkMaxRetriesExceeded(): skip configuration", "error", dn.maxRetriesError)
+	dn.refreshCh <- Message{
+		syncStatus:     consts.SyncStatusFailed,
+		lastSyncError:  dn.maxRetriesError,
+		degradedReason: sriovnetworkv1.ReasonMaxRetriesExceeded,
+	}
+	<-dn.syncCh
+	return true
+}
+
+// resetSyncFailures restarts the counting of the failed syncs for the generation of the node state
+func (dn *Daemon) resetSyncFailures(generation int64) {
+	dn.syncFailuresGeneration = generation
+	dn.syncFailures = 0
+	dn.maxRetriesError = ""
+}
+
+// resetNumVfsConflict resets the detection of conflicts on the number of VFs
+func (dn *Daemon) resetNumVfsConflict() {
+	dn.numVfsRestores = map[string][]time.Time{}
+	dn.numVfsConflictError = ""
+}
+
+// drainDecisionSummary formats the aggregated drain and reboot decision of a sync for the audit event
+func drainDecisionSummary(reqDrain, reqReboot bool, drainOrigins, rebootOrigins []string, disableDrain bool) string {
+	// plugins are stored in a map, sort them to get a stable message
+	slices.Sort(drainOrigins)
+	slices.Sort(rebootOrigins)
+	return fmt.Sprintf("drain-required: %t, reboot-required: %t, drain-required-by: [%s], reboot-required-by: [%s], disable-drain: %t",
+		reqDrain, reqReboot, strings.Join(drainOrigins, ","), strings.Join(rebootOrigins, ","), disableDrain)
+}
+
+// isRebootBlocked returns true if the node opted out of reboots by the operator
+func (dn *Daemon) isRebootBlocked() (bool, error) {
+	node := &corev1.Node{}
+	if err := dn.client.Get(context.Background(), client.ObjectKey{Name: vars.NodeName}, node); err != nil {
+		log.Log.Error(err, "isRebootBlocked(): failed to get node", "name", vars.NodeName)
+		return false, err
+	}
+	return utils.ObjectHasAnnotation(node, consts.NodeNoRebootAnnotation, "true"), nil
+}
+
+// isCordonedExternally returns true if the node is unschedulable while the daemon didn't request a drain,
+// e.g. another controller is draining the node for an upgrade
+func (dn *Daemon) isCordonedExternally() (bool, error) {
+	node := &corev1.Node{}
+	if err := dn.client.Get(context.Background(), client.ObjectKey{Name: vars.NodeName}, node); err != nil {
+		log.Log.Error(err, "isCordonedExternally(): failed to get node", "name", vars.NodeName)
+		return false, err
+	}
+	// the drain was already requested, the node may be cordoned by the operator
+	if utils.ObjectHasAnnotation(node, consts.NodeDrainAnnotation, consts.DrainRequired) ||
+		utils.ObjectHasAnnotation(node, consts.NodeDrainAnnotation, consts.RebootRequired) {
+		return false, nil
+	}
+	if node.Spec.Unschedulable {
+		return true, nil
+	}
+	for _, taint := range node.Spec.Taints {
+		if taint.Key == corev1.TaintNodeUnschedulable {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// isDrainNowRequested returns true if the node state has the drain-now annotation,
+// the drain is then requested without waiting for the node to be released by another controller
+func (dn *Daemon) isDrainNowRequested() bool {
+	return utils.ObjectHasAnnotationKey(dn.desiredNodeState, consts.NodeStateDrainNowAnnotation)
+}
+
+// clearDrainNowRequest removes the drain-now annotation from the node state once the sync handled it
+func (dn *Daemon) clearDrainNowRequest(nodeState *sriovnetworkv1.SriovNetworkNodeState) {
+	if err := utils.RemoveAnnotationFromObject(context.Background(), nodeState,
+		consts.NodeStateDrainNowAnnotation, dn.client); err != nil {
+		log.Log.Error(err, "clearDrainNowRequest(): failed to remove annotation", "annotation", consts.NodeStateDrainNowAnnotation)
+	}
+}
+
+// getPendingKernelArgs returns the sorted kernel arguments of the loaded plugins which differ from the running kernel
+func (dn *Daemon) getPendingKernelArgs() []string {
+	pendingKernelArgs := []string{}
+	for _, p := range dn.loadedPlugins {
+		if reporter, ok := p.(plugin.KernelArgsReporter); ok {
+			pendingKernelArgs = append(pendingKernelArgs, reporter.PendingKernelArgs()...)
+		}
+	}
+	slices.Sort(pendingKernelArgs)
+	return pendingKernelArgs
+}
+
+// writeSystemdConfigFile writes the desired configuration for the sriov-config service to the host and reports
+// if it changed. The result file of the previous run is removed when the configuration changed or when a reapply
+// was requested with the force-systemd-reapply annotation, so the service applies it again on the next boot.
+func (dn *Daemon) writeSystemdConfigFile() (bool, error) {
+	log.Log.V(0).Info("writeSystemdConfigFile(): writing systemd config file to host")
+	systemdConfModified, err := systemd.WriteConfFile(dn.desiredNodeState)
+	if err != nil {
+		log.Log.Error(err, "writeSystemdConfigFile(): failed to write configuration file for systemd mode")
+		return false, err
+	}
+	if dn.isSystemdReapplyForced() {
+		log.Log.Info("writeSystemdConfigFile(): forcing the systemd configuration reapply",
+			"annotation", consts.NodeStateForceSystemdReapplyAnnotation)
+		systemdConfModified = true
+	}
+	if systemdConfModified {
+		// remove existing result file to make sure that we will not use outdated result, e.g. in case if
+		// systemd service was not triggered for some reason
+		err = systemd.RemoveSriovResult()
+		if err != nil {
+			log.Log.Error(err, "writeSystemdConfigFile(): failed to remove result file for systemd mode")
+			return false, err
+		}
+	}
+	return systemdConfModified, nil
+}
+
+// isSystemdReapplyForced returns true if the node state requests to apply the systemd configuration again
+func (dn *Daemon) isSystemdReapplyForced() bool {
+	return vars.UsingSystemdMode &&
+		utils.ObjectHasAnnotationKey(dn.desiredNodeState, consts.NodeStateForceSystemdReapplyAnnotation)
+}
+
+// clearSystemdReapplyRequest removes the force-systemd-reapply annotation from the node state before the reboot
+func (dn *Daemon) clearSystemdReapplyRequest(nodeState *sriovnetworkv1.SriovNetworkNodeState) {
+	if err := utils.RemoveAnnotationFromObject(context.Background(), nodeState,
+		consts.NodeStateForceSystemdReapplyAnnotation, dn.client); err != nil {
+		log.Log.Error(err, "clearSystemdReapplyRequest(): failed to remove annotation",
+			"annotation", consts.NodeStateForceSystemdReapplyAnnotation)
+	}
+}
+
 // isDrainCompleted returns true if the current-state annotation is drain completed
 func (dn *Daemon) isDrainCompleted() bool {
 	return utils.ObjectHasAnnotation(dn.desiredNodeState, consts.NodeStateDrainAnnotationCurrent, consts.DrainComplete)
@@ -2,17 +2,32 @@ package daemon
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
 	"go.uber.org/zap/zapcore"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	fakek8s "k8s.io/client-go/kubernetes/fake"
 	"k8s.io/client-go/kubernetes/scheme"
+	k8stesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/util/workqueue"
+	testingclock "k8s.io/utils/clock/testing"
+	kclientpkg "sigs.k8s.io/controller-runtime/pkg/client"
 	kclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
@@ -23,13 +38,17 @@ import (
 	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/consts"
 	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/featuregate"
 	mock_helper "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/helper/mock"
+	hostTypes "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/host/types"
 	mock_platforms "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/platforms/mock"
 	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/platforms/openshift"
 	plugin "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/plugins"
 	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/plugins/fake"
 	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/plugins/generic"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/systemd"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/utils"
 	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/vars"
 	"github.com/k8snetworkplumbingwg/sriov-network-operator/test/util/fakefilesystem"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/test/util/helpers"
 )
 
 var SriovDevicePluginPod corev1.Pod
@@ -159,12 +178,6 @@ var _ = Describe("Config Daemon", func() {
 
 		sut.loadedPlugins = map[string]plugin.VendorPlugin{generic.PluginName: &fake.FakePlugin{PluginName: "fake"}}
 
-		go func() {
-			defer GinkgoRecover()
-			err := sut.Run(stopCh, exitCh)
-			Expect(err).ToNot(HaveOccurred())
-		}()
-
 		SriovDevicePluginPod = corev1.Pod{
 			ObjectMeta: metav1.ObjectMeta{
 				Name:      "sriov-device-plugin-xxxx",
@@ -179,7 +192,14 @@ var _ = Describe("Config Daemon", func() {
 		}
 		_, err = sut.kubeClient.CoreV1().Pods(vars.Namespace).Create(context.Background(), &SriovDevicePluginPod, metav1.CreateOptions{})
 		Expect(err).ToNot(HaveOccurred())
+	})
 
+	JustBeforeEach(func() {
+		go func() {
+			defer GinkgoRecover()
+			err := sut.Run(stopCh, exitCh)
+			Expect(err).ToNot(HaveOccurred())
+		}()
 	})
 
 	AfterEach(func() {
@@ -251,6 +271,249 @@ var _ = Describe("Config Daemon", func() {
 
 		})
 
+		It("record the duration of the generic plugin phases", func() {
+			onNodeStateChangeCount := pluginPhaseSampleCount(generic.PluginName, pluginPhaseOnNodeStateChange)
+			applyCount := pluginPhaseSampleCount(generic.PluginName, pluginPhaseApply)
+
+			nodeState := &sriovnetworkv1.SriovNetworkNodeState{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "test-node",
+					Generation:  123,
+					Annotations: map[string]string{consts.NodeStateDrainAnnotationCurrent: consts.DrainIdle},
+				},
+			}
+			Expect(createSriovNetworkNodeState(sut.sriovClient, nodeState)).To(BeNil())
+
+			var msg Message
+			Eventually(refreshCh, "30s").Should(Receive(&msg))
+			Expect(msg.syncStatus).To(Equal("InProgress"))
+			Eventually(refreshCh, "30s").Should(Receive(&msg))
+			Expect(msg.syncStatus).To(Equal("Succeeded"))
+
+			Expect(pluginPhaseSampleCount(generic.PluginName, pluginPhaseOnNodeStateChange)).To(Equal(onNodeStateChangeCount + 1))
+			Expect(pluginPhaseSampleCount(generic.PluginName, pluginPhaseApply)).To(Equal(applyCount + 1))
+		})
+
+		It("restart sriov-device-plugin pod only once when the same configuration is applied twice", func() {
+			deletedPods := 0
+			sut.kubeClient.(*fakek8s.Clientset).PrependReactor("delete", "pods",
+				func(action k8stesting.Action) (bool, runtime.Object, error) {
+					deletedPods++
+					return false, nil, nil
+				})
+
+			_, err := sut.kubeClient.CoreV1().Nodes().
+				Create(context.Background(), &corev1.Node{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-node"},
+				}, metav1.CreateOptions{})
+			Expect(err).To(BeNil())
+
+			nodeState := &sriovnetworkv1.SriovNetworkNodeState{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "test-node",
+					Generation:  123,
+					Annotations: map[string]string{consts.NodeStateDrainAnnotationCurrent: consts.DrainIdle},
+				},
+				Spec: sriovnetworkv1.SriovNetworkNodeStateSpec{},
+				Status: sriovnetworkv1.SriovNetworkNodeStateStatus{
+					Interfaces: []sriovnetworkv1.InterfaceExt{
+						{
+							DeviceID:   "158b",
+							Driver:     "i40e",
+							Mtu:        1500,
+							Name:       "ens803f0",
+							PciAddress: "0000:86:00.0",
+							Vendor:     "8086",
+							TotalVfs:   64,
+						},
+					},
+				},
+			}
+			Expect(
+				createSriovNetworkNodeState(sut.sriovClient, nodeState)).
+				To(BeNil())
+
+			var msg Message
+			Eventually(refreshCh, "30s").Should(Receive(&msg))
+			Expect(msg.syncStatus).To(Equal("InProgress"))
+
+			Eventually(refreshCh, "30s").Should(Receive(&msg))
+			Expect(msg.syncStatus).To(Equal("Succeeded"))
+			Expect(deletedPods).To(Equal(1))
+
+			// the device plugin pod is recreated by the daemonset
+			_, err = sut.kubeClient.CoreV1().Pods(vars.Namespace).Create(context.Background(), &SriovDevicePluginPod, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			nodeState.Generation = 124
+			Expect(
+				updateSriovNetworkNodeState(sut.sriovClient, nodeState)).
+				To(BeNil())
+
+			Eventually(refreshCh, "30s").Should(Receive(&msg))
+			Expect(msg.syncStatus).To(Equal("InProgress"))
+
+			Eventually(refreshCh, "30s").Should(Receive(&msg))
+			Expect(msg.syncStatus).To(Equal("Succeeded"))
+			Expect(sut.currentNodeState.GetGeneration()).To(BeNumerically("==", 124))
+			Expect(deletedPods).To(Equal(1))
+		})
+
+		It("not restart sriov-device-plugin pod twice within the restart interval", func() {
+			sut.featureGate.Init(map[string]bool{consts.BlockDevicePluginUntilConfiguredFeatureGate: true})
+			fakeClock := testingclock.NewFakeClock(time.Now())
+			sut.clock = fakeClock
+			deletedPods := 0
+			sut.kubeClient.(*fakek8s.Clientset).PrependReactor("delete", "pods",
+				func(action k8stesting.Action) (bool, runtime.Object, error) {
+					deletedPods++
+					return false, nil, nil
+				})
+
+			_, err := sut.kubeClient.CoreV1().Nodes().
+				Create(context.Background(), &corev1.Node{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-node"},
+				}, metav1.CreateOptions{})
+			Expect(err).To(BeNil())
+
+			nodeState := &sriovnetworkv1.SriovNetworkNodeState{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "test-node",
+					Generation:  123,
+					Annotations: map[string]string{consts.NodeStateDrainAnnotationCurrent: consts.DrainIdle},
+				},
+			}
+			Expect(
+				createSriovNetworkNodeState(sut.sriovClient, nodeState)).
+				To(BeNil())
+
+			syncNodeState := func(generation int64) {
+				var msg Message
+				if generation > nodeState.Generation {
+					nodeState.Generation = generation
+					Expect(
+						updateSriovNetworkNodeState(sut.sriovClient, nodeState)).
+						To(BeNil())
+				}
+				Eventually(refreshCh, "30s").Should(Receive(&msg))
+				Expect(msg.syncStatus).To(Equal("InProgress"))
+				Eventually(refreshCh, "30s").Should(Receive(&msg))
+				Expect(msg.syncStatus).To(Equal("Succeeded"))
+				// the device plugin pod is recreated by the daemonset
+				_, err := sut.kubeClient.CoreV1().Pods(vars.Namespace).Create(context.Background(), &SriovDevicePluginPod, metav1.CreateOptions{})
+				if err != nil {
+					Expect(apierrors.IsAlreadyExists(err)).To(BeTrue())
+				}
+			}
+
+			syncNodeState(123)
+			Expect(deletedPods).To(Equal(1))
+
+			fakeClock.Step(vars.DevicePluginRestartInterval / 2)
+			syncNodeState(124)
+			Expect(deletedPods).To(Equal(1))
+
+			fakeClock.Step(vars.DevicePluginRestartInterval)
+			syncNodeState(125)
+			Expect(deletedPods).To(Equal(2))
+		})
+
+		It("report the time of the last successful apply", func() {
+			fakeClock := testingclock.NewFakeClock(time.Now())
+			sut.clock = fakeClock
+
+			nodeState := &sriovnetworkv1.SriovNetworkNodeState{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "test-node",
+					Generation:  123,
+					Annotations: map[string]string{consts.NodeStateDrainAnnotationCurrent: consts.DrainIdle},
+				},
+			}
+			Expect(createSriovNetworkNodeState(sut.sriovClient, nodeState)).To(BeNil())
+
+			var msg Message
+			Eventually(refreshCh, "30s").Should(Receive(&msg))
+			Expect(msg.syncStatus).To(Equal("InProgress"))
+			Expect(msg.appliedTime.IsZero()).To(BeTrue())
+			Eventually(refreshCh, "30s").Should(Receive(&msg))
+			Expect(msg.syncStatus).To(Equal("Succeeded"))
+			firstApply := msg.appliedTime
+			Expect(firstApply).To(Equal(fakeClock.Now()))
+
+			fakeClock.Step(time.Minute)
+			nodeState.Generation = 124
+			Expect(updateSriovNetworkNodeState(sut.sriovClient, nodeState)).To(BeNil())
+
+			Eventually(refreshCh, "30s").Should(Receive(&msg))
+			Expect(msg.syncStatus).To(Equal("InProgress"))
+			Eventually(refreshCh, "30s").Should(Receive(&msg))
+			Expect(msg.syncStatus).To(Equal("Succeeded"))
+			Expect(msg.appliedTime).To(Equal(firstApply.Add(time.Minute)))
+		})
+
+		It("not restart sriov-device-plugin pod when the restart is disabled by feature gate", func() {
+			sut.featureGate.Init(map[string]bool{
+				consts.DisableDevicePluginRestartFeatureGate:       true,
+				consts.BlockDevicePluginUntilConfiguredFeatureGate: true,
+			})
+			deletedPods := 0
+			sut.kubeClient.(*fakek8s.Clientset).PrependReactor("delete", "pods",
+				func(action k8stesting.Action) (bool, runtime.Object, error) {
+					deletedPods++
+					return false, nil, nil
+				})
+
+			_, err := sut.kubeClient.CoreV1().Nodes().
+				Create(context.Background(), &corev1.Node{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-node"},
+				}, metav1.CreateOptions{})
+			Expect(err).To(BeNil())
+
+			nodeState := &sriovnetworkv1.SriovNetworkNodeState{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "test-node",
+					Generation:  123,
+					Annotations: map[string]string{consts.NodeStateDrainAnnotationCurrent: consts.DrainIdle},
+				},
+				Spec: sriovnetworkv1.SriovNetworkNodeStateSpec{},
+				Status: sriovnetworkv1.SriovNetworkNodeStateStatus{
+					Interfaces: []sriovnetworkv1.InterfaceExt{
+						{
+							VFs: []sriovnetworkv1.VirtualFunction{
+								{},
+							},
+							DeviceID:   "158b",
+							Driver:     "i40e",
+							Mtu:        1500,
+							Name:       "ens803f0",
+							PciAddress: "0000:86:00.0",
+							Vendor:     "8086",
+							NumVfs:     4,
+							TotalVfs:   64,
+						},
+					},
+				},
+			}
+			Expect(
+				createSriovNetworkNodeState(sut.sriovClient, nodeState)).
+				To(BeNil())
+
+			var msg Message
+			Eventually(refreshCh, "30s").Should(Receive(&msg))
+			Expect(msg.syncStatus).To(Equal("InProgress"))
+
+			Eventually(refreshCh, "30s").Should(Receive(&msg))
+			Expect(msg.syncStatus).To(Equal("Succeeded"))
+			Expect(deletedPods).To(BeZero())
+
+			podList, err := sut.kubeClient.CoreV1().Pods(vars.Namespace).List(context.Background(), metav1.ListOptions{
+				LabelSelector: "app=sriov-device-plugin",
+				FieldSelector: "spec.nodeName=test-node",
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(podList.Items).To(HaveLen(1))
+		})
+
 		It("ignore non latest SriovNetworkNodeState generations", func() {
 
 			_, err := sut.kubeClient.CoreV1().Nodes().Create(context.Background(), &corev1.Node{
@@ -303,7 +566,7 @@ var _ = Describe("Config Daemon", func() {
 			_, err = sut.kubeClient.CoreV1().Pods(vars.Namespace).Create(context.Background(), otherPod2, metav1.CreateOptions{})
 			Expect(err).ToNot(HaveOccurred())
 
-			err = sut.restartDevicePluginPod()
+			err = sut.restartDevicePluginPod(true)
 			Expect(err).ToNot(HaveOccurred())
 
 			Eventually(func() (int, error) {
@@ -320,18 +583,1129 @@ var _ = Describe("Config Daemon", func() {
 			}, "1s").Should(BeZero())
 		})
 	})
-})
 
-func createSriovNetworkNodeState(c snclient.Interface, nodeState *sriovnetworkv1.SriovNetworkNodeState) error {
-	_, err := c.SriovnetworkV1().
-		SriovNetworkNodeStates(vars.Namespace).
-		Create(context.Background(), nodeState, metav1.CreateOptions{})
-	return err
-}
+	Context("with a custom resync period", func() {
+		BeforeEach(func() {
+			DeferCleanup(func(old time.Duration) { vars.DaemonResyncPeriod = old }, vars.DaemonResyncPeriod)
+			vars.DaemonResyncPeriod = time.Second
+		})
 
-func updateSriovNetworkNodeState(c snclient.Interface, nodeState *sriovnetworkv1.SriovNetworkNodeState) error {
-	_, err := c.SriovnetworkV1().
-		SriovNetworkNodeStates(vars.Namespace).
-		Update(context.Background(), nodeState, metav1.UpdateOptions{})
-	return err
-}
+		It("re-process the node state at the configured period", func() {
+			var nodeStateGets atomic.Int32
+			sut.sriovClient.(*snclientset.Clientset).PrependReactor("get", "sriovnetworknodestates",
+				func(action k8stesting.Action) (bool, runtime.Object, error) {
+					nodeStateGets.Add(1)
+					return false, nil, nil
+				})
+
+			nodeState := &sriovnetworkv1.SriovNetworkNodeState{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "test-node",
+					Generation:  123,
+					Annotations: map[string]string{consts.NodeStateDrainAnnotationCurrent: consts.DrainIdle},
+				},
+				Status: sriovnetworkv1.SriovNetworkNodeStateStatus{
+					SyncStatus: consts.SyncStatusSucceeded,
+				},
+			}
+			Expect(createSriovNetworkNodeState(sut.sriovClient, nodeState)).To(BeNil())
+
+			var msg Message
+			Eventually(refreshCh, "10s").Should(Receive(&msg))
+			Expect(msg.syncStatus).To(Equal("InProgress"))
+			Eventually(refreshCh, "10s").Should(Receive(&msg))
+			Expect(msg.syncStatus).To(Equal("Succeeded"))
+
+			// the node state is not updated anymore, it is only processed again because of the resync
+			processed := nodeStateGets.Load()
+			Eventually(nodeStateGets.Load, "5s", "100ms").Should(BeNumerically(">=", processed+2))
+		})
+	})
+
+	Context("on a node which opted out of reboots", func() {
+		var rebootPlugin *rebootRequiredPlugin
+
+		BeforeEach(func() {
+			node := &corev1.Node{}
+			Expect(sut.client.Get(context.Background(), kclientpkg.ObjectKey{Name: "test-node"}, node)).To(Succeed())
+			node.Annotations = map[string]string{consts.NodeNoRebootAnnotation: "true"}
+			Expect(sut.client.Update(context.Background(), node)).To(Succeed())
+
+			rebootPlugin = &rebootRequiredPlugin{FakePlugin: fake.FakePlugin{PluginName: "fake"}}
+			sut.loadedPlugins = map[string]plugin.VendorPlugin{generic.PluginName: rebootPlugin}
+		})
+
+		It("not reboot the node and report the configuration as degraded", func() {
+			nodeState := &sriovnetworkv1.SriovNetworkNodeState{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "test-node",
+					Generation:  123,
+					Annotations: map[string]string{consts.NodeStateDrainAnnotationCurrent: consts.DrainIdle},
+				},
+				Status: sriovnetworkv1.SriovNetworkNodeStateStatus{
+					SyncStatus: consts.SyncStatusSucceeded,
+				},
+			}
+			Expect(createSriovNetworkNodeState(sut.sriovClient, nodeState)).To(BeNil())
+
+			var msg Message
+			Eventually(refreshCh, "10s").Should(Receive(&msg))
+			Expect(msg.syncStatus).To(Equal("InProgress"))
+			Eventually(refreshCh, "10s").Should(Receive(&msg))
+			Expect(msg.syncStatus).To(Equal("Failed"))
+			Expect(msg.degradedReason).To(Equal(sriovnetworkv1.ReasonRebootBlocked))
+			Expect(msg.lastSyncError).To(ContainSubstring(consts.NodeNoRebootAnnotation))
+
+			Expect(rebootPlugin.applied.Load()).To(BeFalse())
+		})
+
+		It("report the pending kernel arguments before the reboot", func() {
+			sut.loadedPlugins = map[string]plugin.VendorPlugin{generic.PluginName: &kernelArgsPlugin{
+				rebootRequiredPlugin: rebootRequiredPlugin{FakePlugin: fake.FakePlugin{PluginName: "fake"}},
+				pending:              []string{"intel_iommu=on", "iommu=pt"},
+			}}
+			nodeState := &sriovnetworkv1.SriovNetworkNodeState{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "test-node",
+					Generation:  123,
+					Annotations: map[string]string{consts.NodeStateDrainAnnotationCurrent: consts.DrainIdle},
+				},
+				Status: sriovnetworkv1.SriovNetworkNodeStateStatus{
+					SyncStatus: consts.SyncStatusSucceeded,
+				},
+			}
+			Expect(createSriovNetworkNodeState(sut.sriovClient, nodeState)).To(BeNil())
+
+			var msg Message
+			Eventually(refreshCh, "10s").Should(Receive(&msg))
+			Expect(msg.syncStatus).To(Equal("InProgress"))
+			Eventually(refreshCh, "10s").Should(Receive(&msg))
+			Expect(msg.pendingKernelArgs).To(Equal([]string{"intel_iommu=on", "iommu=pt"}))
+			Eventually(refreshCh, "10s").Should(Receive(&msg))
+			Expect(msg.degradedReason).To(Equal(sriovnetworkv1.ReasonRebootBlocked))
+		})
+
+		It("emit an event summarizing the drain decision", func() {
+			nodeState := &sriovnetworkv1.SriovNetworkNodeState{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "test-node",
+					Generation:  123,
+					Annotations: map[string]string{consts.NodeStateDrainAnnotationCurrent: consts.DrainIdle},
+				},
+				Status: sriovnetworkv1.SriovNetworkNodeStateStatus{
+					SyncStatus: consts.SyncStatusSucceeded,
+				},
+			}
+			Expect(createSriovNetworkNodeState(sut.sriovClient, nodeState)).To(BeNil())
+
+			Eventually(refreshCh, "10s").Should(Receive())
+			Eventually(refreshCh, "10s").Should(Receive())
+
+			Eventually(func(g Gomega) {
+				events, err := sut.kubeClient.CoreV1().Events("").List(context.Background(), metav1.ListOptions{})
+				g.Expect(err).ToNot(HaveOccurred())
+				messages := []string{}
+				for _, e := range events.Items {
+					if e.Reason == consts.ReasonDrainDecision {
+						messages = append(messages, e.Message)
+					}
+				}
+				g.Expect(messages).To(ConsistOf("drain-required: true, reboot-required: true, " +
+					"drain-required-by: [generic], reboot-required-by: [generic], disable-drain: false"))
+			}, "10s", "100ms").Should(Succeed())
+		})
+	})
+	Context("with a configuration failing to apply", func() {
+		var failPlugin *failingPlugin
+
+		BeforeEach(func() {
+			origMaxSyncFailures := maxSyncFailures
+			maxSyncFailures = 2
+			DeferCleanup(func() { maxSyncFailures = origMaxSyncFailures })
+
+			failPlugin = &failingPlugin{FakePlugin: fake.FakePlugin{PluginName: "fake"}}
+			failPlugin.fail.Store(true)
+			sut.loadedPlugins = map[string]plugin.VendorPlugin{generic.PluginName: failPlugin}
+		})
+
+		It("stop retrying after too many consecutive failures until the generation changes", func() {
+			nodeState := &sriovnetworkv1.SriovNetworkNodeState{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "test-node",
+					Generation:  123,
+					Annotations: map[string]string{consts.NodeStateDrainAnnotationCurrent: consts.DrainIdle},
+				},
+			}
+			Expect(createSriovNetworkNodeState(sut.sriovClient, nodeState)).To(BeNil())
+
+			var msg Message
+			for i := 0; i < 2; i++ {
+				Eventually(refreshCh, "10s").Should(Receive(&msg))
+				Expect(msg.syncStatus).To(Equal("InProgress"))
+				Eventually(refreshCh, "10s").Should(Receive(&msg))
+				Expect(msg.syncStatus).To(Equal("Failed"))
+				Expect(msg.lastSyncError).To(ContainSubstring("apply failed"))
+			}
+
+			Eventually(refreshCh, "10s").Should(Receive(&msg))
+			Expect(msg.syncStatus).To(Equal("Failed"))
+			Expect(msg.degradedReason).To(Equal(sriovnetworkv1.ReasonMaxRetriesExceeded))
+			Expect(msg.lastSyncError).To(ContainSubstring("apply failed"))
+			Expect(failPlugin.applies.Load()).To(Equal(int32(2)))
+
+			// the generation is not retried anymore
+			Consistently(refreshCh, "3s").ShouldNot(Receive())
+			Expect(failPlugin.applies.Load()).To(Equal(int32(2)))
+
+			failPlugin.fail.Store(false)
+			nodeState.Generation = 124
+			Expect(updateSriovNetworkNodeState(sut.sriovClient, nodeState)).To(BeNil())
+
+			Eventually(refreshCh, "10s").Should(Receive(&msg))
+			Expect(msg.syncStatus).To(Equal("InProgress"))
+			Eventually(refreshCh, "10s").Should(Receive(&msg))
+			Expect(msg.syncStatus).To(Equal("Succeeded"))
+			Expect(failPlugin.applies.Load()).To(Equal(int32(3)))
+		})
+
+		It("retry the configuration right away when an immediate drain is requested", func() {
+			nodeState := &sriovnetworkv1.SriovNetworkNodeState{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "test-node",
+					Generation:  123,
+					Annotations: map[string]string{consts.NodeStateDrainAnnotationCurrent: consts.DrainIdle},
+				},
+			}
+			Expect(createSriovNetworkNodeState(sut.sriovClient, nodeState)).To(BeNil())
+
+			var msg Message
+			for i := 0; i < 2; i++ {
+				Eventually(refreshCh, "10s").Should(Receive(&msg))
+				Eventually(refreshCh, "10s").Should(Receive(&msg))
+			}
+			Eventually(refreshCh, "10s").Should(Receive(&msg))
+			Expect(msg.degradedReason).To(Equal(sriovnetworkv1.ReasonMaxRetriesExceeded))
+			Consistently(refreshCh, "3s").ShouldNot(Receive())
+
+			// the same generation is retried once the drain-now annotation is set
+			failPlugin.fail.Store(false)
+			nodeState.Annotations[consts.NodeStateDrainNowAnnotation] = ""
+			Expect(updateSriovNetworkNodeState(sut.sriovClient, nodeState)).To(BeNil())
+
+			Eventually(refreshCh, "10s").Should(Receive(&msg))
+			Expect(msg.syncStatus).To(Equal("InProgress"))
+			Eventually(refreshCh, "10s").Should(Receive(&msg))
+			Expect(msg.syncStatus).To(Equal("Succeeded"))
+			Expect(failPlugin.applies.Load()).To(Equal(int32(3)))
+		})
+	})
+
+	Context("with a required kernel module not loaded", func() {
+		BeforeEach(func() {
+			hostHelper := sut.HostHelpers.(*mock_helper.MockHostHelpersInterface)
+			hostHelper.EXPECT().IsKernelModuleLoaded("i40e").Return(true, nil).AnyTimes()
+			hostHelper.EXPECT().IsKernelModuleLoaded("vfio_pci").Return(false, nil).AnyTimes()
+		})
+
+		It("report the missing kernel module and the configuration as degraded", func() {
+			nodeState := &sriovnetworkv1.SriovNetworkNodeState{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "test-node",
+					Generation:  123,
+					Annotations: map[string]string{consts.NodeStateDrainAnnotationCurrent: consts.DrainIdle},
+				},
+				Spec: sriovnetworkv1.SriovNetworkNodeStateSpec{
+					Interfaces: sriovnetworkv1.Interfaces{{
+						PciAddress: "0000:d8:00.0",
+						NumVfs:     4,
+						VfGroups: []sriovnetworkv1.VfGroup{{
+							ResourceName: "dpdk",
+							DeviceType:   consts.DeviceTypeVfioPci,
+							VfRange:      "0-3",
+						}},
+					}},
+				},
+				Status: sriovnetworkv1.SriovNetworkNodeStateStatus{
+					Interfaces: sriovnetworkv1.InterfaceExts{{
+						PciAddress: "0000:d8:00.0",
+						Driver:     "i40e",
+					}},
+					SyncStatus: consts.SyncStatusSucceeded,
+				},
+			}
+			Expect(createSriovNetworkNodeState(sut.sriovClient, nodeState)).To(BeNil())
+
+			var msg Message
+			Eventually(refreshCh, "10s").Should(Receive(&msg))
+			Expect(msg.syncStatus).To(Equal("InProgress"))
+			Eventually(refreshCh, "10s").Should(Receive(&msg))
+			Expect(msg.syncStatus).To(Equal("Failed"))
+			Expect(msg.degradedReason).To(Equal(sriovnetworkv1.ReasonKernelModuleMissing))
+			Expect(msg.lastSyncError).To(ContainSubstring("vfio_pci"))
+			Expect(msg.kernelModules).To(Equal(&sriovnetworkv1.KernelModules{
+				Loaded:  []string{"i40e"},
+				Missing: []string{"vfio_pci"},
+			}))
+		})
+	})
+
+	Context("with an uplink of a managed OVS bridge in error state", func() {
+		BeforeEach(func() {
+			sut.loadedPlugins = map[string]plugin.VendorPlugin{generic.PluginName: &ovsErrorPlugin{FakePlugin: fake.FakePlugin{PluginName: "fake"}}}
+		})
+
+		It("report the OVS error and the configuration as degraded", func() {
+			nodeState := &sriovnetworkv1.SriovNetworkNodeState{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "test-node",
+					Generation:  123,
+					Annotations: map[string]string{consts.NodeStateDrainAnnotationCurrent: consts.DrainIdle},
+				},
+			}
+			Expect(createSriovNetworkNodeState(sut.sriovClient, nodeState)).To(BeNil())
+
+			var msg Message
+			Eventually(refreshCh, "10s").Should(Receive(&msg))
+			Expect(msg.syncStatus).To(Equal("InProgress"))
+			Eventually(refreshCh, "10s").Should(Receive(&msg))
+			Expect(msg.syncStatus).To(Equal("Failed"))
+			Expect(msg.degradedReason).To(Equal(sriovnetworkv1.ReasonOVSInterfaceError))
+			Expect(msg.lastSyncError).To(ContainSubstring("could not open network device enp216s0f0np0 (No such device)"))
+
+			Eventually(func(g Gomega) {
+				events, err := sut.kubeClient.CoreV1().Events("").List(context.Background(), metav1.ListOptions{})
+				g.Expect(err).ToNot(HaveOccurred())
+				messages := []string{}
+				for _, e := range events.Items {
+					if e.Reason == sriovnetworkv1.ReasonOVSInterfaceError {
+						messages = append(messages, e.Message)
+					}
+				}
+				g.Expect(messages).To(ContainElement(ContainSubstring("interface enp216s0f0np0 of OVS bridge br-0000_d8_00.0 is in error state")))
+			}, "10s", "100ms").Should(Succeed())
+		})
+	})
+})
+
+// pluginPhaseSampleCount returns the number of durations recorded for the phase of the plugin
+func pluginPhaseSampleCount(pluginName, phase string) uint64 {
+	m := &dto.Metric{}
+	ExpectWithOffset(1, pluginApplyDuration.WithLabelValues(pluginName, phase).(prometheus.Metric).Write(m)).To(Succeed())
+	return m.GetHistogram().GetSampleCount()
+}
+
+// failingPlugin is a fake plugin which fails to apply the configuration while fail is set
+type failingPlugin struct {
+	fake.FakePlugin
+	fail    atomic.Bool
+	applies atomic.Int32
+}
+
+func (p *failingPlugin) Apply() error {
+	p.applies.Add(1)
+	if p.fail.Load() {
+		return errors.New("apply failed")
+	}
+	return nil
+}
+
+// ovsErrorPlugin is a fake plugin which fails to configure a managed OVS bridge with an uplink in error state
+type ovsErrorPlugin struct {
+	fake.FakePlugin
+}
+
+func (p *ovsErrorPlugin) Apply() error {
+	return fmt.Errorf("failed to configure bridges: %w", &hostTypes.OVSInterfaceError{
+		Bridge:    "br-0000_d8_00.0",
+		Interface: "enp216s0f0np0",
+		Message:   "could not open network device enp216s0f0np0 (No such device)",
+	})
+}
+
+// rebootRequiredPlugin is a fake plugin which always requires a reboot to apply the configuration
+type rebootRequiredPlugin struct {
+	fake.FakePlugin
+	applied atomic.Bool
+}
+
+func (p *rebootRequiredPlugin) OnNodeStateChange(new *sriovnetworkv1.SriovNetworkNodeState) (bool, bool, error) {
+	return true, true, nil
+}
+
+func (p *rebootRequiredPlugin) Apply() error {
+	p.applied.Store(true)
+	return nil
+}
+
+// kernelArgsPlugin requires a reboot to apply kernel arguments
+type kernelArgsPlugin struct {
+	rebootRequiredPlugin
+	pending []string
+}
+
+func (p *kernelArgsPlugin) PendingKernelArgs() []string {
+	return p.pending
+}
+
+var _ = Describe("Daemon progress message", func() {
+	It("should write the progress messages to the node state status", func() {
+		vars.NodeName = "test-node"
+		vars.Namespace = "sriov-network-operator"
+		client := snclientset.NewSimpleClientset(&sriovnetworkv1.SriovNetworkNodeState{
+			ObjectMeta: metav1.ObjectMeta{Name: vars.NodeName, Namespace: vars.Namespace},
+		})
+		written := []string{}
+		client.PrependReactor("update", "sriovnetworknodestates", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			if action.GetSubresource() == "status" {
+				ns := action.(k8stesting.UpdateAction).GetObject().(*sriovnetworkv1.SriovNetworkNodeState)
+				written = append(written, ns.Status.ProgressMessage)
+			}
+			return false, nil, nil
+		})
+		dn := &Daemon{sriovClient: client}
+
+		dn.updateProgressMessage("configured 1/2 PFs")
+		dn.updateProgressMessage("configured 2/2 PFs")
+		// unchanged message is not written again
+		dn.updateProgressMessage("configured 2/2 PFs")
+		dn.updateProgressMessage("")
+
+		Expect(written).To(Equal([]string{"configured 1/2 PFs", "configured 2/2 PFs", ""}))
+		ns, err := client.SriovnetworkV1().SriovNetworkNodeStates(vars.Namespace).Get(context.Background(), vars.NodeName, metav1.GetOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ns.Status.ProgressMessage).To(BeEmpty())
+	})
+})
+
+var _ = Describe("Daemon RDMA checks", func() {
+	var (
+		dn         *Daemon
+		hostHelper *mock_helper.MockHostHelpersInterface
+	)
+
+	BeforeEach(func() {
+		helpers.GinkgoConfigureFakeFS(&fakefilesystem.FS{})
+		origSkipRdma := vars.SkipRdma
+		DeferCleanup(func() { vars.SkipRdma = origSkipRdma })
+		// the strict mock fails on any unexpected call of the RDMA host helpers
+		hostHelper = mock_helper.NewMockHostHelpersInterface(gomock.NewController(GinkgoT()))
+		dn = &Daemon{HostHelpers: hostHelper}
+	})
+
+	It("should check RDMA when preparing the host", func() {
+		vars.SkipRdma = false
+		hostHelper.EXPECT().CheckRDMAEnabled().Return(true, nil)
+		hostHelper.EXPECT().TryEnableTun()
+		hostHelper.EXPECT().TryEnableVhostNet()
+		dn.prepareHost()
+	})
+
+	It("should not call the RDMA host helpers when the RDMA checks are skipped", func() {
+		vars.SkipRdma = true
+		hostHelper.EXPECT().TryEnableTun()
+		hostHelper.EXPECT().TryEnableVhostNet()
+		dn.prepareHost()
+
+		By("not reporting a stale RDMA mode in the status")
+		snclient := snclientset.NewSimpleClientset()
+		w := NewNodeStateStatusWriter(snclient, fakek8s.NewSimpleClientset(), nil, NewEventRecorder(snclient, fakek8s.NewSimpleClientset()), hostHelper, nil)
+		w.status.System.RdmaMode = "shared"
+		hostHelper.EXPECT().DiscoverSriovDevices(hostHelper).Return([]sriovnetworkv1.InterfaceExt{}, nil)
+		Expect(w.pollNicStatus()).To(Succeed())
+		Expect(w.status.System.RdmaMode).To(BeEmpty())
+	})
+})
+
+var _ = Describe("Daemon interrupted apply", func() {
+	var (
+		dn             *Daemon
+		hostHelper     *mock_helper.MockHostHelpersInterface
+		checkpointPath string
+	)
+
+	readInProgress := func() *ApplyInProgress {
+		data, err := os.ReadFile(checkpointPath)
+		Expect(err).ToNot(HaveOccurred())
+		cp := &checkpoint{}
+		Expect(json.Unmarshal(data, cp)).To(Succeed())
+		Expect(cp.Status.Interfaces).To(HaveLen(1))
+		return cp.InProgress
+	}
+
+	BeforeEach(func() {
+		origDest := vars.Destdir
+		vars.Destdir = GinkgoT().TempDir()
+		checkpointPath = filepath.Join(vars.Destdir, CheckpointFileName)
+		data, err := json.Marshal(&checkpoint{SriovNetworkNodeState: sriovnetworkv1.SriovNetworkNodeState{
+			Status: sriovnetworkv1.SriovNetworkNodeStateStatus{
+				Interfaces: sriovnetworkv1.InterfaceExts{{Name: "eth0", PciAddress: "0000:d8:00.0", NumVfs: 4}},
+			},
+		}})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(os.WriteFile(checkpointPath, data, 0644)).To(Succeed())
+		DeferCleanup(func() { vars.Destdir = origDest })
+
+		client := snclientset.NewSimpleClientset()
+		er := NewEventRecorder(client, fakek8s.NewSimpleClientset())
+		DeferCleanup(er.Shutdown)
+		hostHelper = mock_helper.NewMockHostHelpersInterface(gomock.NewController(GinkgoT()))
+		dn = &Daemon{HostHelpers: hostHelper, eventRecorder: er}
+	})
+
+	It("should record the PF being configured when terminated during apply", func() {
+		dn.setStepInProgress("apply generic")
+		// the generic plugin reports the PF whose configuration starts
+		dn.setPfInProgress("0000:d8:00.0")
+
+		// SIGTERM received
+		Expect(dn.PersistInProgress()).To(Succeed())
+		Expect(readInProgress()).To(Equal(&ApplyInProgress{Step: "apply generic", PciAddress: "0000:d8:00.0"}))
+
+		By("resetting the PF on the next start")
+		ifaceStatus := sriovnetworkv1.InterfaceExt{Name: "eth0", PciAddress: "0000:d8:00.0", NumVfs: 2}
+		hostHelper.EXPECT().LoadPfsStatus("0000:d8:00.0").Return(&sriovnetworkv1.Interface{PciAddress: "0000:d8:00.0", NumVfs: 4}, true, nil)
+		hostHelper.EXPECT().Chroot(consts.Host).Return(func() error { return nil }, nil)
+		hostHelper.EXPECT().DiscoverSriovDevices(hostHelper).Return([]sriovnetworkv1.InterfaceExt{ifaceStatus}, nil)
+		hostHelper.EXPECT().ResetSriovDevice(ifaceStatus).Return(nil)
+		hostHelper.EXPECT().RemovePfAppliedStatus("0000:d8:00.0").Return(nil)
+
+		Expect(dn.rollbackInterruptedApply()).To(Succeed())
+		Expect(readInProgress()).To(BeNil())
+	})
+
+	It("should not record anything when terminated outside of apply", func() {
+		dn.setStepInProgress("apply generic")
+		dn.setPfInProgress("0000:d8:00.0")
+		dn.setPfInProgress("")
+		dn.setStepInProgress("")
+
+		Expect(dn.PersistInProgress()).To(Succeed())
+		Expect(readInProgress()).To(BeNil())
+
+		// nothing to roll back on the next start
+		Expect(dn.rollbackInterruptedApply()).To(Succeed())
+	})
+})
+
+var _ = Describe("Daemon drain timeout", func() {
+	var (
+		dn         *Daemon
+		fakeClock  *testingclock.FakeClock
+		refreshCh  chan Message
+		kubeClient *fakek8s.Clientset
+	)
+
+	setCurrentDrainState := func(state string) {
+		dn.desiredNodeState.Annotations = map[string]string{
+			consts.NodeStateDrainAnnotationCurrent:   state,
+			consts.NodeStateDrainStartTimeAnnotation: fakeClock.Now().Format(time.RFC3339),
+		}
+	}
+
+	BeforeEach(func() {
+		Expect(sriovnetworkv1.AddToScheme(scheme.Scheme)).To(Succeed())
+		vars.NodeName = "test-node"
+		vars.Namespace = "sriov-network-operator"
+		nodeState := &sriovnetworkv1.SriovNetworkNodeState{
+			ObjectMeta: metav1.ObjectMeta{Name: vars.NodeName, Namespace: vars.Namespace},
+			Spec: sriovnetworkv1.SriovNetworkNodeStateSpec{
+				System: sriovnetworkv1.System{DrainTimeoutSeconds: 60},
+			},
+		}
+		client := snclientset.NewSimpleClientset(nodeState)
+		kubeClient = fakek8s.NewSimpleClientset()
+		er := NewEventRecorder(client, kubeClient)
+		DeferCleanup(er.Shutdown)
+
+		refreshCh = make(chan Message, 10)
+		syncCh := make(chan struct{}, 10)
+		for i := 0; i < 10; i++ {
+			syncCh <- struct{}{}
+		}
+		fakeClock = testingclock.NewFakeClock(time.Now())
+		dn = &Daemon{
+			sriovClient:      client,
+			desiredNodeState: nodeState.DeepCopy(),
+			refreshCh:        refreshCh,
+			syncCh:           syncCh,
+			eventRecorder:    er,
+			clock:            fakeClock,
+		}
+	})
+
+	It("should report the sync status as failed when the drain timeout is exceeded", func() {
+		setCurrentDrainState(consts.Draining)
+		Expect(dn.handleDrain(false)).To(BeTrue())
+		fakeClock.Step(30 * time.Second)
+		Expect(dn.handleDrain(false)).To(BeTrue())
+		Expect(refreshCh).To(BeEmpty())
+
+		fakeClock.Step(31 * time.Second)
+		Expect(dn.handleDrain(false)).To(BeTrue())
+		Expect(refreshCh).To(HaveLen(1))
+		msg := <-refreshCh
+		Expect(msg.syncStatus).To(Equal(consts.SyncStatusFailed))
+		Expect(msg.lastSyncError).To(ContainSubstring(consts.ReasonDrainTimeout))
+
+		// the failure is reported only once
+		fakeClock.Step(60 * time.Second)
+		Expect(dn.handleDrain(false)).To(BeTrue())
+		Expect(refreshCh).To(BeEmpty())
+
+		Eventually(func() []string {
+			events, err := kubeClient.CoreV1().Events("").List(context.Background(), metav1.ListOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			reasons := []string{}
+			for _, e := range events.Items {
+				reasons = append(reasons, e.Reason)
+			}
+			return reasons
+		}, "5s").Should(ContainElement(consts.ReasonDrainTimeout))
+	})
+
+	It("should keep the drain timer across the restarts of the daemon", func() {
+		dn.desiredNodeState.Annotations = map[string]string{
+			consts.NodeStateDrainAnnotationCurrent:   consts.Draining,
+			consts.NodeStateDrainStartTimeAnnotation: fakeClock.Now().Add(-61 * time.Second).Format(time.RFC3339),
+		}
+		Expect(dn.handleDrain(false)).To(BeTrue())
+		Expect(refreshCh).To(HaveLen(1))
+		msg := <-refreshCh
+		Expect(msg.lastSyncError).To(ContainSubstring(consts.ReasonDrainTimeout))
+	})
+
+	It("should reset the drain timer when the drain completes", func() {
+		setCurrentDrainState(consts.Draining)
+		Expect(dn.handleDrain(false)).To(BeTrue())
+		fakeClock.Step(61 * time.Second)
+		Expect(dn.handleDrain(false)).To(BeTrue())
+		Expect(dn.drainTimeoutError).ToNot(BeEmpty())
+
+		setCurrentDrainState(consts.DrainComplete)
+		Expect(dn.handleDrain(false)).To(BeFalse())
+		Expect(dn.drainTimeoutError).To(BeEmpty())
+
+		<-refreshCh
+		setCurrentDrainState(consts.Draining)
+		Expect(dn.handleDrain(false)).To(BeTrue())
+		fakeClock.Step(30 * time.Second)
+		Expect(dn.handleDrain(false)).To(BeTrue())
+		Expect(refreshCh).To(BeEmpty())
+	})
+
+	It("should not time out while an immediate drain is requested", func() {
+		setCurrentDrainState(consts.Draining)
+		dn.desiredNodeState.Annotations[consts.NodeStateDrainNowAnnotation] = ""
+		Expect(dn.handleDrain(false)).To(BeTrue())
+		fakeClock.Step(61 * time.Second)
+		Expect(dn.handleDrain(false)).To(BeTrue())
+		Expect(refreshCh).To(BeEmpty())
+		Expect(dn.drainTimeoutError).To(BeEmpty())
+	})
+
+	It("should not time out when no drain timeout is configured", func() {
+		dn.desiredNodeState.Spec.System.DrainTimeoutSeconds = 0
+		setCurrentDrainState(consts.Draining)
+		Expect(dn.handleDrain(false)).To(BeTrue())
+		fakeClock.Step(time.Hour)
+		Expect(dn.handleDrain(false)).To(BeTrue())
+		Expect(refreshCh).To(BeEmpty())
+	})
+})
+
+var _ = Describe("Daemon drain-now request", func() {
+	var (
+		dn        *Daemon
+		nodeState *sriovnetworkv1.SriovNetworkNodeState
+	)
+
+	BeforeEach(func() {
+		Expect(sriovnetworkv1.AddToScheme(scheme.Scheme)).To(Succeed())
+		vars.NodeName = "test-node"
+		vars.Namespace = "sriov-network-operator"
+		nodeState = &sriovnetworkv1.SriovNetworkNodeState{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       vars.NodeName,
+				Namespace:  vars.Namespace,
+				Generation: 2,
+				Annotations: map[string]string{
+					consts.NodeStateDrainAnnotationCurrent: consts.DrainIdle,
+					consts.NodeStateDrainNowAnnotation:     "",
+				},
+			},
+		}
+		dn = &Daemon{
+			client: kclient.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(nodeState.DeepCopy()).Build(),
+			workqueue: workqueue.NewRateLimitingQueue(
+				workqueue.NewItemExponentialFailureRateLimiter(time.Second, maxUpdateBackoff)),
+		}
+		DeferCleanup(dn.workqueue.ShutDown)
+	})
+
+	It("should sync the node state right away instead of waiting for the backoff of the failed syncs", func() {
+		key := nodeState.GetGeneration()
+		// the previous drain attempts failed, the next retry is scheduled in more than 30 seconds
+		for i := 0; i < 5; i++ {
+			dn.workqueue.AddRateLimited(key)
+		}
+		Expect(dn.workqueue.Len()).To(BeZero())
+
+		withoutAnnotation := nodeState.DeepCopy()
+		delete(withoutAnnotation.Annotations, consts.NodeStateDrainNowAnnotation)
+		dn.enqueueNodeState(withoutAnnotation)
+		Expect(dn.workqueue.NumRequeues(key)).To(Equal(5))
+		item, _ := dn.workqueue.Get()
+		dn.workqueue.Done(item)
+
+		dn.enqueueNodeState(nodeState)
+		Expect(dn.workqueue.NumRequeues(key)).To(BeZero())
+		Expect(dn.workqueue.Len()).To(Equal(1))
+
+		// a new failure is retried after the initial backoff
+		item, _ = dn.workqueue.Get()
+		dn.workqueue.AddRateLimited(item)
+		dn.workqueue.Done(item)
+		Eventually(dn.workqueue.Len, "2s", "100ms").Should(Equal(1))
+	})
+
+	It("should remove the annotation once the sync handled it", func() {
+		dn.clearDrainNowRequest(nodeState)
+
+		updated := &sriovnetworkv1.SriovNetworkNodeState{}
+		Expect(dn.client.Get(context.Background(), kclientpkg.ObjectKeyFromObject(nodeState), updated)).To(Succeed())
+		Expect(updated.Annotations).ToNot(HaveKey(consts.NodeStateDrainNowAnnotation))
+		Expect(updated.Annotations).To(HaveKeyWithValue(consts.NodeStateDrainAnnotationCurrent, consts.DrainIdle))
+	})
+})
+
+var _ = Describe("Daemon forced systemd reapply", func() {
+	var (
+		dn        *Daemon
+		nodeState *sriovnetworkv1.SriovNetworkNodeState
+	)
+
+	BeforeEach(func() {
+		helpers.GinkgoConfigureFakeFS(&fakefilesystem.FS{
+			Dirs: []string{"/host/etc/sriov-operator"},
+		})
+		origUsingSystemdMode := vars.UsingSystemdMode
+		DeferCleanup(func() { vars.UsingSystemdMode = origUsingSystemdMode })
+		vars.UsingSystemdMode = true
+
+		Expect(sriovnetworkv1.AddToScheme(scheme.Scheme)).To(Succeed())
+		vars.NodeName = "test-node"
+		vars.Namespace = "sriov-network-operator"
+		nodeState = &sriovnetworkv1.SriovNetworkNodeState{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       vars.NodeName,
+				Namespace:  vars.Namespace,
+				Generation: 2,
+				Annotations: map[string]string{
+					consts.NodeStateDrainAnnotationCurrent: consts.DrainIdle,
+				},
+			},
+		}
+		dn = &Daemon{
+			client:           kclient.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(nodeState.DeepCopy()).Build(),
+			desiredNodeState: nodeState.DeepCopy(),
+		}
+
+		// the configuration was already applied by the sriov-config service
+		_, err := dn.writeSystemdConfigFile()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(os.WriteFile(utils.GetHostExtensionPath(systemd.SriovSystemdResultPath), []byte("syncStatus: Succeeded\n"), 0644)).To(Succeed())
+	})
+
+	It("should keep the result file when the configuration didn't change", func() {
+		modified, err := dn.writeSystemdConfigFile()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(modified).To(BeFalse())
+		Expect(utils.GetHostExtensionPath(systemd.SriovSystemdResultPath)).To(BeAnExistingFile())
+	})
+
+	It("should remove the result file and require a reapply when the annotation is set", func() {
+		dn.desiredNodeState.Annotations[consts.NodeStateForceSystemdReapplyAnnotation] = ""
+		Expect(dn.isSystemdReapplyForced()).To(BeTrue())
+
+		modified, err := dn.writeSystemdConfigFile()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(modified).To(BeTrue())
+		Expect(utils.GetHostExtensionPath(systemd.SriovSystemdResultPath)).ToNot(BeAnExistingFile())
+	})
+
+	It("should ignore the annotation in daemon mode", func() {
+		vars.UsingSystemdMode = false
+		dn.desiredNodeState.Annotations[consts.NodeStateForceSystemdReapplyAnnotation] = ""
+		Expect(dn.isSystemdReapplyForced()).To(BeFalse())
+	})
+
+	It("should remove the annotation before the reboot", func() {
+		withAnnotation := &sriovnetworkv1.SriovNetworkNodeState{}
+		Expect(dn.client.Get(context.Background(), kclientpkg.ObjectKeyFromObject(nodeState), withAnnotation)).To(Succeed())
+		withAnnotation.Annotations[consts.NodeStateForceSystemdReapplyAnnotation] = ""
+		Expect(dn.client.Update(context.Background(), withAnnotation)).To(Succeed())
+
+		dn.clearSystemdReapplyRequest(withAnnotation)
+
+		updated := &sriovnetworkv1.SriovNetworkNodeState{}
+		Expect(dn.client.Get(context.Background(), kclientpkg.ObjectKeyFromObject(nodeState), updated)).To(Succeed())
+		Expect(updated.Annotations).ToNot(HaveKey(consts.NodeStateForceSystemdReapplyAnnotation))
+		Expect(updated.Annotations).To(HaveKeyWithValue(consts.NodeStateDrainAnnotationCurrent, consts.DrainIdle))
+	})
+})
+
+var _ = Describe("Daemon number of VFs conflict", func() {
+	var (
+		dn        *Daemon
+		fakeClock *testingclock.FakeClock
+		refreshCh chan Message
+		drifted   *sriovnetworkv1.SriovNetworkNodeState
+	)
+
+	BeforeEach(func() {
+		vars.NodeName = "test-node"
+		vars.Namespace = "sriov-network-operator"
+		nodeState := &sriovnetworkv1.SriovNetworkNodeState{
+			ObjectMeta: metav1.ObjectMeta{Name: vars.NodeName, Namespace: vars.Namespace, Generation: 2},
+			Spec: sriovnetworkv1.SriovNetworkNodeStateSpec{
+				Interfaces: sriovnetworkv1.Interfaces{
+					{PciAddress: "0000:d8:00.0", NumVfs: 4},
+					{PciAddress: "0000:d8:00.1", NumVfs: 2, ExternallyManaged: true},
+				},
+			},
+		}
+		client := snclientset.NewSimpleClientset(nodeState)
+		er := NewEventRecorder(client, fakek8s.NewSimpleClientset())
+		DeferCleanup(er.Shutdown)
+
+		refreshCh = make(chan Message, 10)
+		syncCh := make(chan struct{}, 10)
+		for i := 0; i < 10; i++ {
+			syncCh <- struct{}{}
+		}
+		fakeClock = testingclock.NewFakeClock(time.Now())
+		dn = &Daemon{
+			sriovClient:      client,
+			currentNodeState: nodeState.DeepCopy(),
+			refreshCh:        refreshCh,
+			syncCh:           syncCh,
+			eventRecorder:    er,
+			clock:            fakeClock,
+			numVfsRestores:   map[string][]time.Time{},
+		}
+
+		// an external actor removes the VFs after every sync
+		drifted = nodeState.DeepCopy()
+		drifted.Status.Interfaces = sriovnetworkv1.InterfaceExts{
+			{PciAddress: "0000:d8:00.0", NumVfs: 0},
+			{PciAddress: "0000:d8:00.1", NumVfs: 0},
+		}
+	})
+
+	It("should stop configuring the PF and report the conflict after repeated external changes", func() {
+		for i := 0; i < numVfsConflictThreshold; i++ {
+			Expect(dn.checkNumVfsConflict(drifted)).To(BeFalse())
+			fakeClock.Step(time.Minute)
+		}
+		Expect(refreshCh).To(BeEmpty())
+
+		Expect(dn.checkNumVfsConflict(drifted)).To(BeTrue())
+		Expect(refreshCh).To(HaveLen(1))
+		msg := <-refreshCh
+		Expect(msg.syncStatus).To(Equal(consts.SyncStatusFailed))
+		Expect(msg.degradedReason).To(Equal(sriovnetworkv1.ReasonExternalConflict))
+		Expect(msg.lastSyncError).To(ContainSubstring(sriovnetworkv1.ReasonExternalConflict))
+		Expect(msg.lastSyncError).To(ContainSubstring("0000:d8:00.0"))
+
+		// the daemon keeps skipping the configuration even if the PF is not changed anymore
+		Expect(dn.checkNumVfsConflict(dn.currentNodeState)).To(BeTrue())
+		Expect((<-refreshCh).degradedReason).To(Equal(sriovnetworkv1.ReasonExternalConflict))
+
+		dn.resetNumVfsConflict()
+		Expect(dn.checkNumVfsConflict(drifted)).To(BeFalse())
+		Expect(refreshCh).To(BeEmpty())
+	})
+
+	It("should not report a conflict for changes spread over a long period", func() {
+		for i := 0; i < 2*numVfsConflictThreshold; i++ {
+			Expect(dn.checkNumVfsConflict(drifted)).To(BeFalse())
+			fakeClock.Step(numVfsConflictWindow / 2)
+		}
+		Expect(refreshCh).To(BeEmpty())
+	})
+})
+
+func createSriovNetworkNodeState(c snclient.Interface, nodeState *sriovnetworkv1.SriovNetworkNodeState) error {
+	_, err := c.SriovnetworkV1().
+		SriovNetworkNodeStates(vars.Namespace).
+		Create(context.Background(), nodeState, metav1.CreateOptions{})
+	return err
+}
+
+func updateSriovNetworkNodeState(c snclient.Interface, nodeState *sriovnetworkv1.SriovNetworkNodeState) error {
+	_, err := c.SriovnetworkV1().
+		SriovNetworkNodeStates(vars.Namespace).
+		Update(context.Background(), nodeState, metav1.UpdateOptions{})
+	return err
+}
+
+var _ = Describe("Daemon external drain", func() {
+	var (
+		dn   *Daemon
+		node *corev1.Node
+	)
+
+	BeforeEach(func() {
+		Expect(sriovnetworkv1.AddToScheme(scheme.Scheme)).To(Succeed())
+		vars.NodeName = "test-node"
+		vars.Namespace = "sriov-network-operator"
+		nodeState := &sriovnetworkv1.SriovNetworkNodeState{
+			ObjectMeta: metav1.ObjectMeta{Name: vars.NodeName, Namespace: vars.Namespace},
+		}
+		// the node is cordoned by a simulated upgrade controller
+		node = &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: vars.NodeName},
+			Spec: corev1.NodeSpec{
+				Unschedulable: true,
+				Taints:        []corev1.Taint{{Key: corev1.TaintNodeUnschedulable, Effect: corev1.TaintEffectNoSchedule}},
+			},
+		}
+		dn = &Daemon{
+			client:           kclient.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(node, nodeState).Build(),
+			desiredNodeState: nodeState.DeepCopy(),
+		}
+	})
+
+	getNodeDrainAnnotation := func() string {
+		n := &corev1.Node{}
+		Expect(dn.client.Get(context.Background(), kclientpkg.ObjectKey{Name: vars.NodeName}, n)).To(Succeed())
+		return n.Annotations[consts.NodeDrainAnnotation]
+	}
+
+	It("should defer the drain while the node is cordoned by another controller", func() {
+		Expect(dn.handleDrain(false)).To(BeTrue())
+		Expect(getNodeDrainAnnotation()).To(BeEmpty())
+
+		// the external controller releases the node
+		Expect(dn.client.Get(context.Background(), kclientpkg.ObjectKey{Name: vars.NodeName}, node)).To(Succeed())
+		node.Spec.Unschedulable = false
+		node.Spec.Taints = nil
+		Expect(dn.client.Update(context.Background(), node)).To(Succeed())
+
+		Expect(dn.handleDrain(false)).To(BeTrue())
+		Expect(getNodeDrainAnnotation()).To(Equal(consts.DrainRequired))
+	})
+
+	It("should not defer the drain when an immediate drain is requested", func() {
+		dn.desiredNodeState.Annotations = map[string]string{consts.NodeStateDrainNowAnnotation: ""}
+		Expect(dn.handleDrain(false)).To(BeTrue())
+		Expect(getNodeDrainAnnotation()).To(Equal(consts.DrainRequired))
+	})
+
+	It("should not defer a drain already requested by the daemon", func() {
+		Expect(dn.client.Get(context.Background(), kclientpkg.ObjectKey{Name: vars.NodeName}, node)).To(Succeed())
+		node.Annotations = map[string]string{consts.NodeDrainAnnotation: consts.RebootRequired}
+		Expect(dn.client.Update(context.Background(), node)).To(Succeed())
+
+		Expect(dn.handleDrain(true)).To(BeTrue())
+		nodeState := &sriovnetworkv1.SriovNetworkNodeState{}
+		Expect(dn.client.Get(context.Background(), kclientpkg.ObjectKey{Name: vars.NodeName, Namespace: vars.Namespace}, nodeState)).To(Succeed())
+		Expect(nodeState.Annotations).To(HaveKeyWithValue(consts.NodeStateDrainAnnotation, consts.RebootRequired))
+	})
+})
+
+var _ = Describe("Daemon link events", func() {
+	var (
+		dn          *Daemon
+		hostHelper  *mock_helper.MockHostHelpersInterface
+		featureGate featuregate.FeatureGate
+		links       chan string
+		done        <-chan struct{}
+	)
+
+	BeforeEach(func() {
+		hostHelper = mock_helper.NewMockHostHelpersInterface(gomock.NewController(GinkgoT()))
+		featureGate = featuregate.New()
+		featureGate.Init(map[string]bool{consts.LinkEventsRefreshFeatureGate: true})
+		dn = &Daemon{
+			HostHelpers: hostHelper,
+			featureGate: featureGate,
+			workqueue: workqueue.NewRateLimitingQueue(
+				workqueue.NewItemExponentialFailureRateLimiter(time.Second, maxUpdateBackoff)),
+		}
+		DeferCleanup(dn.workqueue.ShutDown)
+
+		dn.setManagedLinks(&sriovnetworkv1.SriovNetworkNodeState{
+			ObjectMeta: metav1.ObjectMeta{Generation: 3},
+			Spec: sriovnetworkv1.SriovNetworkNodeStateSpec{
+				Interfaces: sriovnetworkv1.Interfaces{{PciAddress: "0000:d8:00.0", NumVfs: 1}},
+			},
+			Status: sriovnetworkv1.SriovNetworkNodeStateStatus{
+				Interfaces: sriovnetworkv1.InterfaceExts{{
+					PciAddress: "0000:d8:00.0",
+					Name:       "enp216s0f0np0",
+					VFs:        []sriovnetworkv1.VirtualFunction{{PciAddress: "0000:d8:00.2", Name: "enp216s0f0v0"}},
+				}, {
+					PciAddress: "0000:d8:00.1",
+					Name:       "enp216s0f1np1",
+				}},
+			},
+		})
+
+		links = make(chan string)
+		hostHelper.EXPECT().SubscribeLinkUpdates(gomock.Any()).DoAndReturn(func(stopCh <-chan struct{}) (<-chan string, error) {
+			done = stopCh
+			return links, nil
+		})
+		dn.updateLinkWatcher()
+		DeferCleanup(func() { close(links) })
+	})
+
+	It("should requeue the node state when a link of a configured VF changes", func() {
+		links <- "enp216s0f0v0"
+		Eventually(dn.workqueue.Len).Should(Equal(1))
+		Expect(dn.linkChanged.Load()).To(BeTrue())
+		item, _ := dn.workqueue.Get()
+		Expect(item).To(Equal(int64(3)))
+	})
+
+	It("should ignore the links not configured by the node state", func() {
+		links <- "veth1234"
+		links <- "enp216s0f1np1"
+		Consistently(dn.workqueue.Len, "200ms").Should(BeZero())
+		Expect(dn.linkChanged.Load()).To(BeFalse())
+	})
+
+	It("should stop watching the link events when the feature gate is disabled", func() {
+		dn.updateLinkWatcher()
+		Expect(done).ToNot(BeClosed())
+
+		featureGate.Init(map[string]bool{consts.LinkEventsRefreshFeatureGate: false})
+		dn.updateLinkWatcher()
+		Expect(done).To(BeClosed())
+		Expect(dn.linkWatcherStopCh).To(BeNil())
+	})
+})
+
+var _ = Describe("Daemon configuration mode", func() {
+	var (
+		dn         *Daemon
+		hostHelper *mock_helper.MockHostHelpersInterface
+	)
+
+	BeforeEach(func() {
+		helpers.GinkgoConfigureFakeFS(&fakefilesystem.FS{})
+		origUsingSystemdMode, origSkipRdma, origClusterType := vars.UsingSystemdMode, vars.SkipRdma, vars.ClusterType
+		DeferCleanup(func() {
+			vars.UsingSystemdMode, vars.SkipRdma, vars.ClusterType = origUsingSystemdMode, origSkipRdma, origClusterType
+		})
+		vars.UsingSystemdMode = false
+		vars.SkipRdma = true
+		vars.ClusterType = consts.ClusterTypeKubernetes
+		hostHelper = mock_helper.NewMockHostHelpersInterface(gomock.NewController(GinkgoT()))
+		dn = &Daemon{
+			HostHelpers:      hostHelper,
+			desiredNodeState: &sriovnetworkv1.SriovNetworkNodeState{},
+			loadedPlugins:    map[string]plugin.VendorPlugin{generic.PluginName: &fake.FakePlugin{PluginName: "fake"}},
+		}
+	})
+
+	It("should use the systemd mode on the nodes of a pool forcing it", func() {
+		dn.desiredNodeState.Spec.System.ConfigurationMode = sriovnetworkv1.SystemdConfigurationMode
+		Expect(dn.updateConfigurationMode()).To(Succeed())
+		Expect(vars.UsingSystemdMode).To(BeTrue())
+		// the plugins are reloaded to install the systemd services
+		Expect(dn.loadedPlugins).To(BeEmpty())
+
+		By("going back to the daemon default when the pool doesn't override the mode anymore")
+		dn.loadedPlugins = map[string]plugin.VendorPlugin{generic.PluginName: &fake.FakePlugin{PluginName: "fake"}}
+		dn.desiredNodeState.Spec.System.ConfigurationMode = ""
+		hostHelper.EXPECT().TryEnableTun()
+		hostHelper.EXPECT().TryEnableVhostNet()
+		Expect(dn.updateConfigurationMode()).To(Succeed())
+		Expect(vars.UsingSystemdMode).To(BeFalse())
+		Expect(dn.loadedPlugins).To(BeEmpty())
+	})
+
+	It("should use the daemon mode on the nodes of a pool forcing it", func() {
+		dn.defaultUsingSystemdMode = true
+		vars.UsingSystemdMode = true
+
+		dn.desiredNodeState.Spec.System.ConfigurationMode = sriovnetworkv1.DaemonConfigurationMode
+		hostHelper.EXPECT().TryEnableTun()
+		hostHelper.EXPECT().TryEnableVhostNet()
+		Expect(dn.updateConfigurationMode()).To(Succeed())
+		Expect(vars.UsingSystemdMode).To(BeFalse())
+		Expect(dn.loadedPlugins).To(BeEmpty())
+
+		By("not preparing the host again nor reloading the plugins when the mode doesn't change")
+		dn.loadedPlugins = map[string]plugin.VendorPlugin{generic.PluginName: &fake.FakePlugin{PluginName: "fake"}}
+		Expect(dn.updateConfigurationMode()).To(Succeed())
+		Expect(vars.UsingSystemdMode).To(BeFalse())
+		Expect(dn.loadedPlugins).To(HaveLen(1))
+	})
+
+	It("should not switch to the systemd mode on openshift while the systemd services are missing", func() {
+		vars.ClusterType = consts.ClusterTypeOpenshift
+		dn.desiredNodeState.Spec.System.ConfigurationMode = sriovnetworkv1.SystemdConfigurationMode
+		hostHelper.EXPECT().IsServiceEnabled(systemd.SriovServicePath).Return(true, nil).Times(2)
+		hostHelper.EXPECT().IsServiceEnabled(systemd.SriovPostNetworkServicePath).Return(false, nil)
+		Expect(dn.updateConfigurationMode()).To(MatchError(ContainSubstring("sriov-config-post-network available:false")))
+		Expect(vars.UsingSystemdMode).To(BeFalse())
+		Expect(dn.loadedPlugins).To(HaveLen(1))
+
+		By("switching once the machine config deployed the services")
+		hostHelper.EXPECT().IsServiceEnabled(systemd.SriovPostNetworkServicePath).Return(true, nil)
+		Expect(dn.updateConfigurationMode()).To(Succeed())
+		Expect(vars.UsingSystemdMode).To(BeTrue())
+		Expect(dn.loadedPlugins).To(BeEmpty())
+	})
+})
+
+var _ = Describe("Daemon feature gates", func() {
+	It("should report the feature gates configured in the SriovOperatorConfig", func() {
+		vars.NodeName = "test-node"
+		vars.Namespace = "sriov-network-operator"
+		origMlxPluginFwReset := vars.MlxPluginFwReset
+		origVfioHugepagesAdvisory := vars.VfioHugepagesAdvisory
+		origReportVfConsumerPods := vars.ReportVfConsumerPods
+		origSkipRdma := vars.SkipRdma
+		DeferCleanup(func() {
+			vars.MlxPluginFwReset = origMlxPluginFwReset
+			vars.VfioHugepagesAdvisory = origVfioHugepagesAdvisory
+			vars.ReportVfConsumerPods = origReportVfConsumerPods
+			vars.SkipRdma = origSkipRdma
+		})
+		snclient := snclientset.NewSimpleClientset(&sriovnetworkv1.SriovNetworkNodeState{
+			ObjectMeta: metav1.ObjectMeta{Name: vars.NodeName, Namespace: vars.Namespace},
+		})
+		dn := &Daemon{featureGate: featuregate.New()}
+		w := NewNodeStateStatusWriter(snclient, fakek8s.NewSimpleClientset(), nil, NewEventRecorder(snclient, fakek8s.NewSimpleClientset()), nil, nil)
+		w.FeatureGate = dn.featureGate
+
+		ns, err := w.setNodeStateStatus(Message{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ns.Status.FeatureGates).To(BeNil())
+
+		cfg := &sriovnetworkv1.SriovOperatorConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: consts.DefaultConfigName, Namespace: vars.Namespace},
+			Spec: sriovnetworkv1.SriovOperatorConfigSpec{
+				FeatureGates: map[string]bool{
+					consts.ParallelNicConfigFeatureGate:     true,
+					consts.ManageSoftwareBridgesFeatureGate: false,
+				},
+			},
+		}
+		dn.operatorConfigAddHandler(cfg)
+		ns, err = w.setNodeStateStatus(Message{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ns.Status.FeatureGates).To(Equal(cfg.Spec.FeatureGates))
+
+		newCfg := cfg.DeepCopy()
+		newCfg.Spec.FeatureGates = map[string]bool{consts.ParallelNicConfigFeatureGate: false}
+		dn.operatorConfigChangeHandler(cfg, newCfg)
+		ns, err = w.setNodeStateStatus(Message{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ns.Status.FeatureGates).To(Equal(map[string]bool{consts.ParallelNicConfigFeatureGate: false}))
+	})
+})
@@ -0,0 +1,77 @@
+package daemon
+
+import (
+	"slices"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	sriovnetworkv1 "github.com/k8snetworkplumbingwg/sriov-network-operator/api/v1"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/consts"
+)
+
+// updateLinkWatcher starts or stops the watcher of the link events according to the LinkEventsRefreshFeatureGate
+func (dn *Daemon) updateLinkWatcher() {
+	enabled := dn.featureGate.IsEnabled(consts.LinkEventsRefreshFeatureGate)
+	if enabled == (dn.linkWatcherStopCh != nil) {
+		return
+	}
+	if !enabled {
+		log.Log.Info("updateLinkWatcher(): stop watching the link events")
+		close(dn.linkWatcherStopCh)
+		dn.linkWatcherStopCh = nil
+		return
+	}
+
+	stopCh := make(chan struct{})
+	links, err := dn.HostHelpers.SubscribeLinkUpdates(stopCh)
+	if err != nil {
+		log.Log.Error(err, "updateLinkWatcher(): failed to watch the link events, the node state is only refreshed periodically")
+		return
+	}
+	log.Log.Info("updateLinkWatcher(): start watching the link events")
+	dn.linkWatcherStopCh = stopCh
+	go func() {
+		for name := range links {
+			dn.onLinkUpdate(name)
+		}
+	}()
+}
+
+// onLinkUpdate requeues the latest node state when the link of a configured PF or of one of its VFs changed,
+// so the drift is detected without waiting for the next resync
+func (dn *Daemon) onLinkUpdate(name string) {
+	dn.managedLinksMu.Lock()
+	managed, generation := dn.managedLinks[name], dn.managedLinksGeneration
+	dn.managedLinksMu.Unlock()
+	if !managed {
+		return
+	}
+	log.Log.V(2).Info("onLinkUpdate(): managed link changed, requeue the node state", "link", name, "generation", generation)
+	dn.linkChanged.Store(true)
+	dn.workqueue.Add(generation)
+}
+
+// setManagedLinks records the links of the PFs configured by the node state and of their VFs
+func (dn *Daemon) setManagedLinks(ns *sriovnetworkv1.SriovNetworkNodeState) {
+	links := map[string]bool{}
+	for _, ifaceStatus := range ns.Status.Interfaces {
+		if !slices.ContainsFunc(ns.Spec.Interfaces, func(iface sriovnetworkv1.Interface) bool {
+			return iface.PciAddress == ifaceStatus.PciAddress
+		}) {
+			continue
+		}
+		if ifaceStatus.Name != "" {
+			links[ifaceStatus.Name] = true
+		}
+		for _, vf := range ifaceStatus.VFs {
+			if vf.Name != "" {
+				links[vf.Name] = true
+			}
+		}
+	}
+
+	dn.managedLinksMu.Lock()
+	defer dn.managedLinksMu.Unlock()
+	dn.managedLinks = links
+	dn.managedLinksGeneration = ns.GetGeneration()
+}
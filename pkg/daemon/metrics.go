@@ -0,0 +1,137 @@
+package daemon
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	sriovnetworkv1 "github.com/k8snetworkplumbingwg/sriov-network-operator/api/v1"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/helper"
+)
+
+// phases of the plugins reported in the plugin duration metric
+const (
+	pluginPhaseOnNodeStateChange  = "OnNodeStateChange"
+	pluginPhaseApply              = "Apply"
+	pluginPhaseCheckStatusChanges = "CheckStatusChanges"
+)
+
+// pluginApplyDuration tracks how long each phase of the plugins takes, to find which plugin dominates the apply time
+var pluginApplyDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+	Name:    "sriov_plugin_apply_duration_seconds",
+	Help:    "Duration of the execution of the config daemon plugins by plugin name and phase",
+	Buckets: prometheus.ExponentialBuckets(0.001, 4, 10),
+}, []string{"plugin", "phase"})
+
+// nodeVfsConfigured and nodeVfsTotal report the VF capacity of the PFs of the node state status,
+// for the dashboards to show the VF utilization
+var (
+	nodeVfsConfigured = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "sriov_node_vfs_configured",
+		Help: "Number of VFs configured on the PF",
+	}, []string{"node", "pf", "pci_address"})
+	nodeVfsTotal = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "sriov_node_vfs_total",
+		Help: "Maximum number of VFs supported by the PF",
+	}, []string{"node", "pf", "pci_address"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(pluginApplyDuration, nodeVfsConfigured, nodeVfsTotal)
+}
+
+// recordVfCapacity sets the VF capacity metrics to the PFs of the interfaces reported in the node state status,
+// the metrics of the PFs which are not reported anymore are removed
+func recordVfCapacity(nodeName string, interfaces sriovnetworkv1.InterfaceExts) {
+	nodeVfsConfigured.Reset()
+	nodeVfsTotal.Reset()
+	for _, iface := range interfaces {
+		if iface.TotalVfs == 0 {
+			continue
+		}
+		nodeVfsConfigured.WithLabelValues(nodeName, iface.Name, iface.PciAddress).Set(float64(iface.NumVfs))
+		nodeVfsTotal.WithLabelValues(nodeName, iface.Name, iface.PciAddress).Set(float64(iface.TotalVfs))
+	}
+}
+
+// newMetricsServer returns the server exposing the metrics of the config daemon on /metrics
+func newMetricsServer(addr string) *http.Server {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(metrics.Registry, promhttp.HandlerOpts{}))
+	return &http.Server{Addr: addr, Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+}
+
+// ServeMetrics exposes the metrics of the config daemon registered in the controller-runtime registry
+// on the address until stopCh is closed, the config daemon doesn't run a controller manager serving them
+func ServeMetrics(addr string, stopCh <-chan struct{}) {
+	server := newMetricsServer(addr)
+	go func() {
+		<-stopCh
+		if err := server.Close(); err != nil {
+			log.Log.Error(err, "failed to stop the metrics server")
+		}
+	}()
+	log.Log.Info("serving the config daemon metrics", "address", addr)
+	if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		log.Log.Error(err, "failed to serve the config daemon metrics", "address", addr)
+	}
+}
+
+// observePluginDuration logs and records the time spent by the plugin in the phase since start
+func observePluginDuration(pluginName, phase string, start time.Time) {
+	duration := time.Since(start)
+	pluginApplyDuration.WithLabelValues(pluginName, phase).Observe(duration.Seconds())
+	log.Log.V(2).Info("plugin phase completed", "plugin", pluginName, "phase", phase, "duration", duration.String())
+}
+
+// ovsPortCounters maps the OVSDB interface statistics exposed by the OVS port statistics collector to their metrics
+var ovsPortCounters = map[string]*prometheus.Desc{
+	"rx_packets": prometheus.NewDesc("sriov_ovs_port_rx_packets_total",
+		"Number of packets received by the port of the managed OVS bridge", []string{"bridge", "port"}, nil),
+	"tx_packets": prometheus.NewDesc("sriov_ovs_port_tx_packets_total",
+		"Number of packets transmitted by the port of the managed OVS bridge", []string{"bridge", "port"}, nil),
+	"rx_bytes": prometheus.NewDesc("sriov_ovs_port_rx_bytes_total",
+		"Number of bytes received by the port of the managed OVS bridge", []string{"bridge", "port"}, nil),
+	"tx_bytes": prometheus.NewDesc("sriov_ovs_port_tx_bytes_total",
+		"Number of bytes transmitted by the port of the managed OVS bridge", []string{"bridge", "port"}, nil),
+}
+
+// ovsPortStatisticsCollector reads the counters of the ports of the managed OVS bridges from OVSDB on each scrape
+type ovsPortStatisticsCollector struct {
+	hostHelpers helper.HostHelpersInterface
+}
+
+// NewOVSPortStatisticsCollector returns a collector exposing the packet and byte counters of the ports of the managed OVS bridges
+func NewOVSPortStatisticsCollector(hostHelpers helper.HostHelpersInterface) prometheus.Collector {
+	return &ovsPortStatisticsCollector{hostHelpers: hostHelpers}
+}
+
+// Describe implements prometheus.Collector
+func (c *ovsPortStatisticsCollector) Describe(ch chan<- *prometheus.Desc) {
+	for _, desc := range ovsPortCounters {
+		ch <- desc
+	}
+}
+
+// Collect implements prometheus.Collector, no metric is reported when the statistics can't be read
+func (c *ovsPortStatisticsCollector) Collect(ch chan<- prometheus.Metric) {
+	ports, err := c.hostHelpers.DiscoverBridgesStatistics()
+	if err != nil {
+		log.Log.Error(err, "failed to read the statistics of the managed OVS bridges")
+		return
+	}
+	for _, port := range ports {
+		for name, desc := range ovsPortCounters {
+			value, ok := port.Statistics[name]
+			if !ok {
+				continue
+			}
+			ch <- prometheus.MustNewConstMetric(desc, prometheus.CounterValue, float64(value), port.Bridge, port.Port)
+		}
+	}
+}
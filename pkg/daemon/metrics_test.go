@@ -0,0 +1,88 @@
+package daemon
+
+import (
+	"fmt"
+	"io"
+	"net/http/httptest"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus"
+
+	mock_helper "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/helper/mock"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/host/types"
+)
+
+var _ = Describe("OVS port statistics collector", func() {
+	var (
+		hostHelper *mock_helper.MockHostHelpersInterface
+		registry   *prometheus.Registry
+	)
+
+	BeforeEach(func() {
+		hostHelper = mock_helper.NewMockHostHelpersInterface(gomock.NewController(GinkgoT()))
+		registry = prometheus.NewRegistry()
+		Expect(registry.Register(NewOVSPortStatisticsCollector(hostHelper))).To(Succeed())
+	})
+
+	It("should expose the counters of the ports of the managed bridges", func() {
+		hostHelper.EXPECT().DiscoverBridgesStatistics().Return([]types.OVSPortStatistics{
+			{
+				Bridge: "br-0000_d8_00.0",
+				Port:   "enp216s0f0np0",
+				Statistics: map[string]int{
+					"rx_packets": 10, "tx_packets": 20, "rx_bytes": 1000, "tx_bytes": 2000, "rx_dropped": 1},
+			},
+			{
+				Bridge:     "br-0000_d8_00.0",
+				Port:       "vhost0",
+				Statistics: map[string]int{"rx_packets": 5},
+			},
+		}, nil)
+
+		families, err := registry.Gather()
+		Expect(err).NotTo(HaveOccurred())
+		values := map[string]float64{}
+		for _, family := range families {
+			for _, m := range family.GetMetric() {
+				labels := map[string]string{}
+				for _, label := range m.GetLabel() {
+					labels[label.GetName()] = label.GetValue()
+				}
+				values[fmt.Sprintf("%s{%s,%s}", family.GetName(), labels["bridge"], labels["port"])] = m.GetCounter().GetValue()
+			}
+		}
+		Expect(values).To(Equal(map[string]float64{
+			"sriov_ovs_port_rx_packets_total{br-0000_d8_00.0,enp216s0f0np0}": 10,
+			"sriov_ovs_port_tx_packets_total{br-0000_d8_00.0,enp216s0f0np0}": 20,
+			"sriov_ovs_port_rx_bytes_total{br-0000_d8_00.0,enp216s0f0np0}":   1000,
+			"sriov_ovs_port_tx_bytes_total{br-0000_d8_00.0,enp216s0f0np0}":   2000,
+			"sriov_ovs_port_rx_packets_total{br-0000_d8_00.0,vhost0}":        5,
+		}))
+	})
+
+	It("should not expose any metric when the statistics can't be read", func() {
+		hostHelper.EXPECT().DiscoverBridgesStatistics().Return(nil, fmt.Errorf("failed to connect to OVSDB"))
+
+		families, err := registry.Gather()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(families).To(BeEmpty())
+	})
+})
+
+var _ = Describe("Config daemon metrics server", func() {
+	It("should serve the metrics registered in the controller-runtime registry", func() {
+		observePluginDuration("generic", pluginPhaseApply, time.Now())
+
+		server := httptest.NewServer(newMetricsServer("").Handler)
+		defer server.Close()
+		resp, err := server.Client().Get(server.URL + "/metrics")
+		Expect(err).NotTo(HaveOccurred())
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(body)).To(ContainSubstring(`sriov_plugin_apply_duration_seconds_count{phase="Apply",plugin="generic"}`))
+	})
+})
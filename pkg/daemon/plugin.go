@@ -30,7 +30,8 @@ var (
 	K8sPlugin         = k8splugin.NewK8sPlugin
 )
 
-func loadPlugins(ns *sriovnetworkv1.SriovNetworkNodeState, helpers helper.HostHelpersInterface, disabledPlugins []string) (map[string]plugin.VendorPlugin, error) {
+func loadPlugins(ns *sriovnetworkv1.SriovNetworkNodeState, helpers helper.HostHelpersInterface, disabledPlugins []string,
+	genericPluginOptions ...genericplugin.Option) (map[string]plugin.VendorPlugin, error) {
 	log.Log.Info("loadPlugins(): loading plugins")
 	loadedPlugins := map[string]plugin.VendorPlugin{}
 
@@ -63,7 +64,7 @@ func loadPlugins(ns *sriovnetworkv1.SriovNetworkNodeState, helpers helper.HostHe
 				loadedPlugins[pluginName] = k8sPlugin
 			}
 		}
-		genericPlugin, err := GenericPlugin(helpers)
+		genericPlugin, err := GenericPlugin(helpers, genericPluginOptions...)
 		if err != nil {
 			log.Log.Error(err, "loadPlugins(): failed to load the generic plugin")
 			return nil, err
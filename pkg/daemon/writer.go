@@ -9,17 +9,21 @@ import (
 	"time"
 
 	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/util/retry"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	sriovnetworkv1 "github.com/k8snetworkplumbingwg/sriov-network-operator/api/v1"
 	snclientset "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/client/clientset/versioned"
 	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/consts"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/featuregate"
 	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/helper"
 	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/platforms"
 	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/vars"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/version"
 )
 
 const (
@@ -27,22 +31,45 @@ const (
 	Unknown            = "Unknown"
 )
 
+// checkpoint is the content of the checkpoint file, it contains the initial node state
+// and the version of the config-daemon that wrote the file
+type checkpoint struct {
+	sriovnetworkv1.SriovNetworkNodeState `json:",inline"`
+	// OperatorVersion is the version of the config-daemon that wrote the checkpoint
+	OperatorVersion string `json:"operatorVersion,omitempty"`
+	// InProgress is the configuration step interrupted by the termination of the config-daemon
+	InProgress *ApplyInProgress `json:"inProgress,omitempty"`
+}
+
+// ApplyInProgress is the configuration step the config-daemon is running
+type ApplyInProgress struct {
+	// Step is the name of the step, e.g. the plugin being applied
+	Step string `json:"step"`
+	// PciAddress is the PF being configured by the step, empty if the step is not configuring a PF
+	PciAddress string `json:"pciAddress,omitempty"`
+}
+
 type NodeStateStatusWriter struct {
 	client             snclientset.Interface
+	kubeClient         kubernetes.Interface
 	status             sriovnetworkv1.SriovNetworkNodeStateStatus
 	OnHeartbeatFailure func()
 	platformHelper     platforms.Interface
 	hostHelper         helper.HostHelpersInterface
 	eventRecorder      *EventRecorder
+	// FeatureGate is the state of the feature gates reported in the status, not reported if nil
+	FeatureGate featuregate.FeatureGate
 }
 
 // NewNodeStateStatusWriter Create a new NodeStateStatusWriter
 func NewNodeStateStatusWriter(c snclientset.Interface,
+	kubeClient kubernetes.Interface,
 	f func(), er *EventRecorder,
 	hostHelper helper.HostHelpersInterface,
 	platformHelper platforms.Interface) *NodeStateStatusWriter {
 	return &NodeStateStatusWriter{
 		client:             c,
+		kubeClient:         kubeClient,
 		OnHeartbeatFailure: f,
 		eventRecorder:      er,
 		hostHelper:         hostHelper,
@@ -137,11 +164,17 @@ func (w *NodeStateStatusWriter) pollNicStatus() error {
 				return err
 			}
 		}
+		if vars.ReportVfConsumerPods {
+			w.setVfConsumerPods(iface)
+		}
 	}
 
-	rdmaMode, err = w.hostHelper.DiscoverRDMASubsystem()
-	if err != nil {
-		return err
+	// the RDMA mode isn't reported when the RDMA checks are skipped
+	if !vars.SkipRdma {
+		rdmaMode, err = w.hostHelper.DiscoverRDMASubsystem()
+		if err != nil {
+			return err
+		}
 	}
 
 	w.status.Interfaces = iface
@@ -151,6 +184,45 @@ func (w *NodeStateStatusWriter) pollNicStatus() error {
 	return nil
 }
 
+// setVfConsumerPods reports the number of VFs of each PF allocated by the kubelet and the pod each VF is
+// allocated to on a best-effort basis, the VFs of the pods which can't be found, e.g. already deleted pods
+// still present in the kubelet checkpoint, are counted but their pod is not reported
+func (w *NodeStateStatusWriter) setVfConsumerPods(ifaces []sriovnetworkv1.InterfaceExt) {
+	consumers, err := w.hostHelper.DiscoverVfConsumers()
+	if err != nil {
+		log.Log.V(2).Info("setVfConsumerPods(): can't discover the VF consumers", "error", err.Error())
+		return
+	}
+	if len(consumers) == 0 {
+		return
+	}
+	for i := range ifaces {
+		for _, vf := range ifaces[i].VFs {
+			if _, ok := consumers[vf.PciAddress]; ok {
+				ifaces[i].NumVfsInUse++
+			}
+		}
+	}
+	pods, err := w.kubeClient.CoreV1().Pods("").List(context.Background(), metav1.ListOptions{
+		FieldSelector: "spec.nodeName=" + vars.NodeName,
+	})
+	if err != nil {
+		log.Log.V(2).Info("setVfConsumerPods(): can't list the pods of the node", "error", err.Error())
+		return
+	}
+	podNames := map[string]string{}
+	for _, pod := range pods.Items {
+		podNames[string(pod.UID)] = pod.Namespace + "/" + pod.Name
+	}
+	for i := range ifaces {
+		for j := range ifaces[i].VFs {
+			if podUID, ok := consumers[ifaces[i].VFs[j].PciAddress]; ok {
+				ifaces[i].VFs[j].ConsumerPod = podNames[podUID]
+			}
+		}
+	}
+}
+
 func (w *NodeStateStatusWriter) updateNodeStateStatusRetry(f func(*sriovnetworkv1.SriovNetworkNodeState)) (*sriovnetworkv1.SriovNetworkNodeState, error) {
 	var nodeState *sriovnetworkv1.SriovNetworkNodeState
 	var oldStatus, newStatus, lastError string
@@ -186,15 +258,41 @@ func (w *NodeStateStatusWriter) updateNodeStateStatusRetry(f func(*sriovnetworkv
 }
 
 func (w *NodeStateStatusWriter) setNodeStateStatus(msg Message) (*sriovnetworkv1.SriovNetworkNodeState, error) {
+	if msg.kernelModules != nil {
+		w.status.System.KernelModules = msg.kernelModules
+	}
+	if msg.pendingKernelArgs != nil {
+		w.status.PendingKernelArgs = msg.pendingKernelArgs
+	}
 	nodeState, err := w.updateNodeStateStatusRetry(func(nodeState *sriovnetworkv1.SriovNetworkNodeState) {
 		nodeState.Status.Interfaces = w.status.Interfaces
 		nodeState.Status.Bridges = w.status.Bridges
 		nodeState.Status.System = w.status.System
+		nodeState.Status.PendingKernelArgs = w.status.PendingKernelArgs
+		if w.FeatureGate != nil {
+			nodeState.Status.FeatureGates = nil
+			if gates := w.FeatureGate.State(); len(gates) > 0 {
+				nodeState.Status.FeatureGates = gates
+			}
+		}
 		if msg.lastSyncError != "" || msg.syncStatus == consts.SyncStatusSucceeded {
 			// clear lastSyncError when sync Succeeded
 			nodeState.Status.LastSyncError = msg.lastSyncError
 		}
 		nodeState.Status.SyncStatus = msg.syncStatus
+		if !msg.appliedTime.IsZero() {
+			nodeState.Status.LastAppliedTime = metav1.NewTime(msg.appliedTime)
+		}
+		if msg.degradedReason != "" {
+			meta.SetStatusCondition(&nodeState.Status.Conditions, metav1.Condition{
+				Type:    sriovnetworkv1.ConditionDegraded,
+				Status:  metav1.ConditionTrue,
+				Reason:  msg.degradedReason,
+				Message: msg.lastSyncError,
+			})
+		} else if msg.syncStatus == consts.SyncStatusSucceeded {
+			meta.RemoveStatusCondition(&nodeState.Status.Conditions, sriovnetworkv1.ConditionDegraded)
+		}
 
 		log.Log.V(0).Info("setNodeStateStatus(): status",
 			"sync-status", nodeState.Status.SyncStatus,
@@ -203,6 +301,7 @@ func (w *NodeStateStatusWriter) setNodeStateStatus(msg Message) (*sriovnetworkv1
 	if err != nil {
 		return nil, err
 	}
+	recordVfCapacity(nodeState.Name, nodeState.Status.Interfaces)
 	return nodeState, nil
 }
 
@@ -254,20 +353,34 @@ func (w *NodeStateStatusWriter) writeCheckpointFile(ns *sriovnetworkv1.SriovNetw
 	}
 	defer file.Close()
 	log.Log.Info("writeCheckpointFile(): try to decode the checkpoint file")
-	if err = json.NewDecoder(file).Decode(&sriovnetworkv1.InitialState); err != nil {
+	cp := &checkpoint{}
+	if err = json.NewDecoder(file).Decode(cp); err != nil {
 		log.Log.V(2).Error(err, "writeCheckpointFile(): fail to decode, writing new file instead")
-		log.Log.Info("writeCheckpointFile(): write checkpoint file")
-		if err = file.Truncate(0); err != nil {
-			return err
-		}
-		if _, err = file.Seek(0, 0); err != nil {
-			return err
-		}
-		if err = json.NewEncoder(file).Encode(*ns); err != nil {
-			return err
-		}
-		sriovnetworkv1.InitialState = *ns
+		cp.SriovNetworkNodeState = *ns
+	} else if cp.OperatorVersion != version.Raw {
+		// the checkpoint was written by a different version of the config-daemon (e.g. before an upgrade),
+		// keep the initial node state but rewrite the file in the format of the running version
+		log.Log.Info("writeCheckpointFile(): checkpoint version mismatch, refreshing the checkpoint file",
+			"checkpoint-version", cp.OperatorVersion, "running-version", version.Raw)
+		w.eventRecorder.SendEvent("CheckpointVersionMismatch",
+			fmt.Sprintf("Checkpoint file was written by version %q, refreshed by version %q", cp.OperatorVersion, version.Raw))
+	} else {
+		sriovnetworkv1.InitialState = cp.SriovNetworkNodeState
+		return nil
+	}
+
+	log.Log.Info("writeCheckpointFile(): write checkpoint file")
+	cp.OperatorVersion = version.Raw
+	if err = file.Truncate(0); err != nil {
+		return err
+	}
+	if _, err = file.Seek(0, 0); err != nil {
+		return err
 	}
+	if err = json.NewEncoder(file).Encode(cp); err != nil {
+		return err
+	}
+	sriovnetworkv1.InitialState = cp.SriovNetworkNodeState
 	return nil
 }
 
@@ -282,9 +395,55 @@ func (w *NodeStateStatusWriter) getCheckPointNodeState() (*sriovnetworkv1.SriovN
 		return nil, err
 	}
 	defer file.Close()
-	if err = json.NewDecoder(file).Decode(&sriovnetworkv1.InitialState); err != nil {
+	cp := &checkpoint{}
+	if err = json.NewDecoder(file).Decode(cp); err != nil {
 		return nil, err
 	}
+	sriovnetworkv1.InitialState = cp.SriovNetworkNodeState
 
 	return &sriovnetworkv1.InitialState, nil
 }
+
+// writeInProgressCheckpoint records the interrupted configuration step in the checkpoint file,
+// a nil step removes the record
+func writeInProgressCheckpoint(inProgress *ApplyInProgress) error {
+	configdir := filepath.Join(vars.Destdir, CheckpointFileName)
+	file, err := os.OpenFile(configdir, os.O_RDWR, 0644)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	cp := &checkpoint{}
+	if err = json.NewDecoder(file).Decode(cp); err != nil {
+		return fmt.Errorf("failed to decode the checkpoint file: %v", err)
+	}
+	cp.InProgress = inProgress
+	if err = file.Truncate(0); err != nil {
+		return err
+	}
+	if _, err = file.Seek(0, 0); err != nil {
+		return err
+	}
+	return json.NewEncoder(file).Encode(cp)
+}
+
+// popInProgressCheckpoint returns the configuration step interrupted by the previous termination
+// of the config-daemon and removes it from the checkpoint file, nil if no step was interrupted
+func popInProgressCheckpoint() (*ApplyInProgress, error) {
+	configdir := filepath.Join(vars.Destdir, CheckpointFileName)
+	data, err := os.ReadFile(configdir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	cp := &checkpoint{}
+	if err = json.Unmarshal(data, cp); err != nil {
+		return nil, fmt.Errorf("failed to decode the checkpoint file: %v", err)
+	}
+	if cp.InProgress == nil {
+		return nil, nil
+	}
+	return cp.InProgress, writeInProgressCheckpoint(nil)
+}
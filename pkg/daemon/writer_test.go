@@ -0,0 +1,251 @@
+package daemon
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakek8s "k8s.io/client-go/kubernetes/fake"
+
+	sriovnetworkv1 "github.com/k8snetworkplumbingwg/sriov-network-operator/api/v1"
+	snclientset "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/client/clientset/versioned/fake"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/consts"
+	mock_helper "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/helper/mock"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/vars"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/version"
+)
+
+var _ = Describe("NodeStateStatusWriter", func() {
+	var (
+		w              *NodeStateStatusWriter
+		origDest       string
+		checkpointPath string
+	)
+
+	newNodeState := func(ifaceName string) *sriovnetworkv1.SriovNetworkNodeState {
+		return &sriovnetworkv1.SriovNetworkNodeState{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-node", Namespace: "sriov-network-operator"},
+			Status: sriovnetworkv1.SriovNetworkNodeStateStatus{
+				Interfaces: sriovnetworkv1.InterfaceExts{{Name: ifaceName, PciAddress: "0000:d8:00.0"}},
+			},
+		}
+	}
+
+	writeRawCheckpoint := func(content interface{}) {
+		data, err := json.Marshal(content)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(os.WriteFile(checkpointPath, data, 0644)).To(Succeed())
+	}
+
+	readRawCheckpoint := func() map[string]interface{} {
+		data, err := os.ReadFile(checkpointPath)
+		Expect(err).ToNot(HaveOccurred())
+		result := map[string]interface{}{}
+		Expect(json.Unmarshal(data, &result)).To(Succeed())
+		return result
+	}
+
+	BeforeEach(func() {
+		origDest = vars.Destdir
+		vars.Destdir = GinkgoT().TempDir()
+		checkpointPath = filepath.Join(vars.Destdir, CheckpointFileName)
+		snclient := snclientset.NewSimpleClientset()
+		w = NewNodeStateStatusWriter(snclient, fakek8s.NewSimpleClientset(), nil, NewEventRecorder(snclient, fakek8s.NewSimpleClientset()), nil, nil)
+		DeferCleanup(func() {
+			vars.Destdir = origDest
+			sriovnetworkv1.InitialState = sriovnetworkv1.SriovNetworkNodeState{}
+		})
+	})
+
+	Context("writeCheckpointFile", func() {
+		It("should create the checkpoint with the running version", func() {
+			Expect(w.writeCheckpointFile(newNodeState("eth0"))).To(Succeed())
+			content := readRawCheckpoint()
+			Expect(content["operatorVersion"]).To(Equal(version.Raw))
+			Expect(sriovnetworkv1.InitialState.Status.Interfaces[0].Name).To(Equal("eth0"))
+		})
+
+		It("should refresh a checkpoint written by an old version and keep the initial state", func() {
+			writeRawCheckpoint(map[string]interface{}{
+				"metadata":        newNodeState("initial").ObjectMeta,
+				"status":          newNodeState("initial").Status,
+				"operatorVersion": "v0.0.1",
+			})
+			Expect(w.writeCheckpointFile(newNodeState("eth0"))).To(Succeed())
+			content := readRawCheckpoint()
+			Expect(content["operatorVersion"]).To(Equal(version.Raw))
+			Expect(sriovnetworkv1.InitialState.Status.Interfaces[0].Name).To(Equal("initial"))
+
+			ns, err := w.getCheckPointNodeState()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ns.Status.Interfaces[0].Name).To(Equal("initial"))
+		})
+
+		It("should refresh a checkpoint without a version", func() {
+			writeRawCheckpoint(newNodeState("initial"))
+			Expect(w.writeCheckpointFile(newNodeState("eth0"))).To(Succeed())
+			content := readRawCheckpoint()
+			Expect(content["operatorVersion"]).To(Equal(version.Raw))
+			Expect(sriovnetworkv1.InitialState.Status.Interfaces[0].Name).To(Equal("initial"))
+		})
+
+		It("should not rewrite a checkpoint with the running version", func() {
+			writeRawCheckpoint(map[string]interface{}{
+				"status":          newNodeState("initial").Status,
+				"operatorVersion": version.Raw,
+				"unknownField":    "keep",
+			})
+			Expect(w.writeCheckpointFile(newNodeState("eth0"))).To(Succeed())
+			content := readRawCheckpoint()
+			Expect(content["unknownField"]).To(Equal("keep"))
+			Expect(sriovnetworkv1.InitialState.Status.Interfaces[0].Name).To(Equal("initial"))
+		})
+	})
+
+	Context("setNodeStateStatus", func() {
+		It("should report the Degraded condition on external conflicts until a sync succeeds", func() {
+			vars.NodeName = "test-node"
+			vars.Namespace = "sriov-network-operator"
+			snclient := snclientset.NewSimpleClientset(newNodeState("eth0"))
+			w = NewNodeStateStatusWriter(snclient, fakek8s.NewSimpleClientset(), nil, NewEventRecorder(snclient, fakek8s.NewSimpleClientset()), nil, nil)
+
+			ns, err := w.setNodeStateStatus(Message{syncStatus: consts.SyncStatusFailed, lastSyncError: "conflict", degradedReason: sriovnetworkv1.ReasonExternalConflict})
+			Expect(err).ToNot(HaveOccurred())
+			cond := meta.FindStatusCondition(ns.Status.Conditions, sriovnetworkv1.ConditionDegraded)
+			Expect(cond).ToNot(BeNil())
+			Expect(cond.Status).To(Equal(metav1.ConditionTrue))
+			Expect(cond.Reason).To(Equal(sriovnetworkv1.ReasonExternalConflict))
+			Expect(cond.Message).To(Equal("conflict"))
+
+			ns, err = w.setNodeStateStatus(Message{syncStatus: consts.SyncStatusInProgress})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(meta.IsStatusConditionTrue(ns.Status.Conditions, sriovnetworkv1.ConditionDegraded)).To(BeTrue())
+
+			ns, err = w.setNodeStateStatus(Message{syncStatus: consts.SyncStatusSucceeded})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(meta.FindStatusCondition(ns.Status.Conditions, sriovnetworkv1.ConditionDegraded)).To(BeNil())
+		})
+
+		It("should keep the last applied time until the next successful apply", func() {
+			vars.NodeName = "test-node"
+			vars.Namespace = "sriov-network-operator"
+			snclient := snclientset.NewSimpleClientset(newNodeState("eth0"))
+			w = NewNodeStateStatusWriter(snclient, fakek8s.NewSimpleClientset(), nil, NewEventRecorder(snclient, fakek8s.NewSimpleClientset()), nil, nil)
+
+			appliedTime := metav1.NewTime(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC))
+			ns, err := w.setNodeStateStatus(Message{syncStatus: consts.SyncStatusSucceeded, appliedTime: appliedTime.Time})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ns.Status.LastAppliedTime).To(Equal(appliedTime))
+
+			ns, err = w.setNodeStateStatus(Message{syncStatus: consts.SyncStatusFailed, lastSyncError: "failed"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ns.Status.LastAppliedTime).To(Equal(appliedTime))
+		})
+
+		It("should keep the pending kernel arguments until they are cleared", func() {
+			vars.NodeName = "test-node"
+			vars.Namespace = "sriov-network-operator"
+			snclient := snclientset.NewSimpleClientset(newNodeState("eth0"))
+			w = NewNodeStateStatusWriter(snclient, fakek8s.NewSimpleClientset(), nil, NewEventRecorder(snclient, fakek8s.NewSimpleClientset()), nil, nil)
+
+			ns, err := w.setNodeStateStatus(Message{syncStatus: consts.SyncStatusInProgress, pendingKernelArgs: []string{"intel_iommu=on"}})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ns.Status.PendingKernelArgs).To(Equal([]string{"intel_iommu=on"}))
+
+			ns, err = w.setNodeStateStatus(Message{syncStatus: consts.SyncStatusInProgress})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ns.Status.PendingKernelArgs).To(Equal([]string{"intel_iommu=on"}))
+
+			ns, err = w.setNodeStateStatus(Message{syncStatus: consts.SyncStatusSucceeded, pendingKernelArgs: []string{}})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ns.Status.PendingKernelArgs).To(BeEmpty())
+		})
+
+		It("should report the VF capacity of the PFs of the status", func() {
+			vars.NodeName = "test-node"
+			vars.Namespace = "sriov-network-operator"
+			snclient := snclientset.NewSimpleClientset(newNodeState("eth0"))
+			w = NewNodeStateStatusWriter(snclient, fakek8s.NewSimpleClientset(), nil, NewEventRecorder(snclient, fakek8s.NewSimpleClientset()), nil, nil)
+			w.status.Interfaces = sriovnetworkv1.InterfaceExts{
+				{Name: "eth0", PciAddress: "0000:d8:00.0", NumVfs: 4, TotalVfs: 64},
+				{Name: "eth1", PciAddress: "0000:d8:00.1", NumVfs: 0, TotalVfs: 64},
+				// not a SR-IOV capable device
+				{Name: "eno1", PciAddress: "0000:3b:00.0"},
+			}
+
+			_, err := w.setNodeStateStatus(Message{syncStatus: consts.SyncStatusSucceeded})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(testutil.ToFloat64(nodeVfsConfigured.WithLabelValues("test-node", "eth0", "0000:d8:00.0"))).To(Equal(4.0))
+			Expect(testutil.ToFloat64(nodeVfsConfigured.WithLabelValues("test-node", "eth1", "0000:d8:00.1"))).To(Equal(0.0))
+			Expect(testutil.ToFloat64(nodeVfsTotal.WithLabelValues("test-node", "eth0", "0000:d8:00.0"))).To(Equal(64.0))
+			Expect(testutil.CollectAndCount(nodeVfsTotal)).To(Equal(2))
+
+			// the PFs removed from the status are not reported anymore
+			w.status.Interfaces = w.status.Interfaces[:1]
+			_, err = w.setNodeStateStatus(Message{syncStatus: consts.SyncStatusSucceeded})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(testutil.CollectAndCount(nodeVfsConfigured)).To(Equal(1))
+			Expect(testutil.CollectAndCount(nodeVfsTotal)).To(Equal(1))
+		})
+	})
+
+	Context("pollNicStatus", func() {
+		var hostHelper *mock_helper.MockHostHelpersInterface
+
+		BeforeEach(func() {
+			vars.NodeName = "test-node"
+			origReport := vars.ReportVfConsumerPods
+			vars.ReportVfConsumerPods = true
+			DeferCleanup(func() { vars.ReportVfConsumerPods = origReport })
+
+			hostHelper = mock_helper.NewMockHostHelpersInterface(gomock.NewController(GinkgoT()))
+			hostHelper.EXPECT().DiscoverSriovDevices(hostHelper).Return([]sriovnetworkv1.InterfaceExt{{
+				PciAddress: "0000:d8:00.0",
+				VFs: []sriovnetworkv1.VirtualFunction{
+					{PciAddress: "0000:d8:00.2", VfID: 0},
+					{PciAddress: "0000:d8:00.3", VfID: 1},
+					{PciAddress: "0000:d8:00.4", VfID: 2},
+				},
+			}}, nil)
+			hostHelper.EXPECT().DiscoverRDMASubsystem().Return("shared", nil)
+		})
+
+		It("should report the pods the VFs are allocated to", func() {
+			kubeClient := fakek8s.NewSimpleClientset(&corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: "pod-1", Namespace: "app", UID: "uid-1"},
+				Spec:       corev1.PodSpec{NodeName: "test-node"},
+			})
+			snclient := snclientset.NewSimpleClientset()
+			w = NewNodeStateStatusWriter(snclient, kubeClient, nil, NewEventRecorder(snclient, kubeClient), hostHelper, nil)
+			// the VF 2 is allocated to a pod which doesn't exist anymore
+			hostHelper.EXPECT().DiscoverVfConsumers().Return(map[string]string{
+				"0000:d8:00.2": "uid-1",
+				"0000:d8:00.4": "uid-deleted",
+			}, nil)
+
+			Expect(w.pollNicStatus()).To(Succeed())
+			Expect(w.status.Interfaces[0].VFs[0].ConsumerPod).To(Equal("app/pod-1"))
+			Expect(w.status.Interfaces[0].VFs[1].ConsumerPod).To(BeEmpty())
+			Expect(w.status.Interfaces[0].VFs[2].ConsumerPod).To(BeEmpty())
+			Expect(w.status.Interfaces[0].NumVfsInUse).To(Equal(2))
+		})
+
+		It("should report the VFs without consumer when the checkpoint can't be read", func() {
+			hostHelper.EXPECT().DiscoverVfConsumers().Return(nil, os.ErrNotExist)
+			w.hostHelper = hostHelper
+
+			Expect(w.pollNicStatus()).To(Succeed())
+			Expect(w.status.Interfaces[0].VFs).To(HaveLen(3))
+			Expect(w.status.Interfaces[0].VFs[0].ConsumerPod).To(BeEmpty())
+			Expect(w.status.Interfaces[0].NumVfsInUse).To(BeZero())
+		})
+	})
+})
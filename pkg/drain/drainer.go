@@ -29,7 +29,7 @@ func (w writer) Write(p []byte) (n int, err error) {
 }
 
 type DrainInterface interface {
-	DrainNode(context.Context, *corev1.Node, bool) (bool, error)
+	DrainNode(context.Context, *corev1.Node, bool, string) (bool, error)
 	CompleteDrainNode(context.Context, *corev1.Node) (bool, error)
 }
 
@@ -52,8 +52,9 @@ func NewDrainer(platformHelpers platforms.Interface) (DrainInterface, error) {
 
 // DrainNode the function cordon a node and drain pods from it
 // if fullNodeDrain true all the pods on the system will get drained
+// if podSelector is not empty only the pods matching the label selector are drained
 // for openshift system we also pause the machine config pool this machine is part of it
-func (d *Drainer) DrainNode(ctx context.Context, node *corev1.Node, fullNodeDrain bool) (bool, error) {
+func (d *Drainer) DrainNode(ctx context.Context, node *corev1.Node, fullNodeDrain bool, podSelector string) (bool, error) {
 	reqLogger := log.FromContext(ctx).WithValues("drain node", node.Name)
 	reqLogger.Info("drainNode(): Node drain requested", "node", node.Name)
 
@@ -68,7 +69,7 @@ func (d *Drainer) DrainNode(ctx context.Context, node *corev1.Node, fullNodeDrai
 		return false, nil
 	}
 
-	drainHelper := createDrainHelper(d.kubeClient, ctx, fullNodeDrain)
+	drainHelper := createDrainHelper(d.kubeClient, ctx, fullNodeDrain, podSelector)
 	backoff := wait.Backoff{
 		Steps:    5,
 		Duration: 10 * time.Second,
@@ -111,7 +112,7 @@ func (d *Drainer) CompleteDrainNode(ctx context.Context, node *corev1.Node) (boo
 
 	// Create drain helper object
 	// full drain is not important here
-	drainHelper := createDrainHelper(d.kubeClient, ctx, false)
+	drainHelper := createDrainHelper(d.kubeClient, ctx, false, "")
 
 	// run the un cordon function on the node
 	if err := drain.RunCordonOrUncordon(drainHelper, node, false); err != nil {
@@ -134,7 +135,8 @@ func (d *Drainer) CompleteDrainNode(ctx context.Context, node *corev1.Node) (boo
 // createDrainHelper function to create a drain helper
 // if fullDrain is false we only remove pods that have the resourcePrefix
 // if not we remove all the pods in the node
-func createDrainHelper(kubeClient kubernetes.Interface, ctx context.Context, fullDrain bool) *drain.Helper {
+// if podSelector is not empty only the pods matching the label selector are removed
+func createDrainHelper(kubeClient kubernetes.Interface, ctx context.Context, fullDrain bool, podSelector string) *drain.Helper {
 	logger := log.FromContext(ctx)
 	drainer := &drain.Helper{
 		Client:              kubeClient,
@@ -150,9 +152,10 @@ func createDrainHelper(kubeClient kubernetes.Interface, ctx context.Context, ful
 			}
 			log.Log.Info(fmt.Sprintf("%s pod from Node %s/%s", verbStr, pod.Namespace, pod.Name))
 		},
-		Ctx:    ctx,
-		Out:    writer{logger.Info},
-		ErrOut: writer{func(msg string, kv ...interface{}) { logger.Error(nil, msg, kv...) }},
+		PodSelector: podSelector,
+		Ctx:         ctx,
+		Out:         writer{logger.Info},
+		ErrOut:      writer{func(msg string, kv ...interface{}) { logger.Error(nil, msg, kv...) }},
 	}
 
 	// when we just want to drain and not reboot we can only remove the pods using sriov devices
@@ -16,6 +16,8 @@ type FeatureGate interface {
 	Init(features map[string]bool)
 	// String returns string representation of the feature state
 	String() string
+	// State returns a copy of the state of the features
+	State() map[string]bool
 }
 
 // New returns default implementation of the FeatureGate interface
@@ -62,3 +64,14 @@ func (fg *featureGate) String() string {
 	}
 	return result.String()
 }
+
+// State returns a copy of the state of the features
+func (fg *featureGate) State() map[string]bool {
+	fg.lock.RLock()
+	defer fg.lock.RUnlock()
+	result := make(map[string]bool, len(fg.state))
+	for k, v := range fg.state {
+		result[k] = v
+	}
+	return result
+}
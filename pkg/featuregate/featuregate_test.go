@@ -29,4 +29,14 @@ var _ = Describe("FeatureGate", func() {
 			Expect(f.String()).To(And(ContainSubstring("feat1:true"), ContainSubstring("feat2:false")))
 		})
 	})
+	Context("State", func() {
+		It("return a copy of the feature state", func() {
+			f := New()
+			f.Init(map[string]bool{"feat1": true, "feat2": false})
+			state := f.State()
+			Expect(state).To(Equal(map[string]bool{"feat1": true, "feat2": false}))
+			state["feat2"] = true
+			Expect(f.IsEnabled("feat2")).To(BeFalse())
+		})
+	})
 })
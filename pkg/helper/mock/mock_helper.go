@@ -66,6 +66,20 @@ func (mr *MockHostHelpersInterfaceMockRecorder) AddPersistPFNameUdevRule(pfPciAd
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddPersistPFNameUdevRule", reflect.TypeOf((*MockHostHelpersInterface)(nil).AddPersistPFNameUdevRule), pfPciAddress, pfName)
 }
 
+// AddVfNameUdevRule mocks base method.
+func (m *MockHostHelpersInterface) AddVfNameUdevRule(pfPciAddress string, vfNames map[string]string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddVfNameUdevRule", pfPciAddress, vfNames)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddVfNameUdevRule indicates an expected call of AddVfNameUdevRule.
+func (mr *MockHostHelpersInterfaceMockRecorder) AddVfNameUdevRule(pfPciAddress, vfNames interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddVfNameUdevRule", reflect.TypeOf((*MockHostHelpersInterface)(nil).AddVfNameUdevRule), pfPciAddress, vfNames)
+}
+
 // AddVfRepresentorUdevRule mocks base method.
 func (m *MockHostHelpersInterface) AddVfRepresentorUdevRule(pfPciAddress, pfName, pfSwitchID, pfSwitchPort string) error {
 	m.ctrl.T.Helper()
@@ -196,17 +210,17 @@ func (mr *MockHostHelpersInterfaceMockRecorder) ConfigSriovDeviceVirtual(iface i
 }
 
 // ConfigSriovInterfaces mocks base method.
-func (m *MockHostHelpersInterface) ConfigSriovInterfaces(storeManager store.ManagerInterface, interfaces []v1.Interface, ifaceStatuses []v1.InterfaceExt, skipVFConfiguration bool) error {
+func (m *MockHostHelpersInterface) ConfigSriovInterfaces(storeManager store.ManagerInterface, interfaces []v1.Interface, ifaceStatuses []v1.InterfaceExt, skipVFConfiguration bool, progress types.ConfigProgressFunc) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ConfigSriovInterfaces", storeManager, interfaces, ifaceStatuses, skipVFConfiguration)
+	ret := m.ctrl.Call(m, "ConfigSriovInterfaces", storeManager, interfaces, ifaceStatuses, skipVFConfiguration, progress)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // ConfigSriovInterfaces indicates an expected call of ConfigSriovInterfaces.
-func (mr *MockHostHelpersInterfaceMockRecorder) ConfigSriovInterfaces(storeManager, interfaces, ifaceStatuses, skipVFConfiguration interface{}) *gomock.Call {
+func (mr *MockHostHelpersInterfaceMockRecorder) ConfigSriovInterfaces(storeManager, interfaces, ifaceStatuses, skipVFConfiguration, progress interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfigSriovInterfaces", reflect.TypeOf((*MockHostHelpersInterface)(nil).ConfigSriovInterfaces), storeManager, interfaces, ifaceStatuses, skipVFConfiguration)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfigSriovInterfaces", reflect.TypeOf((*MockHostHelpersInterface)(nil).ConfigSriovInterfaces), storeManager, interfaces, ifaceStatuses, skipVFConfiguration, progress)
 }
 
 // ConfigureBridges mocks base method.
@@ -294,6 +308,21 @@ func (mr *MockHostHelpersInterfaceMockRecorder) DiscoverBridges() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiscoverBridges", reflect.TypeOf((*MockHostHelpersInterface)(nil).DiscoverBridges))
 }
 
+// DiscoverBridgesStatistics mocks base method.
+func (m *MockHostHelpersInterface) DiscoverBridgesStatistics() ([]types.OVSPortStatistics, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DiscoverBridgesStatistics")
+	ret0, _ := ret[0].([]types.OVSPortStatistics)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DiscoverBridgesStatistics indicates an expected call of DiscoverBridgesStatistics.
+func (mr *MockHostHelpersInterfaceMockRecorder) DiscoverBridgesStatistics() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiscoverBridgesStatistics", reflect.TypeOf((*MockHostHelpersInterface)(nil).DiscoverBridgesStatistics))
+}
+
 // DiscoverRDMASubsystem mocks base method.
 func (m *MockHostHelpersInterface) DiscoverRDMASubsystem() (string, error) {
 	m.ctrl.T.Helper()
@@ -338,6 +367,21 @@ func (mr *MockHostHelpersInterfaceMockRecorder) DiscoverVDPAType(pciAddr interfa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiscoverVDPAType", reflect.TypeOf((*MockHostHelpersInterface)(nil).DiscoverVDPAType), pciAddr)
 }
 
+// DiscoverVfConsumers mocks base method.
+func (m *MockHostHelpersInterface) DiscoverVfConsumers() (map[string]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DiscoverVfConsumers")
+	ret0, _ := ret[0].(map[string]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DiscoverVfConsumers indicates an expected call of DiscoverVfConsumers.
+func (mr *MockHostHelpersInterfaceMockRecorder) DiscoverVfConsumers() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiscoverVfConsumers", reflect.TypeOf((*MockHostHelpersInterface)(nil).DiscoverVfConsumers))
+}
+
 // EnableHwTcOffload mocks base method.
 func (m *MockHostHelpersInterface) EnableHwTcOffload(ifaceName string) error {
 	m.ctrl.T.Helper()
@@ -411,6 +455,21 @@ func (mr *MockHostHelpersInterfaceMockRecorder) GetCurrentKernelArgs() *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCurrentKernelArgs", reflect.TypeOf((*MockHostHelpersInterface)(nil).GetCurrentKernelArgs))
 }
 
+// GetDefaultRouteInterfaces mocks base method.
+func (m *MockHostHelpersInterface) GetDefaultRouteInterfaces() ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDefaultRouteInterfaces")
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDefaultRouteInterfaces indicates an expected call of GetDefaultRouteInterfaces.
+func (mr *MockHostHelpersInterfaceMockRecorder) GetDefaultRouteInterfaces() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDefaultRouteInterfaces", reflect.TypeOf((*MockHostHelpersInterface)(nil).GetDefaultRouteInterfaces))
+}
+
 // GetDevlinkDeviceParam mocks base method.
 func (m *MockHostHelpersInterface) GetDevlinkDeviceParam(pciAddr, paramName string) (string, error) {
 	m.ctrl.T.Helper()
@@ -456,6 +515,21 @@ func (mr *MockHostHelpersInterfaceMockRecorder) GetInterfaceIndex(pciAddr interf
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInterfaceIndex", reflect.TypeOf((*MockHostHelpersInterface)(nil).GetInterfaceIndex), pciAddr)
 }
 
+// GetKernelVersion mocks base method.
+func (m *MockHostHelpersInterface) GetKernelVersion() (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetKernelVersion")
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetKernelVersion indicates an expected call of GetKernelVersion.
+func (mr *MockHostHelpersInterfaceMockRecorder) GetKernelVersion() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetKernelVersion", reflect.TypeOf((*MockHostHelpersInterface)(nil).GetKernelVersion))
+}
+
 // GetLinkType mocks base method.
 func (m *MockHostHelpersInterface) GetLinkType(name string) string {
 	m.ctrl.T.Helper()
@@ -501,6 +575,34 @@ func (mr *MockHostHelpersInterfaceMockRecorder) GetMlxNicFwData(pciAddress inter
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMlxNicFwData", reflect.TypeOf((*MockHostHelpersInterface)(nil).GetMlxNicFwData), pciAddress)
 }
 
+// GetNetDevDriverVersion mocks base method.
+func (m *MockHostHelpersInterface) GetNetDevDriverVersion(ifaceName string) string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNetDevDriverVersion", ifaceName)
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetNetDevDriverVersion indicates an expected call of GetNetDevDriverVersion.
+func (mr *MockHostHelpersInterfaceMockRecorder) GetNetDevDriverVersion(ifaceName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNetDevDriverVersion", reflect.TypeOf((*MockHostHelpersInterface)(nil).GetNetDevDriverVersion), ifaceName)
+}
+
+// GetNetDevFirmwareVersion mocks base method.
+func (m *MockHostHelpersInterface) GetNetDevFirmwareVersion(ifaceName string) string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNetDevFirmwareVersion", ifaceName)
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetNetDevFirmwareVersion indicates an expected call of GetNetDevFirmwareVersion.
+func (mr *MockHostHelpersInterfaceMockRecorder) GetNetDevFirmwareVersion(ifaceName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNetDevFirmwareVersion", reflect.TypeOf((*MockHostHelpersInterface)(nil).GetNetDevFirmwareVersion), ifaceName)
+}
+
 // GetNetDevLinkAdminState mocks base method.
 func (m *MockHostHelpersInterface) GetNetDevLinkAdminState(ifaceName string) string {
 	m.ctrl.T.Helper()
@@ -557,6 +659,34 @@ func (mr *MockHostHelpersInterfaceMockRecorder) GetNetDevNodeGUID(pciAddr interf
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNetDevNodeGUID", reflect.TypeOf((*MockHostHelpersInterface)(nil).GetNetDevNodeGUID), pciAddr)
 }
 
+// GetNetDevNumQueues mocks base method.
+func (m *MockHostHelpersInterface) GetNetDevNumQueues(ifaceName string) int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNetDevNumQueues", ifaceName)
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// GetNetDevNumQueues indicates an expected call of GetNetDevNumQueues.
+func (mr *MockHostHelpersInterfaceMockRecorder) GetNetDevNumQueues(ifaceName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNetDevNumQueues", reflect.TypeOf((*MockHostHelpersInterface)(nil).GetNetDevNumQueues), ifaceName)
+}
+
+// GetNetDevVlanFiltering mocks base method.
+func (m *MockHostHelpersInterface) GetNetDevVlanFiltering(ifaceName string) *bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNetDevVlanFiltering", ifaceName)
+	ret0, _ := ret[0].(*bool)
+	return ret0
+}
+
+// GetNetDevVlanFiltering indicates an expected call of GetNetDevVlanFiltering.
+func (mr *MockHostHelpersInterfaceMockRecorder) GetNetDevVlanFiltering(ifaceName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNetDevVlanFiltering", reflect.TypeOf((*MockHostHelpersInterface)(nil).GetNetDevVlanFiltering), ifaceName)
+}
+
 // GetNetdevMTU mocks base method.
 func (m *MockHostHelpersInterface) GetNetdevMTU(pciAddr string) int {
 	m.ctrl.T.Helper()
@@ -645,6 +775,36 @@ func (mr *MockHostHelpersInterfaceMockRecorder) HasDriver(pciAddr interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasDriver", reflect.TypeOf((*MockHostHelpersInterface)(nil).HasDriver), pciAddr)
 }
 
+// HasHugepages mocks base method.
+func (m *MockHostHelpersInterface) HasHugepages() (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasHugepages")
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HasHugepages indicates an expected call of HasHugepages.
+func (mr *MockHostHelpersInterfaceMockRecorder) HasHugepages() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasHugepages", reflect.TypeOf((*MockHostHelpersInterface)(nil).HasHugepages))
+}
+
+// IsDriverAvailable mocks base method.
+func (m *MockHostHelpersInterface) IsDriverAvailable(bus, driver string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsDriverAvailable", bus, driver)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsDriverAvailable indicates an expected call of IsDriverAvailable.
+func (mr *MockHostHelpersInterfaceMockRecorder) IsDriverAvailable(bus, driver interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsDriverAvailable", reflect.TypeOf((*MockHostHelpersInterface)(nil).IsDriverAvailable), bus, driver)
+}
+
 // IsKernelArgsSet mocks base method.
 func (m *MockHostHelpersInterface) IsKernelArgsSet(cmdLine, karg string) bool {
 	m.ctrl.T.Helper()
@@ -688,6 +848,21 @@ func (mr *MockHostHelpersInterfaceMockRecorder) IsKernelModuleLoaded(name interf
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsKernelModuleLoaded", reflect.TypeOf((*MockHostHelpersInterface)(nil).IsKernelModuleLoaded), name)
 }
 
+// IsNetDevMacsecOutdated mocks base method.
+func (m *MockHostHelpersInterface) IsNetDevMacsecOutdated(ifaceName, keyID, key string, encrypt bool) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsNetDevMacsecOutdated", ifaceName, keyID, key, encrypt)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsNetDevMacsecOutdated indicates an expected call of IsNetDevMacsecOutdated.
+func (mr *MockHostHelpersInterfaceMockRecorder) IsNetDevMacsecOutdated(ifaceName, keyID, key, encrypt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsNetDevMacsecOutdated", reflect.TypeOf((*MockHostHelpersInterface)(nil).IsNetDevMacsecOutdated), ifaceName, keyID, key, encrypt)
+}
+
 // IsServiceEnabled mocks base method.
 func (m *MockHostHelpersInterface) IsServiceEnabled(servicePath string) (bool, error) {
 	m.ctrl.T.Helper()
@@ -954,6 +1129,20 @@ func (mr *MockHostHelpersInterfaceMockRecorder) RemovePfAppliedStatus(pciAddress
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemovePfAppliedStatus", reflect.TypeOf((*MockHostHelpersInterface)(nil).RemovePfAppliedStatus), pciAddress)
 }
 
+// RemoveVfNameUdevRule mocks base method.
+func (m *MockHostHelpersInterface) RemoveVfNameUdevRule(pfPciAddress string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveVfNameUdevRule", pfPciAddress)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveVfNameUdevRule indicates an expected call of RemoveVfNameUdevRule.
+func (mr *MockHostHelpersInterfaceMockRecorder) RemoveVfNameUdevRule(pfPciAddress interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveVfNameUdevRule", reflect.TypeOf((*MockHostHelpersInterface)(nil).RemoveVfNameUdevRule), pfPciAddress)
+}
+
 // RemoveVfRepresentorUdevRule mocks base method.
 func (m *MockHostHelpersInterface) RemoveVfRepresentorUdevRule(pfPciAddress string) error {
 	m.ctrl.T.Helper()
@@ -1031,6 +1220,132 @@ func (mr *MockHostHelpersInterfaceMockRecorder) SetDevlinkDeviceParam(pciAddr, p
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetDevlinkDeviceParam", reflect.TypeOf((*MockHostHelpersInterface)(nil).SetDevlinkDeviceParam), pciAddr, paramName, value)
 }
 
+// SetNetDevBroadcastFilter mocks base method.
+func (m *MockHostHelpersInterface) SetNetDevBroadcastFilter(ifaceName string, drop bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetNetDevBroadcastFilter", ifaceName, drop)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetNetDevBroadcastFilter indicates an expected call of SetNetDevBroadcastFilter.
+func (mr *MockHostHelpersInterfaceMockRecorder) SetNetDevBroadcastFilter(ifaceName, drop interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetNetDevBroadcastFilter", reflect.TypeOf((*MockHostHelpersInterface)(nil).SetNetDevBroadcastFilter), ifaceName, drop)
+}
+
+// SetNetDevLinkSettings mocks base method.
+func (m *MockHostHelpersInterface) SetNetDevLinkSettings(ifaceName string, autoNeg *bool, speedMbps int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetNetDevLinkSettings", ifaceName, autoNeg, speedMbps)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetNetDevLinkSettings indicates an expected call of SetNetDevLinkSettings.
+func (mr *MockHostHelpersInterfaceMockRecorder) SetNetDevLinkSettings(ifaceName, autoNeg, speedMbps interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetNetDevLinkSettings", reflect.TypeOf((*MockHostHelpersInterface)(nil).SetNetDevLinkSettings), ifaceName, autoNeg, speedMbps)
+}
+
+// SetNetDevMacsec mocks base method.
+func (m *MockHostHelpersInterface) SetNetDevMacsec(ifaceName, keyID, key string, encrypt bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetNetDevMacsec", ifaceName, keyID, key, encrypt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetNetDevMacsec indicates an expected call of SetNetDevMacsec.
+func (mr *MockHostHelpersInterfaceMockRecorder) SetNetDevMacsec(ifaceName, keyID, key, encrypt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetNetDevMacsec", reflect.TypeOf((*MockHostHelpersInterface)(nil).SetNetDevMacsec), ifaceName, keyID, key, encrypt)
+}
+
+// SetNetDevMulticast mocks base method.
+func (m *MockHostHelpersInterface) SetNetDevMulticast(ifaceName string, enable bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetNetDevMulticast", ifaceName, enable)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetNetDevMulticast indicates an expected call of SetNetDevMulticast.
+func (mr *MockHostHelpersInterfaceMockRecorder) SetNetDevMulticast(ifaceName, enable interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetNetDevMulticast", reflect.TypeOf((*MockHostHelpersInterface)(nil).SetNetDevMulticast), ifaceName, enable)
+}
+
+// SetNetDevNumQueues mocks base method.
+func (m *MockHostHelpersInterface) SetNetDevNumQueues(ifaceName string, numQueues int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetNetDevNumQueues", ifaceName, numQueues)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetNetDevNumQueues indicates an expected call of SetNetDevNumQueues.
+func (mr *MockHostHelpersInterfaceMockRecorder) SetNetDevNumQueues(ifaceName, numQueues interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetNetDevNumQueues", reflect.TypeOf((*MockHostHelpersInterface)(nil).SetNetDevNumQueues), ifaceName, numQueues)
+}
+
+// SetNetDevPromisc mocks base method.
+func (m *MockHostHelpersInterface) SetNetDevPromisc(ifaceName string, enable bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetNetDevPromisc", ifaceName, enable)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetNetDevPromisc indicates an expected call of SetNetDevPromisc.
+func (mr *MockHostHelpersInterfaceMockRecorder) SetNetDevPromisc(ifaceName, enable interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetNetDevPromisc", reflect.TypeOf((*MockHostHelpersInterface)(nil).SetNetDevPromisc), ifaceName, enable)
+}
+
+// SetNetDevRss mocks base method.
+func (m *MockHostHelpersInterface) SetNetDevRss(ifaceName, hashKey string, indirectionSize int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetNetDevRss", ifaceName, hashKey, indirectionSize)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetNetDevRss indicates an expected call of SetNetDevRss.
+func (mr *MockHostHelpersInterfaceMockRecorder) SetNetDevRss(ifaceName, hashKey, indirectionSize interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetNetDevRss", reflect.TypeOf((*MockHostHelpersInterface)(nil).SetNetDevRss), ifaceName, hashKey, indirectionSize)
+}
+
+// SetNetDevSysctl mocks base method.
+func (m *MockHostHelpersInterface) SetNetDevSysctl(ifaceName, name, value string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetNetDevSysctl", ifaceName, name, value)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetNetDevSysctl indicates an expected call of SetNetDevSysctl.
+func (mr *MockHostHelpersInterfaceMockRecorder) SetNetDevSysctl(ifaceName, name, value interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetNetDevSysctl", reflect.TypeOf((*MockHostHelpersInterface)(nil).SetNetDevSysctl), ifaceName, name, value)
+}
+
+// SetNetDevVlanFiltering mocks base method.
+func (m *MockHostHelpersInterface) SetNetDevVlanFiltering(ifaceName string, enable bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetNetDevVlanFiltering", ifaceName, enable)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetNetDevVlanFiltering indicates an expected call of SetNetDevVlanFiltering.
+func (mr *MockHostHelpersInterfaceMockRecorder) SetNetDevVlanFiltering(ifaceName, enable interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetNetDevVlanFiltering", reflect.TypeOf((*MockHostHelpersInterface)(nil).SetNetDevVlanFiltering), ifaceName, enable)
+}
+
 // SetNetdevMTU mocks base method.
 func (m *MockHostHelpersInterface) SetNetdevMTU(pciAddr string, mtu int) error {
 	m.ctrl.T.Helper()
@@ -1101,6 +1416,35 @@ func (mr *MockHostHelpersInterfaceMockRecorder) SetVfAdminMac(vfAddr, pfLink, vf
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetVfAdminMac", reflect.TypeOf((*MockHostHelpersInterface)(nil).SetVfAdminMac), vfAddr, pfLink, vfLink)
 }
 
+// SetVfConfig mocks base method.
+func (m *MockHostHelpersInterface) SetVfConfig(pfName string, vfID int, config types.VfConfig) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetVfConfig", pfName, vfID, config)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetVfConfig indicates an expected call of SetVfConfig.
+func (mr *MockHostHelpersInterfaceMockRecorder) SetVfConfig(pfName, vfID, config interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetVfConfig", reflect.TypeOf((*MockHostHelpersInterface)(nil).SetVfConfig), pfName, vfID, config)
+}
+
+// SubscribeLinkUpdates mocks base method.
+func (m *MockHostHelpersInterface) SubscribeLinkUpdates(done <-chan struct{}) (<-chan string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubscribeLinkUpdates", done)
+	ret0, _ := ret[0].(<-chan string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SubscribeLinkUpdates indicates an expected call of SubscribeLinkUpdates.
+func (mr *MockHostHelpersInterfaceMockRecorder) SubscribeLinkUpdates(done interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubscribeLinkUpdates", reflect.TypeOf((*MockHostHelpersInterface)(nil).SubscribeLinkUpdates), done)
+}
+
 // TryEnableTun mocks base method.
 func (m *MockHostHelpersInterface) TryEnableTun() {
 	m.ctrl.T.Helper()
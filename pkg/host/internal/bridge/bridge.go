@@ -79,3 +79,13 @@ func (b *bridge) DetachInterfaceFromManagedBridge(pciAddr string) error {
 	}
 	return nil
 }
+
+// DiscoverBridgesStatistics returns the statistics of the ports of the managed bridges
+func (b *bridge) DiscoverBridgesStatistics() ([]types.OVSPortStatistics, error) {
+	stats, err := b.ovs.GetOVSPortsStatistics(context.Background())
+	if err != nil {
+		log.Log.Error(err, "DiscoverBridgesStatistics(): failed to get statistics of the managed OVS bridges")
+		return nil, err
+	}
+	return stats, nil
+}
@@ -42,6 +42,21 @@ var _ = Describe("Bridge", func() {
 		})
 	})
 
+	Context("DiscoverBridgesStatistics", func() {
+		It("succeed", func() {
+			ovsMock.EXPECT().GetOVSPortsStatistics(gomock.Any()).Return([]types.OVSPortStatistics{
+				{Bridge: "test", Port: "port1", Statistics: map[string]int{"rx_packets": 1}}}, nil)
+			ret, err := br.DiscoverBridgesStatistics()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ret).To(HaveLen(1))
+		})
+		It("error", func() {
+			ovsMock.EXPECT().GetOVSPortsStatistics(gomock.Any()).Return(nil, testErr)
+			_, err := br.DiscoverBridgesStatistics()
+			Expect(err).To(MatchError(testErr))
+		})
+	})
+
 	Context("ConfigureBridges", func() {
 		It("succeed", func() {
 			brCreate1 := sriovnetworkv1.OVSConfigExt{Name: "br-to-create-1"}
@@ -10,6 +10,7 @@ import (
 
 	gomock "github.com/golang/mock/gomock"
 	v1 "github.com/k8snetworkplumbingwg/sriov-network-operator/api/v1"
+	types "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/host/types"
 )
 
 // MockInterface is a mock of Interface interface.
@@ -64,6 +65,21 @@ func (mr *MockInterfaceMockRecorder) GetOVSBridges(ctx interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOVSBridges", reflect.TypeOf((*MockInterface)(nil).GetOVSBridges), ctx)
 }
 
+// GetOVSPortsStatistics mocks base method.
+func (m *MockInterface) GetOVSPortsStatistics(ctx context.Context) ([]types.OVSPortStatistics, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOVSPortsStatistics", ctx)
+	ret0, _ := ret[0].([]types.OVSPortStatistics)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOVSPortsStatistics indicates an expected call of GetOVSPortsStatistics.
+func (mr *MockInterfaceMockRecorder) GetOVSPortsStatistics(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOVSPortsStatistics", reflect.TypeOf((*MockInterface)(nil).GetOVSPortsStatistics), ctx)
+}
+
 // RemoveInterfaceFromOVSBridge mocks base method.
 func (m *MockInterface) RemoveInterfaceFromOVSBridge(ctx context.Context, ifaceAddr string) error {
 	m.ctrl.T.Helper()
@@ -1,11 +1,19 @@
 package ovs
 
 import (
+	"fmt"
+	"reflect"
 	"slices"
+	"sort"
 
 	"github.com/ovn-org/libovsdb/model"
+	"github.com/ovn-org/libovsdb/ovsdb"
 )
 
+// minOVSVersion is the oldest version of Open vSwitch providing all the columns of the models,
+// the mtu_request column of the Interface table was added in 2.6
+const minOVSVersion = "2.6"
+
 // OpenvSwitchEntry represents some fields of the object in the Open_vSwitch table
 type OpenvSwitchEntry struct {
 	UUID    string   `ovsdb:"_uuid"`
@@ -19,6 +27,8 @@ type BridgeEntry struct {
 	DatapathType string            `ovsdb:"datapath_type"`
 	ExternalIDs  map[string]string `ovsdb:"external_ids"`
 	OtherConfig  map[string]string `ovsdb:"other_config"`
+	FailMode     *string           `ovsdb:"fail_mode"`
+	Controller   []string          `ovsdb:"controller"`
 	Ports        []string          `ovsdb:"ports"`
 }
 
@@ -29,14 +39,17 @@ func (b *BridgeEntry) HasPort(portUUID string) bool {
 
 // InterfaceEntry represents some fields of the object in the Interface table
 type InterfaceEntry struct {
-	UUID        string            `ovsdb:"_uuid"`
-	Name        string            `ovsdb:"name"`
-	Type        string            `ovsdb:"type"`
-	Error       *string           `ovsdb:"error"`
-	Options     map[string]string `ovsdb:"options"`
-	ExternalIDs map[string]string `ovsdb:"external_ids"`
-	OtherConfig map[string]string `ovsdb:"other_config"`
-	MTURequest  *int              `ovsdb:"mtu_request"`
+	UUID                 string            `ovsdb:"_uuid"`
+	Name                 string            `ovsdb:"name"`
+	Type                 string            `ovsdb:"type"`
+	Error                *string           `ovsdb:"error"`
+	Options              map[string]string `ovsdb:"options"`
+	ExternalIDs          map[string]string `ovsdb:"external_ids"`
+	OtherConfig          map[string]string `ovsdb:"other_config"`
+	MTURequest           *int              `ovsdb:"mtu_request"`
+	IngressPolicingRate  int               `ovsdb:"ingress_policing_rate"`
+	IngressPolicingBurst int               `ovsdb:"ingress_policing_burst"`
+	Statistics           map[string]int    `ovsdb:"statistics"`
 }
 
 // PortEntry represents some fields of the object in the Port table
@@ -44,14 +57,70 @@ type PortEntry struct {
 	UUID       string   `ovsdb:"_uuid"`
 	Name       string   `ovsdb:"name"`
 	Interfaces []string `ovsdb:"interfaces"`
+	Tag        *int     `ovsdb:"tag"`
+	VlanMode   *string  `ovsdb:"vlan_mode"`
+	Trunks     []int    `ovsdb:"trunks"`
 }
 
-// DatabaseModel returns the DatabaseModel object to be used in libovsdb
-func DatabaseModel() (model.ClientDBModel, error) {
-	return model.NewClientDBModel("Open_vSwitch", map[string]model.Model{
+// ControllerEntry represents some fields of the object in the Controller table
+type ControllerEntry struct {
+	UUID   string `ovsdb:"_uuid"`
+	Target string `ovsdb:"target"`
+}
+
+// schemaProbeEntry is the minimal model used to read the schema of the OVSDB server
+type schemaProbeEntry struct {
+	UUID string `ovsdb:"_uuid"`
+}
+
+// tableModels returns the models of the tables used by the operator
+func tableModels() map[string]model.Model {
+	return map[string]model.Model{
 		"Bridge":       &BridgeEntry{},
+		"Controller":   &ControllerEntry{},
 		"Interface":    &InterfaceEntry{},
 		"Open_vSwitch": &OpenvSwitchEntry{},
 		"Port":         &PortEntry{},
-	})
+	}
+}
+
+// DatabaseModel returns the DatabaseModel object to be used in libovsdb
+func DatabaseModel() (model.ClientDBModel, error) {
+	return model.NewClientDBModel("Open_vSwitch", tableModels())
+}
+
+// schemaProbeModel returns the DatabaseModel object used to read the schema of the OVSDB server,
+// it only requires the Open_vSwitch table
+func schemaProbeModel() (model.ClientDBModel, error) {
+	return model.NewClientDBModel("Open_vSwitch", map[string]model.Model{"Open_vSwitch": &schemaProbeEntry{}})
+}
+
+// validateSchema returns an error naming the first table or column of the models which is missing
+// from the schema of the OVSDB server, e.g. because the server runs an older version of Open vSwitch
+func validateSchema(schema ovsdb.DatabaseSchema) error {
+	models := tableModels()
+	tables := make([]string, 0, len(models))
+	for table := range models {
+		tables = append(tables, table)
+	}
+	sort.Strings(tables)
+	for _, table := range tables {
+		tableSchema := schema.Table(table)
+		if tableSchema == nil {
+			return fmt.Errorf("table %s is missing from the OVSDB schema %s, Open vSwitch %s or newer is required",
+				table, schema.Version, minOVSVersion)
+		}
+		modelType := reflect.TypeOf(models[table]).Elem()
+		for i := 0; i < modelType.NumField(); i++ {
+			column := modelType.Field(i).Tag.Get("ovsdb")
+			if column == "" || column == "_uuid" {
+				continue
+			}
+			if tableSchema.Column(column) == nil {
+				return fmt.Errorf("column %s of table %s is missing from the OVSDB schema %s, Open vSwitch %s or newer is required",
+					column, table, schema.Version, minOVSVersion)
+			}
+		}
+	}
+	return nil
 }
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"os"
 	"reflect"
+	"slices"
 	"sort"
 	"strings"
 	"time"
@@ -19,6 +20,7 @@ import (
 
 	sriovnetworkv1 "github.com/k8snetworkplumbingwg/sriov-network-operator/api/v1"
 	ovsStorePkg "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/host/internal/bridge/ovs/store"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/host/types"
 	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/utils"
 	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/vars"
 )
@@ -34,6 +36,10 @@ const (
 	interfaceErrorCheckInterval = time.Second
 )
 
+// ErrOVSDBDisconnected is returned when the connection to the OVSDB server is lost during a transaction,
+// the operation can be retried with a new connection
+var ErrOVSDBDisconnected = errors.New("connection to OVSDB server lost")
+
 // Interface provides functions to configure managed OVS bridges
 //
 //go:generate ../../../../../bin/mockgen -destination mock/mock_ovs.go -source ovs.go
@@ -48,6 +54,8 @@ type Interface interface {
 	RemoveOVSBridge(ctx context.Context, bridgeName string) error
 	// RemoveInterfaceFromOVSBridge interface from the managed OVS bridge
 	RemoveInterfaceFromOVSBridge(ctx context.Context, ifaceAddr string) error
+	// GetOVSPortsStatistics returns the statistics of the ports of all managed bridges
+	GetOVSPortsStatistics(ctx context.Context) ([]types.OVSPortStatistics, error)
 }
 
 // New creates new instance of the OVS interface
@@ -61,6 +69,7 @@ type ovs struct {
 
 // CreateOVSBridge creates OVS bridge from the provided config,
 // does nothing if OVS bridge with the right config already exist,
+// if only options, external_ids, other_config or MTU of the uplink interface differ the interface is updated in place,
 // if OVS bridge exist with different config it will be removed and re-created
 func (o *ovs) CreateOVSBridge(ctx context.Context, conf *sriovnetworkv1.OVSConfigExt) error {
 	ctx, cancel := setDefaultTimeout(ctx)
@@ -109,6 +118,14 @@ func (o *ovs) CreateOVSBridge(ctx context.Context, conf *sriovnetworkv1.OVSConfi
 			}
 			funcLog.V(2).Info("CreateOVSBridge(): bridge state differs from the current configuration, reconfiguration required")
 			keepBridge = reflect.DeepEqual(conf.Bridge, currentState.Bridge)
+			if keepBridge && canUpdateInterfaceInPlace(conf, currentState) {
+				funcLog.V(2).Info("CreateOVSBridge(): only uplink interface configuration differs, update the interface in place")
+				if err := o.updateInterface(ctx, dbClient, &knownConfig.Uplinks[0].Interface, &conf.Uplinks[0]); err != nil {
+					funcLog.Error(err, "CreateOVSBridge(): failed to update uplink interface")
+					return err
+				}
+				return nil
+			}
 		}
 	} else {
 		funcLog.V(2).Info("CreateOVSBridge(): configuration for the bridge not found in the store, create the bridge")
@@ -128,13 +145,22 @@ func (o *ovs) CreateOVSBridge(ctx context.Context, conf *sriovnetworkv1.OVSConfi
 			return err
 		}
 		funcLog.V(2).Info("CreateOVSBridge(): create OVS bridge", "config", conf)
-		if err := o.createBridge(ctx, dbClient, &BridgeEntry{
+		br := &BridgeEntry{
 			Name:         conf.Name,
 			UUID:         uuid.NewString(),
 			DatapathType: conf.Bridge.DatapathType,
 			ExternalIDs:  conf.Bridge.ExternalIDs,
 			OtherConfig:  conf.Bridge.OtherConfig,
-		}); err != nil {
+		}
+		if conf.Bridge.FailMode != "" {
+			failMode := conf.Bridge.FailMode
+			br.FailMode = &failMode
+		}
+		var controller *ControllerEntry
+		if conf.Bridge.Controller != "" {
+			controller = &ControllerEntry{UUID: uuid.NewString(), Target: conf.Bridge.Controller}
+		}
+		if err := o.createBridge(ctx, dbClient, br, controller); err != nil {
 			return err
 		}
 	}
@@ -153,20 +179,22 @@ func (o *ovs) CreateOVSBridge(ctx context.Context, conf *sriovnetworkv1.OVSConfi
 		Name: bridge.Name,
 		UUID: uuid.NewString(),
 		Type: "internal",
-	}); err != nil {
+	}, nil); err != nil {
 		funcLog.Error(err, "CreateOVSBridge(): failed to add internal interface to the bridge")
 		return err
 	}
 	funcLog.V(2).Info("CreateOVSBridge(): add uplink interface to the bridge")
 	if err := o.addInterface(ctx, dbClient, bridge, &InterfaceEntry{
-		Name:        conf.Uplinks[0].Name,
-		UUID:        uuid.NewString(),
-		Type:        conf.Uplinks[0].Interface.Type,
-		Options:     conf.Uplinks[0].Interface.Options,
-		ExternalIDs: conf.Uplinks[0].Interface.ExternalIDs,
-		OtherConfig: conf.Uplinks[0].Interface.OtherConfig,
-		MTURequest:  conf.Uplinks[0].Interface.MTURequest,
-	}); err != nil {
+		Name:                 conf.Uplinks[0].Name,
+		UUID:                 uuid.NewString(),
+		Type:                 conf.Uplinks[0].Interface.Type,
+		Options:              conf.Uplinks[0].Interface.Options,
+		ExternalIDs:          conf.Uplinks[0].Interface.ExternalIDs,
+		OtherConfig:          conf.Uplinks[0].Interface.OtherConfig,
+		MTURequest:           conf.Uplinks[0].Interface.MTURequest,
+		IngressPolicingRate:  conf.Uplinks[0].Interface.IngressPolicingRate,
+		IngressPolicingBurst: conf.Uplinks[0].Interface.IngressPolicingBurst,
+	}, &conf.Uplinks[0].Port); err != nil {
 		funcLog.Error(err, "CreateOVSBridge(): failed to add uplink interface to the bridge")
 		return err
 	}
@@ -320,6 +348,65 @@ func (o *ovs) RemoveInterfaceFromOVSBridge(ctx context.Context, pciAddress strin
 	return nil
 }
 
+// GetOVSPortsStatistics returns the statistics of the ports of all managed bridges
+func (o *ovs) GetOVSPortsStatistics(ctx context.Context) ([]types.OVSPortStatistics, error) {
+	ctx, cancel := setDefaultTimeout(ctx)
+	defer cancel()
+	funcLog := log.Log
+	funcLog.V(2).Info("GetOVSPortsStatistics(): get statistics of the ports of the managed OVS bridges")
+	knownConfigs, err := o.store.GetManagedOVSBridges()
+	if err != nil {
+		funcLog.Error(err, "GetOVSPortsStatistics(): failed to read data from store")
+		return nil, fmt.Errorf("failed to read data from store: %v", err)
+	}
+	if len(knownConfigs) == 0 {
+		return nil, nil
+	}
+	dbClient, err := getClient(ctx)
+	if err != nil {
+		funcLog.Error(err, "GetOVSPortsStatistics(): failed to connect to OVSDB")
+		return nil, fmt.Errorf("failed to connect to OVSDB: %v", err)
+	}
+	defer dbClient.Close()
+
+	result := []types.OVSPortStatistics{}
+	for brName := range knownConfigs {
+		bridge, err := o.getBridgeByName(ctx, dbClient, brName)
+		if err != nil {
+			return nil, err
+		}
+		if bridge == nil {
+			continue
+		}
+		for _, portUUID := range bridge.Ports {
+			port := &PortEntry{UUID: portUUID}
+			if err := dbClient.Get(ctx, port); err != nil {
+				return nil, fmt.Errorf("get call for the port %s failed: %v", portUUID, err)
+			}
+			stats := map[string]int{}
+			// bonded ports have multiple interfaces, the counters of the port are the sum of them
+			for _, ifaceUUID := range port.Interfaces {
+				iface := &InterfaceEntry{UUID: ifaceUUID}
+				if err := dbClient.Get(ctx, iface); err != nil {
+					return nil, fmt.Errorf("get call for the interface %s failed: %v", ifaceUUID, err)
+				}
+				for name, value := range iface.Statistics {
+					stats[name] += value
+				}
+			}
+			result = append(result, types.OVSPortStatistics{Bridge: bridge.Name, Port: port.Name, Statistics: stats})
+		}
+	}
+	// always return ports in the same order
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Bridge != result[j].Bridge {
+			return result[i].Bridge < result[j].Bridge
+		}
+		return result[i].Port < result[j].Port
+	})
+	return result, nil
+}
+
 func (o *ovs) getBridgeByName(ctx context.Context, dbClient client.Client, name string) (*BridgeEntry, error) {
 	br := &BridgeEntry{Name: name}
 	if err := dbClient.Get(ctx, br); err != nil {
@@ -378,8 +465,17 @@ func (o *ovs) getBridgeByPort(ctx context.Context, dbClient client.Client, port
 	return brEntryList[0], nil
 }
 
-// create bridge with provided configuration
-func (o *ovs) createBridge(ctx context.Context, dbClient client.Client, br *BridgeEntry) error {
+// create bridge with provided configuration, the controller is optional
+func (o *ovs) createBridge(ctx context.Context, dbClient client.Client, br *BridgeEntry, controller *ControllerEntry) error {
+	var operations [][]ovsdb.Operation
+	if controller != nil {
+		controllerCreateOps, err := dbClient.Create(controller)
+		if err != nil {
+			return fmt.Errorf("failed to prepare operation for controller creation: %v", err)
+		}
+		operations = append(operations, controllerCreateOps)
+		br.Controller = []string{controller.UUID}
+	}
 	brCreateOps, err := dbClient.Create(br)
 	if err != nil {
 		return fmt.Errorf("failed to prepare operation for bridge creation: %v", err)
@@ -396,20 +492,31 @@ func (o *ovs) createBridge(ctx context.Context, dbClient client.Client, br *Brid
 	if err != nil {
 		return fmt.Errorf("failed to create mutate operation for Open_vSwitch table: %v", err)
 	}
-	if err := o.execTransaction(ctx, dbClient, brCreateOps, ovsMutateOps); err != nil {
-		return fmt.Errorf("bridge creation failed: %v", err)
+	operations = append(operations, brCreateOps, ovsMutateOps)
+	if err := o.execTransaction(ctx, dbClient, operations...); err != nil {
+		return fmt.Errorf("bridge creation failed: %w", err)
 	}
 	return nil
 }
 
-// add interface with provided configuration to the provided bridge
-// and check that interface has no error for the next 2 seconds
-func (o *ovs) addInterface(ctx context.Context, dbClient client.Client, br *BridgeEntry, iface *InterfaceEntry) error {
+// add interface with provided configuration to the provided bridge,
+// portConf is optional and contains the VLAN configuration of the port created for the interface,
+// check that interface has no error for the next 2 seconds
+func (o *ovs) addInterface(ctx context.Context, dbClient client.Client, br *BridgeEntry, iface *InterfaceEntry,
+	portConf *sriovnetworkv1.OVSPortConfig) error {
 	addInterfaceOPs, err := dbClient.Create(iface)
 	if err != nil {
 		return fmt.Errorf("failed to prepare operation for interface creation: %v", err)
 	}
 	port := &PortEntry{Name: iface.Name, UUID: uuid.NewString(), Interfaces: []string{iface.UUID}}
+	if portConf != nil {
+		port.Tag = portConf.Vlan
+		port.Trunks = portConf.Trunks
+		if portConf.VlanMode != "" {
+			vlanMode := portConf.VlanMode
+			port.VlanMode = &vlanMode
+		}
+	}
 	addPortOPs, err := dbClient.Create(port)
 	if err != nil {
 		return fmt.Errorf("failed to prepare operation for port creation: %v", err)
@@ -423,7 +530,7 @@ func (o *ovs) addInterface(ctx context.Context, dbClient client.Client, br *Brid
 		return fmt.Errorf("failed to prepare operation for bridge mutate: %v", err)
 	}
 	if err := o.execTransaction(ctx, dbClient, addInterfaceOPs, addPortOPs, bridgeMutateOps); err != nil {
-		return fmt.Errorf("bridge deletion failed: %v", err)
+		return fmt.Errorf("bridge deletion failed: %w", err)
 	}
 	// check that interface has no error right after creation
 	for i := 0; i < interfaceErrorCheckCount; i++ {
@@ -435,12 +542,64 @@ func (o *ovs) addInterface(ctx context.Context, dbClient client.Client, br *Brid
 			return fmt.Errorf("failed to read interface after creation: %v", err)
 		}
 		if iface.Error != nil {
-			return fmt.Errorf("created interface is in error state: %s", *iface.Error)
+			return &types.OVSInterfaceError{Bridge: br.Name, Interface: iface.Name, Message: *iface.Error}
 		}
 	}
 	return nil
 }
 
+// update options, external_ids, other_config, mtu_request and ingress policing of the existing uplink interface without recreating it.
+// knownConfig contains the previous configuration of the interface, it is used to remove keys which are no longer managed
+func (o *ovs) updateInterface(ctx context.Context, dbClient client.Client,
+	knownConfig *sriovnetworkv1.OVSInterfaceConfig, uplink *sriovnetworkv1.OVSUplinkConfigExt) error {
+	iface, err := o.getInterfaceByName(ctx, dbClient, uplink.Name)
+	if err != nil {
+		return err
+	}
+	if iface == nil {
+		return fmt.Errorf("can't find interface %s", uplink.Name)
+	}
+	var mutations []model.Mutation
+	mutations = append(mutations, getMapMutations(&iface.Options, iface.Options, knownConfig.Options, uplink.Interface.Options)...)
+	mutations = append(mutations, getMapMutations(&iface.ExternalIDs, iface.ExternalIDs, knownConfig.ExternalIDs, uplink.Interface.ExternalIDs)...)
+	mutations = append(mutations, getMapMutations(&iface.OtherConfig, iface.OtherConfig, knownConfig.OtherConfig, uplink.Interface.OtherConfig)...)
+
+	var operations [][]ovsdb.Operation
+	// mutations are applied in separate operations to make sure that keys are removed before new values are inserted
+	for _, m := range mutations {
+		mutateOps, err := dbClient.Where(iface).Mutate(iface, m)
+		if err != nil {
+			return fmt.Errorf("failed to prepare operation for interface mutate: %v", err)
+		}
+		operations = append(operations, mutateOps)
+	}
+	if !reflect.DeepEqual(iface.MTURequest, uplink.Interface.MTURequest) {
+		iface.MTURequest = uplink.Interface.MTURequest
+		updateOps, err := dbClient.Where(iface).Update(iface, &iface.MTURequest)
+		if err != nil {
+			return fmt.Errorf("failed to prepare operation for interface update: %v", err)
+		}
+		operations = append(operations, updateOps)
+	}
+	if iface.IngressPolicingRate != uplink.Interface.IngressPolicingRate ||
+		iface.IngressPolicingBurst != uplink.Interface.IngressPolicingBurst {
+		iface.IngressPolicingRate = uplink.Interface.IngressPolicingRate
+		iface.IngressPolicingBurst = uplink.Interface.IngressPolicingBurst
+		updateOps, err := dbClient.Where(iface).Update(iface, &iface.IngressPolicingRate, &iface.IngressPolicingBurst)
+		if err != nil {
+			return fmt.Errorf("failed to prepare operation for interface update: %v", err)
+		}
+		operations = append(operations, updateOps)
+	}
+	if len(operations) == 0 {
+		return nil
+	}
+	if err := o.execTransaction(ctx, dbClient, operations...); err != nil {
+		return fmt.Errorf("interface update failed: %w", err)
+	}
+	return nil
+}
+
 // delete bridge by the name
 func (o *ovs) deleteBridgeByName(ctx context.Context, dbClient client.Client, brName string) error {
 	br, err := o.getBridgeByName(ctx, dbClient, brName)
@@ -467,7 +626,7 @@ func (o *ovs) deleteBridgeByName(ctx context.Context, dbClient client.Client, br
 		return fmt.Errorf("failed to create mutate operation for Open_vSwitch table: %v", err)
 	}
 	if err := o.execTransaction(ctx, dbClient, brDeleteOps, ovsMutateOps); err != nil {
-		return fmt.Errorf("bridge deletion failed: %v", err)
+		return fmt.Errorf("bridge deletion failed: %w", err)
 	}
 	return nil
 }
@@ -516,7 +675,7 @@ func (o *ovs) deleteInterfaceByName(ctx context.Context, dbClient client.Client,
 		}
 	}
 	if err := o.execTransaction(ctx, dbClient, operations...); err != nil {
-		return fmt.Errorf("failed to remove interface %s: %v", iface.Name, err)
+		return fmt.Errorf("failed to remove interface %s: %w", iface.Name, err)
 	}
 	return nil
 }
@@ -527,9 +686,9 @@ func (o *ovs) execTransaction(ctx context.Context, dbClient client.Client, ops .
 	for _, o := range ops {
 		operations = append(operations, o...)
 	}
-	result, err := dbClient.Transact(ctx, operations...)
+	result, err := transact(ctx, dbClient, operations)
 	if err != nil {
-		return fmt.Errorf("transaction failed: %v", err)
+		return fmt.Errorf("transaction failed: %w", err)
 	}
 	operationsErr, err := ovsdb.CheckOperationResults(result, operations)
 	if err != nil || len(operationsErr) > 0 {
@@ -538,6 +697,35 @@ func (o *ovs) execTransaction(ctx context.Context, dbClient client.Client, ops .
 	return nil
 }
 
+// transact executes the operations and aborts the transaction as soon as the client
+// is notified that the connection to the OVSDB server is lost
+func transact(ctx context.Context, dbClient client.Client, operations []ovsdb.Operation) ([]ovsdb.OperationResult, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	disconnected := make(chan struct{})
+	go func() {
+		select {
+		case <-dbClient.DisconnectNotify():
+			close(disconnected)
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+	result, err := dbClient.Transact(ctx, operations...)
+	if err != nil {
+		select {
+		case <-disconnected:
+			return nil, fmt.Errorf("%w: %v", ErrOVSDBDisconnected, err)
+		default:
+		}
+		if errors.Is(err, client.ErrNotConnected) {
+			return nil, fmt.Errorf("%w: %v", ErrOVSDBDisconnected, err)
+		}
+		return nil, err
+	}
+	return result, nil
+}
+
 // return current state of the bridge and of the uplink interface.
 // uses knownConfig to check which fields are managed by the operator (other fields can be updated OVS itself or by other programs,
 // we should not take them into account)
@@ -561,6 +749,17 @@ func (o *ovs) getCurrentBridgeState(ctx context.Context, dbClient client.Client,
 			OtherConfig: updateMap(knownConfig.Bridge.OtherConfig, bridge.OtherConfig),
 		},
 	}
+	// fail_mode and controller are reported only if they were set by the operator
+	if knownConfig.Bridge.FailMode != "" && bridge.FailMode != nil {
+		currentConfig.Bridge.FailMode = *bridge.FailMode
+	}
+	if knownConfig.Bridge.Controller != "" && len(bridge.Controller) > 0 {
+		controller := &ControllerEntry{UUID: bridge.Controller[0]}
+		if err := dbClient.Get(ctx, controller); err != nil {
+			return nil, fmt.Errorf("get call for the controller of the bridge %s failed: %v", bridge.Name, err)
+		}
+		currentConfig.Bridge.Controller = controller.Target
+	}
 	if len(knownConfig.Uplinks) == 0 {
 		return currentConfig, nil
 	}
@@ -596,16 +795,28 @@ func (o *ovs) getCurrentBridgeState(ctx context.Context, dbClient client.Client,
 		PciAddress: knownConfigUplink.PciAddress,
 		Name:       knownConfigUplink.Name,
 		Interface: sriovnetworkv1.OVSInterfaceConfig{
-			Type:        iface.Type,
-			ExternalIDs: updateMap(knownConfigUplink.Interface.ExternalIDs, iface.ExternalIDs),
-			Options:     updateMap(knownConfigUplink.Interface.Options, iface.Options),
-			OtherConfig: updateMap(knownConfigUplink.Interface.OtherConfig, iface.OtherConfig),
+			Type:                 iface.Type,
+			ExternalIDs:          updateMap(knownConfigUplink.Interface.ExternalIDs, iface.ExternalIDs),
+			Options:              updateMap(knownConfigUplink.Interface.Options, iface.Options),
+			OtherConfig:          updateMap(knownConfigUplink.Interface.OtherConfig, iface.OtherConfig),
+			IngressPolicingRate:  iface.IngressPolicingRate,
+			IngressPolicingBurst: iface.IngressPolicingBurst,
+		},
+		Port: sriovnetworkv1.OVSPortConfig{
+			Trunks: getSortedTrunks(port.Trunks),
 		},
 	}}
 	if iface.MTURequest != nil {
 		mtu := *iface.MTURequest
 		currentConfig.Uplinks[0].Interface.MTURequest = &mtu
 	}
+	if port.Tag != nil {
+		tag := *port.Tag
+		currentConfig.Uplinks[0].Port.Vlan = &tag
+	}
+	if port.VlanMode != nil {
+		currentConfig.Uplinks[0].Port.VlanMode = *port.VlanMode
+	}
 	return currentConfig, nil
 }
 
@@ -644,6 +855,65 @@ func updateMap(old, new map[string]string) map[string]string {
 	return result
 }
 
+// returns true if the uplink interface of the current state can be updated to the desired
+// configuration without recreation, this is possible if the uplink is the same, the interface type is not changed
+// and the port configuration is the same
+func canUpdateInterfaceInPlace(desired, current *sriovnetworkv1.OVSConfigExt) bool {
+	if len(desired.Uplinks) != 1 || len(current.Uplinks) != 1 {
+		return false
+	}
+	return desired.Uplinks[0].Name == current.Uplinks[0].Name &&
+		desired.Uplinks[0].PciAddress == current.Uplinks[0].PciAddress &&
+		desired.Uplinks[0].Interface.Type == current.Uplinks[0].Interface.Type &&
+		reflect.DeepEqual(desired.Uplinks[0].Port, current.Uplinks[0].Port)
+}
+
+// returns sorted copy of the trunks of the port, OVS stores them as a set,
+// nil is returned if the port has no trunks
+func getSortedTrunks(trunks []int) []int {
+	if len(trunks) == 0 {
+		return nil
+	}
+	result := slices.Clone(trunks)
+	slices.Sort(result)
+	return result
+}
+
+// returns mutations for the map column (field) with the current value which set all keys from the desired map
+// and remove keys that were managed before (known) but are not part of the desired map.
+// keys which are not managed by the operator are kept as is
+func getMapMutations(field *map[string]string, current, known, desired map[string]string) []model.Mutation {
+	toDelete := []string{}
+	toInsert := map[string]string{}
+	for k, v := range desired {
+		curVal, found := current[k]
+		if found && curVal == v {
+			continue
+		}
+		if found {
+			toDelete = append(toDelete, k)
+		}
+		toInsert[k] = v
+	}
+	for k := range known {
+		if _, found := desired[k]; found {
+			continue
+		}
+		if _, found := current[k]; found {
+			toDelete = append(toDelete, k)
+		}
+	}
+	var mutations []model.Mutation
+	if len(toDelete) > 0 {
+		sort.Strings(toDelete)
+		mutations = append(mutations, model.Mutation{Field: field, Mutator: ovsdb.MutateOperationDelete, Value: toDelete})
+	}
+	if len(toInsert) > 0 {
+		mutations = append(mutations, model.Mutation{Field: field, Mutator: ovsdb.MutateOperationInsert, Value: toInsert})
+	}
+	return mutations
+}
+
 // returns path for the OVDSB socket
 // for unix sockets it is taking into account current FS root and possible symlinks
 func getDBSocketPath() (string, error) {
@@ -673,10 +943,47 @@ func getDBSocketPath() (string, error) {
 	return "unix://" + resolvedPath, nil
 }
 
+// checks that the unix socket path points to a socket,
+// tcp sockets are not validated
+func validateDBSocket(socketPath string) error {
+	pathNoPrefix, isUnix := strings.CutPrefix(socketPath, "unix://")
+	if !isUnix {
+		return nil
+	}
+	info, err := os.Stat(pathNoPrefix)
+	if err != nil {
+		return fmt.Errorf("can't access OVSDB socket %s: %v", pathNoPrefix, err)
+	}
+	if info.Mode()&os.ModeSocket == 0 {
+		return fmt.Errorf("OVSDB socket path %s is not a unix socket", pathNoPrefix)
+	}
+	return nil
+}
+
+// getServerSchema connects to the OVSDB server with a minimal model and returns the schema of the Open_vSwitch database
+func getServerSchema(ctx context.Context, socketPath string) (ovsdb.DatabaseSchema, error) {
+	probeModel, err := schemaProbeModel()
+	if err != nil {
+		return ovsdb.DatabaseSchema{}, fmt.Errorf("can't create schema probe DB model: %v", err)
+	}
+	probeClient, err := client.NewOVSDBClient(probeModel,
+		client.WithEndpoint(socketPath),
+		client.WithLogger(&log.Log))
+	if err != nil {
+		return ovsdb.DatabaseSchema{}, fmt.Errorf("can't create schema probe DB client: %v", err)
+	}
+	if err := probeClient.Connect(ctx); err != nil {
+		return ovsdb.DatabaseSchema{}, fmt.Errorf("can't connect to ovsdb server: %v", err)
+	}
+	defer probeClient.Close()
+	return probeClient.Schema(), nil
+}
+
 // initialize and return OVSDB client
 func getClient(ctx context.Context) (client.Client, error) {
 	openvSwitchEntry := &OpenvSwitchEntry{}
 	bridgeEntry := &BridgeEntry{}
+	controllerEntry := &ControllerEntry{}
 	interfaceEntry := &InterfaceEntry{}
 	portEntry := &PortEntry{}
 	clientDBModel, err := DatabaseModel()
@@ -688,6 +995,19 @@ func getClient(ctx context.Context) (client.Client, error) {
 	if err != nil {
 		return nil, fmt.Errorf("can't find OVSDB socket %s: %v", vars.OVSDBSocketPath, err)
 	}
+	if err := validateDBSocket(socketPath); err != nil {
+		return nil, err
+	}
+
+	// the client fails to connect with an obscure error if a column of the models is missing,
+	// check the schema of the server first to report the missing column
+	schema, err := getServerSchema(ctx, socketPath)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateSchema(schema); err != nil {
+		return nil, err
+	}
 
 	dbClient, err := client.NewOVSDBClient(clientDBModel,
 		client.WithEndpoint(socketPath),
@@ -711,8 +1031,14 @@ func getClient(ctx context.Context) (client.Client, error) {
 			&bridgeEntry.DatapathType,
 			&bridgeEntry.ExternalIDs,
 			&bridgeEntry.OtherConfig,
+			&bridgeEntry.FailMode,
+			&bridgeEntry.Controller,
 			&bridgeEntry.Ports,
 		),
+		client.WithTable(controllerEntry,
+			&controllerEntry.UUID,
+			&controllerEntry.Target,
+		),
 		client.WithTable(interfaceEntry,
 			&interfaceEntry.UUID,
 			&interfaceEntry.Name,
@@ -722,11 +1048,16 @@ func getClient(ctx context.Context) (client.Client, error) {
 			&interfaceEntry.ExternalIDs,
 			&interfaceEntry.OtherConfig,
 			&interfaceEntry.MTURequest,
+			&interfaceEntry.IngressPolicingRate,
+			&interfaceEntry.IngressPolicingBurst,
 		),
 		client.WithTable(portEntry,
 			&portEntry.UUID,
 			&portEntry.Name,
 			&portEntry.Interfaces,
+			&portEntry.Tag,
+			&portEntry.VlanMode,
+			&portEntry.Trunks,
 		),
 	))
 	if err != nil {
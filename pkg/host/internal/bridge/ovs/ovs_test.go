@@ -21,6 +21,7 @@ import (
 
 	sriovnetworkv1 "github.com/k8snetworkplumbingwg/sriov-network-operator/api/v1"
 	ovsStoreMockPkg "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/host/internal/bridge/ovs/store/mock"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/host/types"
 	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/vars"
 	"github.com/k8snetworkplumbingwg/sriov-network-operator/test/util/fakefilesystem"
 	"github.com/k8snetworkplumbingwg/sriov-network-operator/test/util/helpers"
@@ -40,11 +41,13 @@ func getManagedBridges() map[string]*sriovnetworkv1.OVSConfigExt {
 				PciAddress: "0000:d8:00.0",
 				Name:       "enp216s0f0np0",
 				Interface: sriovnetworkv1.OVSInterfaceConfig{
-					Type:        "dpdk",
-					ExternalIDs: map[string]string{"iface_externalID_key": "iface_externalID_value"},
-					OtherConfig: map[string]string{"iface_otherConfig_key": "iface_otherConfig_value"},
-					Options:     map[string]string{"iface_options_key": "iface_options_value"},
-					MTURequest:  &mtu,
+					Type:                 "dpdk",
+					ExternalIDs:          map[string]string{"iface_externalID_key": "iface_externalID_value"},
+					OtherConfig:          map[string]string{"iface_otherConfig_key": "iface_otherConfig_value"},
+					Options:              map[string]string{"iface_options_key": "iface_options_value"},
+					MTURequest:           &mtu,
+					IngressPolicingRate:  5000,
+					IngressPolicingBurst: 500,
 				},
 			}},
 		},
@@ -54,6 +57,7 @@ func getManagedBridges() map[string]*sriovnetworkv1.OVSConfigExt {
 type testDBEntries struct {
 	OpenVSwitch []*OpenvSwitchEntry
 	Bridge      []*BridgeEntry
+	Controller  []*ControllerEntry
 	Port        []*PortEntry
 	Interface   []*InterfaceEntry
 }
@@ -68,6 +72,9 @@ func (t *testDBEntries) GetCreateOperations(c client.Client) []ovsdb.Operation {
 	for _, o := range t.Bridge {
 		mdls = append(mdls, o)
 	}
+	for _, o := range t.Controller {
+		mdls = append(mdls, o)
+	}
 	for _, o := range t.Port {
 		mdls = append(mdls, o)
 	}
@@ -87,13 +94,15 @@ func (t *testDBEntries) GetCreateOperations(c client.Client) []ovsdb.Operation {
 func getDefaultInitialDBContent() *testDBEntries {
 	mtu := 5000
 	iface := &InterfaceEntry{
-		Name:        "enp216s0f0np0",
-		UUID:        uuid.NewString(),
-		Type:        "dpdk",
-		ExternalIDs: map[string]string{"iface_externalID_key": "iface_externalID_value"},
-		OtherConfig: map[string]string{"iface_otherConfig_key": "iface_otherConfig_value"},
-		Options:     map[string]string{"iface_options_key": "iface_options_value"},
-		MTURequest:  &mtu,
+		Name:                 "enp216s0f0np0",
+		UUID:                 uuid.NewString(),
+		Type:                 "dpdk",
+		ExternalIDs:          map[string]string{"iface_externalID_key": "iface_externalID_value"},
+		OtherConfig:          map[string]string{"iface_otherConfig_key": "iface_otherConfig_value"},
+		Options:              map[string]string{"iface_options_key": "iface_options_value"},
+		MTURequest:           &mtu,
+		IngressPolicingRate:  5000,
+		IngressPolicingBurst: 500,
 	}
 	port := &PortEntry{
 		Name:       "enp216s0f0np0",
@@ -124,6 +133,11 @@ func getDBContent(ctx context.Context, c client.Client) *testDBEntries {
 	ret := &testDBEntries{}
 	Expect(c.List(ctx, &ret.OpenVSwitch)).NotTo(HaveOccurred())
 	Expect(c.List(ctx, &ret.Bridge)).NotTo(HaveOccurred())
+	Expect(c.List(ctx, &ret.Controller)).NotTo(HaveOccurred())
+	if len(ret.Controller) == 0 {
+		// most of the tests don't configure controllers, keep the content comparable with the initial one
+		ret.Controller = nil
+	}
 	Expect(c.List(ctx, &ret.Port)).NotTo(HaveOccurred())
 	Expect(c.List(ctx, &ret.Interface)).NotTo(HaveOccurred())
 	return ret
@@ -169,6 +183,15 @@ func validateDBConfig(dbContent *testDBEntries, conf *sriovnetworkv1.OVSConfigEx
 	Expect(iface.OtherConfig).To(Equal(conf.Uplinks[0].Interface.OtherConfig))
 	Expect(iface.ExternalIDs).To(Equal(conf.Uplinks[0].Interface.ExternalIDs))
 	Expect(iface.MTURequest).To(Equal(conf.Uplinks[0].Interface.MTURequest))
+	Expect(iface.IngressPolicingRate).To(Equal(conf.Uplinks[0].Interface.IngressPolicingRate))
+	Expect(iface.IngressPolicingBurst).To(Equal(conf.Uplinks[0].Interface.IngressPolicingBurst))
+	Expect(port.Tag).To(Equal(conf.Uplinks[0].Port.Vlan))
+	Expect(getSortedTrunks(port.Trunks)).To(Equal(conf.Uplinks[0].Port.Trunks))
+	if conf.Uplinks[0].Port.VlanMode != "" {
+		Expect(port.VlanMode).To(HaveValue(Equal(conf.Uplinks[0].Port.VlanMode)))
+	} else {
+		Expect(port.VlanMode).To(BeNil())
+	}
 	internalPort, ok := ports[conf.Name]
 	Expect(ok).To(BeTrue())
 	internalIface, ok := interfaces[conf.Name]
@@ -251,6 +274,84 @@ var _ = Describe("OVS", func() {
 			Expect(c).To(BeNil())
 			Expect(err).To(MatchError(ContainSubstring("can't find OVSDB socket")))
 		})
+		It("path is not a socket", func() {
+			helpers.GinkgoConfigureFakeFS(&fakefilesystem.FS{
+				Dirs:  []string{"/ovs"},
+				Files: map[string][]byte{"/ovs/ovsdb.sock": {}},
+			})
+			vars.InChroot = true
+			vars.OVSDBSocketPath = "unix:///ovs/ovsdb.sock"
+			c, err := getClient(ctx)
+			Expect(c).To(BeNil())
+			Expect(err).To(MatchError(ContainSubstring("is not a unix socket")))
+		})
+		It("connect to temp unix socket", func() {
+			tempDir, err := os.MkdirTemp("", "sriov-operator-ovs-test-dir*")
+			Expect(err).NotTo(HaveOccurred())
+			defer os.RemoveAll(tempDir)
+			testServerSocket := filepath.Join(tempDir, "ovsdb.sock")
+			stopServerFunc := startServer("unix", testServerSocket)
+			defer stopServerFunc()
+			vars.InChroot = true
+			vars.FilesystemRoot = ""
+			vars.OVSDBSocketPath = "unix://" + testServerSocket
+			c, err := getClient(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(c.Connected()).To(BeTrue())
+			c.Close()
+		})
+		It("report a column missing from the schema of the server", func() {
+			tempDir, err := os.MkdirTemp("", "sriov-operator-ovs-test-dir*")
+			Expect(err).NotTo(HaveOccurred())
+			defer os.RemoveAll(tempDir)
+			testServerSocket := filepath.Join(tempDir, "ovsdb.sock")
+			stopServerFunc := startLegacyServer("unix", testServerSocket)
+			defer stopServerFunc()
+			vars.InChroot = true
+			vars.FilesystemRoot = ""
+			vars.OVSDBSocketPath = "unix://" + testServerSocket
+			c, err := getClient(ctx)
+			Expect(c).To(BeNil())
+			Expect(err).To(MatchError(MatchRegexp(
+				"column vlan_mode of table Port is missing from the OVSDB schema .*, Open vSwitch 2.6 or newer is required")))
+		})
+		Context("transaction", func() {
+			var (
+				tempDir        string
+				stopServerFunc func()
+				ovsClient      client.Client
+			)
+			BeforeEach(func() {
+				var err error
+				tempDir, err = os.MkdirTemp("", "sriov-operator-ovs-test-dir*")
+				Expect(err).NotTo(HaveOccurred())
+				testServerSocket := filepath.Join(tempDir, "ovsdb.sock")
+				stopServerFunc = startServer("unix", testServerSocket)
+				vars.InChroot = true
+				vars.FilesystemRoot = ""
+				vars.OVSDBSocketPath = "unix://" + testServerSocket
+				ovsClient, err = getClient(ctx)
+				Expect(err).NotTo(HaveOccurred())
+			})
+			AfterEach(func() {
+				ovsClient.Close()
+				stopServerFunc()
+				Expect(os.RemoveAll(tempDir)).NotTo(HaveOccurred())
+			})
+			It("should return ErrOVSDBDisconnected when the connection drops during the transaction", func() {
+				c := &blockingTransactClient{Client: ovsClient, disconnect: make(chan struct{})}
+				ops, err := c.Create(&BridgeEntry{Name: "br-test", UUID: uuid.NewString()})
+				Expect(err).NotTo(HaveOccurred())
+
+				errCh := make(chan error, 1)
+				go func() {
+					errCh <- (&ovs{}).execTransaction(ctx, c, ops)
+				}()
+				// simulate the disconnect notification sent by the client while the transaction is in progress
+				Eventually(c.disconnect).WithTimeout(time.Second * 5).Should(BeSent(struct{}{}))
+				Eventually(errCh).WithTimeout(time.Second * 5).Should(Receive(MatchError(ErrOVSDBDisconnected)))
+			})
+		})
 		Context("getDBSocketPath()", func() {
 			It("tcp socket", func() {
 				vars.OVSDBSocketPath = "tcp://127.0.0.1:4444"
@@ -352,6 +453,71 @@ var _ = Describe("OVS", func() {
 
 				validateDBConfig(getDBContent(ctx, ovsClient), expectedConf)
 			})
+			It("No Bridge, create bridge with fail_mode and controller", func() {
+				expectedConf := getManagedBridges()["br-0000_d8_00.0"]
+				expectedConf.Bridge.FailMode = "secure"
+				expectedConf.Bridge.Controller = "tcp:192.0.2.10:6653"
+				store.EXPECT().GetManagedOVSBridge("br-0000_d8_00.0").Return(nil, nil)
+				createInitialDBContent(ctx, ovsClient, &testDBEntries{OpenVSwitch: []*OpenvSwitchEntry{{UUID: uuid.NewString()}}})
+
+				store.EXPECT().AddManagedOVSBridge(expectedConf).Return(nil)
+				Expect(ovs.CreateOVSBridge(ctx, expectedConf)).NotTo(HaveOccurred())
+
+				dbContent := getDBContent(ctx, ovsClient)
+				validateDBConfig(dbContent, expectedConf)
+				Expect(dbContent.Bridge[0].FailMode).To(HaveValue(Equal("secure")))
+				Expect(dbContent.Controller).To(HaveLen(1))
+				Expect(dbContent.Controller[0].Target).To(Equal("tcp:192.0.2.10:6653"))
+				Expect(dbContent.Bridge[0].Controller).To(Equal([]string{dbContent.Controller[0].UUID}))
+			})
+			It("No Bridge, create bridge with access VLAN on the uplink port", func() {
+				expectedConf := getManagedBridges()["br-0000_d8_00.0"]
+				vlan := 100
+				expectedConf.Uplinks[0].Port = sriovnetworkv1.OVSPortConfig{Vlan: &vlan, VlanMode: "access"}
+				store.EXPECT().GetManagedOVSBridge("br-0000_d8_00.0").Return(nil, nil)
+				createInitialDBContent(ctx, ovsClient, &testDBEntries{OpenVSwitch: []*OpenvSwitchEntry{{UUID: uuid.NewString()}}})
+
+				store.EXPECT().AddManagedOVSBridge(expectedConf).Return(nil)
+				Expect(ovs.CreateOVSBridge(ctx, expectedConf)).NotTo(HaveOccurred())
+
+				validateDBConfig(getDBContent(ctx, ovsClient), expectedConf)
+			})
+			It("No Bridge, create bridge with trunk VLANs on the uplink port", func() {
+				expectedConf := getManagedBridges()["br-0000_d8_00.0"]
+				vlan := 10
+				expectedConf.Uplinks[0].Port = sriovnetworkv1.OVSPortConfig{Vlan: &vlan, VlanMode: "native-untagged", Trunks: []int{10, 100, 200}}
+				store.EXPECT().GetManagedOVSBridge("br-0000_d8_00.0").Return(nil, nil)
+				createInitialDBContent(ctx, ovsClient, &testDBEntries{OpenVSwitch: []*OpenvSwitchEntry{{UUID: uuid.NewString()}}})
+
+				store.EXPECT().AddManagedOVSBridge(expectedConf).Return(nil)
+				Expect(ovs.CreateOVSBridge(ctx, expectedConf)).NotTo(HaveOccurred())
+
+				dbContent := getDBContent(ctx, ovsClient)
+				validateDBConfig(dbContent, expectedConf)
+
+				// the VLAN configuration is read back from the port, nothing to do on the next call
+				store.EXPECT().GetManagedOVSBridge("br-0000_d8_00.0").Return(expectedConf, nil)
+				Expect(ovs.CreateOVSBridge(ctx, expectedConf)).NotTo(HaveOccurred())
+				newDBContent := getDBContent(ctx, ovsClient)
+				Expect(newDBContent.Port).To(ConsistOf(dbContent.Port))
+				Expect(newDBContent.Interface).To(ConsistOf(dbContent.Interface))
+			})
+			It("Bridge exist with right config, uplink port VLAN changed, should recreate interface only", func() {
+				knownConf := getManagedBridges()["br-0000_d8_00.0"]
+				store.EXPECT().GetManagedOVSBridge("br-0000_d8_00.0").Return(knownConf, nil)
+				expectedConf := getManagedBridges()["br-0000_d8_00.0"]
+				expectedConf.Uplinks[0].Port = sriovnetworkv1.OVSPortConfig{VlanMode: "trunk", Trunks: []int{100, 200}}
+				store.EXPECT().AddManagedOVSBridge(expectedConf).Return(nil)
+				initialDBContent := getDefaultInitialDBContent()
+				createInitialDBContent(ctx, ovsClient, initialDBContent)
+
+				Expect(ovs.CreateOVSBridge(ctx, expectedConf)).NotTo(HaveOccurred())
+
+				dbContent := getDBContent(ctx, ovsClient)
+				validateDBConfig(dbContent, expectedConf)
+				Expect(dbContent.Bridge[0].UUID).To(Equal(initialDBContent.Bridge[0].UUID))
+				Expect(dbContent.Interface[0].UUID).NotTo(Equal(initialDBContent.Interface[0].UUID))
+			})
 			It("Bridge exist, no data in store, should recreate", func() {
 				expectedConf := getManagedBridges()["br-0000_d8_00.0"]
 				store.EXPECT().GetManagedOVSBridge("br-0000_d8_00.0").Return(nil, nil)
@@ -406,6 +572,58 @@ var _ = Describe("OVS", func() {
 				Expect(dbContent.Bridge[0].UUID).To(Equal(initialDBContent.Bridge[0].UUID))
 				Expect(dbContent.Interface[0].UUID).NotTo(Equal(initialDBContent.Interface[0].UUID))
 			})
+			It("Bridge exist with right config, interface options changed, should update interface in place", func() {
+				expectedConf := getManagedBridges()["br-0000_d8_00.0"]
+				expectedConf.Uplinks[0].Interface.Options = map[string]string{"iface_options_key": "new_value", "new_options_key": "value"}
+				expectedConf.Uplinks[0].Interface.ExternalIDs = map[string]string{}
+				mtu := 9000
+				expectedConf.Uplinks[0].Interface.MTURequest = &mtu
+
+				oldConfig := getManagedBridges()["br-0000_d8_00.0"]
+				store.EXPECT().GetManagedOVSBridge("br-0000_d8_00.0").Return(oldConfig, nil)
+				store.EXPECT().AddManagedOVSBridge(expectedConf).Return(nil)
+
+				initialDBContent := getDefaultInitialDBContent()
+				// not managed by the operator, should be kept
+				initialDBContent.Interface[0].OtherConfig["unmanaged_key"] = "unmanaged_value"
+				createInitialDBContent(ctx, ovsClient, initialDBContent)
+
+				Expect(ovs.CreateOVSBridge(ctx, expectedConf)).NotTo(HaveOccurred())
+
+				dbContent := getDBContent(ctx, ovsClient)
+				Expect(dbContent.Bridge).To(HaveLen(1))
+				Expect(dbContent.Interface).To(HaveLen(1))
+				Expect(dbContent.Bridge[0].UUID).To(Equal(initialDBContent.Bridge[0].UUID))
+				iface := dbContent.Interface[0]
+				Expect(iface.UUID).To(Equal(initialDBContent.Interface[0].UUID))
+				Expect(iface.Options).To(Equal(expectedConf.Uplinks[0].Interface.Options))
+				Expect(iface.ExternalIDs).To(BeEmpty())
+				Expect(iface.OtherConfig).To(Equal(map[string]string{
+					"iface_otherConfig_key": "iface_otherConfig_value",
+					"unmanaged_key":         "unmanaged_value"}))
+				Expect(iface.MTURequest).To(Equal(&mtu))
+			})
+			It("Bridge exist with right config, ingress policing changed, should update interface in place", func() {
+				expectedConf := getManagedBridges()["br-0000_d8_00.0"]
+				expectedConf.Uplinks[0].Interface.IngressPolicingRate = 20000
+				expectedConf.Uplinks[0].Interface.IngressPolicingBurst = 2000
+
+				oldConfig := getManagedBridges()["br-0000_d8_00.0"]
+				store.EXPECT().GetManagedOVSBridge("br-0000_d8_00.0").Return(oldConfig, nil)
+				store.EXPECT().AddManagedOVSBridge(expectedConf).Return(nil)
+
+				initialDBContent := getDefaultInitialDBContent()
+				createInitialDBContent(ctx, ovsClient, initialDBContent)
+
+				Expect(ovs.CreateOVSBridge(ctx, expectedConf)).NotTo(HaveOccurred())
+
+				dbContent := getDBContent(ctx, ovsClient)
+				Expect(dbContent.Interface).To(HaveLen(1))
+				iface := dbContent.Interface[0]
+				Expect(iface.UUID).To(Equal(initialDBContent.Interface[0].UUID))
+				Expect(iface.IngressPolicingRate).To(Equal(20000))
+				Expect(iface.IngressPolicingBurst).To(Equal(2000))
+			})
 			It("Interface has an error, should recreate interface only", func() {
 				expectedConf := getManagedBridges()["br-0000_d8_00.0"]
 				store.EXPECT().GetManagedOVSBridge("br-0000_d8_00.0").Return(expectedConf, nil)
@@ -441,6 +659,37 @@ var _ = Describe("OVS", func() {
 				Expect(err).NotTo(HaveOccurred())
 				Expect(ret).To(ContainElement(*conf["br-0000_d8_00.0"]))
 			})
+			It("Managed bridge exist with fail_mode and controller", func() {
+				initialDBContent := getDefaultInitialDBContent()
+				failMode := "standalone"
+				controller := &ControllerEntry{UUID: uuid.NewString(), Target: "tcp:192.0.2.10:6653"}
+				initialDBContent.Bridge[0].FailMode = &failMode
+				initialDBContent.Bridge[0].Controller = []string{controller.UUID}
+				initialDBContent.Controller = []*ControllerEntry{controller}
+				createInitialDBContent(ctx, ovsClient, initialDBContent)
+				conf := getManagedBridges()
+				conf["br-0000_d8_00.0"].Bridge.FailMode = "standalone"
+				conf["br-0000_d8_00.0"].Bridge.Controller = "tcp:192.0.2.10:6653"
+				store.EXPECT().GetManagedOVSBridges().Return(conf, nil)
+				ret, err := ovs.GetOVSBridges(ctx)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(ret).To(ContainElement(*conf["br-0000_d8_00.0"]))
+			})
+			It("Managed bridge exist with VLAN configuration on the uplink port", func() {
+				initialDBContent := getDefaultInitialDBContent()
+				vlan := 10
+				vlanMode := "native-tagged"
+				initialDBContent.Port[0].Tag = &vlan
+				initialDBContent.Port[0].VlanMode = &vlanMode
+				initialDBContent.Port[0].Trunks = []int{200, 10}
+				createInitialDBContent(ctx, ovsClient, initialDBContent)
+				conf := getManagedBridges()
+				conf["br-0000_d8_00.0"].Uplinks[0].Port = sriovnetworkv1.OVSPortConfig{Vlan: &vlan, VlanMode: vlanMode, Trunks: []int{10, 200}}
+				store.EXPECT().GetManagedOVSBridges().Return(conf, nil)
+				ret, err := ovs.GetOVSBridges(ctx)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(ret).To(ContainElement(*conf["br-0000_d8_00.0"]))
+			})
 			It("Managed bridge exist, interface not found", func() {
 				initialDBContent := getDefaultInitialDBContent()
 				initialDBContent.Bridge[0].Ports = nil
@@ -491,6 +740,36 @@ var _ = Describe("OVS", func() {
 				Expect(ret[0].Uplinks[0].Interface.MTURequest).To(BeNil())
 			})
 		})
+		Context("GetOVSPortsStatistics", func() {
+			It("No managed bridges", func() {
+				createInitialDBContent(ctx, ovsClient, getDefaultInitialDBContent())
+				store.EXPECT().GetManagedOVSBridges().Return(nil, nil)
+				ret, err := ovs.GetOVSPortsStatistics(ctx)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(ret).To(BeEmpty())
+			})
+			It("Should report the statistics of the ports of the managed bridge", func() {
+				initialDBContent := getDefaultInitialDBContent()
+				initialDBContent.Interface[0].Statistics = map[string]int{
+					"rx_packets": 10, "rx_bytes": 1000, "tx_packets": 20, "tx_bytes": 2000}
+				createInitialDBContent(ctx, ovsClient, initialDBContent)
+				store.EXPECT().GetManagedOVSBridges().Return(getManagedBridges(), nil)
+				ret, err := ovs.GetOVSPortsStatistics(ctx)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(ret).To(Equal([]types.OVSPortStatistics{{
+					Bridge: "br-0000_d8_00.0",
+					Port:   "enp216s0f0np0",
+					Statistics: map[string]int{
+						"rx_packets": 10, "rx_bytes": 1000, "tx_packets": 20, "tx_bytes": 2000},
+				}}))
+			})
+			It("Config exist, bridge not found", func() {
+				store.EXPECT().GetManagedOVSBridges().Return(getManagedBridges(), nil)
+				ret, err := ovs.GetOVSPortsStatistics(ctx)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(ret).To(BeEmpty())
+			})
+		})
 		Context("RemoveOVSBridge", func() {
 			It("No config", func() {
 				store.EXPECT().GetManagedOVSBridge("br-0000_d8_00.0").Return(nil, nil)
@@ -547,10 +826,49 @@ var _ = Describe("OVS", func() {
 
 })
 
+// blockingTransactClient is an OVSDB client which transactions never complete,
+// it is used to simulate the loss of the connection while a transaction is in progress
+type blockingTransactClient struct {
+	client.Client
+	disconnect chan struct{}
+}
+
+func (c *blockingTransactClient) DisconnectNotify() chan struct{} {
+	return c.disconnect
+}
+
+func (c *blockingTransactClient) Transact(ctx context.Context, _ ...ovsdb.Operation) ([]ovsdb.OperationResult, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
 func startServer(protocol, path string) func() {
 	clientDBModels, err := DatabaseModel()
 	Expect(err).NotTo(HaveOccurred())
+	return startServerWithSchema(protocol, path, getSchema(), clientDBModels)
+}
+
+// legacyPortEntry represents the Port table of an OVSDB schema without the vlan_mode column
+type legacyPortEntry struct {
+	UUID       string   `ovsdb:"_uuid"`
+	Name       string   `ovsdb:"name"`
+	Interfaces []string `ovsdb:"interfaces"`
+	Tag        *int     `ovsdb:"tag"`
+	Trunks     []int    `ovsdb:"trunks"`
+}
+
+// startLegacyServer starts an OVSDB server with a schema lacking the vlan_mode column of the Port table
+func startLegacyServer(protocol, path string) func() {
 	schema := getSchema()
+	delete(schema.Tables["Port"].Columns, "vlan_mode")
+	models := tableModels()
+	models["Port"] = &legacyPortEntry{}
+	clientDBModels, err := model.NewClientDBModel("Open_vSwitch", models)
+	Expect(err).NotTo(HaveOccurred())
+	return startServerWithSchema(protocol, path, schema, clientDBModels)
+}
+
+func startServerWithSchema(protocol, path string, schema ovsdb.DatabaseSchema, clientDBModels model.ClientDBModel) func() {
 	ovsDB := inmemory.NewDatabase(map[string]model.ClientDBModel{
 		schema.Name: clientDBModels,
 	})
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 
 	"sigs.k8s.io/controller-runtime/pkg/log"
@@ -258,6 +259,21 @@ func (k *kernel) GetDriverByBusAndDevice(bus, device string) (string, error) {
 	return getDriverByBusAndDevice(bus, device)
 }
 
+// IsDriverAvailable returns true if the driver is registered on the bus
+// bus - the bus path in the sysfs, e.g. "pci" or "vdpa"
+// driver - the name of the driver, e.g. vfio-pci or vhost_vdpa.
+func (k *kernel) IsDriverAvailable(bus, driver string) (bool, error) {
+	log.Log.V(2).Info("IsDriverAvailable(): check if driver is available", "bus", bus, "driver", driver)
+	_, err := os.Stat(filepath.Join(vars.FilesystemRoot, consts.SysBus, bus, "drivers", driver))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
 // CheckRDMAEnabled returns true if RDMA modules are loaded on host
 func (k *kernel) CheckRDMAEnabled() (bool, error) {
 	log.Log.V(2).Info("CheckRDMAEnabled()")
@@ -310,6 +326,40 @@ func (k *kernel) IsKernelLockdownMode() bool {
 	return strings.Contains(stdout, "[integrity]") || strings.Contains(stdout, "[confidentiality]")
 }
 
+// HasHugepages returns true if hugepages of any size are allocated on the host
+func (k *kernel) HasHugepages() (bool, error) {
+	log.Log.V(2).Info("HasHugepages(): check if hugepages are allocated")
+	paths, err := filepath.Glob(filepath.Join(vars.FilesystemRoot, consts.SysKernelMmHugepages, "hugepages-*", "nr_hugepages"))
+	if err != nil {
+		return false, err
+	}
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return false, err
+		}
+		nrHugepages, err := strconv.Atoi(strings.TrimSpace(string(data)))
+		if err != nil {
+			return false, fmt.Errorf("failed to parse %s: %v", path, err)
+		}
+		if nrHugepages > 0 {
+			log.Log.V(2).Info("HasHugepages(): hugepages allocated", "path", path, "count", nrHugepages)
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// GetKernelVersion returns the release of the running kernel, e.g. 5.14.0-427.el9.x86_64
+func (k *kernel) GetKernelVersion() (string, error) {
+	path := filepath.Join(vars.FilesystemRoot, consts.ProcKernelOsRelease)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("GetKernelVersion(): failed to read %s: %v", path, err)
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
 // returns driver for device on the bus
 func getDriverByBusAndDevice(bus, device string) (string, error) {
 	driverLink := filepath.Join(vars.FilesystemRoot, consts.SysBus, bus, "devices", device, "driver")
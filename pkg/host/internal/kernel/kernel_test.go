@@ -219,6 +219,66 @@ var _ = Describe("Kernel", func() {
 			})
 		})
 
+		Context("IsDriverAvailable", func() {
+			It("driver exists", func() {
+				helpers.GinkgoConfigureFakeFS(&fakefilesystem.FS{
+					Dirs: []string{"/sys/bus/pci/drivers/custom_driver"},
+				})
+				available, err := k.IsDriverAvailable(consts.BusPci, "custom_driver")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(available).To(BeTrue())
+			})
+			It("driver doesn't exist", func() {
+				helpers.GinkgoConfigureFakeFS(&fakefilesystem.FS{
+					Dirs: []string{"/sys/bus/pci/drivers/vfio-pci"},
+				})
+				available, err := k.IsDriverAvailable(consts.BusPci, "custom_driver")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(available).To(BeFalse())
+			})
+		})
+
+		Context("HasHugepages", func() {
+			It("should return true when hugepages are allocated", func() {
+				helpers.GinkgoConfigureFakeFS(&fakefilesystem.FS{
+					Dirs: []string{"/sys/kernel/mm/hugepages/hugepages-2048kB", "/sys/kernel/mm/hugepages/hugepages-1048576kB"},
+					Files: map[string][]byte{
+						"/sys/kernel/mm/hugepages/hugepages-2048kB/nr_hugepages":    []byte("0\n"),
+						"/sys/kernel/mm/hugepages/hugepages-1048576kB/nr_hugepages": []byte("4\n")},
+				})
+				hasHugepages, err := k.HasHugepages()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(hasHugepages).To(BeTrue())
+			})
+			It("should return false when no hugepages are allocated", func() {
+				helpers.GinkgoConfigureFakeFS(&fakefilesystem.FS{
+					Dirs: []string{"/sys/kernel/mm/hugepages/hugepages-2048kB"},
+					Files: map[string][]byte{
+						"/sys/kernel/mm/hugepages/hugepages-2048kB/nr_hugepages": []byte("0\n")},
+				})
+				hasHugepages, err := k.HasHugepages()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(hasHugepages).To(BeFalse())
+			})
+		})
+
+		Context("GetKernelVersion", func() {
+			It("should return the release of the running kernel", func() {
+				helpers.GinkgoConfigureFakeFS(&fakefilesystem.FS{
+					Dirs:  []string{"/proc/sys/kernel"},
+					Files: map[string][]byte{"/proc/sys/kernel/osrelease": []byte("5.14.0-427.el9.x86_64\n")},
+				})
+				version, err := k.GetKernelVersion()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(version).To(Equal("5.14.0-427.el9.x86_64"))
+			})
+			It("should fail when the release can't be read", func() {
+				helpers.GinkgoConfigureFakeFS(&fakefilesystem.FS{})
+				_, err := k.GetKernelVersion()
+				Expect(err).To(HaveOccurred())
+			})
+		})
+
 		Context("IsKernelLockdownMode", func() {
 			It("should return true when kernel boots in lockdown integrity", func() {
 				helpers.GinkgoConfigureFakeFS(&fakefilesystem.FS{
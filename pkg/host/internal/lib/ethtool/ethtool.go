@@ -1,9 +1,35 @@
 package ethtool
 
 import (
+	"runtime"
+	"unsafe"
+
 	"github.com/safchain/ethtool"
+	"golang.org/x/sys/unix"
 )
 
+// ethtoolGRssh is the ETHTOOL_GRSSH command returning the RSS configuration of the interface
+const ethtoolGRssh = 0x00000046
+
+// ethtoolRxfh is the header of the struct ethtool_rxfh, the RSS configuration is not requested
+// so only the sizes of the indirection table and of the hash key are returned by the kernel
+type ethtoolRxfh struct {
+	cmd        uint32
+	rssContext uint32
+	indirSize  uint32
+	keySize    uint32
+	hfunc      uint8
+	rsvd8      [3]uint8
+	rsvd32     uint32
+}
+
+// ifreq is the struct ifreq passed to the SIOCETHTOOL ioctl
+type ifreq struct {
+	name [unix.IFNAMSIZ]byte
+	data uintptr
+	_    [16]byte
+}
+
 func New() EthtoolLib {
 	return &libWrapper{}
 }
@@ -16,6 +42,19 @@ type EthtoolLib interface {
 	FeatureNames(ifaceName string) (map[string]uint, error)
 	// Change requests a change in the given device's features.
 	Change(ifaceName string, config map[string]bool) error
+	// GetChannels returns the number of channels for the given interface name.
+	GetChannels(ifaceName string) (ethtool.Channels, error)
+	// SetChannels sets the number of channels for the given interface name.
+	SetChannels(ifaceName string, channels ethtool.Channels) (ethtool.Channels, error)
+	// DriverInfo returns the driver information of the given interface name.
+	DriverInfo(ifaceName string) (ethtool.DrvInfo, error)
+	// CmdGet returns the link settings of the given interface name.
+	CmdGet(ifaceName string) (ethtool.EthtoolCmd, error)
+	// CmdSet applies the link settings to the given interface name.
+	CmdSet(ifaceName string, cmd ethtool.EthtoolCmd) error
+	// RssSizes returns the sizes of the RSS indirection table and hash key of the given interface name,
+	// the error is unix.EOPNOTSUPP if the driver doesn't report the RSS configuration.
+	RssSizes(ifaceName string) (indirSize uint32, keySize uint32, err error)
 }
 
 type libWrapper struct{}
@@ -49,3 +88,76 @@ func (w *libWrapper) Change(ifaceName string, config map[string]bool) error {
 	defer e.Close()
 	return e.Change(ifaceName, config)
 }
+
+// GetChannels returns the number of channels for the given interface name.
+func (w *libWrapper) GetChannels(ifaceName string) (ethtool.Channels, error) {
+	e, err := ethtool.NewEthtool()
+	if err != nil {
+		return ethtool.Channels{}, err
+	}
+	defer e.Close()
+	return e.GetChannels(ifaceName)
+}
+
+// SetChannels sets the number of channels for the given interface name.
+func (w *libWrapper) SetChannels(ifaceName string, channels ethtool.Channels) (ethtool.Channels, error) {
+	e, err := ethtool.NewEthtool()
+	if err != nil {
+		return ethtool.Channels{}, err
+	}
+	defer e.Close()
+	return e.SetChannels(ifaceName, channels)
+}
+
+// DriverInfo returns the driver information of the given interface name.
+func (w *libWrapper) DriverInfo(ifaceName string) (ethtool.DrvInfo, error) {
+	e, err := ethtool.NewEthtool()
+	if err != nil {
+		return ethtool.DrvInfo{}, err
+	}
+	defer e.Close()
+	return e.DriverInfo(ifaceName)
+}
+
+// CmdGet returns the link settings of the given interface name.
+func (w *libWrapper) CmdGet(ifaceName string) (ethtool.EthtoolCmd, error) {
+	e, err := ethtool.NewEthtool()
+	if err != nil {
+		return ethtool.EthtoolCmd{}, err
+	}
+	defer e.Close()
+	cmd := ethtool.EthtoolCmd{}
+	_, err = e.CmdGet(&cmd, ifaceName)
+	return cmd, err
+}
+
+// CmdSet applies the link settings to the given interface name.
+func (w *libWrapper) CmdSet(ifaceName string, cmd ethtool.EthtoolCmd) error {
+	e, err := ethtool.NewEthtool()
+	if err != nil {
+		return err
+	}
+	defer e.Close()
+	_, err = e.CmdSet(&cmd, ifaceName)
+	return err
+}
+
+// RssSizes returns the sizes of the RSS indirection table and hash key of the given interface name,
+// the error is unix.EOPNOTSUPP if the driver doesn't report the RSS configuration.
+func (w *libWrapper) RssSizes(ifaceName string) (uint32, uint32, error) {
+	fd, err := unix.Socket(unix.AF_INET, unix.SOCK_DGRAM|unix.SOCK_CLOEXEC, 0)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer unix.Close(fd)
+
+	rxfh := &ethtoolRxfh{cmd: ethtoolGRssh}
+	req := &ifreq{data: uintptr(unsafe.Pointer(rxfh))}
+	copy(req.name[:unix.IFNAMSIZ-1], ifaceName)
+	_, _, errno := unix.Syscall(unix.SYS_IOCTL, uintptr(fd), unix.SIOCETHTOOL, uintptr(unsafe.Pointer(req)))
+	runtime.KeepAlive(rxfh)
+	if errno != 0 {
+		return 0, 0, errno
+	}
+	return rxfh.indirSize, rxfh.keySize, nil
+}
@@ -8,6 +8,7 @@ import (
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
+	ethtool "github.com/safchain/ethtool"
 )
 
 // MockEthtoolLib is a mock of EthtoolLib interface.
@@ -47,6 +48,50 @@ func (mr *MockEthtoolLibMockRecorder) Change(ifaceName, config interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Change", reflect.TypeOf((*MockEthtoolLib)(nil).Change), ifaceName, config)
 }
 
+// CmdGet mocks base method.
+func (m *MockEthtoolLib) CmdGet(ifaceName string) (ethtool.EthtoolCmd, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CmdGet", ifaceName)
+	ret0, _ := ret[0].(ethtool.EthtoolCmd)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CmdGet indicates an expected call of CmdGet.
+func (mr *MockEthtoolLibMockRecorder) CmdGet(ifaceName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CmdGet", reflect.TypeOf((*MockEthtoolLib)(nil).CmdGet), ifaceName)
+}
+
+// CmdSet mocks base method.
+func (m *MockEthtoolLib) CmdSet(ifaceName string, cmd ethtool.EthtoolCmd) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CmdSet", ifaceName, cmd)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CmdSet indicates an expected call of CmdSet.
+func (mr *MockEthtoolLibMockRecorder) CmdSet(ifaceName, cmd interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CmdSet", reflect.TypeOf((*MockEthtoolLib)(nil).CmdSet), ifaceName, cmd)
+}
+
+// DriverInfo mocks base method.
+func (m *MockEthtoolLib) DriverInfo(ifaceName string) (ethtool.DrvInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DriverInfo", ifaceName)
+	ret0, _ := ret[0].(ethtool.DrvInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DriverInfo indicates an expected call of DriverInfo.
+func (mr *MockEthtoolLibMockRecorder) DriverInfo(ifaceName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DriverInfo", reflect.TypeOf((*MockEthtoolLib)(nil).DriverInfo), ifaceName)
+}
+
 // FeatureNames mocks base method.
 func (m *MockEthtoolLib) FeatureNames(ifaceName string) (map[string]uint, error) {
 	m.ctrl.T.Helper()
@@ -76,3 +121,49 @@ func (mr *MockEthtoolLibMockRecorder) Features(ifaceName interface{}) *gomock.Ca
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Features", reflect.TypeOf((*MockEthtoolLib)(nil).Features), ifaceName)
 }
+
+// GetChannels mocks base method.
+func (m *MockEthtoolLib) GetChannels(ifaceName string) (ethtool.Channels, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChannels", ifaceName)
+	ret0, _ := ret[0].(ethtool.Channels)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChannels indicates an expected call of GetChannels.
+func (mr *MockEthtoolLibMockRecorder) GetChannels(ifaceName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChannels", reflect.TypeOf((*MockEthtoolLib)(nil).GetChannels), ifaceName)
+}
+
+// RssSizes mocks base method.
+func (m *MockEthtoolLib) RssSizes(ifaceName string) (uint32, uint32, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RssSizes", ifaceName)
+	ret0, _ := ret[0].(uint32)
+	ret1, _ := ret[1].(uint32)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// RssSizes indicates an expected call of RssSizes.
+func (mr *MockEthtoolLibMockRecorder) RssSizes(ifaceName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RssSizes", reflect.TypeOf((*MockEthtoolLib)(nil).RssSizes), ifaceName)
+}
+
+// SetChannels mocks base method.
+func (m *MockEthtoolLib) SetChannels(ifaceName string, channels ethtool.Channels) (ethtool.Channels, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetChannels", ifaceName, channels)
+	ret0, _ := ret[0].(ethtool.Channels)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetChannels indicates an expected call of SetChannels.
+func (mr *MockEthtoolLibMockRecorder) SetChannels(ifaceName, channels interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetChannels", reflect.TypeOf((*MockEthtoolLib)(nil).SetChannels), ifaceName, channels)
+}
@@ -102,6 +102,20 @@ func (mr *MockNetlinkLibMockRecorder) DevLinkGetDeviceByName(bus, device interfa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DevLinkGetDeviceByName", reflect.TypeOf((*MockNetlinkLib)(nil).DevLinkGetDeviceByName), bus, device)
 }
 
+// DevLinkSetEswitchInlineMode mocks base method.
+func (m *MockNetlinkLib) DevLinkSetEswitchInlineMode(dev *netlink0.DevlinkDevice, inlineMode string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DevLinkSetEswitchInlineMode", dev, inlineMode)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DevLinkSetEswitchInlineMode indicates an expected call of DevLinkSetEswitchInlineMode.
+func (mr *MockNetlinkLibMockRecorder) DevLinkSetEswitchInlineMode(dev, inlineMode interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DevLinkSetEswitchInlineMode", reflect.TypeOf((*MockNetlinkLib)(nil).DevLinkSetEswitchInlineMode), dev, inlineMode)
+}
+
 // DevLinkSetEswitchMode mocks base method.
 func (m *MockNetlinkLib) DevLinkSetEswitchMode(dev *netlink0.DevlinkDevice, newMode string) error {
 	m.ctrl.T.Helper()
@@ -204,6 +218,34 @@ func (mr *MockNetlinkLibMockRecorder) LinkList() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LinkList", reflect.TypeOf((*MockNetlinkLib)(nil).LinkList))
 }
 
+// LinkSetAllmulticastOff mocks base method.
+func (m *MockNetlinkLib) LinkSetAllmulticastOff(link netlink.Link) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LinkSetAllmulticastOff", link)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LinkSetAllmulticastOff indicates an expected call of LinkSetAllmulticastOff.
+func (mr *MockNetlinkLibMockRecorder) LinkSetAllmulticastOff(link interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LinkSetAllmulticastOff", reflect.TypeOf((*MockNetlinkLib)(nil).LinkSetAllmulticastOff), link)
+}
+
+// LinkSetDown mocks base method.
+func (m *MockNetlinkLib) LinkSetDown(link netlink.Link) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LinkSetDown", link)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LinkSetDown indicates an expected call of LinkSetDown.
+func (mr *MockNetlinkLibMockRecorder) LinkSetDown(link interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LinkSetDown", reflect.TypeOf((*MockNetlinkLib)(nil).LinkSetDown), link)
+}
+
 // LinkSetMTU mocks base method.
 func (m *MockNetlinkLib) LinkSetMTU(link netlink.Link, mtu int) error {
 	m.ctrl.T.Helper()
@@ -218,6 +260,62 @@ func (mr *MockNetlinkLibMockRecorder) LinkSetMTU(link, mtu interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LinkSetMTU", reflect.TypeOf((*MockNetlinkLib)(nil).LinkSetMTU), link, mtu)
 }
 
+// LinkSetMulticastOff mocks base method.
+func (m *MockNetlinkLib) LinkSetMulticastOff(link netlink.Link) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LinkSetMulticastOff", link)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LinkSetMulticastOff indicates an expected call of LinkSetMulticastOff.
+func (mr *MockNetlinkLibMockRecorder) LinkSetMulticastOff(link interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LinkSetMulticastOff", reflect.TypeOf((*MockNetlinkLib)(nil).LinkSetMulticastOff), link)
+}
+
+// LinkSetMulticastOn mocks base method.
+func (m *MockNetlinkLib) LinkSetMulticastOn(link netlink.Link) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LinkSetMulticastOn", link)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LinkSetMulticastOn indicates an expected call of LinkSetMulticastOn.
+func (mr *MockNetlinkLibMockRecorder) LinkSetMulticastOn(link interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LinkSetMulticastOn", reflect.TypeOf((*MockNetlinkLib)(nil).LinkSetMulticastOn), link)
+}
+
+// LinkSetPromiscOff mocks base method.
+func (m *MockNetlinkLib) LinkSetPromiscOff(link netlink.Link) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LinkSetPromiscOff", link)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LinkSetPromiscOff indicates an expected call of LinkSetPromiscOff.
+func (mr *MockNetlinkLibMockRecorder) LinkSetPromiscOff(link interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LinkSetPromiscOff", reflect.TypeOf((*MockNetlinkLib)(nil).LinkSetPromiscOff), link)
+}
+
+// LinkSetPromiscOn mocks base method.
+func (m *MockNetlinkLib) LinkSetPromiscOn(link netlink.Link) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LinkSetPromiscOn", link)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LinkSetPromiscOn indicates an expected call of LinkSetPromiscOn.
+func (mr *MockNetlinkLibMockRecorder) LinkSetPromiscOn(link interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LinkSetPromiscOn", reflect.TypeOf((*MockNetlinkLib)(nil).LinkSetPromiscOn), link)
+}
+
 // LinkSetUp mocks base method.
 func (m *MockNetlinkLib) LinkSetUp(link netlink.Link) error {
 	m.ctrl.T.Helper()
@@ -232,6 +330,20 @@ func (mr *MockNetlinkLibMockRecorder) LinkSetUp(link interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LinkSetUp", reflect.TypeOf((*MockNetlinkLib)(nil).LinkSetUp), link)
 }
 
+// LinkSetVfConfig mocks base method.
+func (m *MockNetlinkLib) LinkSetVfConfig(link netlink.Link, vf int, config netlink.VfConfig) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LinkSetVfConfig", link, vf, config)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LinkSetVfConfig indicates an expected call of LinkSetVfConfig.
+func (mr *MockNetlinkLibMockRecorder) LinkSetVfConfig(link, vf, config interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LinkSetVfConfig", reflect.TypeOf((*MockNetlinkLib)(nil).LinkSetVfConfig), link, vf, config)
+}
+
 // LinkSetVfHardwareAddr mocks base method.
 func (m *MockNetlinkLib) LinkSetVfHardwareAddr(link netlink.Link, vf int, hwaddr net.HardwareAddr) error {
 	m.ctrl.T.Helper()
@@ -274,6 +386,62 @@ func (mr *MockNetlinkLibMockRecorder) LinkSetVfPortGUID(link, vf, portguid inter
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LinkSetVfPortGUID", reflect.TypeOf((*MockNetlinkLib)(nil).LinkSetVfPortGUID), link, vf, portguid)
 }
 
+// LinkSetVfSpoofchk mocks base method.
+func (m *MockNetlinkLib) LinkSetVfSpoofchk(link netlink.Link, vf int, check bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LinkSetVfSpoofchk", link, vf, check)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LinkSetVfSpoofchk indicates an expected call of LinkSetVfSpoofchk.
+func (mr *MockNetlinkLibMockRecorder) LinkSetVfSpoofchk(link, vf, check interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LinkSetVfSpoofchk", reflect.TypeOf((*MockNetlinkLib)(nil).LinkSetVfSpoofchk), link, vf, check)
+}
+
+// LinkSetVfTrust mocks base method.
+func (m *MockNetlinkLib) LinkSetVfTrust(link netlink.Link, vf int, state bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LinkSetVfTrust", link, vf, state)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LinkSetVfTrust indicates an expected call of LinkSetVfTrust.
+func (mr *MockNetlinkLibMockRecorder) LinkSetVfTrust(link, vf, state interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LinkSetVfTrust", reflect.TypeOf((*MockNetlinkLib)(nil).LinkSetVfTrust), link, vf, state)
+}
+
+// LinkSetVfVlanQos mocks base method.
+func (m *MockNetlinkLib) LinkSetVfVlanQos(link netlink.Link, vf, vlan, qos int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LinkSetVfVlanQos", link, vf, vlan, qos)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LinkSetVfVlanQos indicates an expected call of LinkSetVfVlanQos.
+func (mr *MockNetlinkLibMockRecorder) LinkSetVfVlanQos(link, vf, vlan, qos interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LinkSetVfVlanQos", reflect.TypeOf((*MockNetlinkLib)(nil).LinkSetVfVlanQos), link, vf, vlan, qos)
+}
+
+// LinkSubscribe mocks base method.
+func (m *MockNetlinkLib) LinkSubscribe(ch chan<- netlink0.LinkUpdate, done <-chan struct{}) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LinkSubscribe", ch, done)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LinkSubscribe indicates an expected call of LinkSubscribe.
+func (mr *MockNetlinkLibMockRecorder) LinkSubscribe(ch, done interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LinkSubscribe", reflect.TypeOf((*MockNetlinkLib)(nil).LinkSubscribe), ch, done)
+}
+
 // RdmaLinkByName mocks base method.
 func (m *MockNetlinkLib) RdmaLinkByName(name string) (*netlink0.RdmaLink, error) {
 	m.ctrl.T.Helper()
@@ -304,6 +472,21 @@ func (mr *MockNetlinkLibMockRecorder) RdmaSystemGetNetnsMode() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RdmaSystemGetNetnsMode", reflect.TypeOf((*MockNetlinkLib)(nil).RdmaSystemGetNetnsMode))
 }
 
+// RouteList mocks base method.
+func (m *MockNetlinkLib) RouteList(link netlink.Link, family int) ([]netlink0.Route, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RouteList", link, family)
+	ret0, _ := ret[0].([]netlink0.Route)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RouteList indicates an expected call of RouteList.
+func (mr *MockNetlinkLibMockRecorder) RouteList(link, family interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RouteList", reflect.TypeOf((*MockNetlinkLib)(nil).RouteList), link, family)
+}
+
 // VDPADelDev mocks base method.
 func (m *MockNetlinkLib) VDPADelDev(name string) error {
 	m.ctrl.T.Helper()
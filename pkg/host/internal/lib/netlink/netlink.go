@@ -1,11 +1,22 @@
 package netlink
 
 import (
+	"fmt"
 	"net"
+	"syscall"
 
 	"github.com/vishvananda/netlink"
+	"github.com/vishvananda/netlink/nl"
+	"golang.org/x/sys/unix"
 )
 
+var eswitchInlineModes = map[string]uint8{
+	"none":      nl.DEVLINK_ESWITCH_INLINE_MODE_NONE,
+	"link":      nl.DEVLINK_ESWITCH_INLINE_MODE_LINK,
+	"network":   nl.DEVLINK_ESWITCH_INLINE_MODE_NETWORK,
+	"transport": nl.DEVLINK_ESWITCH_INLINE_MODE_TRANSPORT,
+}
+
 func New() NetlinkLib {
 	return &libWrapper{}
 }
@@ -14,6 +25,20 @@ type Link interface {
 	netlink.Link
 }
 
+// VfConfig contains the attributes of a VF set by LinkSetVfConfig, nil attributes are left unchanged
+type VfConfig struct {
+	// Vlan is the VLAN ID of the VF, VlanQoS is only set together with it
+	Vlan    *int
+	VlanQoS int
+	// VlanProto is the lower case VLAN protocol of the VF, e.g. "802.1ad", the default 802.1q protocol is used if empty
+	VlanProto string
+	Trust     *bool
+	SpoofChk  *bool
+	// MinTxRate and MaxTxRate are the TX rate limits of the VF in Mbps, 0 disables the limit
+	MinTxRate *int
+	MaxTxRate *int
+}
+
 //go:generate ../../../../../bin/mockgen -destination mock/mock_netlink.go -source netlink.go
 type NetlinkLib interface {
 	// LinkSetVfNodeGUID sets the node GUID of a vf for the link.
@@ -29,15 +54,53 @@ type NetlinkLib interface {
 	// LinkList gets a list of link devices.
 	// Equivalent to: `ip link show`
 	LinkList() ([]Link, error)
+	// RouteList gets a list of routes in the system, all routes are returned if link is nil.
+	// Equivalent to: `ip route show`
+	RouteList(link Link, family int) ([]netlink.Route, error)
 	// LinkSetVfHardwareAddr sets the hardware address of a vf for the link.
 	// Equivalent to: `ip link set $link vf $vf mac $hwaddr`
 	LinkSetVfHardwareAddr(link Link, vf int, hwaddr net.HardwareAddr) error
+	// LinkSetVfVlanQos sets the vlan and qos priority of a vf for the link.
+	// Equivalent to: `ip link set $link vf $vf vlan $vlan qos $qos`
+	LinkSetVfVlanQos(link Link, vf, vlan, qos int) error
+	// LinkSetVfTrust enables/disables trust state on a vf for the link.
+	// Equivalent to: `ip link set $link vf $vf trust $state`
+	LinkSetVfTrust(link Link, vf int, state bool) error
+	// LinkSetVfSpoofchk enables/disables spoof check on a vf for the link.
+	// Equivalent to: `ip link set $link vf $vf spoofchk $check`
+	LinkSetVfSpoofchk(link Link, vf int, check bool) error
+	// LinkSetVfConfig sets the vlan, qos, vlan proto, trust, spoof check and tx rates of a vf for the link in a single request,
+	// the kernel applies all the attributes under the same lock.
+	// Equivalent to: `ip link set $link vf $vf vlan $vlan qos $qos proto $proto spoofchk $check trust $state min_tx_rate $min max_tx_rate $max`
+	LinkSetVfConfig(link Link, vf int, config VfConfig) error
 	// LinkSetUp enables the link device.
 	// Equivalent to: `ip link set $link up`
 	LinkSetUp(link Link) error
+	// LinkSetDown disables the link device.
+	// Equivalent to: `ip link set $link down`
+	LinkSetDown(link Link) error
+	// LinkSetPromiscOn enables the promiscuous mode of the link device.
+	// Equivalent to: `ip link set $link promisc on`
+	LinkSetPromiscOn(link Link) error
+	// LinkSetPromiscOff disables the promiscuous mode of the link device.
+	// Equivalent to: `ip link set $link promisc off`
+	LinkSetPromiscOff(link Link) error
+	// LinkSetMulticastOn enables the reception of the multicast frames by the link device.
+	// Equivalent to: `ip link set $link multicast on`
+	LinkSetMulticastOn(link Link) error
+	// LinkSetMulticastOff disables the reception of the multicast frames by the link device.
+	// Equivalent to: `ip link set $link multicast off`
+	LinkSetMulticastOff(link Link) error
+	// LinkSetAllmulticastOff disables the all-multicast mode of the link device.
+	// Equivalent to: `ip link set $link allmulticast off`
+	LinkSetAllmulticastOff(link Link) error
 	// LinkSetMTU sets the mtu of the link device.
 	// Equivalent to: `ip link set $link mtu $mtu`
 	LinkSetMTU(link Link, mtu int) error
+	// LinkSubscribe sends the updates of the links to ch until done is closed,
+	// ch is closed when the subscription ends.
+	// Equivalent to: `ip monitor link`
+	LinkSubscribe(ch chan<- netlink.LinkUpdate, done <-chan struct{}) error
 	// DevlinkGetDeviceByName provides a pointer to devlink device and nil error,
 	// otherwise returns an error code.
 	DevLinkGetDeviceByName(bus string, device string) (*netlink.DevlinkDevice, error)
@@ -46,6 +109,10 @@ type NetlinkLib interface {
 	// Equivalent to: `devlink dev eswitch set $dev mode switchdev`
 	// Equivalent to: `devlink dev eswitch set $dev mode legacy`
 	DevLinkSetEswitchMode(dev *netlink.DevlinkDevice, newMode string) error
+	// DevLinkSetEswitchInlineMode sets eswitch inline mode if able to set successfully or
+	// returns an error code.
+	// Equivalent to: `devlink dev eswitch set $dev inline-mode $mode`
+	DevLinkSetEswitchInlineMode(dev *netlink.DevlinkDevice, inlineMode string) error
 	// VDPAGetDevByName returns VDPA device selected by name
 	// Equivalent to: `vdpa dev show <name>`
 	VDPAGetDevByName(name string) (*netlink.VDPADev, error)
@@ -113,24 +180,157 @@ func (w *libWrapper) LinkList() ([]Link, error) {
 	return customLinks, nil
 }
 
+// RouteList gets a list of routes in the system, all routes are returned if link is nil.
+// Equivalent to: `ip route show`
+func (w *libWrapper) RouteList(link Link, family int) ([]netlink.Route, error) {
+	return netlink.RouteList(link, family)
+}
+
 // LinkSetVfHardwareAddr sets the hardware address of a vf for the link.
 // Equivalent to: `ip link set $link vf $vf mac $hwaddr`
 func (w *libWrapper) LinkSetVfHardwareAddr(link Link, vf int, hwaddr net.HardwareAddr) error {
 	return netlink.LinkSetVfHardwareAddr(link, vf, hwaddr)
 }
 
+// LinkSetVfVlanQos sets the vlan and qos priority of a vf for the link.
+// Equivalent to: `ip link set $link vf $vf vlan $vlan qos $qos`
+func (w *libWrapper) LinkSetVfVlanQos(link Link, vf, vlan, qos int) error {
+	return netlink.LinkSetVfVlanQos(link, vf, vlan, qos)
+}
+
+// LinkSetVfTrust enables/disables trust state on a vf for the link.
+// Equivalent to: `ip link set $link vf $vf trust $state`
+func (w *libWrapper) LinkSetVfTrust(link Link, vf int, state bool) error {
+	return netlink.LinkSetVfTrust(link, vf, state)
+}
+
+// LinkSetVfSpoofchk enables/disables spoof check on a vf for the link.
+// Equivalent to: `ip link set $link vf $vf spoofchk $check`
+func (w *libWrapper) LinkSetVfSpoofchk(link Link, vf int, check bool) error {
+	return netlink.LinkSetVfSpoofchk(link, vf, check)
+}
+
+// LinkSetVfConfig sets the vlan, qos, vlan proto, trust, spoof check and tx rates of a vf for the link in a single request,
+// the kernel applies all the attributes under the same lock.
+// Equivalent to: `ip link set $link vf $vf vlan $vlan qos $qos proto $proto spoofchk $check trust $state min_tx_rate $min max_tx_rate $max`
+func (w *libWrapper) LinkSetVfConfig(link Link, vf int, config VfConfig) error {
+	// the netlink library sends a request per attribute
+	req := nl.NewNetlinkRequest(unix.RTM_SETLINK, unix.NLM_F_ACK)
+	msg := nl.NewIfInfomsg(unix.AF_UNSPEC)
+	msg.Index = int32(link.Attrs().Index)
+	req.AddData(msg)
+
+	data := nl.NewRtAttr(unix.IFLA_VFINFO_LIST, nil)
+	info := data.AddRtAttr(nl.IFLA_VF_INFO, nil)
+	if config.Vlan != nil {
+		vfmsg := nl.VfVlan{Vf: uint32(vf), Vlan: uint32(*config.Vlan), Qos: uint32(config.VlanQoS)}
+		proto := netlink.StringToVlanProtocol(config.VlanProto)
+		if config.VlanProto == "" || proto == netlink.VLAN_PROTOCOL_8021Q {
+			info.AddRtAttr(nl.IFLA_VF_VLAN, vfmsg.Serialize())
+		} else {
+			if proto == netlink.VLAN_PROTOCOL_UNKNOWN {
+				return fmt.Errorf("unknown VLAN protocol %q", config.VlanProto)
+			}
+			// only the VLAN list carries the protocol, the list is not supported by all the drivers
+			// so it's used only for the protocols other than the default one
+			vlanList := info.AddRtAttr(nl.IFLA_VF_VLAN_LIST, nil)
+			infomsg := nl.VfVlanInfo{VfVlan: vfmsg, VlanProto: htons(uint16(proto))}
+			vlanList.AddRtAttr(nl.IFLA_VF_VLAN_INFO, infomsg.Serialize())
+		}
+	}
+	if config.SpoofChk != nil {
+		vfmsg := nl.VfSpoofchk{Vf: uint32(vf), Setting: boolToUint32(*config.SpoofChk)}
+		info.AddRtAttr(nl.IFLA_VF_SPOOFCHK, vfmsg.Serialize())
+	}
+	if config.Trust != nil {
+		vfmsg := nl.VfTrust{Vf: uint32(vf), Setting: boolToUint32(*config.Trust)}
+		info.AddRtAttr(nl.IFLA_VF_TRUST, vfmsg.Serialize())
+	}
+	if config.MinTxRate != nil || config.MaxTxRate != nil {
+		// both rates are set by the same attribute, an unset rate disables the limit
+		vfmsg := nl.VfRate{Vf: uint32(vf), MinTxRate: uint32(ptrToInt(config.MinTxRate)), MaxTxRate: uint32(ptrToInt(config.MaxTxRate))}
+		info.AddRtAttr(nl.IFLA_VF_RATE, vfmsg.Serialize())
+	}
+	req.AddData(data)
+
+	_, err := req.Execute(unix.NETLINK_ROUTE, 0)
+	return err
+}
+
+func ptrToInt(i *int) int {
+	if i == nil {
+		return 0
+	}
+	return *i
+}
+
+// htons converts the value to network byte order as the VLAN protocol is serialized in host byte order
+func htons(v uint16) uint16 {
+	return v>>8 | v<<8
+}
+
+func boolToUint32(b bool) uint32 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
 // LinkSetUp enables the link device.
 // Equivalent to: `ip link set $link up`
 func (w *libWrapper) LinkSetUp(link Link) error {
 	return netlink.LinkSetUp(link)
 }
 
+// LinkSetDown disables the link device.
+// Equivalent to: `ip link set $link down`
+func (w *libWrapper) LinkSetDown(link Link) error {
+	return netlink.LinkSetDown(link)
+}
+
+// LinkSetPromiscOn enables the promiscuous mode of the link device.
+// Equivalent to: `ip link set $link promisc on`
+func (w *libWrapper) LinkSetPromiscOn(link Link) error {
+	return netlink.SetPromiscOn(link)
+}
+
+// LinkSetPromiscOff disables the promiscuous mode of the link device.
+// Equivalent to: `ip link set $link promisc off`
+func (w *libWrapper) LinkSetPromiscOff(link Link) error {
+	return netlink.SetPromiscOff(link)
+}
+
+// LinkSetMulticastOn enables the reception of the multicast frames by the link device.
+// Equivalent to: `ip link set $link multicast on`
+func (w *libWrapper) LinkSetMulticastOn(link Link) error {
+	return netlink.LinkSetMulticastOn(link)
+}
+
+// LinkSetMulticastOff disables the reception of the multicast frames by the link device.
+// Equivalent to: `ip link set $link multicast off`
+func (w *libWrapper) LinkSetMulticastOff(link Link) error {
+	return netlink.LinkSetMulticastOff(link)
+}
+
+// LinkSetAllmulticastOff disables the all-multicast mode of the link device.
+// Equivalent to: `ip link set $link allmulticast off`
+func (w *libWrapper) LinkSetAllmulticastOff(link Link) error {
+	return netlink.LinkSetAllmulticastOff(link)
+}
+
 // LinkSetMTU sets the mtu of the link device.
 // Equivalent to: `ip link set $link mtu $mtu`
 func (w *libWrapper) LinkSetMTU(link Link, mtu int) error {
 	return netlink.LinkSetMTU(link, mtu)
 }
 
+// LinkSubscribe sends the updates of the links to ch until done is closed,
+// ch is closed when the subscription ends.
+// Equivalent to: `ip monitor link`
+func (w *libWrapper) LinkSubscribe(ch chan<- netlink.LinkUpdate, done <-chan struct{}) error {
+	return netlink.LinkSubscribe(ch, done)
+}
+
 // DevlinkGetDeviceByName provides a pointer to devlink device and nil error,
 // otherwise returns an error code.
 func (w *libWrapper) DevLinkGetDeviceByName(bus string, device string) (*netlink.DevlinkDevice, error) {
@@ -145,6 +345,28 @@ func (w *libWrapper) DevLinkSetEswitchMode(dev *netlink.DevlinkDevice, newMode s
 	return netlink.DevLinkSetEswitchMode(dev, newMode)
 }
 
+// DevLinkSetEswitchInlineMode sets eswitch inline mode if able to set successfully or
+// returns an error code.
+// Equivalent to: `devlink dev eswitch set $dev inline-mode $mode`
+func (w *libWrapper) DevLinkSetEswitchInlineMode(dev *netlink.DevlinkDevice, inlineMode string) error {
+	mode, ok := eswitchInlineModes[inlineMode]
+	if !ok {
+		return fmt.Errorf("invalid eswitch inline mode %q", inlineMode)
+	}
+	// the netlink library can read the inline mode but doesn't provide a setter
+	family, err := netlink.GenlFamilyGet(nl.GENL_DEVLINK_NAME)
+	if err != nil {
+		return err
+	}
+	req := nl.NewNetlinkRequest(int(family.ID), syscall.NLM_F_REQUEST|syscall.NLM_F_ACK)
+	req.AddData(&nl.Genlmsg{Command: nl.DEVLINK_CMD_ESWITCH_SET, Version: nl.GENL_DEVLINK_VERSION})
+	req.AddData(nl.NewRtAttr(nl.DEVLINK_ATTR_BUS_NAME, nl.ZeroTerminated(dev.BusName)))
+	req.AddData(nl.NewRtAttr(nl.DEVLINK_ATTR_DEV_NAME, nl.ZeroTerminated(dev.DeviceName)))
+	req.AddData(nl.NewRtAttr(nl.DEVLINK_ATTR_ESWITCH_INLINE_MODE, nl.Uint8Attr(mode)))
+	_, err = req.Execute(syscall.NETLINK_GENERIC, 0)
+	return err
+}
+
 // VDPAGetDevByName returns VDPA device selected by name
 // Equivalent to: `vdpa dev show <name>`
 func (w *libWrapper) VDPAGetDevByName(name string) (*netlink.VDPADev, error) {
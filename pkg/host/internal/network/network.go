@@ -1,17 +1,23 @@
 package network
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"io/fs"
+	"net"
 	"os"
 	"path/filepath"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/cenkalti/backoff"
+	"github.com/vishvananda/netlink"
 	"github.com/vishvananda/netlink/nl"
+	"golang.org/x/sys/unix"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/consts"
@@ -393,6 +399,535 @@ func (n *network) EnableHwTcOffload(ifaceName string) error {
 	return nil
 }
 
+// GetNetDevNumQueues returns the number of combined queues of the interface, 0 if it can't be read
+func (n *network) GetNetDevNumQueues(ifaceName string) int {
+	log.Log.V(2).Info("GetNetDevNumQueues(): get number of queues", "device", ifaceName)
+	channels, err := n.ethtoolLib.GetChannels(ifaceName)
+	if err != nil {
+		log.Log.V(2).Info("GetNetDevNumQueues(): can't read channels", "device", ifaceName, "error", err)
+		return 0
+	}
+	return int(channels.CombinedCount)
+}
+
+// GetNetDevDriverVersion returns the version of the driver bound to the interface, empty string if it can't be read
+func (n *network) GetNetDevDriverVersion(ifaceName string) string {
+	log.Log.V(2).Info("GetNetDevDriverVersion(): get driver version", "device", ifaceName)
+	info, err := n.ethtoolLib.DriverInfo(ifaceName)
+	if err != nil {
+		log.Log.V(2).Info("GetNetDevDriverVersion(): can't read driver info", "device", ifaceName, "error", err)
+		return ""
+	}
+	return strings.TrimSpace(info.Version)
+}
+
+// GetNetDevFirmwareVersion returns the firmware version of the NIC of the interface, empty string if it can't be read
+func (n *network) GetNetDevFirmwareVersion(ifaceName string) string {
+	log.Log.V(2).Info("GetNetDevFirmwareVersion(): get firmware version", "device", ifaceName)
+	info, err := n.ethtoolLib.DriverInfo(ifaceName)
+	if err != nil {
+		log.Log.V(2).Info("GetNetDevFirmwareVersion(): can't read driver info", "device", ifaceName, "error", err)
+		return ""
+	}
+	// drop the PSID suffix, e.g. "22.41.1000 (MT_0000000359)"
+	fields := strings.Fields(info.FwVersion)
+	if len(fields) == 0 {
+		return ""
+	}
+	return fields[0]
+}
+
+// macsecConfigAliasPrefix prefixes the alias of the MACsec interfaces created by the operator,
+// the alias holds a fingerprint of the configuration as the key can't be read back from the kernel
+const macsecConfigAliasPrefix = "sriov-macsec-"
+
+// macsecConfigAlias returns the alias identifying the MACsec configuration, made of a hash of the key
+func macsecConfigAlias(keyID, key string, encrypt bool) string {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s/%s/%t", keyID, key, encrypt)))
+	return macsecConfigAliasPrefix + hex.EncodeToString(sum[:8])
+}
+
+// getNetDevMacsec returns the name of the MACsec interface on top of the interface, whether it exists and
+// whether it has the requested configuration
+func (n *network) getNetDevMacsec(ifaceName, keyID, key string, encrypt bool) (string, bool, bool, error) {
+	link, err := n.netlinkLib.LinkByName(ifaceName)
+	if err != nil {
+		log.Log.Error(err, "getNetDevMacsec(): failed to get link", "device", ifaceName)
+		return "", false, false, err
+	}
+	// the name of the MACsec interface is derived from the index to stay within the interface name length limit
+	macsecName := fmt.Sprintf("macsec%d", link.Attrs().Index)
+	macsecLink, err := n.netlinkLib.LinkByName(macsecName)
+	if err != nil {
+		return macsecName, false, false, nil
+	}
+	return macsecName, true, macsecLink.Attrs().Alias == macsecConfigAlias(keyID, key, encrypt), nil
+}
+
+// IsNetDevMacsecOutdated returns true if a MACsec interface exists on top of the interface
+// with a configuration different from the requested one
+func (n *network) IsNetDevMacsecOutdated(ifaceName, keyID, key string, encrypt bool) (bool, error) {
+	_, exists, upToDate, err := n.getNetDevMacsec(ifaceName, keyID, key, encrypt)
+	if err != nil {
+		return false, err
+	}
+	return exists && !upToDate, nil
+}
+
+// SetNetDevMacsec creates a MACsec interface on top of the interface with a transmit secure association
+// using the hex encoded key, an existing MACsec interface is only recreated if its configuration differs
+func (n *network) SetNetDevMacsec(ifaceName, keyID, key string, encrypt bool) error {
+	log.Log.V(2).Info("SetNetDevMacsec(): configure MACsec", "device", ifaceName, "encrypt", encrypt)
+	macsecName, exists, upToDate, err := n.getNetDevMacsec(ifaceName, keyID, key, encrypt)
+	if err != nil {
+		return err
+	}
+	if upToDate {
+		log.Log.V(2).Info("SetNetDevMacsec(): MACsec already configured", "device", ifaceName)
+		return nil
+	}
+	encryptArg := "off"
+	if encrypt {
+		encryptArg = "on"
+	}
+	commands := []string{}
+	if exists {
+		commands = append(commands, fmt.Sprintf("link del %s", macsecName))
+	}
+	commands = append(commands,
+		fmt.Sprintf("link add link %s name %s type macsec encrypt %s", ifaceName, macsecName, encryptArg),
+		fmt.Sprintf("macsec add %s tx sa 0 pn 1 on key %s %s", macsecName, keyID, key),
+		fmt.Sprintf("link set %s alias %s", macsecName, macsecConfigAlias(keyID, key, encrypt)),
+		fmt.Sprintf("link set %s up", macsecName))
+
+	// the commands are passed in a batch file to keep the key out of the command line and the logs
+	batchFile, err := os.CreateTemp("", "sriov-macsec-")
+	if err != nil {
+		log.Log.Error(err, "SetNetDevMacsec(): failed to create batch file", "device", ifaceName)
+		return err
+	}
+	defer os.Remove(batchFile.Name())
+	_, err = batchFile.WriteString(strings.Join(commands, "\n") + "\n")
+	if closeErr := batchFile.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		log.Log.Error(err, "SetNetDevMacsec(): failed to write batch file", "device", ifaceName)
+		return err
+	}
+	_, stderr, err := n.utilsHelper.RunCommand("ip", "-batch", batchFile.Name())
+	if err != nil {
+		log.Log.Error(err, "SetNetDevMacsec(): failed to configure MACsec", "device", ifaceName, "stderr", stderr)
+		return fmt.Errorf("failed to configure MACsec on %s: %v, %s", ifaceName, err, stderr)
+	}
+	return nil
+}
+
+// GetDefaultRouteInterfaces returns the names of the interfaces carrying the default route of the host
+// together with the interfaces enslaved to them, e.g. the members of a bond used by the default route
+func (n *network) GetDefaultRouteInterfaces() ([]string, error) {
+	routes, err := n.netlinkLib.RouteList(nil, netlink.FAMILY_ALL)
+	if err != nil {
+		log.Log.Error(err, "GetDefaultRouteInterfaces(): failed to list routes")
+		return nil, err
+	}
+	indexes := map[int]bool{}
+	for _, route := range routes {
+		if route.Dst != nil {
+			if ones, _ := route.Dst.Mask.Size(); ones != 0 {
+				continue
+			}
+		}
+		if route.LinkIndex > 0 {
+			indexes[route.LinkIndex] = true
+		}
+		for _, path := range route.MultiPath {
+			if path.LinkIndex > 0 {
+				indexes[path.LinkIndex] = true
+			}
+		}
+	}
+	if len(indexes) == 0 {
+		return nil, nil
+	}
+
+	links, err := n.netlinkLib.LinkList()
+	if err != nil {
+		log.Log.Error(err, "GetDefaultRouteInterfaces(): failed to list links")
+		return nil, err
+	}
+	// walk down the masters (bridges, bonds) to the interfaces backing them
+	for changed := true; changed; {
+		changed = false
+		for _, link := range links {
+			attrs := link.Attrs()
+			if !indexes[attrs.Index] && indexes[attrs.MasterIndex] {
+				indexes[attrs.Index] = true
+				changed = true
+			}
+		}
+	}
+	names := []string{}
+	for _, link := range links {
+		if indexes[link.Attrs().Index] {
+			names = append(names, link.Attrs().Name)
+		}
+	}
+	log.Log.V(2).Info("GetDefaultRouteInterfaces()", "interfaces", names)
+	return names, nil
+}
+
+// SetNetDevNumQueues sets the number of combined queues of the interface if the driver supports it
+func (n *network) SetNetDevNumQueues(ifaceName string, numQueues int) error {
+	log.Log.V(2).Info("SetNetDevNumQueues(): set number of queues", "device", ifaceName, "queues", numQueues)
+	channels, err := n.ethtoolLib.GetChannels(ifaceName)
+	if err != nil || channels.MaxCombined == 0 {
+		log.Log.Info("SetNetDevNumQueues(): WARNING, can't set number of queues, not supported by the driver",
+			"device", ifaceName, "error", err)
+		return nil
+	}
+	if int(channels.CombinedCount) == numQueues {
+		log.Log.V(2).Info("SetNetDevNumQueues(): number of queues already set", "device", ifaceName)
+		return nil
+	}
+	if numQueues > int(channels.MaxCombined) {
+		return fmt.Errorf("requested number of queues %d is greater than the maximum %d for device %s",
+			numQueues, channels.MaxCombined, ifaceName)
+	}
+	channels.CombinedCount = uint32(numQueues)
+	if _, err := n.ethtoolLib.SetChannels(ifaceName, channels); err != nil {
+		log.Log.Error(err, "SetNetDevNumQueues(): can't set number of queues", "device", ifaceName)
+		return err
+	}
+	return nil
+}
+
+// SetNetDevRss sets the RSS hash key and spreads the traffic equally over the first indirectionSize
+// receive queues of the interface, empty hashKey or zero indirectionSize leave the setting unchanged.
+// The settings not supported by the driver are skipped with a warning
+func (n *network) SetNetDevRss(ifaceName, hashKey string, indirectionSize int) error {
+	log.Log.V(2).Info("SetNetDevRss(): set RSS", "device", ifaceName, "hash-key", hashKey, "indirection-size", indirectionSize)
+	indirSize, keySize, err := n.ethtoolLib.RssSizes(ifaceName)
+	if err != nil {
+		if errors.Is(err, unix.EOPNOTSUPP) {
+			log.Log.Info("SetNetDevRss(): WARNING, can't set RSS, not supported by the driver", "device", ifaceName)
+			return nil
+		}
+		log.Log.Error(err, "SetNetDevRss(): failed to get RSS capabilities", "device", ifaceName)
+		return err
+	}
+	if hashKey != "" && keySize == 0 {
+		log.Log.Info("SetNetDevRss(): WARNING, can't set RSS hash key, not supported by the driver", "device", ifaceName)
+		hashKey = ""
+	}
+	if indirectionSize > 0 && indirSize == 0 {
+		log.Log.Info("SetNetDevRss(): WARNING, can't set RSS indirection table, not supported by the driver", "device", ifaceName)
+		indirectionSize = 0
+	}
+	if hashKey == "" && indirectionSize == 0 {
+		return nil
+	}
+	args := []string{"-X", ifaceName}
+	if hashKey != "" {
+		args = append(args, "hkey", hashKey)
+	}
+	if indirectionSize > 0 {
+		args = append(args, "equal", strconv.Itoa(indirectionSize))
+	}
+	_, stderr, err := n.utilsHelper.RunCommand("ethtool", args...)
+	if err != nil {
+		log.Log.Error(err, "SetNetDevRss(): failed to set RSS", "device", ifaceName, "stderr", stderr)
+		return fmt.Errorf("failed to set RSS on %s: %v, %s", ifaceName, err, stderr)
+	}
+	return nil
+}
+
+// SetNetDevLinkSettings sets the auto-negotiation and the forced speed of the interface link,
+// a forced speed disables the auto-negotiation
+func (n *network) SetNetDevLinkSettings(ifaceName string, autoNeg *bool, speedMbps int) error {
+	log.Log.V(2).Info("SetNetDevLinkSettings(): set link settings", "device", ifaceName, "autoNeg", autoNeg, "speed", speedMbps)
+	cmd, err := n.ethtoolLib.CmdGet(ifaceName)
+	if err != nil {
+		log.Log.Error(err, "SetNetDevLinkSettings(): can't get link settings", "device", ifaceName)
+		return err
+	}
+	desired := cmd
+	if speedMbps > 0 {
+		desired.Autoneg = 0
+		desired.Speed = uint16(speedMbps & 0xffff)
+		desired.Speed_hi = uint16(speedMbps >> 16)
+	} else if autoNeg != nil {
+		desired.Autoneg = 0
+		if *autoNeg {
+			desired.Autoneg = 1
+		}
+	}
+	if desired == cmd {
+		log.Log.V(2).Info("SetNetDevLinkSettings(): link settings already set", "device", ifaceName)
+		return nil
+	}
+	if err := n.ethtoolLib.CmdSet(ifaceName, desired); err != nil {
+		log.Log.Error(err, "SetNetDevLinkSettings(): can't set link settings", "device", ifaceName)
+		return err
+	}
+	return nil
+}
+
+// vlanFilterFeatureName is the ethtool feature of the hardware VLAN filtering
+const vlanFilterFeatureName = "rx-vlan-filter"
+
+// GetNetDevVlanFiltering returns the state of the hardware VLAN filtering of the interface,
+// nil if the driver doesn't support it or the state can't be read
+func (n *network) GetNetDevVlanFiltering(ifaceName string) *bool {
+	log.Log.V(2).Info("GetNetDevVlanFiltering(): get VLAN filtering", "device", ifaceName)
+	features, err := n.ethtoolLib.Features(ifaceName)
+	if err != nil {
+		log.Log.V(2).Info("GetNetDevVlanFiltering(): can't read features state", "device", ifaceName, "error", err)
+		return nil
+	}
+	enabled, isKnown := features[vlanFilterFeatureName]
+	if !isKnown {
+		return nil
+	}
+	return &enabled
+}
+
+// SetNetDevVlanFiltering enables or disables the hardware VLAN filtering of the interface
+func (n *network) SetNetDevVlanFiltering(ifaceName string, enable bool) error {
+	log.Log.V(2).Info("SetNetDevVlanFiltering(): set VLAN filtering", "device", ifaceName, "enable", enable)
+	knownFeatures, err := n.ethtoolLib.FeatureNames(ifaceName)
+	if err != nil {
+		log.Log.Error(err, "SetNetDevVlanFiltering(): can't list supported features", "device", ifaceName)
+		return err
+	}
+	if _, isKnown := knownFeatures[vlanFilterFeatureName]; !isKnown {
+		return fmt.Errorf("VLAN filtering is not supported by device %s", ifaceName)
+	}
+	currentFeaturesState, err := n.ethtoolLib.Features(ifaceName)
+	if err != nil {
+		log.Log.Error(err, "SetNetDevVlanFiltering(): can't read features state for device", "device", ifaceName)
+		return err
+	}
+	if currentFeaturesState[vlanFilterFeatureName] == enable {
+		log.Log.V(2).Info("SetNetDevVlanFiltering(): VLAN filtering already set", "device", ifaceName)
+		return nil
+	}
+	if err := n.ethtoolLib.Change(ifaceName, map[string]bool{vlanFilterFeatureName: enable}); err != nil {
+		log.Log.Error(err, "SetNetDevVlanFiltering(): can't set feature for device", "device", ifaceName)
+		return err
+	}
+	return nil
+}
+
+// SetNetDevSysctl sets the per-interface sysctl, the <if> element of the sysctl name is replaced by the interface name
+func (n *network) SetNetDevSysctl(ifaceName, name, value string) error {
+	log.Log.V(2).Info("SetNetDevSysctl(): set sysctl", "device", ifaceName, "name", name, "value", value)
+	// split the name before the substitution, the interface name may contain dots
+	elements := strings.Split(name, ".")
+	for i := range elements {
+		if elements[i] == consts.SysctlInterfacePlaceholder {
+			elements[i] = ifaceName
+		}
+	}
+	path := filepath.Join(append([]string{vars.FilesystemRoot, consts.ProcSys}, elements...)...)
+	if err := os.WriteFile(path, []byte(value), 0644); err != nil {
+		log.Log.Error(err, "SetNetDevSysctl(): failed to set sysctl", "device", ifaceName, "name", name)
+		return err
+	}
+	return nil
+}
+
+// SetNetDevPromisc enables or disables the promiscuous mode of the interface
+func (n *network) SetNetDevPromisc(ifaceName string, enable bool) error {
+	log.Log.V(2).Info("SetNetDevPromisc(): set promiscuous mode", "device", ifaceName, "enable", enable)
+	link, err := n.netlinkLib.LinkByName(ifaceName)
+	if err != nil {
+		log.Log.Error(err, "SetNetDevPromisc(): failed to get link", "device", ifaceName)
+		return err
+	}
+	if (link.Attrs().Promisc != 0) == enable {
+		log.Log.V(2).Info("SetNetDevPromisc(): promiscuous mode already set", "device", ifaceName)
+		return nil
+	}
+	if enable {
+		err = n.netlinkLib.LinkSetPromiscOn(link)
+	} else {
+		err = n.netlinkLib.LinkSetPromiscOff(link)
+	}
+	if err != nil {
+		log.Log.Error(err, "SetNetDevPromisc(): failed to set promiscuous mode", "device", ifaceName)
+		return err
+	}
+	return nil
+}
+
+// SetNetDevMulticast enables or disables the reception of the multicast frames by the interface,
+// disabling it also disables the all-multicast mode
+func (n *network) SetNetDevMulticast(ifaceName string, enable bool) error {
+	log.Log.V(2).Info("SetNetDevMulticast(): set multicast mode", "device", ifaceName, "enable", enable)
+	link, err := n.netlinkLib.LinkByName(ifaceName)
+	if err != nil {
+		log.Log.Error(err, "SetNetDevMulticast(): failed to get link", "device", ifaceName)
+		return err
+	}
+	if !enable && link.Attrs().Allmulti != 0 {
+		if err := n.netlinkLib.LinkSetAllmulticastOff(link); err != nil {
+			log.Log.Error(err, "SetNetDevMulticast(): failed to disable all-multicast mode", "device", ifaceName)
+			return err
+		}
+	}
+	if (link.Attrs().Flags&net.FlagMulticast != 0) == enable {
+		log.Log.V(2).Info("SetNetDevMulticast(): multicast mode already set", "device", ifaceName)
+		return nil
+	}
+	if enable {
+		err = n.netlinkLib.LinkSetMulticastOn(link)
+	} else {
+		err = n.netlinkLib.LinkSetMulticastOff(link)
+	}
+	if err != nil {
+		log.Log.Error(err, "SetNetDevMulticast(): failed to set multicast mode", "device", ifaceName)
+		return err
+	}
+	return nil
+}
+
+// broadcastFilterRule is the description printed by ethtool of the classification rule dropping the broadcast
+// frames, only the rules matching it exactly are considered as created by SetNetDevBroadcastFilter
+var broadcastFilterRule = []string{
+	"Flow Type: Raw Ethernet",
+	"Src MAC addr: 00:00:00:00:00:00 mask: FF:FF:FF:FF:FF:FF",
+	"Dest MAC addr: FF:FF:FF:FF:FF:FF mask: 00:00:00:00:00:00",
+	"Ethertype: 0x0 mask: 0xFFFF",
+	"Action: Drop",
+}
+
+// SetNetDevBroadcastFilter adds or removes the ethtool classification rule dropping the broadcast frames
+// received by the interface. The location of the rule is allocated by ethtool so the rules configured
+// by the user are kept, the removal only deletes the rules matching the one added by this function
+func (n *network) SetNetDevBroadcastFilter(ifaceName string, drop bool) error {
+	log.Log.V(2).Info("SetNetDevBroadcastFilter(): set broadcast filter", "device", ifaceName, "drop", drop)
+	locations := n.getBroadcastFilterRuleLocations(ifaceName)
+	if (len(locations) > 0) == drop {
+		log.Log.V(2).Info("SetNetDevBroadcastFilter(): broadcast filter already set", "device", ifaceName)
+		return nil
+	}
+	if drop {
+		return n.runBroadcastFilterCommand(ifaceName, "-N", ifaceName, "flow-type", "ether", "dst", "ff:ff:ff:ff:ff:ff", "action", "-1")
+	}
+	for _, loc := range locations {
+		if err := n.runBroadcastFilterCommand(ifaceName, "-N", ifaceName, "delete", loc); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getBroadcastFilterRuleLocations returns the locations of the classification rules of the interface dropping
+// the broadcast frames, the interfaces which don't support the classification rules don't have any
+func (n *network) getBroadcastFilterRuleLocations(ifaceName string) []string {
+	stdout, stderr, err := n.utilsHelper.RunCommand("ethtool", "-n", ifaceName)
+	if err != nil {
+		log.Log.V(2).Info("getBroadcastFilterRuleLocations(): failed to list the classification rules",
+			"device", ifaceName, "error", err, "stderr", stderr)
+		return nil
+	}
+	locations := []string{}
+	// the rules are printed as a "Filter: <location>" line followed by the fields of the rule
+	var location string
+	var fields []string
+	flush := func() {
+		if location != "" && slices.Equal(fields, broadcastFilterRule) {
+			locations = append(locations, location)
+		}
+		location, fields = "", nil
+	}
+	for _, line := range strings.Split(stdout, "\n") {
+		line = strings.TrimSpace(line)
+		if loc, found := strings.CutPrefix(line, "Filter:"); found {
+			flush()
+			location = strings.TrimSpace(loc)
+		} else if location != "" && line != "" {
+			fields = append(fields, line)
+		}
+	}
+	flush()
+	return locations
+}
+
+// runBroadcastFilterCommand runs the ethtool command changing the classification rules of the interface
+func (n *network) runBroadcastFilterCommand(ifaceName string, args ...string) error {
+	_, stderr, err := n.utilsHelper.RunCommand("ethtool", args...)
+	if err != nil {
+		log.Log.Error(err, "SetNetDevBroadcastFilter(): failed to set broadcast filter", "device", ifaceName, "stderr", stderr)
+		return fmt.Errorf("failed to set broadcast filter on %s: %v, %s", ifaceName, err, stderr)
+	}
+	return nil
+}
+
+// SetVfConfig applies the attributes of the VF that differ from the current ones through the PF
+// in a single request
+func (n *network) SetVfConfig(pfName string, vfID int, config types.VfConfig) error {
+	log.Log.V(2).Info("SetVfConfig(): set VF config", "pf", pfName, "vf", vfID, "config", config)
+	link, err := n.netlinkLib.LinkByName(pfName)
+	if err != nil {
+		log.Log.Error(err, "SetVfConfig(): failed to get PF link", "pf", pfName)
+		return err
+	}
+	for _, vf := range link.Attrs().Vfs {
+		if vf.ID != vfID {
+			continue
+		}
+		if config.Vlan != nil && *config.Vlan == vf.Vlan && config.VlanQoS == vf.Qos && vlanProtoEqual(config.VlanProto, vf.VlanProto) {
+			config.Vlan = nil
+		}
+		if config.Trust != nil && *config.Trust == (vf.Trust != 0) {
+			config.Trust = nil
+		}
+		if config.SpoofChk != nil && *config.SpoofChk == vf.Spoofchk {
+			config.SpoofChk = nil
+		}
+		if config.MinTxRate != nil || config.MaxTxRate != nil {
+			// both rates are set together, the rate which is not requested keeps its current value
+			minTxRate, maxTxRate := int(vf.MinTxRate), int(vf.MaxTxRate)
+			if config.MinTxRate != nil {
+				minTxRate = *config.MinTxRate
+			}
+			if config.MaxTxRate != nil {
+				maxTxRate = *config.MaxTxRate
+			}
+			if minTxRate == int(vf.MinTxRate) && maxTxRate == int(vf.MaxTxRate) {
+				config.MinTxRate, config.MaxTxRate = nil, nil
+			} else {
+				config.MinTxRate, config.MaxTxRate = &minTxRate, &maxTxRate
+			}
+		}
+		break
+	}
+	if config.Vlan == nil && config.Trust == nil && config.SpoofChk == nil && config.MinTxRate == nil && config.MaxTxRate == nil {
+		log.Log.V(2).Info("SetVfConfig(): VF already configured", "pf", pfName, "vf", vfID)
+		return nil
+	}
+	if err := n.netlinkLib.LinkSetVfConfig(link, vfID, netlinkPkg.VfConfig(config)); err != nil {
+		log.Log.Error(err, "SetVfConfig(): failed to set VF config", "pf", pfName, "vf", vfID)
+		return err
+	}
+	return nil
+}
+
+// vlanProtoEqual returns true if the VLAN protocol of the VF matches the requested one, the kernels which don't
+// report the protocol of the VFs only support the default 802.1q protocol
+func vlanProtoEqual(requested string, current int) bool {
+	requestedProto, currentProto := netlink.VLAN_PROTOCOL_8021Q, netlink.VLAN_PROTOCOL_8021Q
+	if requested != "" {
+		requestedProto = netlink.StringToVlanProtocol(requested)
+	}
+	if current != 0 {
+		currentProto = netlink.VlanProtocol(current)
+	}
+	return requestedProto == currentProto
+}
+
 // GetNetDevLinkAdminState returns the admin state of the interface.
 func (n *network) GetNetDevLinkAdminState(ifaceName string) string {
 	log.Log.V(2).Info("GetNetDevLinkAdminState(): get LinkAdminState", "device", ifaceName)
@@ -430,6 +965,33 @@ func (n *network) GetPciAddressFromInterfaceName(interfaceName string) (string,
 	return pciAddress, nil
 }
 
+// SubscribeLinkUpdates returns a channel receiving the name of each link added, removed or changed on the host
+// until done is closed, the channel is closed when the subscription ends
+func (n *network) SubscribeLinkUpdates(done <-chan struct{}) (<-chan string, error) {
+	log.Log.V(2).Info("SubscribeLinkUpdates(): subscribe to the link updates")
+	updates := make(chan netlink.LinkUpdate)
+	if err := n.netlinkLib.LinkSubscribe(updates, done); err != nil {
+		log.Log.Error(err, "SubscribeLinkUpdates(): failed to subscribe to the link updates")
+		return nil, err
+	}
+
+	names := make(chan string)
+	go func() {
+		defer close(names)
+		for update := range updates {
+			if update.Link == nil {
+				continue
+			}
+			select {
+			case names <- update.Link.Attrs().Name:
+			case <-done:
+				return
+			}
+		}
+	}()
+	return names, nil
+}
+
 func (n *network) DiscoverRDMASubsystem() (string, error) {
 	subsystem, err := n.netlinkLib.RdmaSystemGetNetnsMode()
 
@@ -2,17 +2,22 @@ package network
 
 import (
 	"fmt"
+	"net"
+	"os"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"github.com/safchain/ethtool"
 	"github.com/vishvananda/netlink"
 	"github.com/vishvananda/netlink/nl"
+	"golang.org/x/sys/unix"
 
 	"github.com/golang/mock/gomock"
 
 	hostMockPkg "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/helper/mock"
 	dputilsMockPkg "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/host/internal/lib/dputils/mock"
 	ethtoolMockPkg "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/host/internal/lib/ethtool/mock"
+	netlinkPkg "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/host/internal/lib/netlink"
 	netlinkMockPkg "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/host/internal/lib/netlink/mock"
 	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/host/types"
 	"github.com/k8snetworkplumbingwg/sriov-network-operator/test/util/fakefilesystem"
@@ -204,6 +209,411 @@ var _ = Describe("Network", func() {
 			Expect(n.EnableHwTcOffload("enp216s0f0np0")).To(MatchError(testErr))
 		})
 	})
+	Context("GetNetDevNumQueues", func() {
+		It("Returns combined count", func() {
+			ethtoolLibMock.EXPECT().GetChannels("enp216s0f0v0").Return(ethtool.Channels{MaxCombined: 8, CombinedCount: 4}, nil)
+			Expect(n.GetNetDevNumQueues("enp216s0f0v0")).To(Equal(4))
+		})
+		It("Returns 0 when channels can't be read", func() {
+			ethtoolLibMock.EXPECT().GetChannels("enp216s0f0v0").Return(ethtool.Channels{}, testErr)
+			Expect(n.GetNetDevNumQueues("enp216s0f0v0")).To(Equal(0))
+		})
+	})
+	Context("GetNetDevDriverVersion", func() {
+		It("Returns the driver version", func() {
+			ethtoolLibMock.EXPECT().DriverInfo("enp216s0f0np0").Return(ethtool.DrvInfo{
+				Driver: "mlx5_core", Version: "24.04-0.6.6 ", FwVersion: "22.41.1000 (MT_0000000359)"}, nil)
+			Expect(n.GetNetDevDriverVersion("enp216s0f0np0")).To(Equal("24.04-0.6.6"))
+		})
+		It("Returns empty when the driver info can't be read", func() {
+			ethtoolLibMock.EXPECT().DriverInfo("enp216s0f0np0").Return(ethtool.DrvInfo{}, testErr)
+			Expect(n.GetNetDevDriverVersion("enp216s0f0np0")).To(BeEmpty())
+		})
+	})
+	Context("GetNetDevFirmwareVersion", func() {
+		It("Returns the firmware version without the PSID", func() {
+			ethtoolLibMock.EXPECT().DriverInfo("enp216s0f0np0").Return(ethtool.DrvInfo{
+				Driver: "mlx5_core", Version: "24.04-0.6.6", FwVersion: "22.41.1000 (MT_0000000359)"}, nil)
+			Expect(n.GetNetDevFirmwareVersion("enp216s0f0np0")).To(Equal("22.41.1000"))
+		})
+		It("Returns empty when the driver info can't be read", func() {
+			ethtoolLibMock.EXPECT().DriverInfo("enp216s0f0np0").Return(ethtool.DrvInfo{}, testErr)
+			Expect(n.GetNetDevFirmwareVersion("enp216s0f0np0")).To(BeEmpty())
+		})
+	})
+	Context("GetNetDevVlanFiltering", func() {
+		It("Returns the state of the feature", func() {
+			ethtoolLibMock.EXPECT().Features("enp216s0f0np0").Return(map[string]bool{"rx-vlan-filter": true}, nil)
+			Expect(n.GetNetDevVlanFiltering("enp216s0f0np0")).To(HaveValue(BeTrue()))
+		})
+		It("Returns nil when the feature is not supported", func() {
+			ethtoolLibMock.EXPECT().Features("enp216s0f0np0").Return(map[string]bool{"hw-tc-offload": true}, nil)
+			Expect(n.GetNetDevVlanFiltering("enp216s0f0np0")).To(BeNil())
+		})
+	})
+	Context("SetNetDevVlanFiltering", func() {
+		It("Changes the feature", func() {
+			ethtoolLibMock.EXPECT().FeatureNames("enp216s0f0np0").Return(map[string]uint{"rx-vlan-filter": 42}, nil)
+			ethtoolLibMock.EXPECT().Features("enp216s0f0np0").Return(map[string]bool{"rx-vlan-filter": true}, nil)
+			ethtoolLibMock.EXPECT().Change("enp216s0f0np0", map[string]bool{"rx-vlan-filter": false}).Return(nil)
+			Expect(n.SetNetDevVlanFiltering("enp216s0f0np0", false)).To(Succeed())
+		})
+		It("Doesn't change the feature when already set", func() {
+			ethtoolLibMock.EXPECT().FeatureNames("enp216s0f0np0").Return(map[string]uint{"rx-vlan-filter": 42}, nil)
+			ethtoolLibMock.EXPECT().Features("enp216s0f0np0").Return(map[string]bool{"rx-vlan-filter": true}, nil)
+			Expect(n.SetNetDevVlanFiltering("enp216s0f0np0", true)).To(Succeed())
+		})
+		It("fail - not supported", func() {
+			ethtoolLibMock.EXPECT().FeatureNames("enp216s0f0np0").Return(map[string]uint{"hw-tc-offload": 42}, nil)
+			Expect(n.SetNetDevVlanFiltering("enp216s0f0np0", true)).To(MatchError(ContainSubstring("not supported")))
+		})
+	})
+	Context("SetVfConfig", func() {
+		It("Applies the attributes that differ from the current ones in a single request", func() {
+			pfLinkMock := netlinkMockPkg.NewMockLink(testCtrl)
+			pfLinkMock.EXPECT().Attrs().Return(&netlink.LinkAttrs{Vfs: []netlink.VfInfo{
+				{ID: 0, Vlan: 100, Qos: 3, Spoofchk: true},
+				{ID: 1, Vlan: 100, Qos: 3, Spoofchk: true, Trust: 1},
+			}}).AnyTimes()
+			netlinkLibMock.EXPECT().LinkByName("enp216s0f0np0").Return(pfLinkMock, nil)
+			vlan, trust, spoofChk := 0, true, true
+			// vlan 0 is not skipped, it clears the vlan previously set on the VF
+			netlinkLibMock.EXPECT().LinkSetVfConfig(pfLinkMock, 0, netlinkPkg.VfConfig{Vlan: &vlan, Trust: &trust}).Return(nil)
+			Expect(n.SetVfConfig("enp216s0f0np0", 0, types.VfConfig{Vlan: &vlan, Trust: &trust, SpoofChk: &spoofChk})).To(Succeed())
+		})
+		It("Doesn't send a request when the VF is already configured", func() {
+			pfLinkMock := netlinkMockPkg.NewMockLink(testCtrl)
+			pfLinkMock.EXPECT().Attrs().Return(&netlink.LinkAttrs{Vfs: []netlink.VfInfo{
+				{ID: 1, Vlan: 100, Qos: 3, Spoofchk: true, Trust: 1},
+			}}).AnyTimes()
+			netlinkLibMock.EXPECT().LinkByName("enp216s0f0np0").Return(pfLinkMock, nil)
+			vlan, trust := 100, true
+			Expect(n.SetVfConfig("enp216s0f0np0", 1, types.VfConfig{Vlan: &vlan, VlanQoS: 3, Trust: &trust})).To(Succeed())
+		})
+		It("Sets the VLAN again when only its protocol differs", func() {
+			pfLinkMock := netlinkMockPkg.NewMockLink(testCtrl)
+			pfLinkMock.EXPECT().Attrs().Return(&netlink.LinkAttrs{Vfs: []netlink.VfInfo{
+				{ID: 0, Vlan: 100, VlanProto: int(netlink.VLAN_PROTOCOL_8021Q)},
+				// kernels which don't report the protocol only support 802.1q
+				{ID: 1, Vlan: 100},
+			}}).AnyTimes()
+			netlinkLibMock.EXPECT().LinkByName("enp216s0f0np0").Return(pfLinkMock, nil).Times(3)
+			vlan := 100
+			netlinkLibMock.EXPECT().LinkSetVfConfig(pfLinkMock, 0, netlinkPkg.VfConfig{Vlan: &vlan, VlanProto: "802.1ad"}).Return(nil)
+			Expect(n.SetVfConfig("enp216s0f0np0", 0, types.VfConfig{Vlan: &vlan, VlanProto: "802.1ad"})).To(Succeed())
+
+			By("not sending a request when the protocol is already set")
+			Expect(n.SetVfConfig("enp216s0f0np0", 0, types.VfConfig{Vlan: &vlan, VlanProto: "802.1q"})).To(Succeed())
+			Expect(n.SetVfConfig("enp216s0f0np0", 1, types.VfConfig{Vlan: &vlan})).To(Succeed())
+		})
+		It("Sets both TX rates keeping the current value of the rate which is not requested", func() {
+			pfLinkMock := netlinkMockPkg.NewMockLink(testCtrl)
+			pfLinkMock.EXPECT().Attrs().Return(&netlink.LinkAttrs{Vfs: []netlink.VfInfo{
+				{ID: 0, MinTxRate: 100, MaxTxRate: 1000},
+			}}).AnyTimes()
+			netlinkLibMock.EXPECT().LinkByName("enp216s0f0np0").Return(pfLinkMock, nil).Times(2)
+			minTxRate, maxTxRate := 100, 2000
+			netlinkLibMock.EXPECT().LinkSetVfConfig(pfLinkMock, 0,
+				netlinkPkg.VfConfig{MinTxRate: &minTxRate, MaxTxRate: &maxTxRate}).Return(nil)
+			Expect(n.SetVfConfig("enp216s0f0np0", 0, types.VfConfig{MaxTxRate: &maxTxRate})).To(Succeed())
+
+			By("not sending a request when the rates are already set")
+			currentMaxTxRate := 1000
+			Expect(n.SetVfConfig("enp216s0f0np0", 0, types.VfConfig{MaxTxRate: &currentMaxTxRate})).To(Succeed())
+		})
+		It("Returns an error when the request fails", func() {
+			pfLinkMock := netlinkMockPkg.NewMockLink(testCtrl)
+			pfLinkMock.EXPECT().Attrs().Return(&netlink.LinkAttrs{}).AnyTimes()
+			netlinkLibMock.EXPECT().LinkByName("enp216s0f0np0").Return(pfLinkMock, nil)
+			trust := true
+			netlinkLibMock.EXPECT().LinkSetVfConfig(pfLinkMock, 2, netlinkPkg.VfConfig{Trust: &trust}).Return(testErr)
+			Expect(n.SetVfConfig("enp216s0f0np0", 2, types.VfConfig{Trust: &trust})).To(MatchError(testErr))
+		})
+	})
+	Context("SetNetDevMacsec", func() {
+		var batch string
+
+		BeforeEach(func() {
+			batch = ""
+			netlinkLibMock.EXPECT().LinkByName("eth0v0").Return(
+				&netlink.Device{LinkAttrs: netlink.LinkAttrs{Index: 7, Name: "eth0v0"}}, nil)
+		})
+		readBatch := func(_ string, args ...string) (string, string, error) {
+			Expect(args[0]).To(Equal("-batch"))
+			content, err := os.ReadFile(args[1])
+			Expect(err).NotTo(HaveOccurred())
+			batch = string(content)
+			return "", "", nil
+		}
+
+		It("Creates the MACsec interface", func() {
+			netlinkLibMock.EXPECT().LinkByName("macsec7").Return(nil, netlink.LinkNotFoundError{})
+			hostMock.EXPECT().RunCommand("ip", "-batch", gomock.Any()).DoAndReturn(readBatch)
+			Expect(n.SetNetDevMacsec("eth0v0", "01", "81818181818181818181818181818181", true)).NotTo(HaveOccurred())
+			Expect(batch).To(Equal("link add link eth0v0 name macsec7 type macsec encrypt on\n" +
+				"macsec add macsec7 tx sa 0 pn 1 on key 01 81818181818181818181818181818181\n" +
+				"link set macsec7 alias " + macsecConfigAlias("01", "81818181818181818181818181818181", true) + "\n" +
+				"link set macsec7 up\n"))
+		})
+		It("Recreates an existing MACsec interface with another configuration", func() {
+			netlinkLibMock.EXPECT().LinkByName("macsec7").Return(
+				&netlink.GenericLink{LinkAttrs: netlink.LinkAttrs{Index: 8, Name: "macsec7",
+					Alias: macsecConfigAlias("00", "82828282828282828282828282828282", false)}, LinkType: "macsec"}, nil)
+			hostMock.EXPECT().RunCommand("ip", "-batch", gomock.Any()).DoAndReturn(readBatch)
+			Expect(n.SetNetDevMacsec("eth0v0", "00", "81818181818181818181818181818181", false)).NotTo(HaveOccurred())
+			Expect(batch).To(Equal("link del macsec7\n" +
+				"link add link eth0v0 name macsec7 type macsec encrypt off\n" +
+				"macsec add macsec7 tx sa 0 pn 1 on key 00 81818181818181818181818181818181\n" +
+				"link set macsec7 alias " + macsecConfigAlias("00", "81818181818181818181818181818181", false) + "\n" +
+				"link set macsec7 up\n"))
+		})
+		It("Keeps an existing MACsec interface with the same configuration", func() {
+			netlinkLibMock.EXPECT().LinkByName("macsec7").Return(
+				&netlink.GenericLink{LinkAttrs: netlink.LinkAttrs{Index: 8, Name: "macsec7",
+					Alias: macsecConfigAlias("00", "81818181818181818181818181818181", false)}, LinkType: "macsec"}, nil)
+			Expect(n.SetNetDevMacsec("eth0v0", "00", "81818181818181818181818181818181", false)).NotTo(HaveOccurred())
+		})
+		It("Reports an existing MACsec interface with another key as outdated", func() {
+			netlinkLibMock.EXPECT().LinkByName("macsec7").Return(
+				&netlink.GenericLink{LinkAttrs: netlink.LinkAttrs{Index: 8, Name: "macsec7",
+					Alias: macsecConfigAlias("00", "82828282828282828282828282828282", true)}, LinkType: "macsec"}, nil)
+			Expect(n.IsNetDevMacsecOutdated("eth0v0", "00", "81818181818181818181818181818181", true)).To(BeTrue())
+		})
+		It("Doesn't report a missing MACsec interface as outdated", func() {
+			netlinkLibMock.EXPECT().LinkByName("macsec7").Return(nil, netlink.LinkNotFoundError{})
+			Expect(n.IsNetDevMacsecOutdated("eth0v0", "00", "81818181818181818181818181818181", true)).To(BeFalse())
+		})
+		It("Fails when the ip command fails", func() {
+			netlinkLibMock.EXPECT().LinkByName("macsec7").Return(nil, netlink.LinkNotFoundError{})
+			hostMock.EXPECT().RunCommand("ip", "-batch", gomock.Any()).Return("", "RTNETLINK answers: Operation not supported", testErr)
+			err := n.SetNetDevMacsec("eth0v0", "00", "81818181818181818181818181818181", true)
+			Expect(err).To(MatchError(ContainSubstring("Operation not supported")))
+		})
+	})
+	Context("SetNetDevPromisc", func() {
+		It("Enables the promiscuous mode", func() {
+			link := &netlink.Device{LinkAttrs: netlink.LinkAttrs{Name: "eth0v0"}}
+			netlinkLibMock.EXPECT().LinkByName("eth0v0").Return(link, nil)
+			netlinkLibMock.EXPECT().LinkSetPromiscOn(link).Return(nil)
+			Expect(n.SetNetDevPromisc("eth0v0", true)).NotTo(HaveOccurred())
+		})
+		It("Disables the promiscuous mode", func() {
+			link := &netlink.Device{LinkAttrs: netlink.LinkAttrs{Name: "eth0v0", Promisc: 1}}
+			netlinkLibMock.EXPECT().LinkByName("eth0v0").Return(link, nil)
+			netlinkLibMock.EXPECT().LinkSetPromiscOff(link).Return(nil)
+			Expect(n.SetNetDevPromisc("eth0v0", false)).NotTo(HaveOccurred())
+		})
+		It("Does nothing when the promiscuous mode is already set", func() {
+			netlinkLibMock.EXPECT().LinkByName("eth0v0").Return(
+				&netlink.Device{LinkAttrs: netlink.LinkAttrs{Name: "eth0v0", Promisc: 1}}, nil)
+			Expect(n.SetNetDevPromisc("eth0v0", true)).NotTo(HaveOccurred())
+		})
+	})
+	Context("SetNetDevMulticast", func() {
+		It("Disables the multicast and the all-multicast modes", func() {
+			link := &netlink.Device{LinkAttrs: netlink.LinkAttrs{Name: "eth0v0", Flags: net.FlagMulticast, Allmulti: 1}}
+			netlinkLibMock.EXPECT().LinkByName("eth0v0").Return(link, nil)
+			allmultiOff := netlinkLibMock.EXPECT().LinkSetAllmulticastOff(link).Return(nil)
+			netlinkLibMock.EXPECT().LinkSetMulticastOff(link).Return(nil).After(allmultiOff)
+			Expect(n.SetNetDevMulticast("eth0v0", false)).NotTo(HaveOccurred())
+		})
+		It("Enables the multicast mode", func() {
+			link := &netlink.Device{LinkAttrs: netlink.LinkAttrs{Name: "eth0v0"}}
+			netlinkLibMock.EXPECT().LinkByName("eth0v0").Return(link, nil)
+			netlinkLibMock.EXPECT().LinkSetMulticastOn(link).Return(nil)
+			Expect(n.SetNetDevMulticast("eth0v0", true)).NotTo(HaveOccurred())
+		})
+		It("Does nothing when the multicast mode is already set", func() {
+			netlinkLibMock.EXPECT().LinkByName("eth0v0").Return(
+				&netlink.Device{LinkAttrs: netlink.LinkAttrs{Name: "eth0v0", Flags: net.FlagMulticast}}, nil)
+			Expect(n.SetNetDevMulticast("eth0v0", true)).NotTo(HaveOccurred())
+		})
+	})
+	Context("SetNetDevBroadcastFilter", func() {
+		broadcastRule := func(location int) string {
+			return fmt.Sprintf("Filter: %d\n"+
+				"\tFlow Type: Raw Ethernet\n"+
+				"\tSrc MAC addr: 00:00:00:00:00:00 mask: FF:FF:FF:FF:FF:FF\n"+
+				"\tDest MAC addr: FF:FF:FF:FF:FF:FF mask: 00:00:00:00:00:00\n"+
+				"\tEthertype: 0x0 mask: 0xFFFF\n"+
+				"\tAction: Drop\n\n", location)
+		}
+		// a rule of the user dropping the broadcast frames of a VLAN
+		userRule := "Filter: 0\n" +
+			"\tFlow Type: Raw Ethernet\n" +
+			"\tSrc MAC addr: 00:00:00:00:00:00 mask: FF:FF:FF:FF:FF:FF\n" +
+			"\tDest MAC addr: FF:FF:FF:FF:FF:FF mask: 00:00:00:00:00:00\n" +
+			"\tEthertype: 0x0 mask: 0xFFFF\n" +
+			"\tVLAN EtherType: 0x0 mask: 0xffff\n" +
+			"\tVLAN: 0x64 mask: 0xf000\n" +
+			"\tUser-defined: 0x0 mask: 0xffffffffffffffff\n" +
+			"\tAction: Drop\n\n"
+		rulesHeader := "4 RX rings available\nTotal 1 rules\n\n"
+
+		It("Adds the rule dropping the broadcast frames at a free location", func() {
+			hostMock.EXPECT().RunCommand("ethtool", "-n", "eth0v0").Return(rulesHeader+userRule, "", nil)
+			hostMock.EXPECT().RunCommand("ethtool", "-N", "eth0v0", "flow-type", "ether", "dst", "ff:ff:ff:ff:ff:ff",
+				"action", "-1").Return("Added rule with ID 1023", "", nil)
+			Expect(n.SetNetDevBroadcastFilter("eth0v0", true)).NotTo(HaveOccurred())
+		})
+		It("Removes only the rules dropping the broadcast frames", func() {
+			hostMock.EXPECT().RunCommand("ethtool", "-n", "eth0v0").Return(rulesHeader+userRule+broadcastRule(1023), "", nil)
+			hostMock.EXPECT().RunCommand("ethtool", "-N", "eth0v0", "delete", "1023").Return("", "", nil)
+			Expect(n.SetNetDevBroadcastFilter("eth0v0", false)).NotTo(HaveOccurred())
+		})
+		It("Does nothing when the rule already exists", func() {
+			hostMock.EXPECT().RunCommand("ethtool", "-n", "eth0v0").Return(rulesHeader+broadcastRule(1023)+userRule, "", nil)
+			Expect(n.SetNetDevBroadcastFilter("eth0v0", true)).NotTo(HaveOccurred())
+		})
+		It("Does nothing when removing the rule of an interface without classification rules", func() {
+			hostMock.EXPECT().RunCommand("ethtool", "-n", "eth0v0").
+				Return("", "rxclass: Cannot get RX class rule count: Operation not supported", testErr)
+			Expect(n.SetNetDevBroadcastFilter("eth0v0", false)).NotTo(HaveOccurred())
+		})
+		It("Fails when the driver doesn't support ntuple filters", func() {
+			hostMock.EXPECT().RunCommand("ethtool", "-n", "eth0v0").
+				Return("", "rxclass: Cannot get RX class rule count: Operation not supported", testErr)
+			hostMock.EXPECT().RunCommand("ethtool", "-N", "eth0v0", "flow-type", "ether", "dst", "ff:ff:ff:ff:ff:ff",
+				"action", "-1").Return("", "rmgr: Cannot insert RX class rule: Operation not supported", testErr)
+			Expect(n.SetNetDevBroadcastFilter("eth0v0", true)).To(MatchError(ContainSubstring("Operation not supported")))
+		})
+	})
+	Context("SetNetDevRss", func() {
+		It("Sets the hash key and the indirection table", func() {
+			ethtoolLibMock.EXPECT().RssSizes("eth0v0").Return(uint32(64), uint32(40), nil)
+			hostMock.EXPECT().RunCommand("ethtool", "-X", "eth0v0", "hkey", "6d:5a:56:da", "equal", "4").Return("", "", nil)
+			Expect(n.SetNetDevRss("eth0v0", "6d:5a:56:da", 4)).NotTo(HaveOccurred())
+		})
+		It("Sets only the indirection table", func() {
+			ethtoolLibMock.EXPECT().RssSizes("eth0v0").Return(uint32(64), uint32(40), nil)
+			hostMock.EXPECT().RunCommand("ethtool", "-X", "eth0v0", "equal", "2").Return("", "", nil)
+			Expect(n.SetNetDevRss("eth0v0", "", 2)).NotTo(HaveOccurred())
+		})
+		It("Skips drivers not supporting RSS configuration", func() {
+			ethtoolLibMock.EXPECT().RssSizes("eth0v0").Return(uint32(0), uint32(0), unix.EOPNOTSUPP)
+			Expect(n.SetNetDevRss("eth0v0", "", 2)).NotTo(HaveOccurred())
+		})
+		It("Skips the hash key when the driver doesn't support it", func() {
+			ethtoolLibMock.EXPECT().RssSizes("eth0v0").Return(uint32(64), uint32(0), nil)
+			hostMock.EXPECT().RunCommand("ethtool", "-X", "eth0v0", "equal", "4").Return("", "", nil)
+			Expect(n.SetNetDevRss("eth0v0", "6d:5a:56:da", 4)).NotTo(HaveOccurred())
+		})
+		It("Skips the configuration when the driver has no indirection table nor hash key", func() {
+			ethtoolLibMock.EXPECT().RssSizes("eth0v0").Return(uint32(0), uint32(0), nil)
+			Expect(n.SetNetDevRss("eth0v0", "6d:5a:56:da", 4)).NotTo(HaveOccurred())
+		})
+		It("Fails when the RSS capabilities can't be read", func() {
+			ethtoolLibMock.EXPECT().RssSizes("eth0v0").Return(uint32(0), uint32(0), unix.ENODEV)
+			Expect(n.SetNetDevRss("eth0v0", "", 2)).To(MatchError(unix.ENODEV))
+		})
+		It("Fails when the ethtool command fails", func() {
+			ethtoolLibMock.EXPECT().RssSizes("eth0v0").Return(uint32(64), uint32(40), nil)
+			hostMock.EXPECT().RunCommand("ethtool", "-X", "eth0v0", "equal", "8").
+				Return("", "Cannot set RX flow hash configuration: Operation not supported", testErr)
+			Expect(n.SetNetDevRss("eth0v0", "", 8)).To(MatchError(ContainSubstring("Operation not supported")))
+		})
+	})
+	Context("GetDefaultRouteInterfaces", func() {
+		It("Returns the interfaces enslaved to the default route interface", func() {
+			_, subnet, _ := net.ParseCIDR("10.0.0.0/24")
+			netlinkLibMock.EXPECT().RouteList(nil, netlink.FAMILY_ALL).Return([]netlink.Route{
+				{LinkIndex: 4, Dst: subnet},
+				{LinkIndex: 3},
+			}, nil)
+			netlinkLibMock.EXPECT().LinkList().Return([]netlinkPkg.Link{
+				&netlink.Device{LinkAttrs: netlink.LinkAttrs{Index: 1, Name: "eno1", MasterIndex: 2}},
+				&netlink.Bond{LinkAttrs: netlink.LinkAttrs{Index: 2, Name: "bond0", MasterIndex: 3}},
+				&netlink.Bridge{LinkAttrs: netlink.LinkAttrs{Index: 3, Name: "br-ex"}},
+				&netlink.Device{LinkAttrs: netlink.LinkAttrs{Index: 4, Name: "ens1f0"}},
+			}, nil)
+			Expect(n.GetDefaultRouteInterfaces()).To(ConsistOf("eno1", "bond0", "br-ex"))
+		})
+		It("Returns nothing when there is no default route", func() {
+			netlinkLibMock.EXPECT().RouteList(nil, netlink.FAMILY_ALL).Return([]netlink.Route{}, nil)
+			Expect(n.GetDefaultRouteInterfaces()).To(BeEmpty())
+		})
+		It("Fails when the routes can't be listed", func() {
+			netlinkLibMock.EXPECT().RouteList(nil, netlink.FAMILY_ALL).Return(nil, testErr)
+			_, err := n.GetDefaultRouteInterfaces()
+			Expect(err).To(MatchError(testErr))
+		})
+	})
+	Context("SetNetDevNumQueues", func() {
+		It("Set", func() {
+			ethtoolLibMock.EXPECT().GetChannels("enp216s0f0v0").Return(ethtool.Channels{MaxCombined: 8, CombinedCount: 1}, nil)
+			ethtoolLibMock.EXPECT().SetChannels("enp216s0f0v0", ethtool.Channels{MaxCombined: 8, CombinedCount: 4}).
+				Return(ethtool.Channels{MaxCombined: 8, CombinedCount: 4}, nil)
+			Expect(n.SetNetDevNumQueues("enp216s0f0v0", 4)).NotTo(HaveOccurred())
+		})
+		It("Already set", func() {
+			ethtoolLibMock.EXPECT().GetChannels("enp216s0f0v0").Return(ethtool.Channels{MaxCombined: 8, CombinedCount: 4}, nil)
+			Expect(n.SetNetDevNumQueues("enp216s0f0v0", 4)).NotTo(HaveOccurred())
+		})
+		It("Skip when not supported by the driver", func() {
+			ethtoolLibMock.EXPECT().GetChannels("enp216s0f0v0").Return(ethtool.Channels{}, testErr)
+			Expect(n.SetNetDevNumQueues("enp216s0f0v0", 4)).NotTo(HaveOccurred())
+		})
+		It("Skip when driver doesn't expose combined queues", func() {
+			ethtoolLibMock.EXPECT().GetChannels("enp216s0f0v0").Return(ethtool.Channels{MaxRx: 8, MaxTx: 8}, nil)
+			Expect(n.SetNetDevNumQueues("enp216s0f0v0", 4)).NotTo(HaveOccurred())
+		})
+		It("fail - greater than max", func() {
+			ethtoolLibMock.EXPECT().GetChannels("enp216s0f0v0").Return(ethtool.Channels{MaxCombined: 2, CombinedCount: 1}, nil)
+			Expect(n.SetNetDevNumQueues("enp216s0f0v0", 4)).To(HaveOccurred())
+		})
+		It("fail - can't set channels", func() {
+			ethtoolLibMock.EXPECT().GetChannels("enp216s0f0v0").Return(ethtool.Channels{MaxCombined: 8, CombinedCount: 1}, nil)
+			ethtoolLibMock.EXPECT().SetChannels("enp216s0f0v0", gomock.Any()).Return(ethtool.Channels{}, testErr)
+			Expect(n.SetNetDevNumQueues("enp216s0f0v0", 4)).To(MatchError(testErr))
+		})
+	})
+	Context("SetNetDevLinkSettings", func() {
+		It("Force speed", func() {
+			ethtoolLibMock.EXPECT().CmdGet("enp216s0f0np0").Return(ethtool.EthtoolCmd{Autoneg: 1, Speed: 25000}, nil)
+			ethtoolLibMock.EXPECT().CmdSet("enp216s0f0np0", ethtool.EthtoolCmd{Autoneg: 0, Speed: 10000}).Return(nil)
+			Expect(n.SetNetDevLinkSettings("enp216s0f0np0", nil, 10000)).NotTo(HaveOccurred())
+		})
+		It("Force speed above 65535 Mb/s", func() {
+			ethtoolLibMock.EXPECT().CmdGet("enp216s0f0np0").Return(ethtool.EthtoolCmd{Autoneg: 1, Speed: 25000}, nil)
+			ethtoolLibMock.EXPECT().CmdSet("enp216s0f0np0", ethtool.EthtoolCmd{Autoneg: 0, Speed: 100000 & 0xffff, Speed_hi: 1}).Return(nil)
+			Expect(n.SetNetDevLinkSettings("enp216s0f0np0", nil, 100000)).NotTo(HaveOccurred())
+		})
+		It("Enable auto-negotiation", func() {
+			ethtoolLibMock.EXPECT().CmdGet("enp216s0f0np0").Return(ethtool.EthtoolCmd{Autoneg: 0, Speed: 10000}, nil)
+			ethtoolLibMock.EXPECT().CmdSet("enp216s0f0np0", ethtool.EthtoolCmd{Autoneg: 1, Speed: 10000}).Return(nil)
+			autoNeg := true
+			Expect(n.SetNetDevLinkSettings("enp216s0f0np0", &autoNeg, 0)).NotTo(HaveOccurred())
+		})
+		It("Already set", func() {
+			ethtoolLibMock.EXPECT().CmdGet("enp216s0f0np0").Return(ethtool.EthtoolCmd{Autoneg: 0, Speed: 10000}, nil)
+			autoNeg := false
+			Expect(n.SetNetDevLinkSettings("enp216s0f0np0", &autoNeg, 10000)).NotTo(HaveOccurred())
+		})
+		It("fail - can't get link settings", func() {
+			ethtoolLibMock.EXPECT().CmdGet("enp216s0f0np0").Return(ethtool.EthtoolCmd{}, testErr)
+			Expect(n.SetNetDevLinkSettings("enp216s0f0np0", nil, 10000)).To(MatchError(testErr))
+		})
+		It("fail - can't set link settings", func() {
+			ethtoolLibMock.EXPECT().CmdGet("enp216s0f0np0").Return(ethtool.EthtoolCmd{Autoneg: 1}, nil)
+			ethtoolLibMock.EXPECT().CmdSet("enp216s0f0np0", gomock.Any()).Return(testErr)
+			autoNeg := false
+			Expect(n.SetNetDevLinkSettings("enp216s0f0np0", &autoNeg, 0)).To(MatchError(testErr))
+		})
+	})
+	Context("SetNetDevSysctl", func() {
+		It("Set", func() {
+			helpers.GinkgoConfigureFakeFS(&fakefilesystem.FS{
+				Dirs:  []string{"/proc/sys/net/ipv4/conf/enp216s0f0v0.100"},
+				Files: map[string][]byte{"/proc/sys/net/ipv4/conf/enp216s0f0v0.100/rp_filter": []byte("1")},
+			})
+			Expect(n.SetNetDevSysctl("enp216s0f0v0.100", "net.ipv4.conf.<if>.rp_filter", "2")).To(Succeed())
+			helpers.GinkgoAssertFileContentsEquals("/proc/sys/net/ipv4/conf/enp216s0f0v0.100/rp_filter", "2")
+		})
+		It("fail - sysctl doesn't exist", func() {
+			helpers.GinkgoConfigureFakeFS(&fakefilesystem.FS{})
+			Expect(n.SetNetDevSysctl("enp216s0f0v0", "net.ipv4.conf.<if>.rp_filter", "2")).To(HaveOccurred())
+		})
+	})
 	Context("GetNetDevNodeGUID", func() {
 		It("Returns empty when pciAddr is empty", func() {
 			Expect(n.GetNetDevNodeGUID("")).To(Equal(""))
@@ -283,6 +693,33 @@ var _ = Describe("Network", func() {
 			Expect(pci).To(Equal("0000:3b:00.0"))
 		})
 	})
+	Context("SubscribeLinkUpdates", func() {
+		It("Should send the name of the updated links", func() {
+			done := make(chan struct{})
+			defer close(done)
+			netlinkLibMock.EXPECT().LinkSubscribe(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(ch chan<- netlink.LinkUpdate, _ <-chan struct{}) error {
+					go func() {
+						ch <- netlink.LinkUpdate{Link: &netlink.Device{LinkAttrs: netlink.LinkAttrs{Name: "enp216s0f0np0"}}}
+						ch <- netlink.LinkUpdate{}
+						ch <- netlink.LinkUpdate{Link: &netlink.Device{LinkAttrs: netlink.LinkAttrs{Name: "enp216s0f0v0"}}}
+						close(ch)
+					}()
+					return nil
+				})
+
+			names, err := n.SubscribeLinkUpdates(done)
+			Expect(err).NotTo(HaveOccurred())
+			Eventually(names).Should(Receive(Equal("enp216s0f0np0")))
+			Eventually(names).Should(Receive(Equal("enp216s0f0v0")))
+			Eventually(names).Should(BeClosed())
+		})
+		It("Should return an error when the subscription fails", func() {
+			netlinkLibMock.EXPECT().LinkSubscribe(gomock.Any(), gomock.Any()).Return(testErr)
+			_, err := n.SubscribeLinkUpdates(make(chan struct{}))
+			Expect(err).To(MatchError(testErr))
+		})
+	})
 	Context("DiscoverRDMASubsystem", func() {
 		It("Should get RDMA Subsystem using netlink", func() {
 			netlinkLibMock.EXPECT().RdmaSystemGetNetnsMode().Return("shared", nil)
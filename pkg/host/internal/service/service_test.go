@@ -0,0 +1,62 @@
+package service
+
+import (
+	"strings"
+
+	"github.com/coreos/go-systemd/v22/unit"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/host/types"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/utils"
+)
+
+const sriovUnits = "../../../../bindata/manifests/sriov-config-service/kubernetes/"
+
+var _ = Describe("Service", func() {
+	var s types.ServiceInterface
+
+	BeforeEach(func() {
+		s = New(utils.New())
+	})
+
+	Context("sriov-config units", func() {
+		DescribeTable("should restart the unit on failure a bounded number of times",
+			func(manifest, name string) {
+				service, err := s.ReadServiceManifestFile(sriovUnits + manifest)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(service.Name).To(Equal(name))
+				Expect(service.Path).To(Equal("/etc/systemd/system/" + name))
+
+				opts, err := unit.Deserialize(strings.NewReader(service.Content))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(opts).To(ContainElements(
+					unit.NewUnitOption("Service", "Restart", "on-failure"),
+					unit.NewUnitOption("Service", "RestartSec", "10"),
+					unit.NewUnitOption("Unit", "StartLimitIntervalSec", "300"),
+					unit.NewUnitOption("Unit", "StartLimitBurst", "4"),
+				))
+			},
+			Entry("pre network", "sriov-config-service.yaml", "sriov-config.service"),
+			Entry("post network", "sriov-config-post-network-service.yaml", "sriov-config-post-network.service"),
+		)
+
+		It("should update the units installed without the restart directives", func() {
+			service, err := s.ReadServiceManifestFile(sriovUnits + "sriov-config-service.yaml")
+			Expect(err).NotTo(HaveOccurred())
+			installed := &types.Service{
+				Name:    service.Name,
+				Path:    service.Path,
+				Content: strings.NewReplacer("Restart=on-failure\n", "", "RestartSec=10\n", "").Replace(service.Content),
+			}
+
+			needUpdate, err := s.CompareServices(installed, service)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(needUpdate).To(BeTrue())
+
+			needUpdate, err = s.CompareServices(service, service)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(needUpdate).To(BeFalse())
+		})
+	})
+})
@@ -1,8 +1,10 @@
 package sriov
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
+	"net"
 	"os"
 	"path/filepath"
 	"strconv"
@@ -155,6 +157,9 @@ func (s *sriov) getVfInfo(vfAddr string, pfName string, eswitchMode string, devi
 			vf.Name = name
 			vf.Mtu = link.Attrs().MTU
 			vf.Mac = link.Attrs().HardwareAddr.String()
+			vf.NumQueues = s.networkHelper.GetNetDevNumQueues(name)
+			vf.Promisc = link.Attrs().Promisc != 0
+			vf.Multicast = link.Attrs().Flags&net.FlagMulticast != 0
 		}
 	}
 	vf.GUID = s.networkHelper.GetNetDevNodeGUID(vfAddr)
@@ -208,6 +213,50 @@ func (s *sriov) SetVfAdminMac(vfAddr string, pfLink, vfLink netlink.Link) error
 	return nil
 }
 
+// kubeletCheckpoint is the subset of the kubelet device plugin checkpoint describing the allocated devices
+type kubeletCheckpoint struct {
+	Data struct {
+		PodDeviceEntries []struct {
+			PodUID       string
+			ResourceName string
+			// the device IDs are indexed by NUMA node on recent kubelets, older ones report a plain list
+			DeviceIDs json.RawMessage
+		}
+	}
+}
+
+// DiscoverVfConsumers returns the UID of the pod each VF is allocated to, indexed by the PCI address of the VF,
+// from the kubelet device plugin checkpoint
+func (s *sriov) DiscoverVfConsumers() (map[string]string, error) {
+	log.Log.V(2).Info("DiscoverVfConsumers()")
+	data, err := os.ReadFile(utils.GetHostExtensionPath(consts.KubeletDevicePluginCheckpoint))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the kubelet device plugin checkpoint: %v", err)
+	}
+	cp := &kubeletCheckpoint{}
+	if err := json.Unmarshal(data, cp); err != nil {
+		return nil, fmt.Errorf("failed to decode the kubelet device plugin checkpoint: %v", err)
+	}
+	consumers := map[string]string{}
+	for _, entry := range cp.Data.PodDeviceEntries {
+		deviceIDs := []string{}
+		perNuma := map[string][]string{}
+		if err := json.Unmarshal(entry.DeviceIDs, &perNuma); err == nil {
+			for _, ids := range perNuma {
+				deviceIDs = append(deviceIDs, ids...)
+			}
+		} else if err := json.Unmarshal(entry.DeviceIDs, &deviceIDs); err != nil {
+			log.Log.V(2).Info("DiscoverVfConsumers(): can't decode the device IDs, skipping",
+				"pod", entry.PodUID, "resource", entry.ResourceName)
+			continue
+		}
+		for _, id := range deviceIDs {
+			consumers[id] = entry.PodUID
+		}
+	}
+	return consumers, nil
+}
+
 func (s *sriov) DiscoverSriovDevices(storeManager store.ManagerInterface) ([]sriovnetworkv1.InterfaceExt, error) {
 	log.Log.V(2).Info("DiscoverSriovDevices")
 	pfList := []sriovnetworkv1.InterfaceExt{}
@@ -277,6 +326,10 @@ func (s *sriov) DiscoverSriovDevices(storeManager store.ManagerInterface) ([]sri
 			LinkType:       s.encapTypeToLinkType(link.Attrs().EncapType),
 			LinkSpeed:      s.networkHelper.GetNetDevLinkSpeed(pfNetName),
 			LinkAdminState: s.networkHelper.GetNetDevLinkAdminState(pfNetName),
+			PciLinkSpeed:   getPciDeviceAttr(device.Address, consts.CurrentLinkSpeedFile),
+			PciLinkWidth:   getPciDeviceAttr(device.Address, consts.CurrentLinkWidthFile),
+			DriverVersion:  s.networkHelper.GetNetDevDriverVersion(pfNetName),
+			VlanFiltering:  s.networkHelper.GetNetDevVlanFiltering(pfNetName),
 		}
 
 		pfStatus, exist, err := storeManager.LoadPfsStatus(iface.PciAddress)
@@ -291,7 +344,7 @@ func (s *sriov) DiscoverSriovDevices(storeManager store.ManagerInterface) ([]sri
 		if s.dputilsLib.IsSriovPF(device.Address) {
 			iface.TotalVfs = s.dputilsLib.GetSriovVFcapacity(device.Address)
 			iface.NumVfs = s.dputilsLib.GetVFconfigured(device.Address)
-			iface.EswitchMode = s.GetNicSriovMode(device.Address)
+			iface.EswitchMode, iface.EswitchInlineMode = s.getNicEswitchModes(device.Address)
 			if s.dputilsLib.SriovConfigured(device.Address) {
 				vfs, err := s.dputilsLib.GetVFList(device.Address)
 				if err != nil {
@@ -301,6 +354,14 @@ func (s *sriov) DiscoverSriovDevices(storeManager store.ManagerInterface) ([]sri
 				}
 				for _, vf := range vfs {
 					instance := s.getVfInfo(vf, pfNetName, iface.EswitchMode, devices)
+					// the attributes configured on the VF through the PF are only reported by the PF link
+					for _, vfInfo := range link.Attrs().Vfs {
+						if vfInfo.ID == instance.VfID {
+							instance.Vlan = vfInfo.Vlan
+							instance.Trust = vfInfo.Trust != 0
+							break
+						}
+					}
 					iface.VFs = append(iface.VFs, instance)
 				}
 			}
@@ -344,6 +405,14 @@ func (s *sriov) configSriovPFDevice(iface *sriovnetworkv1.Interface) error {
 		log.Log.Error(err, "configSriovPFDevice(): fail to add VR representor udev rule", "device", iface.PciAddress)
 		return err
 	}
+	if err := s.addVfNameUdevRule(iface); err != nil {
+		log.Log.Error(err, "configSriovPFDevice(): fail to add VF name udev rule", "device", iface.PciAddress)
+		return err
+	}
+	if err := s.setEswitchInlineMode(iface); err != nil {
+		log.Log.Error(err, "configSriovPFDevice(): fail to set eSwitch inline mode", "device", iface.PciAddress)
+		return err
+	}
 	// set PF mtu
 	if iface.Mtu > 0 && iface.Mtu > s.networkHelper.GetNetdevMTU(iface.PciAddress) {
 		err = s.networkHelper.SetNetdevMTU(iface.PciAddress, iface.Mtu)
@@ -524,18 +593,30 @@ func (s *sriov) configSriovVFDevices(iface *sriovnetworkv1.Interface) error {
 					return err
 				}
 			}
-			if !sriovnetworkv1.StringInArray(group.DeviceType, vars.DpdkDrivers) {
+			if group.VfDriver != "" {
+				if err := s.kernelHelper.BindDriverByBusAndDevice(consts.BusPci, addr, group.VfDriver); err != nil {
+					log.Log.Error(err, "configSriovVFDevices(): fail to bind driver for device",
+						"driver", group.VfDriver, "device", addr)
+					return err
+				}
+			} else if !sriovnetworkv1.StringInArray(group.DeviceType, vars.DpdkDrivers) {
 				if err := s.kernelHelper.BindDefaultDriver(addr); err != nil {
 					log.Log.Error(err, "configSriovVFDevices(): fail to bind default driver for device", "device", addr)
 					return err
 				}
 				// only set MTU for VF with default driver
-				if group.Mtu > 0 {
-					if err := s.networkHelper.SetNetdevMTU(addr, group.Mtu); err != nil {
+				if group.GetVfMtu() > 0 {
+					if err := s.networkHelper.SetNetdevMTU(addr, group.GetVfMtu()); err != nil {
 						log.Log.Error(err, "configSriovVFDevices(): fail to set mtu for VF", "address", addr)
 						return err
 					}
 				}
+				if group.NumVfQueues > 0 {
+					if err := s.configVfNumQueues(addr, group.NumVfQueues); err != nil {
+						log.Log.Error(err, "configSriovVFDevices(): fail to set number of queues for VF", "address", addr)
+						return err
+					}
+				}
 				if sriovnetworkv1.GetEswitchModeFromSpec(iface) == sriovnetworkv1.ESwithModeSwitchDev && group.VdpaType != "" {
 					if err := s.vdpaHelper.CreateVDPADevice(addr, group.VdpaType); err != nil {
 						log.Log.Error(err, "configSriovVFDevices(): fail to create VDPA device",
@@ -555,6 +636,15 @@ func (s *sriov) configSriovVFDevices(iface *sriovnetworkv1.Interface) error {
 	return nil
 }
 
+// configVfNumQueues sets the number of combined queues of the VF netdev
+func (s *sriov) configVfNumQueues(vfAddr string, numQueues int) error {
+	name := s.networkHelper.TryGetInterfaceName(vfAddr)
+	if name == "" {
+		return fmt.Errorf("failed to get netdev name for VF %s", vfAddr)
+	}
+	return s.networkHelper.SetNetDevNumQueues(name, numQueues)
+}
+
 func (s *sriov) configSriovDevice(iface *sriovnetworkv1.Interface, skipVFConfiguration bool) error {
 	log.Log.V(2).Info("configSriovDevice(): configure sriov device",
 		"device", iface.PciAddress, "config", iface, "skipVFConfiguration", skipVFConfiguration)
@@ -583,22 +673,34 @@ func (s *sriov) configSriovDevice(iface *sriovnetworkv1.Interface, skipVFConfigu
 	if err := s.configSriovVFDevices(iface); err != nil {
 		return err
 	}
-	// Set PF link up
+	return s.setPfLinkState(iface)
+}
+
+// setPfLinkState sets the PF admin link state according to the interface spec,
+// the PF link is brought up unless the spec requests it to be down
+func (s *sriov) setPfLinkState(iface *sriovnetworkv1.Interface) error {
 	pfLink, err := s.netlinkLib.LinkByName(iface.Name)
 	if err != nil {
 		return err
 	}
-	if !s.netlinkLib.IsLinkAdminStateUp(pfLink) {
-		err = s.netlinkLib.LinkSetUp(pfLink)
-		if err != nil {
-			return err
+	isUp := s.netlinkLib.IsLinkAdminStateUp(pfLink)
+	if sriovnetworkv1.GetPfLinkStateFromSpec(iface) == sriovnetworkv1.PfLinkStateDown {
+		if isUp {
+			log.Log.V(2).Info("setPfLinkState(): set PF link down", "device", iface.PciAddress, "name", iface.Name)
+			return s.netlinkLib.LinkSetDown(pfLink)
 		}
+		return nil
+	}
+	if !isUp {
+		log.Log.V(2).Info("setPfLinkState(): set PF link up", "device", iface.PciAddress, "name", iface.Name)
+		return s.netlinkLib.LinkSetUp(pfLink)
 	}
 	return nil
 }
 
 func (s *sriov) ConfigSriovInterfaces(storeManager store.ManagerInterface,
-	interfaces []sriovnetworkv1.Interface, ifaceStatuses []sriovnetworkv1.InterfaceExt, skipVFConfiguration bool) error {
+	interfaces []sriovnetworkv1.Interface, ifaceStatuses []sriovnetworkv1.InterfaceExt, skipVFConfiguration bool,
+	progress types.ConfigProgressFunc) error {
 	toBeConfigured, toBeResetted, err := s.getConfigureAndReset(storeManager, interfaces, ifaceStatuses)
 	if err != nil {
 		log.Log.Error(err, "cannot get a list of interfaces to configure")
@@ -606,16 +708,17 @@ func (s *sriov) ConfigSriovInterfaces(storeManager store.ManagerInterface,
 	}
 
 	if vars.ParallelNicConfig {
-		err = s.configSriovInterfacesInParallel(storeManager, toBeConfigured, skipVFConfiguration)
+		err = s.configSriovInterfacesInParallel(storeManager, toBeConfigured, skipVFConfiguration, progress)
 	} else {
-		err = s.configSriovInterfaces(storeManager, toBeConfigured, skipVFConfiguration)
+		err = s.configSriovInterfaces(storeManager, toBeConfigured, skipVFConfiguration, progress)
 	}
 	if err != nil {
 		log.Log.Error(err, "cannot configure sriov interfaces")
 		return fmt.Errorf("cannot configure sriov interfaces")
 	}
-	if sriovnetworkv1.ContainsSwitchdevInterface(interfaces) && len(toBeConfigured) > 0 {
+	if (sriovnetworkv1.ContainsSwitchdevInterface(interfaces) || containsVfNamePrefix(interfaces)) && len(toBeConfigured) > 0 {
 		// for switchdev devices we create udev rule that renames VF representors
+		// and for PFs with a VF name prefix udev rule that renames the VFs
 		// after VFs are created. Reload rules to update interfaces
 		if err := s.udevHelper.LoadUdevRules(); err != nil {
 			log.Log.Error(err, "cannot reload udev rules")
@@ -635,6 +738,16 @@ func (s *sriov) ConfigSriovInterfaces(storeManager store.ManagerInterface,
 	return nil
 }
 
+// containsVfNamePrefix returns true if one of the interfaces requests a prefix for the names of its VFs
+func containsVfNamePrefix(interfaces []sriovnetworkv1.Interface) bool {
+	for _, iface := range interfaces {
+		if iface.VfNamePrefix != "" {
+			return true
+		}
+	}
+	return false
+}
+
 func (s *sriov) getConfigureAndReset(storeManager store.ManagerInterface, interfaces []sriovnetworkv1.Interface,
 	ifaceStatuses []sriovnetworkv1.InterfaceExt) ([]interfaceToConfigure, []sriovnetworkv1.InterfaceExt, error) {
 	toBeConfigured := []interfaceToConfigure{}
@@ -650,6 +763,12 @@ func (s *sriov) getConfigureAndReset(storeManager store.ManagerInterface, interf
 					return nil, nil, err
 				}
 				if skip {
+					// the NetworkManager opt-out doesn't require the PF reconfiguration,
+					// make sure the udev rule follows it
+					if err := s.syncDisableNMUdevRule(&iface); err != nil {
+						log.Log.Error(err, "getConfigureAndReset(): failed to sync NetworkManager udev rule", "device", iface.PciAddress)
+						return nil, nil, err
+					}
 					break
 				}
 				iface := iface
@@ -665,7 +784,8 @@ func (s *sriov) getConfigureAndReset(storeManager store.ManagerInterface, interf
 	return toBeConfigured, toBeResetted, nil
 }
 
-func (s *sriov) configSriovInterfacesInParallel(storeManager store.ManagerInterface, interfaces []interfaceToConfigure, skipVFConfiguration bool) error {
+func (s *sriov) configSriovInterfacesInParallel(storeManager store.ManagerInterface, interfaces []interfaceToConfigure,
+	skipVFConfiguration bool, progress types.ConfigProgressFunc) error {
 	log.Log.V(2).Info("configSriovInterfacesInParallel(): start sriov configuration")
 
 	var result error
@@ -696,9 +816,14 @@ func (s *sriov) configSriovInterfacesInParallel(storeManager store.ManagerInterf
 		}
 	}
 
+	configured := 0
 	for i := 0; i < interfacesToConfigure; i++ {
 		errMsg := <-errChannel
 		result = errors.Join(result, errMsg)
+		if errMsg == nil && progress != nil {
+			configured++
+			progress(configured, interfacesToConfigure, "")
+		}
 	}
 	if result != nil {
 		log.Log.Error(result, "configSriovInterfacesInParallel(): fail to configure sriov interfaces")
@@ -736,9 +861,13 @@ func (s *sriov) resetSriovInterfacesInParallel(storeManager store.ManagerInterfa
 	return nil
 }
 
-func (s *sriov) configSriovInterfaces(storeManager store.ManagerInterface, interfaces []interfaceToConfigure, skipVFConfiguration bool) error {
+func (s *sriov) configSriovInterfaces(storeManager store.ManagerInterface, interfaces []interfaceToConfigure,
+	skipVFConfiguration bool, progress types.ConfigProgressFunc) error {
 	log.Log.V(2).Info("configSriovInterfaces(): start sriov configuration")
-	for _, iface := range interfaces {
+	for i, iface := range interfaces {
+		if progress != nil {
+			progress(i, len(interfaces), iface.iface.PciAddress)
+		}
 		if err := s.configSriovDevice(&iface.iface, skipVFConfiguration); err != nil {
 			log.Log.Error(err, "configSriovInterfaces(): fail to configure sriov interface. resetting interface.", "address", iface.iface.PciAddress)
 			if iface.iface.ExternallyManaged {
@@ -757,6 +886,9 @@ func (s *sriov) configSriovInterfaces(storeManager store.ManagerInterface, inter
 			log.Log.Error(err, "configSriovInterfaces(): failed to save PF applied config to host")
 			return err
 		}
+		if progress != nil {
+			progress(i+1, len(interfaces), "")
+		}
 	}
 	log.Log.V(2).Info("configSriovInterfaces(): sriov configuration finished")
 	return nil
@@ -773,9 +905,24 @@ func (s *sriov) resetSriovInterfaces(storeManager store.ManagerInterface, interf
 	return nil
 }
 
-// / skipSriovConfig checks if we need to apply SR-IOV configuration specified specific interface
+// / skipSriovConfig checks if we need to apply SR-IOV configuration specified specific interface,
+// the VF MTU, VLAN and trust mode requested by the groups are set in place on the existing VFs by the generic plugin
 func skipSriovConfig(iface *sriovnetworkv1.Interface, ifaceStatus *sriovnetworkv1.InterfaceExt, storeManager store.ManagerInterface) (bool, error) {
-	if !sriovnetworkv1.NeedToUpdateSriov(iface, ifaceStatus) {
+	if !sriovnetworkv1.NeedToUpdateSriovExceptInPlaceVfConfig(iface, ifaceStatus) {
+		if sriovnetworkv1.GetEswitchModeFromSpec(iface) == sriovnetworkv1.ESwithModeSwitchDev {
+			// the naming of the VF representors is not reported in the status, compare it with the last applied
+			// configuration to regenerate the udev rules when it is toggled
+			pfStatus, exist, err := storeManager.LoadPfsStatus(iface.PciAddress)
+			if err != nil {
+				log.Log.Error(err, "ConfigSriovInterfaces(): failed to load info about PF status for device", "address", iface.PciAddress)
+				return false, err
+			}
+			if exist && pfStatus.KeepDefaultRepresentorNames != iface.KeepDefaultRepresentorNames {
+				log.Log.V(2).Info("ConfigSriovInterfaces(): VF representors naming changed, update interface", "address", iface.PciAddress)
+				return false, nil
+			}
+		}
+
 		log.Log.V(2).Info("ConfigSriovInterfaces(): no need update interface", "address", iface.PciAddress)
 
 		// Save the PF status to the host
@@ -883,19 +1030,40 @@ func (s *sriov) ConfigSriovDeviceVirtual(iface *sriovnetworkv1.Interface) error
 	return nil
 }
 
+// getPciDeviceAttr returns the content of the sysfs attribute file of the PCI device,
+// an empty string is returned if the attribute is not available
+func getPciDeviceAttr(pciAddr, attr string) string {
+	data, err := os.ReadFile(filepath.Join(vars.FilesystemRoot, consts.SysBusPciDevices, pciAddr, attr))
+	if err != nil {
+		log.Log.V(2).Info("getPciDeviceAttr(): failed to read PCI device attribute", "device", pciAddr, "attr", attr, "error", err)
+		return ""
+	}
+	return strings.TrimSpace(string(data))
+}
+
 func (s *sriov) GetNicSriovMode(pciAddress string) string {
 	log.Log.V(2).Info("GetNicSriovMode()", "device", pciAddress)
+	mode, _ := s.getNicEswitchModes(pciAddress)
+	return mode
+}
+
+// getNicEswitchModes returns the eSwitch mode and the eSwitch inline mode of the device,
+// the mode defaults to legacy and the inline mode is empty if it is not reported by devlink
+func (s *sriov) getNicEswitchModes(pciAddress string) (string, string) {
 	devLink, err := s.netlinkLib.DevLinkGetDeviceByName("pci", pciAddress)
 	if err != nil {
 		if !errors.Is(err, syscall.ENODEV) {
-			log.Log.Error(err, "GetNicSriovMode(): failed to get eswitch mode, assume legacy", "device", pciAddress)
+			log.Log.Error(err, "getNicEswitchModes(): failed to get eswitch mode, assume legacy", "device", pciAddress)
 		}
 	}
-	if devLink != nil && devLink.Attrs.Eswitch.Mode != "" {
-		return devLink.Attrs.Eswitch.Mode
+	if devLink == nil {
+		return sriovnetworkv1.ESwithModeLegacy, ""
 	}
-
-	return sriovnetworkv1.ESwithModeLegacy
+	mode := devLink.Attrs.Eswitch.Mode
+	if mode == "" {
+		mode = sriovnetworkv1.ESwithModeLegacy
+	}
+	return mode, devLink.Attrs.Eswitch.InlineMode
 }
 
 func (s *sriov) SetNicSriovMode(pciAddress string, mode string) error {
@@ -913,6 +1081,26 @@ func (s *sriov) SetNicSriovMode(pciAddress string, mode string) error {
 	return nil
 }
 
+// setEswitchInlineMode sets the eSwitch inline mode requested in the interface spec, if any
+func (s *sriov) setEswitchInlineMode(iface *sriovnetworkv1.Interface) error {
+	if iface.EswitchInlineMode == "" {
+		return nil
+	}
+	dev, err := s.netlinkLib.DevLinkGetDeviceByName("pci", iface.PciAddress)
+	if err != nil {
+		return fmt.Errorf("can't get devlink device [%s] to set eSwitch inline mode to [%s]: %w", iface.PciAddress, iface.EswitchInlineMode, err)
+	}
+	if dev.Attrs.Eswitch.InlineMode == iface.EswitchInlineMode {
+		return nil
+	}
+	log.Log.V(2).Info("setEswitchInlineMode(): set eSwitch inline mode", "device", iface.PciAddress,
+		"mode", iface.EswitchInlineMode, "current", dev.Attrs.Eswitch.InlineMode)
+	if err := s.netlinkLib.DevLinkSetEswitchInlineMode(dev, iface.EswitchInlineMode); err != nil {
+		return fmt.Errorf("can't set eSwitch inline mode to [%s] on device [%s]: %w", iface.EswitchInlineMode, iface.PciAddress, err)
+	}
+	return nil
+}
+
 func (s *sriov) GetLinkType(name string) string {
 	log.Log.V(2).Info("GetLinkType()", "name", name)
 	link, err := s.netlinkLib.LinkByName(name)
@@ -933,12 +1121,12 @@ func (s *sriov) encapTypeToLinkType(encapType string) string {
 }
 
 // create required udev rules for PF:
-// * rule to disable NetworkManager for VFs - for all modes
+// * rule to disable NetworkManager for VFs - for all modes, unless the PF opts out with ManageByNetworkManager
 // * rule to keep PF name after switching to switchdev mode - only for switchdev mode
 func (s *sriov) addUdevRules(iface *sriovnetworkv1.Interface) error {
 	log.Log.V(2).Info("addUdevRules(): add udev rules for device",
 		"device", iface.PciAddress)
-	if err := s.udevHelper.AddDisableNMUdevRule(iface.PciAddress); err != nil {
+	if err := s.syncDisableNMUdevRule(iface); err != nil {
 		return err
 	}
 	if sriovnetworkv1.GetEswitchModeFromSpec(iface) == sriovnetworkv1.ESwithModeSwitchDev {
@@ -949,12 +1137,28 @@ func (s *sriov) addUdevRules(iface *sriovnetworkv1.Interface) error {
 	return nil
 }
 
+// syncDisableNMUdevRule adds the rule which disables NetworkManager for the VFs of the PF
+// or removes it if the VFs should be managed by NetworkManager
+func (s *sriov) syncDisableNMUdevRule(iface *sriovnetworkv1.Interface) error {
+	if iface.ManageByNetworkManager {
+		log.Log.V(2).Info("syncDisableNMUdevRule(): VFs are managed by NetworkManager", "device", iface.PciAddress)
+		return s.udevHelper.RemoveDisableNMUdevRule(iface.PciAddress)
+	}
+	return s.udevHelper.AddDisableNMUdevRule(iface.PciAddress)
+}
+
 // add switchdev-specific udev rule that renames representors.
 // this rule relies on phys_port_name and phys_switch_id parameter which
 // on old kernels can be read only after switching PF to switchdev mode.
-// if PF doesn't expose phys_port_name and phys_switch_id, then rule creation will be skipped
+// if PF doesn't expose phys_port_name and phys_switch_id, then rule creation will be skipped.
+// the rule is not created for PFs opting out with KeepDefaultRepresentorNames
 func (s *sriov) addVfRepresentorUdevRule(iface *sriovnetworkv1.Interface) error {
 	if sriovnetworkv1.GetEswitchModeFromSpec(iface) == sriovnetworkv1.ESwithModeSwitchDev {
+		if iface.KeepDefaultRepresentorNames {
+			log.Log.V(2).Info("addVfRepresentorUdevRule(): VF representors keep the default names, skip creation of UDEV rule",
+				"device", iface.PciAddress)
+			return nil
+		}
 		portName, err := s.networkHelper.GetPhysPortName(iface.Name)
 		if err != nil {
 			log.Log.Error(err, "addVfRepresentorUdevRule(): WARNING: can't read phys_port_name for device, skip creation of UDEV rule")
@@ -970,6 +1174,30 @@ func (s *sriov) addVfRepresentorUdevRule(iface *sriovnetworkv1.Interface) error
 	return nil
 }
 
+// add udev rule that renames the VF netdevs after the VfNamePrefix of the PF and the VF index.
+// the rule matches the PCI addresses of the VFs so it's created after the VFs,
+// the VF netdevs are renamed when the udev rules are reloaded.
+func (s *sriov) addVfNameUdevRule(iface *sriovnetworkv1.Interface) error {
+	if iface.VfNamePrefix == "" || iface.NumVfs == 0 {
+		return nil
+	}
+	vfAddrs, err := s.dputilsLib.GetVFList(iface.PciAddress)
+	if err != nil {
+		log.Log.Error(err, "addVfNameUdevRule(): failed to read VF list", "device", iface.PciAddress)
+		return err
+	}
+	vfNames := map[string]string{}
+	for _, addr := range vfAddrs {
+		vfID, err := s.dputilsLib.GetVFID(addr)
+		if err != nil {
+			log.Log.Error(err, "addVfNameUdevRule(): failed to get VF ID", "device", addr)
+			return err
+		}
+		vfNames[addr] = iface.GetVfName(vfID)
+	}
+	return s.udevHelper.AddVfNameUdevRule(iface.PciAddress, vfNames)
+}
+
 // remove all udev rules for PF created by the operator
 func (s *sriov) removeUdevRules(pciAddress string) error {
 	log.Log.V(2).Info("removeUdevRules(): remove udev rules for device",
@@ -980,6 +1208,9 @@ func (s *sriov) removeUdevRules(pciAddress string) error {
 	if err := s.udevHelper.RemoveVfRepresentorUdevRule(pciAddress); err != nil {
 		return err
 	}
+	if err := s.udevHelper.RemoveVfNameUdevRule(pciAddress); err != nil {
+		return err
+	}
 	return s.udevHelper.RemovePersistPFNameUdevRule(pciAddress)
 }
 
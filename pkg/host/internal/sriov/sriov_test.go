@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"net"
 	"strconv"
+	"strings"
 	"syscall"
 
 	"github.com/golang/mock/gomock"
@@ -83,9 +84,12 @@ var _ = Describe("SRIOV", func() {
 				MTU:          1500,
 				HardwareAddr: mac,
 				EncapType:    "ether",
+				Vfs:          []netlink.VfInfo{{ID: 0, Vlan: 100, Trust: 1}},
 			}).MinTimes(1)
 			hostMock.EXPECT().GetNetDevLinkSpeed("enp216s0f0np0").Return("100000 Mb/s")
 			hostMock.EXPECT().GetNetDevLinkAdminState("enp216s0f0np0").Return("up")
+			hostMock.EXPECT().GetNetDevDriverVersion("enp216s0f0np0").Return("24.04-0.6.6")
+			hostMock.EXPECT().GetNetDevVlanFiltering("enp216s0f0np0").Return(nil)
 			hostMock.EXPECT().GetNetDevNodeGUID("0000:d8:00.2").Return("guid1")
 			storeManagerMode.EXPECT().LoadPfsStatus("0000:d8:00.0").Return(nil, false, nil)
 
@@ -109,6 +113,7 @@ var _ = Describe("SRIOV", func() {
 				MTU:          1500,
 				HardwareAddr: mac,
 			}).MinTimes(1)
+			hostMock.EXPECT().GetNetDevNumQueues("enp216s0f0v0").Return(4)
 
 			sriovnetLibMock.EXPECT().GetVfRepresentor("enp216s0f0np0", 0).Return("enp216s0f0np0_0", nil)
 
@@ -127,6 +132,7 @@ var _ = Describe("SRIOV", func() {
 				LinkSpeed:         "100000 Mb/s",
 				LinkType:          "ETH",
 				LinkAdminState:    "up",
+				DriverVersion:     "24.04-0.6.6",
 				EswitchMode:       "switchdev",
 				ExternallyManaged: false,
 				TotalVfs:          1,
@@ -137,13 +143,126 @@ var _ = Describe("SRIOV", func() {
 					PciAddress:      "0000:d8:00.2",
 					Vendor:          "15b3",
 					DeviceID:        "101e",
+					Vlan:            100,
+					Trust:           true,
 					Mtu:             1500,
 					VfID:            0,
 					RepresentorName: "enp216s0f0np0_0",
 					GUID:            "guid1",
+					NumQueues:       4,
 				}},
 			}))
 		})
+		It("discovered with PCI link information", func() {
+			helpers.GinkgoConfigureFakeFS(&fakefilesystem.FS{
+				Dirs: []string{"/sys/bus/pci/devices/0000:d8:00.0"},
+				Files: map[string][]byte{
+					"/sys/bus/pci/devices/0000:d8:00.0/current_link_speed": []byte("8.0 GT/s PCIe\n"),
+					"/sys/bus/pci/devices/0000:d8:00.0/current_link_width": []byte("8\n"),
+				},
+			})
+			ghwLibMock.EXPECT().PCI().Return(getTestPCIDevices(), nil)
+			dputilsLibMock.EXPECT().IsSriovVF("0000:d8:00.0").Return(false)
+			dputilsLibMock.EXPECT().IsSriovVF("0000:d8:00.2").Return(true)
+			dputilsLibMock.EXPECT().IsSriovVF("0000:3b:00.0").Return(false)
+			dputilsLibMock.EXPECT().GetDriverName("0000:d8:00.0").Return("mlx5_core", nil)
+			hostMock.EXPECT().TryGetInterfaceName("0000:d8:00.0").Return("enp216s0f0np0")
+
+			pfLinkMock := netlinkMockPkg.NewMockLink(testCtrl)
+			netlinkLibMock.EXPECT().LinkByName("enp216s0f0np0").Return(pfLinkMock, nil)
+			pfLinkMock.EXPECT().Attrs().Return(&netlink.LinkAttrs{MTU: 1500, EncapType: "ether"}).MinTimes(1)
+			hostMock.EXPECT().GetNetDevLinkSpeed("enp216s0f0np0").Return("100000 Mb/s")
+			hostMock.EXPECT().GetNetDevLinkAdminState("enp216s0f0np0").Return("up")
+			hostMock.EXPECT().GetNetDevDriverVersion("enp216s0f0np0").Return("")
+			hostMock.EXPECT().GetNetDevVlanFiltering("enp216s0f0np0").Return(nil)
+			storeManagerMode.EXPECT().LoadPfsStatus("0000:d8:00.0").Return(nil, false, nil)
+			dputilsLibMock.EXPECT().IsSriovPF("0000:d8:00.0").Return(false)
+
+			ret, err := s.DiscoverSriovDevices(storeManagerMode)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ret).To(HaveLen(1))
+			Expect(ret[0].PciLinkSpeed).To(Equal("8.0 GT/s PCIe"))
+			Expect(ret[0].PciLinkWidth).To(Equal("8"))
+		})
+		It("discovered with VFs moved to pods or bound to vfio-pci", func() {
+			ghwLibMock.EXPECT().PCI().Return(getTestPCIDevices(), nil)
+			dputilsLibMock.EXPECT().IsSriovVF("0000:d8:00.0").Return(false)
+			dputilsLibMock.EXPECT().IsSriovVF("0000:d8:00.2").Return(true)
+			dputilsLibMock.EXPECT().IsSriovVF("0000:3b:00.0").Return(false)
+			dputilsLibMock.EXPECT().GetDriverName("0000:d8:00.0").Return("mlx5_core", nil)
+			hostMock.EXPECT().TryGetInterfaceName("0000:d8:00.0").Return("enp216s0f0np0")
+
+			pfLinkMock := netlinkMockPkg.NewMockLink(testCtrl)
+			netlinkLibMock.EXPECT().LinkByName("enp216s0f0np0").Return(pfLinkMock, nil)
+			pfLinkMock.EXPECT().Attrs().Return(&netlink.LinkAttrs{MTU: 1500, EncapType: "ether"}).MinTimes(1)
+			hostMock.EXPECT().GetNetDevLinkSpeed("enp216s0f0np0").Return("100000 Mb/s")
+			hostMock.EXPECT().GetNetDevLinkAdminState("enp216s0f0np0").Return("up")
+			hostMock.EXPECT().GetNetDevDriverVersion("enp216s0f0np0").Return("")
+			hostMock.EXPECT().GetNetDevVlanFiltering("enp216s0f0np0").Return(nil)
+			storeManagerMode.EXPECT().LoadPfsStatus("0000:d8:00.0").Return(nil, false, nil)
+
+			dputilsLibMock.EXPECT().IsSriovPF("0000:d8:00.0").Return(true)
+			dputilsLibMock.EXPECT().GetSriovVFcapacity("0000:d8:00.0").Return(4)
+			dputilsLibMock.EXPECT().GetVFconfigured("0000:d8:00.0").Return(3)
+			netlinkLibMock.EXPECT().DevLinkGetDeviceByName("pci", "0000:d8:00.0").Return(
+				&netlink.DevlinkDevice{Attrs: netlink.DevlinkDevAttrs{Eswitch: netlink.DevlinkDevEswitchAttr{Mode: "legacy"}}}, nil)
+			dputilsLibMock.EXPECT().SriovConfigured("0000:d8:00.0").Return(true)
+			dputilsLibMock.EXPECT().GetVFList("0000:d8:00.0").Return([]string{"0000:d8:00.2", "0000:d8:00.3", "0000:d8:00.4"}, nil)
+			for i, vf := range []struct{ addr, driver string }{
+				{"0000:d8:00.2", "mlx5_core"},
+				// netdev moved to the network namespace of a pod
+				{"0000:d8:00.3", "mlx5_core"},
+				{"0000:d8:00.4", "vfio-pci"},
+			} {
+				dputilsLibMock.EXPECT().GetDriverName(vf.addr).Return(vf.driver, nil)
+				dputilsLibMock.EXPECT().GetVFID(vf.addr).Return(i, nil)
+				hostMock.EXPECT().DiscoverVDPAType(vf.addr).Return("")
+				hostMock.EXPECT().GetNetDevNodeGUID(vf.addr).Return("")
+			}
+			hostMock.EXPECT().TryGetInterfaceName("0000:d8:00.2").Return("enp216s0f0v0")
+			hostMock.EXPECT().TryGetInterfaceName("0000:d8:00.3").Return("")
+			hostMock.EXPECT().TryGetInterfaceName("0000:d8:00.4").Return("")
+			vfLinkMock := netlinkMockPkg.NewMockLink(testCtrl)
+			netlinkLibMock.EXPECT().LinkByName("enp216s0f0v0").Return(vfLinkMock, nil)
+			vfLinkMock.EXPECT().Attrs().Return(&netlink.LinkAttrs{MTU: 1500}).MinTimes(1)
+			hostMock.EXPECT().GetNetDevNumQueues("enp216s0f0v0").Return(4)
+
+			ret, err := s.DiscoverSriovDevices(storeManagerMode)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ret).To(HaveLen(1))
+			Expect(ret[0].NumVfs).To(Equal(3))
+			Expect(ret[0].VFs).To(HaveLen(3))
+			// the VFs allocated to pods are counted from the kubelet checkpoint, not from the VF drivers
+			Expect(ret[0].NumVfsInUse).To(BeZero())
+		})
+	})
+
+	Context("DiscoverVfConsumers", func() {
+		It("should map the VFs to the pods they are allocated to", func() {
+			helpers.GinkgoConfigureFakeFS(&fakefilesystem.FS{
+				Dirs: []string{"/host/var/lib/kubelet/device-plugins"},
+				Files: map[string][]byte{
+					"/host/var/lib/kubelet/device-plugins/kubelet_internal_checkpoint": []byte(`{"Data":{"PodDeviceEntries":[` +
+						`{"PodUID":"uid-1","ContainerName":"c1","ResourceName":"openshift.io/intel","DeviceIDs":{"0":["0000:d8:02.0","0000:d8:02.1"]}},` +
+						`{"PodUID":"uid-2","ContainerName":"c2","ResourceName":"openshift.io/mlx","DeviceIDs":["0000:3b:00.2"]},` +
+						`{"PodUID":"uid-3","ContainerName":"c3","ResourceName":"nvidia.com/gpu","DeviceIDs":{"1":["GPU-1"]}}` +
+						`],"RegisteredDevices":{}},"Checksum":1}`),
+				},
+			})
+			consumers, err := s.DiscoverVfConsumers()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(consumers).To(Equal(map[string]string{
+				"0000:d8:02.0": "uid-1",
+				"0000:d8:02.1": "uid-1",
+				"0000:3b:00.2": "uid-2",
+				"GPU-1":        "uid-3",
+			}))
+		})
+		It("fail - no checkpoint", func() {
+			helpers.GinkgoConfigureFakeFS(&fakefilesystem.FS{})
+			_, err := s.DiscoverVfConsumers()
+			Expect(err).To(MatchError(ContainSubstring("failed to read the kubelet device plugin checkpoint")))
+		})
 	})
 
 	Context("SetSriovNumVfs", func() {
@@ -179,6 +298,14 @@ var _ = Describe("SRIOV", func() {
 			mode := s.GetNicSriovMode("0000:d8:00.0")
 			Expect(mode).To(Equal("legacy"))
 		})
+		It("devlink returns the inline mode", func() {
+			netlinkLibMock.EXPECT().DevLinkGetDeviceByName("pci", "0000:d8:00.0").Return(
+				&netlink.DevlinkDevice{Attrs: netlink.DevlinkDevAttrs{Eswitch: netlink.DevlinkDevEswitchAttr{InlineMode: "network"}}},
+				nil)
+			mode, inlineMode := s.(*sriov).getNicEswitchModes("0000:d8:00.0")
+			Expect(mode).To(Equal("legacy"))
+			Expect(inlineMode).To(Equal("network"))
+		})
 	})
 
 	Context("SetNicSriovMode", func() {
@@ -200,6 +327,35 @@ var _ = Describe("SRIOV", func() {
 		})
 	})
 
+	Context("setEswitchInlineMode", func() {
+		newDev := func(inlineMode string) *netlink.DevlinkDevice {
+			return &netlink.DevlinkDevice{Attrs: netlink.DevlinkDevAttrs{
+				Eswitch: netlink.DevlinkDevEswitchAttr{Mode: "switchdev", InlineMode: inlineMode}}}
+		}
+		It("not requested", func() {
+			Expect(s.(*sriov).setEswitchInlineMode(&sriovnetworkv1.Interface{PciAddress: "0000:d8:00.0"})).NotTo(HaveOccurred())
+		})
+		It("already set", func() {
+			netlinkLibMock.EXPECT().DevLinkGetDeviceByName("pci", "0000:d8:00.0").Return(newDev("network"), nil)
+			Expect(s.(*sriov).setEswitchInlineMode(&sriovnetworkv1.Interface{
+				PciAddress: "0000:d8:00.0", EswitchInlineMode: "network"})).NotTo(HaveOccurred())
+		})
+		It("set", func() {
+			dev := newDev("none")
+			netlinkLibMock.EXPECT().DevLinkGetDeviceByName("pci", "0000:d8:00.0").Return(dev, nil)
+			netlinkLibMock.EXPECT().DevLinkSetEswitchInlineMode(dev, "transport").Return(nil)
+			Expect(s.(*sriov).setEswitchInlineMode(&sriovnetworkv1.Interface{
+				PciAddress: "0000:d8:00.0", EswitchInlineMode: "transport"})).NotTo(HaveOccurred())
+		})
+		It("fail to set", func() {
+			dev := newDev("none")
+			netlinkLibMock.EXPECT().DevLinkGetDeviceByName("pci", "0000:d8:00.0").Return(dev, nil)
+			netlinkLibMock.EXPECT().DevLinkSetEswitchInlineMode(dev, "link").Return(testError)
+			Expect(s.(*sriov).setEswitchInlineMode(&sriovnetworkv1.Interface{
+				PciAddress: "0000:d8:00.0", EswitchInlineMode: "link"})).To(MatchError(testError))
+		})
+	})
+
 	Context("ConfigSriovInterfaces", func() {
 		It("should configure", func() {
 			helpers.GinkgoConfigureFakeFS(&fakefilesystem.FS{
@@ -215,6 +371,7 @@ var _ = Describe("SRIOV", func() {
 			hostMock.EXPECT().RemoveDisableNMUdevRule("0000:d8:00.0").Return(nil)
 			hostMock.EXPECT().RemovePersistPFNameUdevRule("0000:d8:00.0").Return(nil)
 			hostMock.EXPECT().RemoveVfRepresentorUdevRule("0000:d8:00.0").Return(nil)
+			hostMock.EXPECT().RemoveVfNameUdevRule("0000:d8:00.0").Return(nil)
 			hostMock.EXPECT().AddDisableNMUdevRule("0000:d8:00.0").Return(nil)
 			dputilsLibMock.EXPECT().GetVFList("0000:d8:00.0").Return([]string{"0000:d8:00.2", "0000:d8:00.3"}, nil)
 			pfLinkMock := netlinkMockPkg.NewMockLink(testCtrl)
@@ -230,6 +387,8 @@ var _ = Describe("SRIOV", func() {
 			hostMock.EXPECT().UnbindDriverIfNeeded("0000:d8:00.2", true).Return(nil)
 			hostMock.EXPECT().BindDefaultDriver("0000:d8:00.2").Return(nil)
 			hostMock.EXPECT().SetNetdevMTU("0000:d8:00.2", 2000).Return(nil)
+			hostMock.EXPECT().TryGetInterfaceName("0000:d8:00.2").Return("enp216s0f0_0")
+			hostMock.EXPECT().SetNetDevNumQueues("enp216s0f0_0", 4).Return(nil)
 			hostMock.EXPECT().GetInterfaceIndex("0000:d8:00.2").Return(42, nil)
 			vf0LinkMock := netlinkMockPkg.NewMockLink(testCtrl)
 			vf0Mac, _ := net.ParseMAC("02:42:19:51:2f:af")
@@ -244,6 +403,7 @@ var _ = Describe("SRIOV", func() {
 
 			storeManagerMode.EXPECT().SaveLastPfAppliedStatus(gomock.Any()).Return(nil)
 
+			progress := []string{}
 			Expect(s.ConfigSriovInterfaces(storeManagerMode,
 				[]sriovnetworkv1.Interface{{
 					Name:       "enp216s0f0np0",
@@ -256,6 +416,7 @@ var _ = Describe("SRIOV", func() {
 							PolicyName:   "test-policy0",
 							Mtu:          2000,
 							IsRdma:       true,
+							NumVfQueues:  4,
 						},
 						{
 							VfRange:      "1-1",
@@ -267,8 +428,11 @@ var _ = Describe("SRIOV", func() {
 						}},
 				}},
 				[]sriovnetworkv1.InterfaceExt{{PciAddress: "0000:d8:00.0"}, {PciAddress: "0000:d8:00.1"}},
-				false)).NotTo(HaveOccurred())
+				false, func(configured, total int, pciAddress string) {
+					progress = append(progress, strings.TrimSpace(fmt.Sprintf("%d/%d %s", configured, total, pciAddress)))
+				})).NotTo(HaveOccurred())
 			helpers.GinkgoAssertFileContentsEquals("/sys/bus/pci/devices/0000:d8:00.0/sriov_numvfs", "2")
+			Expect(progress).To(Equal([]string{"0/1 0000:d8:00.0", "1/1"}))
 		})
 		It("should configure IB", func() {
 			helpers.GinkgoConfigureFakeFS(&fakefilesystem.FS{
@@ -284,6 +448,7 @@ var _ = Describe("SRIOV", func() {
 			hostMock.EXPECT().RemoveDisableNMUdevRule("0000:d8:00.0").Return(nil)
 			hostMock.EXPECT().RemovePersistPFNameUdevRule("0000:d8:00.0").Return(nil)
 			hostMock.EXPECT().RemoveVfRepresentorUdevRule("0000:d8:00.0").Return(nil)
+			hostMock.EXPECT().RemoveVfNameUdevRule("0000:d8:00.0").Return(nil)
 			hostMock.EXPECT().AddDisableNMUdevRule("0000:d8:00.0").Return(nil)
 			dputilsLibMock.EXPECT().GetVFList("0000:d8:00.0").Return([]string{"0000:d8:00.2"}, nil)
 			pfLinkMock := netlinkMockPkg.NewMockLink(testCtrl)
@@ -318,7 +483,7 @@ var _ = Describe("SRIOV", func() {
 						}},
 				}},
 				[]sriovnetworkv1.InterfaceExt{{PciAddress: "0000:d8:00.0"}},
-				false)).NotTo(HaveOccurred())
+				false, nil)).NotTo(HaveOccurred())
 			helpers.GinkgoAssertFileContentsEquals("/sys/bus/pci/devices/0000:d8:00.0/sriov_numvfs", "1")
 		})
 
@@ -334,6 +499,7 @@ var _ = Describe("SRIOV", func() {
 			hostMock.EXPECT().RemoveDisableNMUdevRule("0000:d8:00.0").Return(nil)
 			hostMock.EXPECT().RemovePersistPFNameUdevRule("0000:d8:00.0").Return(nil)
 			hostMock.EXPECT().RemoveVfRepresentorUdevRule("0000:d8:00.0").Return(nil)
+			hostMock.EXPECT().RemoveVfNameUdevRule("0000:d8:00.0").Return(nil)
 			hostMock.EXPECT().AddDisableNMUdevRule("0000:d8:00.0").Return(nil)
 			hostMock.EXPECT().AddPersistPFNameUdevRule("0000:d8:00.0", "enp216s0f0np0").Return(nil)
 			hostMock.EXPECT().EnableHwTcOffload("enp216s0f0np0").Return(nil)
@@ -387,7 +553,77 @@ var _ = Describe("SRIOV", func() {
 						}},
 				}},
 				[]sriovnetworkv1.InterfaceExt{{PciAddress: "0000:d8:00.0"}},
-				false)).NotTo(HaveOccurred())
+				false, nil)).NotTo(HaveOccurred())
+			helpers.GinkgoAssertFileContentsEquals("/sys/bus/pci/devices/0000:d8:00.0/sriov_numvfs", "1")
+		})
+
+		It("should configure switchdev - keep default representor names", func() {
+			helpers.GinkgoConfigureFakeFS(&fakefilesystem.FS{
+				Dirs:  []string{"/sys/bus/pci/devices/0000:d8:00.0"},
+				Files: map[string][]byte{"/sys/bus/pci/devices/0000:d8:00.0/sriov_numvfs": {}},
+			})
+
+			dputilsLibMock.EXPECT().GetSriovVFcapacity("0000:d8:00.0").Return(1)
+			dputilsLibMock.EXPECT().GetVFconfigured("0000:d8:00.0").Return(0)
+			dputilsLibMock.EXPECT().GetDriverName("0000:d8:00.0").Return("mlx5_core", nil)
+			hostMock.EXPECT().RemoveDisableNMUdevRule("0000:d8:00.0").Return(nil)
+			hostMock.EXPECT().RemovePersistPFNameUdevRule("0000:d8:00.0").Return(nil)
+			hostMock.EXPECT().RemoveVfRepresentorUdevRule("0000:d8:00.0").Return(nil)
+			hostMock.EXPECT().RemoveVfNameUdevRule("0000:d8:00.0").Return(nil)
+			hostMock.EXPECT().AddDisableNMUdevRule("0000:d8:00.0").Return(nil)
+			hostMock.EXPECT().AddPersistPFNameUdevRule("0000:d8:00.0", "enp216s0f0np0").Return(nil)
+			hostMock.EXPECT().EnableHwTcOffload("enp216s0f0np0").Return(nil)
+			hostMock.EXPECT().GetDevlinkDeviceParam("0000:d8:00.0", "flow_steering_mode").Return("", syscall.EINVAL)
+			dputilsLibMock.EXPECT().GetVFList("0000:d8:00.0").Return([]string{"0000:d8:00.2"}, nil).Times(2)
+			pfLinkMock := netlinkMockPkg.NewMockLink(testCtrl)
+			netlinkLibMock.EXPECT().LinkByName("enp216s0f0np0").Return(pfLinkMock, nil).Times(2)
+			netlinkLibMock.EXPECT().IsLinkAdminStateUp(pfLinkMock).Return(false)
+			netlinkLibMock.EXPECT().LinkSetUp(pfLinkMock).Return(nil)
+			netlinkLibMock.EXPECT().DevLinkGetDeviceByName("pci", "0000:d8:00.0").Return(&netlink.DevlinkDevice{
+				Attrs: netlink.DevlinkDevAttrs{Eswitch: netlink.DevlinkDevEswitchAttr{Mode: "legacy"}}}, nil).Times(2)
+			netlinkLibMock.EXPECT().DevLinkSetEswitchMode(gomock.Any(), "switchdev").Return(nil)
+
+			dputilsLibMock.EXPECT().GetVFID("0000:d8:00.2").Return(0, nil).Times(2)
+			hostMock.EXPECT().Unbind("0000:d8:00.2").Return(nil)
+			hostMock.EXPECT().HasDriver("0000:d8:00.2").Return(false, "")
+			hostMock.EXPECT().BindDefaultDriver("0000:d8:00.2").Return(nil)
+			hostMock.EXPECT().HasDriver("0000:d8:00.2").Return(true, "test")
+			hostMock.EXPECT().UnbindDriverIfNeeded("0000:d8:00.2", true).Return(nil)
+			hostMock.EXPECT().BindDefaultDriver("0000:d8:00.2").Return(nil)
+			hostMock.EXPECT().SetNetdevMTU("0000:d8:00.2", 2000).Return(nil)
+			hostMock.EXPECT().GetInterfaceIndex("0000:d8:00.2").Return(42, nil).AnyTimes()
+			vf0LinkMock := netlinkMockPkg.NewMockLink(testCtrl)
+			vf0Mac, _ := net.ParseMAC("02:42:19:51:2f:af")
+			vf0LinkMock.EXPECT().Attrs().Return(&netlink.LinkAttrs{Name: "enp216s0f0_0", HardwareAddr: vf0Mac})
+			netlinkLibMock.EXPECT().LinkByIndex(42).Return(vf0LinkMock, nil).AnyTimes()
+			netlinkLibMock.EXPECT().LinkSetVfHardwareAddr(vf0LinkMock, 0, vf0Mac).Return(nil)
+			hostMock.EXPECT().CreateVDPADevice("0000:d8:00.2", "vhost_vdpa")
+			// the representor rename rule is removed and not added back for the PF
+			hostMock.EXPECT().AddVfRepresentorUdevRule(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			hostMock.EXPECT().LoadUdevRules().Return(nil)
+
+			storeManagerMode.EXPECT().SaveLastPfAppliedStatus(gomock.Any()).Return(nil)
+
+			Expect(s.ConfigSriovInterfaces(storeManagerMode,
+				[]sriovnetworkv1.Interface{{
+					Name:                        "enp216s0f0np0",
+					PciAddress:                  "0000:d8:00.0",
+					NumVfs:                      1,
+					LinkType:                    "ETH",
+					EswitchMode:                 "switchdev",
+					KeepDefaultRepresentorNames: true,
+					VfGroups: []sriovnetworkv1.VfGroup{
+						{
+							VfRange:      "0-0",
+							ResourceName: "test-resource0",
+							PolicyName:   "test-policy0",
+							Mtu:          2000,
+							IsRdma:       true,
+							VdpaType:     "vhost_vdpa",
+						}},
+				}},
+				[]sriovnetworkv1.InterfaceExt{{PciAddress: "0000:d8:00.0"}},
+				false, nil)).NotTo(HaveOccurred())
 			helpers.GinkgoAssertFileContentsEquals("/sys/bus/pci/devices/0000:d8:00.0/sriov_numvfs", "1")
 		})
 
@@ -403,6 +639,7 @@ var _ = Describe("SRIOV", func() {
 			hostMock.EXPECT().RemoveDisableNMUdevRule("0000:d8:00.0").Return(nil)
 			hostMock.EXPECT().RemovePersistPFNameUdevRule("0000:d8:00.0").Return(nil)
 			hostMock.EXPECT().RemoveVfRepresentorUdevRule("0000:d8:00.0").Return(nil)
+			hostMock.EXPECT().RemoveVfNameUdevRule("0000:d8:00.0").Return(nil)
 			hostMock.EXPECT().AddDisableNMUdevRule("0000:d8:00.0").Return(nil)
 			hostMock.EXPECT().AddPersistPFNameUdevRule("0000:d8:00.0", "enp216s0f0np0").Return(nil)
 			hostMock.EXPECT().EnableHwTcOffload("enp216s0f0np0").Return(nil)
@@ -455,7 +692,7 @@ var _ = Describe("SRIOV", func() {
 						}},
 				}},
 				[]sriovnetworkv1.InterfaceExt{{PciAddress: "0000:d8:00.0"}},
-				false)).NotTo(HaveOccurred())
+				false, nil)).NotTo(HaveOccurred())
 			helpers.GinkgoAssertFileContentsEquals("/sys/bus/pci/devices/0000:d8:00.0/sriov_numvfs", "1")
 		})
 
@@ -478,7 +715,7 @@ var _ = Describe("SRIOV", func() {
 						}},
 				}},
 				[]sriovnetworkv1.InterfaceExt{{PciAddress: "0000:d8:00.0"}},
-				false)).To(HaveOccurred())
+				false, nil)).To(HaveOccurred())
 		})
 
 		It("externally managed - wrong MTU", func() {
@@ -504,7 +741,52 @@ var _ = Describe("SRIOV", func() {
 						}},
 				}},
 				[]sriovnetworkv1.InterfaceExt{{PciAddress: "0000:d8:00.0"}},
-				false)).To(HaveOccurred())
+				false, nil)).To(HaveOccurred())
+		})
+
+		It("externally managed - don't configure VF attributes", func() {
+			dputilsLibMock.EXPECT().GetVFconfigured("0000:d8:00.0").Return(1)
+			netlinkLibMock.EXPECT().DevLinkGetDeviceByName("pci", "0000:d8:00.0").Return(
+				&netlink.DevlinkDevice{Attrs: netlink.DevlinkDevAttrs{Eswitch: netlink.DevlinkDevEswitchAttr{Mode: "legacy"}}},
+				nil)
+			hostMock.EXPECT().GetNetdevMTU("0000:d8:00.0")
+			dputilsLibMock.EXPECT().GetVFList("0000:d8:00.0").Return([]string{"0000:d8:00.2"}, nil)
+			pfLinkMock := netlinkMockPkg.NewMockLink(testCtrl)
+			pfLinkMock.EXPECT().Attrs().Return(&netlink.LinkAttrs{Name: "enp216s0f0np0"}).AnyTimes()
+			netlinkLibMock.EXPECT().LinkByName("enp216s0f0np0").Return(pfLinkMock, nil).Times(2)
+			netlinkLibMock.EXPECT().IsLinkAdminStateUp(pfLinkMock).Return(true)
+
+			hostMock.EXPECT().HasDriver("0000:d8:00.2").Return(true, "vfio-pci").Times(2)
+			dputilsLibMock.EXPECT().GetVFID("0000:d8:00.2").Return(0, nil)
+			// the VF attributes are applied by the generic plugin
+			hostMock.EXPECT().UnbindDriverIfNeeded("0000:d8:00.2", false).Return(nil)
+			hostMock.EXPECT().BindDpdkDriver("0000:d8:00.2", "vfio-pci").Return(nil)
+
+			storeManagerMode.EXPECT().SaveLastPfAppliedStatus(gomock.Any()).Return(nil)
+
+			spoofChk := false
+			Expect(s.ConfigSriovInterfaces(storeManagerMode,
+				[]sriovnetworkv1.Interface{{
+					Name:              "enp216s0f0np0",
+					PciAddress:        "0000:d8:00.0",
+					NumVfs:            1,
+					ExternallyManaged: true,
+					VfGroups: []sriovnetworkv1.VfGroup{
+						{
+							VfRange:      "0-0",
+							ResourceName: "test-resource0",
+							PolicyName:   "test-policy0",
+							DeviceType:   "vfio-pci",
+							VfAttributes: &sriovnetworkv1.VfAttributes{
+								Vlan:     100,
+								VlanQoS:  3,
+								Trust:    true,
+								SpoofChk: &spoofChk,
+							},
+						}},
+				}},
+				[]sriovnetworkv1.InterfaceExt{{PciAddress: "0000:d8:00.0"}},
+				false, nil)).NotTo(HaveOccurred())
 		})
 
 		It("reset device", func() {
@@ -525,6 +807,7 @@ var _ = Describe("SRIOV", func() {
 			hostMock.EXPECT().RemoveDisableNMUdevRule("0000:d8:00.0").Return(nil)
 			hostMock.EXPECT().RemovePersistPFNameUdevRule("0000:d8:00.0").Return(nil)
 			hostMock.EXPECT().RemoveVfRepresentorUdevRule("0000:d8:00.0").Return(nil)
+			hostMock.EXPECT().RemoveVfNameUdevRule("0000:d8:00.0").Return(nil)
 			dputilsLibMock.EXPECT().GetDriverName("0000:d8:00.0").Return("mlx5_core", nil)
 			hostMock.EXPECT().SetNetdevMTU("0000:d8:00.0", 1500).Return(nil)
 
@@ -537,7 +820,7 @@ var _ = Describe("SRIOV", func() {
 						LinkType:   "ETH",
 						NumVfs:     2,
 						TotalVfs:   2,
-					}}, false)).NotTo(HaveOccurred())
+					}}, false, nil)).NotTo(HaveOccurred())
 			helpers.GinkgoAssertFileContentsEquals("/sys/bus/pci/devices/0000:d8:00.0/sriov_numvfs", "0")
 		})
 		It("reset device - skip external", func() {
@@ -556,7 +839,70 @@ var _ = Describe("SRIOV", func() {
 						PciAddress: "0000:d8:00.0",
 						NumVfs:     2,
 						TotalVfs:   2,
-					}}, false)).NotTo(HaveOccurred())
+					}}, false, nil)).NotTo(HaveOccurred())
+		})
+		It("no changes - remove NetworkManager udev rule when VFs are managed by NetworkManager", func() {
+			storeManagerMode.EXPECT().SaveLastPfAppliedStatus(gomock.Any()).Return(nil)
+			hostMock.EXPECT().RemoveDisableNMUdevRule("0000:d8:00.0").Return(nil)
+
+			Expect(s.ConfigSriovInterfaces(storeManagerMode,
+				[]sriovnetworkv1.Interface{{
+					Name:                   "enp216s0f0np0",
+					PciAddress:             "0000:d8:00.0",
+					NumVfs:                 2,
+					ManageByNetworkManager: true,
+				}},
+				[]sriovnetworkv1.InterfaceExt{{
+					Name:       "enp216s0f0np0",
+					PciAddress: "0000:d8:00.0",
+					NumVfs:     2,
+					TotalVfs:   2,
+				}}, false, nil)).NotTo(HaveOccurred())
+		})
+		It("no changes - add NetworkManager udev rule back when the opt-out is removed", func() {
+			storeManagerMode.EXPECT().SaveLastPfAppliedStatus(gomock.Any()).Return(nil)
+			hostMock.EXPECT().AddDisableNMUdevRule("0000:d8:00.0").Return(nil)
+
+			Expect(s.ConfigSriovInterfaces(storeManagerMode,
+				[]sriovnetworkv1.Interface{{
+					Name:       "enp216s0f0np0",
+					PciAddress: "0000:d8:00.0",
+					NumVfs:     2,
+				}},
+				[]sriovnetworkv1.InterfaceExt{{
+					Name:       "enp216s0f0np0",
+					PciAddress: "0000:d8:00.0",
+					NumVfs:     2,
+					TotalVfs:   2,
+				}}, false, nil)).NotTo(HaveOccurred())
+		})
+		It("no changes - reconfigure switchdev PF when the representor naming is toggled", func() {
+			iface := &sriovnetworkv1.Interface{
+				Name:        "enp216s0f0np0",
+				PciAddress:  "0000:d8:00.0",
+				NumVfs:      1,
+				EswitchMode: "switchdev",
+			}
+			ifaceStatus := &sriovnetworkv1.InterfaceExt{
+				Name:        "enp216s0f0np0",
+				PciAddress:  "0000:d8:00.0",
+				NumVfs:      1,
+				TotalVfs:    1,
+				EswitchMode: "switchdev",
+			}
+			storeManagerMode.EXPECT().LoadPfsStatus("0000:d8:00.0").Return(&sriovnetworkv1.Interface{
+				PciAddress: "0000:d8:00.0", NumVfs: 1, EswitchMode: "switchdev"}, true, nil)
+			storeManagerMode.EXPECT().SaveLastPfAppliedStatus(iface).Return(nil)
+			skip, err := skipSriovConfig(iface, ifaceStatus, storeManagerMode)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(skip).To(BeTrue())
+
+			iface.KeepDefaultRepresentorNames = true
+			storeManagerMode.EXPECT().LoadPfsStatus("0000:d8:00.0").Return(&sriovnetworkv1.Interface{
+				PciAddress: "0000:d8:00.0", NumVfs: 1, EswitchMode: "switchdev"}, true, nil)
+			skip, err = skipSriovConfig(iface, ifaceStatus, storeManagerMode)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(skip).To(BeFalse())
 		})
 		It("should configure - skipVFConfiguration is true", func() {
 			helpers.GinkgoConfigureFakeFS(&fakefilesystem.FS{
@@ -573,6 +919,7 @@ var _ = Describe("SRIOV", func() {
 			hostMock.EXPECT().RemoveDisableNMUdevRule("0000:d8:00.0").Return(nil)
 			hostMock.EXPECT().RemovePersistPFNameUdevRule("0000:d8:00.0").Return(nil)
 			hostMock.EXPECT().RemoveVfRepresentorUdevRule("0000:d8:00.0").Return(nil)
+			hostMock.EXPECT().RemoveVfNameUdevRule("0000:d8:00.0").Return(nil)
 			hostMock.EXPECT().AddDisableNMUdevRule("0000:d8:00.0").Return(nil)
 			dputilsLibMock.EXPECT().GetVFList("0000:d8:00.0").Return([]string{"0000:d8:00.2", "0000:d8:00.3"}, nil)
 			hostMock.EXPECT().Unbind("0000:d8:00.2").Return(nil)
@@ -603,7 +950,7 @@ var _ = Describe("SRIOV", func() {
 						}},
 				}},
 				[]sriovnetworkv1.InterfaceExt{{PciAddress: "0000:d8:00.0"}},
-				true)).NotTo(HaveOccurred())
+				true, nil)).NotTo(HaveOccurred())
 			helpers.GinkgoAssertFileContentsEquals("/sys/bus/pci/devices/0000:d8:00.0/sriov_numvfs", "2")
 		})
 	})
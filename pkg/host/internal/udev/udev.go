@@ -5,6 +5,7 @@ import (
 	"os"
 	"path"
 	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -96,6 +97,28 @@ func (u *udev) RemovePersistPFNameUdevRule(pfPciAddress string) error {
 	return u.removeUdevRule(pfPciAddress, "10-pf-name")
 }
 
+// AddVfNameUdevRule adds udev rule that renames the VF netdevs of the concrete PF,
+// vfNames maps the PCI addresses of the VFs to their names
+func (u *udev) AddVfNameUdevRule(pfPciAddress string, vfNames map[string]string) error {
+	log.Log.V(2).Info("AddVfNameUdevRule()", "device", pfPciAddress)
+	vfPciAddresses := make([]string, 0, len(vfNames))
+	for vfPciAddress := range vfNames {
+		vfPciAddresses = append(vfPciAddresses, vfPciAddress)
+	}
+	sort.Strings(vfPciAddresses)
+	rules := make([]string, 0, len(vfPciAddresses))
+	for _, vfPciAddress := range vfPciAddresses {
+		rules = append(rules, fmt.Sprintf(consts.VfNameUdevRule, vfPciAddress, vfNames[vfPciAddress]))
+	}
+	return u.addUdevRule(pfPciAddress, "10-vf-name", strings.Join(rules, "\n"))
+}
+
+// RemoveVfNameUdevRule removes udev rule that renames the VF netdevs of the concrete PF
+func (u *udev) RemoveVfNameUdevRule(pfPciAddress string) error {
+	log.Log.V(2).Info("RemoveVfNameUdevRule()", "device", pfPciAddress)
+	return u.removeUdevRule(pfPciAddress, "10-vf-name")
+}
+
 // AddVfRepresentorUdevRule adds udev rule that renames VF representors on the concrete PF
 func (u *udev) AddVfRepresentorUdevRule(pfPciAddress, pfName, pfSwitchID, pfSwitchPort string) error {
 	log.Log.V(2).Info("AddVfRepresentorUdevRule()",
@@ -26,6 +26,8 @@ const (
 		`ATTRS{phys_switch_id}=="7cfe90ff2cc0", ` +
 		`ATTR{phys_port_name}=="pf0vf*", IMPORT{program}="/etc/udev/switchdev-vf-link-name.sh $attr{phys_port_name}", ` +
 		`NAME="enp216s0f0np0_$env{NUMBER}"`
+	testExpectedVfNameUdevRule = `SUBSYSTEM=="net", ACTION=="add", DRIVERS=="?*", KERNELS=="0000:d8:00.2", NAME="sriov0"` + "\n" +
+		`SUBSYSTEM=="net", ACTION=="add", DRIVERS=="?*", KERNELS=="0000:d8:00.3", NAME="sriov1"`
 )
 
 var _ = Describe("UDEV", func() {
@@ -152,6 +154,30 @@ var _ = Describe("UDEV", func() {
 				testExpectedSwitchdevUdevRule)
 		})
 	})
+	Context("AddVfNameUdevRule", func() {
+		It("Created", func() {
+			helpers.GinkgoConfigureFakeFS(&fakefilesystem.FS{})
+			Expect(s.AddVfNameUdevRule("0000:d8:00.0",
+				map[string]string{"0000:d8:00.3": "sriov1", "0000:d8:00.2": "sriov0"})).To(BeNil())
+			helpers.GinkgoAssertFileContentsEquals(
+				"/etc/udev/rules.d/10-vf-name-0000:d8:00.0.rules",
+				testExpectedVfNameUdevRule)
+		})
+	})
+	Context("RemoveVfNameUdevRule", func() {
+		It("Exist", func() {
+			helpers.GinkgoConfigureFakeFS(&fakefilesystem.FS{
+				Dirs: []string{"/etc/udev/rules.d"},
+				Files: map[string][]byte{
+					"/etc/udev/rules.d/10-vf-name-0000:d8:00.0.rules": []byte(testExpectedVfNameUdevRule),
+				},
+			})
+			Expect(s.RemoveVfNameUdevRule("0000:d8:00.0")).To(BeNil())
+			_, err := os.Stat(filepath.Join(vars.FilesystemRoot,
+				"/etc/udev/rules.d/10-vf-name-0000:d8:00.0.rules"))
+			Expect(os.IsNotExist(err)).To(BeTrue())
+		})
+	})
 	Context("RemoveVfRepresentorUdevRule", func() {
 		It("Exist", func() {
 			helpers.GinkgoConfigureFakeFS(&fakefilesystem.FS{
@@ -65,6 +65,20 @@ func (mr *MockHostManagerInterfaceMockRecorder) AddPersistPFNameUdevRule(pfPciAd
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddPersistPFNameUdevRule", reflect.TypeOf((*MockHostManagerInterface)(nil).AddPersistPFNameUdevRule), pfPciAddress, pfName)
 }
 
+// AddVfNameUdevRule mocks base method.
+func (m *MockHostManagerInterface) AddVfNameUdevRule(pfPciAddress string, vfNames map[string]string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddVfNameUdevRule", pfPciAddress, vfNames)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddVfNameUdevRule indicates an expected call of AddVfNameUdevRule.
+func (mr *MockHostManagerInterfaceMockRecorder) AddVfNameUdevRule(pfPciAddress, vfNames interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddVfNameUdevRule", reflect.TypeOf((*MockHostManagerInterface)(nil).AddVfNameUdevRule), pfPciAddress, vfNames)
+}
+
 // AddVfRepresentorUdevRule mocks base method.
 func (m *MockHostManagerInterface) AddVfRepresentorUdevRule(pfPciAddress, pfName, pfSwitchID, pfSwitchPort string) error {
 	m.ctrl.T.Helper()
@@ -166,17 +180,17 @@ func (mr *MockHostManagerInterfaceMockRecorder) ConfigSriovDeviceVirtual(iface i
 }
 
 // ConfigSriovInterfaces mocks base method.
-func (m *MockHostManagerInterface) ConfigSriovInterfaces(storeManager store.ManagerInterface, interfaces []v1.Interface, ifaceStatuses []v1.InterfaceExt, skipVFConfiguration bool) error {
+func (m *MockHostManagerInterface) ConfigSriovInterfaces(storeManager store.ManagerInterface, interfaces []v1.Interface, ifaceStatuses []v1.InterfaceExt, skipVFConfiguration bool, progress types.ConfigProgressFunc) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ConfigSriovInterfaces", storeManager, interfaces, ifaceStatuses, skipVFConfiguration)
+	ret := m.ctrl.Call(m, "ConfigSriovInterfaces", storeManager, interfaces, ifaceStatuses, skipVFConfiguration, progress)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // ConfigSriovInterfaces indicates an expected call of ConfigSriovInterfaces.
-func (mr *MockHostManagerInterfaceMockRecorder) ConfigSriovInterfaces(storeManager, interfaces, ifaceStatuses, skipVFConfiguration interface{}) *gomock.Call {
+func (mr *MockHostManagerInterfaceMockRecorder) ConfigSriovInterfaces(storeManager, interfaces, ifaceStatuses, skipVFConfiguration, progress interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfigSriovInterfaces", reflect.TypeOf((*MockHostManagerInterface)(nil).ConfigSriovInterfaces), storeManager, interfaces, ifaceStatuses, skipVFConfiguration)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfigSriovInterfaces", reflect.TypeOf((*MockHostManagerInterface)(nil).ConfigSriovInterfaces), storeManager, interfaces, ifaceStatuses, skipVFConfiguration, progress)
 }
 
 // ConfigureBridges mocks base method.
@@ -264,6 +278,21 @@ func (mr *MockHostManagerInterfaceMockRecorder) DiscoverBridges() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiscoverBridges", reflect.TypeOf((*MockHostManagerInterface)(nil).DiscoverBridges))
 }
 
+// DiscoverBridgesStatistics mocks base method.
+func (m *MockHostManagerInterface) DiscoverBridgesStatistics() ([]types.OVSPortStatistics, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DiscoverBridgesStatistics")
+	ret0, _ := ret[0].([]types.OVSPortStatistics)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DiscoverBridgesStatistics indicates an expected call of DiscoverBridgesStatistics.
+func (mr *MockHostManagerInterfaceMockRecorder) DiscoverBridgesStatistics() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiscoverBridgesStatistics", reflect.TypeOf((*MockHostManagerInterface)(nil).DiscoverBridgesStatistics))
+}
+
 // DiscoverRDMASubsystem mocks base method.
 func (m *MockHostManagerInterface) DiscoverRDMASubsystem() (string, error) {
 	m.ctrl.T.Helper()
@@ -308,6 +337,21 @@ func (mr *MockHostManagerInterfaceMockRecorder) DiscoverVDPAType(pciAddr interfa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiscoverVDPAType", reflect.TypeOf((*MockHostManagerInterface)(nil).DiscoverVDPAType), pciAddr)
 }
 
+// DiscoverVfConsumers mocks base method.
+func (m *MockHostManagerInterface) DiscoverVfConsumers() (map[string]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DiscoverVfConsumers")
+	ret0, _ := ret[0].(map[string]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DiscoverVfConsumers indicates an expected call of DiscoverVfConsumers.
+func (mr *MockHostManagerInterfaceMockRecorder) DiscoverVfConsumers() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiscoverVfConsumers", reflect.TypeOf((*MockHostManagerInterface)(nil).DiscoverVfConsumers))
+}
+
 // EnableHwTcOffload mocks base method.
 func (m *MockHostManagerInterface) EnableHwTcOffload(ifaceName string) error {
 	m.ctrl.T.Helper()
@@ -366,6 +410,21 @@ func (mr *MockHostManagerInterfaceMockRecorder) GetCurrentKernelArgs() *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCurrentKernelArgs", reflect.TypeOf((*MockHostManagerInterface)(nil).GetCurrentKernelArgs))
 }
 
+// GetDefaultRouteInterfaces mocks base method.
+func (m *MockHostManagerInterface) GetDefaultRouteInterfaces() ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDefaultRouteInterfaces")
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDefaultRouteInterfaces indicates an expected call of GetDefaultRouteInterfaces.
+func (mr *MockHostManagerInterfaceMockRecorder) GetDefaultRouteInterfaces() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDefaultRouteInterfaces", reflect.TypeOf((*MockHostManagerInterface)(nil).GetDefaultRouteInterfaces))
+}
+
 // GetDevlinkDeviceParam mocks base method.
 func (m *MockHostManagerInterface) GetDevlinkDeviceParam(pciAddr, paramName string) (string, error) {
 	m.ctrl.T.Helper()
@@ -411,6 +470,21 @@ func (mr *MockHostManagerInterfaceMockRecorder) GetInterfaceIndex(pciAddr interf
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInterfaceIndex", reflect.TypeOf((*MockHostManagerInterface)(nil).GetInterfaceIndex), pciAddr)
 }
 
+// GetKernelVersion mocks base method.
+func (m *MockHostManagerInterface) GetKernelVersion() (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetKernelVersion")
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetKernelVersion indicates an expected call of GetKernelVersion.
+func (mr *MockHostManagerInterfaceMockRecorder) GetKernelVersion() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetKernelVersion", reflect.TypeOf((*MockHostManagerInterface)(nil).GetKernelVersion))
+}
+
 // GetLinkType mocks base method.
 func (m *MockHostManagerInterface) GetLinkType(name string) string {
 	m.ctrl.T.Helper()
@@ -425,6 +499,34 @@ func (mr *MockHostManagerInterfaceMockRecorder) GetLinkType(name interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLinkType", reflect.TypeOf((*MockHostManagerInterface)(nil).GetLinkType), name)
 }
 
+// GetNetDevDriverVersion mocks base method.
+func (m *MockHostManagerInterface) GetNetDevDriverVersion(ifaceName string) string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNetDevDriverVersion", ifaceName)
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetNetDevDriverVersion indicates an expected call of GetNetDevDriverVersion.
+func (mr *MockHostManagerInterfaceMockRecorder) GetNetDevDriverVersion(ifaceName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNetDevDriverVersion", reflect.TypeOf((*MockHostManagerInterface)(nil).GetNetDevDriverVersion), ifaceName)
+}
+
+// GetNetDevFirmwareVersion mocks base method.
+func (m *MockHostManagerInterface) GetNetDevFirmwareVersion(ifaceName string) string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNetDevFirmwareVersion", ifaceName)
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetNetDevFirmwareVersion indicates an expected call of GetNetDevFirmwareVersion.
+func (mr *MockHostManagerInterfaceMockRecorder) GetNetDevFirmwareVersion(ifaceName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNetDevFirmwareVersion", reflect.TypeOf((*MockHostManagerInterface)(nil).GetNetDevFirmwareVersion), ifaceName)
+}
+
 // GetNetDevLinkAdminState mocks base method.
 func (m *MockHostManagerInterface) GetNetDevLinkAdminState(ifaceName string) string {
 	m.ctrl.T.Helper()
@@ -481,6 +583,34 @@ func (mr *MockHostManagerInterfaceMockRecorder) GetNetDevNodeGUID(pciAddr interf
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNetDevNodeGUID", reflect.TypeOf((*MockHostManagerInterface)(nil).GetNetDevNodeGUID), pciAddr)
 }
 
+// GetNetDevNumQueues mocks base method.
+func (m *MockHostManagerInterface) GetNetDevNumQueues(ifaceName string) int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNetDevNumQueues", ifaceName)
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// GetNetDevNumQueues indicates an expected call of GetNetDevNumQueues.
+func (mr *MockHostManagerInterfaceMockRecorder) GetNetDevNumQueues(ifaceName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNetDevNumQueues", reflect.TypeOf((*MockHostManagerInterface)(nil).GetNetDevNumQueues), ifaceName)
+}
+
+// GetNetDevVlanFiltering mocks base method.
+func (m *MockHostManagerInterface) GetNetDevVlanFiltering(ifaceName string) *bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNetDevVlanFiltering", ifaceName)
+	ret0, _ := ret[0].(*bool)
+	return ret0
+}
+
+// GetNetDevVlanFiltering indicates an expected call of GetNetDevVlanFiltering.
+func (mr *MockHostManagerInterfaceMockRecorder) GetNetDevVlanFiltering(ifaceName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNetDevVlanFiltering", reflect.TypeOf((*MockHostManagerInterface)(nil).GetNetDevVlanFiltering), ifaceName)
+}
+
 // GetNetdevMTU mocks base method.
 func (m *MockHostManagerInterface) GetNetdevMTU(pciAddr string) int {
 	m.ctrl.T.Helper()
@@ -569,6 +699,36 @@ func (mr *MockHostManagerInterfaceMockRecorder) HasDriver(pciAddr interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasDriver", reflect.TypeOf((*MockHostManagerInterface)(nil).HasDriver), pciAddr)
 }
 
+// HasHugepages mocks base method.
+func (m *MockHostManagerInterface) HasHugepages() (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasHugepages")
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HasHugepages indicates an expected call of HasHugepages.
+func (mr *MockHostManagerInterfaceMockRecorder) HasHugepages() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasHugepages", reflect.TypeOf((*MockHostManagerInterface)(nil).HasHugepages))
+}
+
+// IsDriverAvailable mocks base method.
+func (m *MockHostManagerInterface) IsDriverAvailable(bus, driver string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsDriverAvailable", bus, driver)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsDriverAvailable indicates an expected call of IsDriverAvailable.
+func (mr *MockHostManagerInterfaceMockRecorder) IsDriverAvailable(bus, driver interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsDriverAvailable", reflect.TypeOf((*MockHostManagerInterface)(nil).IsDriverAvailable), bus, driver)
+}
+
 // IsKernelArgsSet mocks base method.
 func (m *MockHostManagerInterface) IsKernelArgsSet(cmdLine, karg string) bool {
 	m.ctrl.T.Helper()
@@ -612,6 +772,21 @@ func (mr *MockHostManagerInterfaceMockRecorder) IsKernelModuleLoaded(name interf
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsKernelModuleLoaded", reflect.TypeOf((*MockHostManagerInterface)(nil).IsKernelModuleLoaded), name)
 }
 
+// IsNetDevMacsecOutdated mocks base method.
+func (m *MockHostManagerInterface) IsNetDevMacsecOutdated(ifaceName, keyID, key string, encrypt bool) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsNetDevMacsecOutdated", ifaceName, keyID, key, encrypt)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsNetDevMacsecOutdated indicates an expected call of IsNetDevMacsecOutdated.
+func (mr *MockHostManagerInterfaceMockRecorder) IsNetDevMacsecOutdated(ifaceName, keyID, key, encrypt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsNetDevMacsecOutdated", reflect.TypeOf((*MockHostManagerInterface)(nil).IsNetDevMacsecOutdated), ifaceName, keyID, key, encrypt)
+}
+
 // IsServiceEnabled mocks base method.
 func (m *MockHostManagerInterface) IsServiceEnabled(servicePath string) (bool, error) {
 	m.ctrl.T.Helper()
@@ -804,6 +979,20 @@ func (mr *MockHostManagerInterfaceMockRecorder) RemovePersistPFNameUdevRule(pfPc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemovePersistPFNameUdevRule", reflect.TypeOf((*MockHostManagerInterface)(nil).RemovePersistPFNameUdevRule), pfPciAddress)
 }
 
+// RemoveVfNameUdevRule mocks base method.
+func (m *MockHostManagerInterface) RemoveVfNameUdevRule(pfPciAddress string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveVfNameUdevRule", pfPciAddress)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveVfNameUdevRule indicates an expected call of RemoveVfNameUdevRule.
+func (mr *MockHostManagerInterfaceMockRecorder) RemoveVfNameUdevRule(pfPciAddress interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveVfNameUdevRule", reflect.TypeOf((*MockHostManagerInterface)(nil).RemoveVfNameUdevRule), pfPciAddress)
+}
+
 // RemoveVfRepresentorUdevRule mocks base method.
 func (m *MockHostManagerInterface) RemoveVfRepresentorUdevRule(pfPciAddress string) error {
 	m.ctrl.T.Helper()
@@ -846,6 +1035,132 @@ func (mr *MockHostManagerInterfaceMockRecorder) SetDevlinkDeviceParam(pciAddr, p
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetDevlinkDeviceParam", reflect.TypeOf((*MockHostManagerInterface)(nil).SetDevlinkDeviceParam), pciAddr, paramName, value)
 }
 
+// SetNetDevBroadcastFilter mocks base method.
+func (m *MockHostManagerInterface) SetNetDevBroadcastFilter(ifaceName string, drop bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetNetDevBroadcastFilter", ifaceName, drop)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetNetDevBroadcastFilter indicates an expected call of SetNetDevBroadcastFilter.
+func (mr *MockHostManagerInterfaceMockRecorder) SetNetDevBroadcastFilter(ifaceName, drop interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetNetDevBroadcastFilter", reflect.TypeOf((*MockHostManagerInterface)(nil).SetNetDevBroadcastFilter), ifaceName, drop)
+}
+
+// SetNetDevLinkSettings mocks base method.
+func (m *MockHostManagerInterface) SetNetDevLinkSettings(ifaceName string, autoNeg *bool, speedMbps int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetNetDevLinkSettings", ifaceName, autoNeg, speedMbps)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetNetDevLinkSettings indicates an expected call of SetNetDevLinkSettings.
+func (mr *MockHostManagerInterfaceMockRecorder) SetNetDevLinkSettings(ifaceName, autoNeg, speedMbps interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetNetDevLinkSettings", reflect.TypeOf((*MockHostManagerInterface)(nil).SetNetDevLinkSettings), ifaceName, autoNeg, speedMbps)
+}
+
+// SetNetDevMacsec mocks base method.
+func (m *MockHostManagerInterface) SetNetDevMacsec(ifaceName, keyID, key string, encrypt bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetNetDevMacsec", ifaceName, keyID, key, encrypt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetNetDevMacsec indicates an expected call of SetNetDevMacsec.
+func (mr *MockHostManagerInterfaceMockRecorder) SetNetDevMacsec(ifaceName, keyID, key, encrypt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetNetDevMacsec", reflect.TypeOf((*MockHostManagerInterface)(nil).SetNetDevMacsec), ifaceName, keyID, key, encrypt)
+}
+
+// SetNetDevMulticast mocks base method.
+func (m *MockHostManagerInterface) SetNetDevMulticast(ifaceName string, enable bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetNetDevMulticast", ifaceName, enable)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetNetDevMulticast indicates an expected call of SetNetDevMulticast.
+func (mr *MockHostManagerInterfaceMockRecorder) SetNetDevMulticast(ifaceName, enable interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetNetDevMulticast", reflect.TypeOf((*MockHostManagerInterface)(nil).SetNetDevMulticast), ifaceName, enable)
+}
+
+// SetNetDevNumQueues mocks base method.
+func (m *MockHostManagerInterface) SetNetDevNumQueues(ifaceName string, numQueues int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetNetDevNumQueues", ifaceName, numQueues)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetNetDevNumQueues indicates an expected call of SetNetDevNumQueues.
+func (mr *MockHostManagerInterfaceMockRecorder) SetNetDevNumQueues(ifaceName, numQueues interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetNetDevNumQueues", reflect.TypeOf((*MockHostManagerInterface)(nil).SetNetDevNumQueues), ifaceName, numQueues)
+}
+
+// SetNetDevPromisc mocks base method.
+func (m *MockHostManagerInterface) SetNetDevPromisc(ifaceName string, enable bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetNetDevPromisc", ifaceName, enable)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetNetDevPromisc indicates an expected call of SetNetDevPromisc.
+func (mr *MockHostManagerInterfaceMockRecorder) SetNetDevPromisc(ifaceName, enable interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetNetDevPromisc", reflect.TypeOf((*MockHostManagerInterface)(nil).SetNetDevPromisc), ifaceName, enable)
+}
+
+// SetNetDevRss mocks base method.
+func (m *MockHostManagerInterface) SetNetDevRss(ifaceName, hashKey string, indirectionSize int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetNetDevRss", ifaceName, hashKey, indirectionSize)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetNetDevRss indicates an expected call of SetNetDevRss.
+func (mr *MockHostManagerInterfaceMockRecorder) SetNetDevRss(ifaceName, hashKey, indirectionSize interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetNetDevRss", reflect.TypeOf((*MockHostManagerInterface)(nil).SetNetDevRss), ifaceName, hashKey, indirectionSize)
+}
+
+// SetNetDevSysctl mocks base method.
+func (m *MockHostManagerInterface) SetNetDevSysctl(ifaceName, name, value string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetNetDevSysctl", ifaceName, name, value)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetNetDevSysctl indicates an expected call of SetNetDevSysctl.
+func (mr *MockHostManagerInterfaceMockRecorder) SetNetDevSysctl(ifaceName, name, value interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetNetDevSysctl", reflect.TypeOf((*MockHostManagerInterface)(nil).SetNetDevSysctl), ifaceName, name, value)
+}
+
+// SetNetDevVlanFiltering mocks base method.
+func (m *MockHostManagerInterface) SetNetDevVlanFiltering(ifaceName string, enable bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetNetDevVlanFiltering", ifaceName, enable)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetNetDevVlanFiltering indicates an expected call of SetNetDevVlanFiltering.
+func (mr *MockHostManagerInterfaceMockRecorder) SetNetDevVlanFiltering(ifaceName, enable interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetNetDevVlanFiltering", reflect.TypeOf((*MockHostManagerInterface)(nil).SetNetDevVlanFiltering), ifaceName, enable)
+}
+
 // SetNetdevMTU mocks base method.
 func (m *MockHostManagerInterface) SetNetdevMTU(pciAddr string, mtu int) error {
 	m.ctrl.T.Helper()
@@ -916,6 +1231,35 @@ func (mr *MockHostManagerInterfaceMockRecorder) SetVfAdminMac(vfAddr, pfLink, vf
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetVfAdminMac", reflect.TypeOf((*MockHostManagerInterface)(nil).SetVfAdminMac), vfAddr, pfLink, vfLink)
 }
 
+// SetVfConfig mocks base method.
+func (m *MockHostManagerInterface) SetVfConfig(pfName string, vfID int, config types.VfConfig) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetVfConfig", pfName, vfID, config)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetVfConfig indicates an expected call of SetVfConfig.
+func (mr *MockHostManagerInterfaceMockRecorder) SetVfConfig(pfName, vfID, config interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetVfConfig", reflect.TypeOf((*MockHostManagerInterface)(nil).SetVfConfig), pfName, vfID, config)
+}
+
+// SubscribeLinkUpdates mocks base method.
+func (m *MockHostManagerInterface) SubscribeLinkUpdates(done <-chan struct{}) (<-chan string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubscribeLinkUpdates", done)
+	ret0, _ := ret[0].(<-chan string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SubscribeLinkUpdates indicates an expected call of SubscribeLinkUpdates.
+func (mr *MockHostManagerInterfaceMockRecorder) SubscribeLinkUpdates(done interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubscribeLinkUpdates", reflect.TypeOf((*MockHostManagerInterface)(nil).SubscribeLinkUpdates), done)
+}
+
 // TryEnableTun mocks base method.
 func (m *MockHostManagerInterface) TryEnableTun() {
 	m.ctrl.T.Helper()
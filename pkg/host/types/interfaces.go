@@ -36,6 +36,10 @@ type KernelInterface interface {
 	// bus - the bus path in the sysfs, e.g. "pci" or "vdpa"
 	// device - the name of the device on the bus, e.g. 0000:85:1e.5 for PCI or vpda1 for VDPA
 	GetDriverByBusAndDevice(bus, device string) (string, error)
+	// IsDriverAvailable returns true if the driver is registered on the bus
+	// bus - the bus path in the sysfs, e.g. "pci" or "vdpa"
+	// driver - the name of the driver, e.g. vfio-pci or vhost_vdpa.
+	IsDriverAvailable(bus, driver string) (bool, error)
 	// RebindVfToDefaultDriver rebinds the virtual function to is default driver
 	RebindVfToDefaultDriver(pciAddr string) error
 	// UnbindDriverByBusAndDevice unbind device identified by bus and device ID from the driver
@@ -50,6 +54,10 @@ type KernelInterface interface {
 	IsKernelModuleLoaded(name string) (bool, error)
 	// IsKernelLockdownMode returns true if the kernel is in lockdown mode
 	IsKernelLockdownMode() bool
+	// HasHugepages returns true if hugepages of any size are allocated on the host
+	HasHugepages() (bool, error)
+	// GetKernelVersion returns the release of the running kernel, e.g. 5.14.0-427.el9.x86_64
+	GetKernelVersion() (string, error)
 }
 
 type NetworkInterface interface {
@@ -86,10 +94,55 @@ type NetworkInterface interface {
 	SetDevlinkDeviceParam(pciAddr, paramName, value string) error
 	// EnableHwTcOffload make sure that hw-tc-offload feature is enabled if device supports it
 	EnableHwTcOffload(ifaceName string) error
+	// GetNetDevNumQueues returns the number of combined queues of the interface, 0 if it can't be read
+	GetNetDevNumQueues(ifaceName string) int
+	// SetNetDevNumQueues sets the number of combined queues of the interface if the driver supports it
+	SetNetDevNumQueues(ifaceName string, numQueues int) error
+	// SetNetDevRss sets the RSS hash key and spreads the traffic equally over the first indirectionSize
+	// receive queues of the interface, empty hashKey or zero indirectionSize leave the setting unchanged.
+	// The settings not supported by the driver are skipped with a warning
+	SetNetDevRss(ifaceName, hashKey string, indirectionSize int) error
+	// SetNetDevLinkSettings sets the auto-negotiation and the forced speed of the interface link,
+	// a forced speed disables the auto-negotiation
+	SetNetDevLinkSettings(ifaceName string, autoNeg *bool, speedMbps int) error
+	// GetNetDevVlanFiltering returns the state of the hardware VLAN filtering of the interface,
+	// nil if the driver doesn't support it or the state can't be read
+	GetNetDevVlanFiltering(ifaceName string) *bool
+	// SetNetDevVlanFiltering enables or disables the hardware VLAN filtering of the interface
+	SetNetDevVlanFiltering(ifaceName string, enable bool) error
+	// SetNetDevSysctl sets the per-interface sysctl, the <if> element of the sysctl name is replaced by the interface name
+	SetNetDevSysctl(ifaceName, name, value string) error
+	// SetNetDevPromisc enables or disables the promiscuous mode of the interface
+	SetNetDevPromisc(ifaceName string, enable bool) error
+	// SetNetDevMulticast enables or disables the reception of the multicast frames by the interface,
+	// disabling it also disables the all-multicast mode
+	SetNetDevMulticast(ifaceName string, enable bool) error
+	// SetNetDevBroadcastFilter adds or removes the ethtool classification rule dropping the broadcast frames
+	// received by the interface
+	SetNetDevBroadcastFilter(ifaceName string, drop bool) error
+	// SetVfConfig applies the attributes of the VF that differ from the current ones through the PF
+	// in a single request
+	SetVfConfig(pfName string, vfID int, config VfConfig) error
 	// GetNetDevLinkAdminState returns the admin state of the interface.
 	GetNetDevLinkAdminState(ifaceName string) string
+	// GetNetDevDriverVersion returns the version of the driver bound to the interface, empty string if it can't be read
+	GetNetDevDriverVersion(ifaceName string) string
+	// GetNetDevFirmwareVersion returns the firmware version of the NIC of the interface, empty string if it can't be read
+	GetNetDevFirmwareVersion(ifaceName string) string
+	// SetNetDevMacsec creates a MACsec interface on top of the interface with a transmit secure association
+	// using the hex encoded key, an existing MACsec interface is only recreated if its configuration differs
+	SetNetDevMacsec(ifaceName, keyID, key string, encrypt bool) error
+	// IsNetDevMacsecOutdated returns true if a MACsec interface exists on top of the interface
+	// with a configuration different from the requested one
+	IsNetDevMacsecOutdated(ifaceName, keyID, key string, encrypt bool) (bool, error)
+	// GetDefaultRouteInterfaces returns the names of the interfaces carrying the default route of the host
+	// together with the interfaces enslaved to them, e.g. the members of a bond used by the default route
+	GetDefaultRouteInterfaces() ([]string, error)
 	// GetPciAddressFromInterfaceName parses sysfs to get pci address of an interface by name
 	GetPciAddressFromInterfaceName(interfaceName string) (string, error)
+	// SubscribeLinkUpdates returns a channel receiving the name of each link added, removed or changed on the host
+	// until done is closed, the channel is closed when the subscription ends
+	SubscribeLinkUpdates(done <-chan struct{}) (<-chan string, error)
 	// DiscoverRDMASubsystem returns RDMA subsystem mode
 	DiscoverRDMASubsystem() (string, error)
 	// SetRDMASubsystem changes RDMA subsystem mode
@@ -136,14 +189,23 @@ type SriovInterface interface {
 	ResetSriovDevice(ifaceStatus sriovnetworkv1.InterfaceExt) error
 	// DiscoverSriovDevices returns a list of all the available SR-IOV capable network interfaces on the system
 	DiscoverSriovDevices(storeManager store.ManagerInterface) ([]sriovnetworkv1.InterfaceExt, error)
+	// DiscoverVfConsumers returns the UID of the pod each VF is allocated to, indexed by the PCI address of the VF,
+	// from the kubelet device plugin checkpoint
+	DiscoverVfConsumers() (map[string]string, error)
 	// ConfigSriovInterfaces configure multiple SR-IOV devices with the desired configuration
 	// if skipVFConfiguration flag is set, the function will configure PF and create VFs on it, but will skip VFs configuration
+	// progress is called, if not nil, each time the configuration of a PF completes, and before the configuration
+	// of each PF starts when the PFs are not configured in parallel
 	ConfigSriovInterfaces(storeManager store.ManagerInterface, interfaces []sriovnetworkv1.Interface,
-		ifaceStatuses []sriovnetworkv1.InterfaceExt, skipVFConfiguration bool) error
+		ifaceStatuses []sriovnetworkv1.InterfaceExt, skipVFConfiguration bool, progress ConfigProgressFunc) error
 	// ConfigSriovInterfaces configure virtual functions for virtual environments with the desired configuration
 	ConfigSriovDeviceVirtual(iface *sriovnetworkv1.Interface) error
 }
 
+// ConfigProgressFunc is called with the number of configured PFs out of the total PFs to configure,
+// pciAddress is the PF whose configuration starts, empty when the call reports a completed configuration
+type ConfigProgressFunc func(configured, total int, pciAddress string)
+
 type UdevInterface interface {
 	// PrepareNMUdevRule creates the needed udev rules to disable NetworkManager from
 	// our managed SR-IOV virtual functions
@@ -158,6 +220,11 @@ type UdevInterface interface {
 	AddPersistPFNameUdevRule(pfPciAddress, pfName string) error
 	// RemovePersistPFNameUdevRule removes udev rule that preserves PF name after switching to switchdev mode
 	RemovePersistPFNameUdevRule(pfPciAddress string) error
+	// AddVfNameUdevRule adds udev rule that renames the VF netdevs of the concrete PF,
+	// vfNames maps the PCI addresses of the VFs to their names
+	AddVfNameUdevRule(pfPciAddress string, vfNames map[string]string) error
+	// RemoveVfNameUdevRule removes udev rule that renames the VF netdevs of the concrete PF
+	RemoveVfNameUdevRule(pfPciAddress string) error
 	// AddVfRepresentorUdevRule adds udev rule that renames VF representors on the concrete PF
 	AddVfRepresentorUdevRule(pfPciAddress, pfName, pfSwitchID, pfSwitchPort string) error
 	// RemoveVfRepresentorUdevRule removes udev rule that renames VF representors on the concrete PF
@@ -188,6 +255,8 @@ type BridgeInterface interface {
 	// this step is required before applying some configurations to PF, e.g. changing of eSwitch mode.
 	// The function detach interface from managed bridges only.
 	DetachInterfaceFromManagedBridge(pciAddr string) error
+	// DiscoverBridgesStatistics returns the statistics of the ports of the managed bridges
+	DiscoverBridgesStatistics() ([]OVSPortStatistics, error)
 }
 
 type InfinibandInterface interface {
@@ -1,5 +1,7 @@
 package types
 
+import "fmt"
+
 // Service contains info about systemd service
 type Service struct {
 	Name    string
@@ -28,3 +30,37 @@ type ScriptManifestFile struct {
 		Inline string
 	}
 }
+
+// VfConfig contains the attributes of a VF configured through its PF, nil attributes are left unchanged
+type VfConfig struct {
+	// Vlan is the VLAN ID of the VF, VlanQoS is only applied together with it
+	Vlan    *int
+	VlanQoS int
+	// VlanProto is the lower case VLAN protocol of the VF, e.g. "802.1ad", the default 802.1q protocol is used if empty
+	VlanProto string
+	Trust     *bool
+	SpoofChk  *bool
+	// MinTxRate and MaxTxRate are the TX rate limits of the VF in Mbps, 0 disables the limit
+	MinTxRate *int
+	MaxTxRate *int
+}
+
+// OVSPortStatistics contains the counters reported by OVS for a port of a managed OVS bridge
+type OVSPortStatistics struct {
+	Bridge string
+	Port   string
+	// Statistics are the counters of the interface of the port, e.g. rx_packets or tx_bytes
+	Statistics map[string]int
+}
+
+// OVSInterfaceError is returned when an interface added to a managed OVS bridge is reported in error state by OVS
+type OVSInterfaceError struct {
+	Bridge    string
+	Interface string
+	// Message is the error reported by OVS in the error column of the interface
+	Message string
+}
+
+func (e *OVSInterfaceError) Error() string {
+	return fmt.Sprintf("interface %s of OVS bridge %s is in error state: %s", e.Interface, e.Bridge, e.Message)
+}
@@ -17,11 +17,14 @@ package log
 
 import (
 	"flag"
+	"fmt"
 
 	zzap "go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	sriovnetworkv1 "github.com/k8snetworkplumbingwg/sriov-network-operator/api/v1"
 )
 
 // Options stores controller-runtime (zap) log config
@@ -49,6 +52,20 @@ func InitLog() {
 	log.SetLogger(zap.New(zap.UseFlagOptions(Options)))
 }
 
+// SetLogFormat configures the encoder used by the logger according to the provided format ("text" or "json").
+// It must be called before InitLog to take effect.
+func SetLogFormat(format string) error {
+	switch sriovnetworkv1.LogFormatType(format) {
+	case "", sriovnetworkv1.LogFormatText:
+		// keep the default encoder
+	case sriovnetworkv1.LogFormatJSON:
+		zap.JSONEncoder()(Options)
+	default:
+		return fmt.Errorf("unsupported log format %q, supported formats are %q and %q", format, sriovnetworkv1.LogFormatText, sriovnetworkv1.LogFormatJSON)
+	}
+	return nil
+}
+
 // SetLogLevel provides conversion from the operators LogLevel value ({0,1,2} where 2 is the most verbose) and sets
 // the current logging level accordingly.
 func SetLogLevel(operatorLevel int) {
@@ -1,14 +1,19 @@
 package log
 
 import (
+	"encoding/json"
 	"io"
 	"os"
+	"strings"
 	"testing"
 
 	g "github.com/onsi/ginkgo/v2"
 	o "github.com/onsi/gomega"
 
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	sriovnetworkv1 "github.com/k8snetworkplumbingwg/sriov-network-operator/api/v1"
 )
 
 var tempLogFile *os.File
@@ -66,6 +71,39 @@ var _ = g.Describe("Logging", func() {
 		o.Expect(string(out)).Should(o.ContainSubstring("test level 1"))
 		o.Expect(string(out)).Should(o.ContainSubstring("test level 2"))
 	})
+
+	g.Context("JSON format", func() {
+		g.AfterEach(func() {
+			Options.NewEncoder = nil
+		})
+
+		g.It("should emit one JSON object per log entry", func() {
+			o.Expect(SetLogFormat(string(sriovnetworkv1.LogFormatJSON))).To(o.Succeed())
+			// the global logger can be set only once, build a new one from the same Options
+			logger := zap.New(zap.UseFlagOptions(Options))
+
+			logger.WithName("test").Info("test json", "key", "value")
+
+			out, err := os.ReadFile(tempLogFile.Name())
+			o.Expect(err).NotTo(o.HaveOccurred())
+
+			// the log file is truncated but not rewound between tests, drop the leading zero bytes
+			lines := strings.Split(strings.TrimSpace(strings.TrimLeft(string(out), "\x00")), "\n")
+			o.Expect(lines).To(o.HaveLen(1))
+			entry := map[string]interface{}{}
+			o.Expect(json.Unmarshal([]byte(lines[0]), &entry)).To(o.Succeed())
+			o.Expect(entry).To(o.HaveKeyWithValue("msg", "test json"))
+			o.Expect(entry).To(o.HaveKeyWithValue("level", "info"))
+			o.Expect(entry).To(o.HaveKeyWithValue("logger", "test"))
+			o.Expect(entry).To(o.HaveKeyWithValue("key", "value"))
+			o.Expect(entry).To(o.HaveKey("ts"))
+			o.Expect(entry).To(o.HaveKey("caller"))
+		})
+
+		g.It("should reject an unsupported format", func() {
+			o.Expect(SetLogFormat("xml")).To(o.MatchError(o.ContainSubstring("unsupported log format")))
+		})
+	})
 })
 
 func TestLogging(t *testing.T) {
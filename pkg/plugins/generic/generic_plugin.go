@@ -3,8 +3,14 @@ package generic
 import (
 	"errors"
 	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
 	"syscall"
 
+	utilversion "k8s.io/apimachinery/pkg/util/version"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	sriovnetworkv1 "github.com/k8snetworkplumbingwg/sriov-network-operator/api/v1"
@@ -53,9 +59,13 @@ type GenericPlugin struct {
 	DesireState             *sriovnetworkv1.SriovNetworkNodeState
 	DriverStateMap          DriverStateMapType
 	DesiredKernelArgs       KargStateMapType
+	pendingKernelArgs       []string
 	helpers                 helper.HostHelpersInterface
 	skipVFConfiguration     bool
 	skipBridgeConfiguration bool
+	progressUpdater         func(message string)
+	pfInProgressUpdater     func(pciAddress string)
+	secretGetter            func(name string) (map[string][]byte, error)
 }
 
 type Option = func(c *genericPluginOptions)
@@ -76,9 +86,36 @@ func WithSkipBridgeConfiguration() Option {
 	}
 }
 
+// WithProgressUpdater configures generic plugin to report the progress of the PFs configuration
+// during Apply, the message is cleared when Apply completes.
+func WithProgressUpdater(f func(message string)) Option {
+	return func(c *genericPluginOptions) {
+		c.progressUpdater = f
+	}
+}
+
+// WithPfInProgressUpdater configures generic plugin to report the PCI address of the PF being configured
+// during Apply, an empty address is reported once the configuration of the PF completes.
+func WithPfInProgressUpdater(f func(pciAddress string)) Option {
+	return func(c *genericPluginOptions) {
+		c.pfInProgressUpdater = f
+	}
+}
+
+// WithSecretGetter configures generic plugin to read the Secrets referenced by the configuration,
+// e.g. the MACsec keys of the VFs, with the provided function.
+func WithSecretGetter(f func(name string) (map[string][]byte, error)) Option {
+	return func(c *genericPluginOptions) {
+		c.secretGetter = f
+	}
+}
+
 type genericPluginOptions struct {
 	skipVFConfiguration     bool
 	skipBridgeConfiguration bool
+	progressUpdater         func(message string)
+	pfInProgressUpdater     func(pciAddress string)
+	secretGetter            func(name string) (map[string][]byte, error)
 }
 
 const scriptsPath = "bindata/scripts/kargs.sh"
@@ -135,6 +172,9 @@ func NewGenericPlugin(helpers helper.HostHelpersInterface, options ...Option) (p
 		helpers:                 helpers,
 		skipVFConfiguration:     cfg.skipVFConfiguration,
 		skipBridgeConfiguration: cfg.skipBridgeConfiguration,
+		progressUpdater:         cfg.progressUpdater,
+		pfInProgressUpdater:     cfg.pfInProgressUpdater,
+		secretGetter:            cfg.secretGetter,
 	}, nil
 }
 
@@ -151,9 +191,24 @@ func (p *GenericPlugin) Spec() string {
 // OnNodeStateChange Invoked when SriovNetworkNodeState CR is created or updated, return if need drain and/or reboot node
 func (p *GenericPlugin) OnNodeStateChange(new *sriovnetworkv1.SriovNetworkNodeState) (needDrain bool, needReboot bool, err error) {
 	log.Log.Info("generic plugin OnNodeStateChange()")
+	previous := p.DesireState
 	p.DesireState = new
 
-	needDrain = p.needDrainNode(new.Spec, new.Status)
+	if err = p.checkPrimaryInterface(new); err != nil {
+		return false, false, err
+	}
+	if err = p.checkVfioHugepages(new); err != nil {
+		return false, false, err
+	}
+	if err = p.checkSwitchdevKernelVersion(new); err != nil {
+		return false, false, err
+	}
+	if err = checkVfTxRates(new); err != nil {
+		return false, false, err
+	}
+
+	needDrain = p.needDrainNode(previous, new.Spec, new.Status) || p.needToUpdateMacsec(previous, new) ||
+		needToUpdateLinkSettings(previous, new) || needToUpdateVlanFiltering(new)
 	needReboot, err = p.needRebootNode(new)
 	if err != nil {
 		return needDrain, needReboot, err
@@ -172,6 +227,15 @@ func (p *GenericPlugin) CheckStatusChanges(current *sriovnetworkv1.SriovNetworkN
 	for _, iface := range current.Spec.Interfaces {
 		found := false
 		for _, ifaceStatus := range current.Status.Interfaces {
+			// the VFs of externally managed PFs are only checked for the attributes the operator configures on them
+			if iface.PciAddress == ifaceStatus.PciAddress && iface.ExternallyManaged {
+				found = true
+				if sriovnetworkv1.NeedToUpdateVfAttributes(&iface, &ifaceStatus) {
+					log.Log.Info("CheckStatusChanges(): VF attributes changed for interface", "address", iface.PciAddress)
+					return true, nil
+				}
+				break
+			}
 			// TODO: remove the check for ExternallyManaged - https://github.com/k8snetworkplumbingwg/sriov-network-operator/issues/632
 			if iface.PciAddress == ifaceStatus.PciAddress && !iface.ExternallyManaged {
 				found = true
@@ -179,6 +243,18 @@ func (p *GenericPlugin) CheckStatusChanges(current *sriovnetworkv1.SriovNetworkN
 					log.Log.Info("CheckStatusChanges(): status changed for interface", "address", iface.PciAddress)
 					return true, nil
 				}
+				if sriovnetworkv1.NeedToUpdateLinkSpeed(&iface, &ifaceStatus) {
+					log.Log.Info("CheckStatusChanges(): link speed changed for interface", "address", iface.PciAddress)
+					return true, nil
+				}
+				if sriovnetworkv1.NeedToUpdateVlanFiltering(&iface, &ifaceStatus) {
+					log.Log.Info("CheckStatusChanges(): VLAN filtering changed for interface", "address", iface.PciAddress)
+					return true, nil
+				}
+				if sriovnetworkv1.NeedToUpdateVfNetdevs(&iface, &ifaceStatus) {
+					log.Log.Info("CheckStatusChanges(): VF netdevs changed for interface", "address", iface.PciAddress)
+					return true, nil
+				}
 				break
 			}
 		}
@@ -234,8 +310,31 @@ func (p *GenericPlugin) Apply() error {
 		defer exit()
 	}
 
+	if err := p.checkVfDrivers(); err != nil {
+		return err
+	}
+
+	var progress hostTypes.ConfigProgressFunc
+	if p.progressUpdater != nil || p.pfInProgressUpdater != nil {
+		progress = func(configured, total int, pciAddress string) {
+			if p.pfInProgressUpdater != nil {
+				p.pfInProgressUpdater(pciAddress)
+			}
+			// the message only reports the completed configurations
+			if p.progressUpdater != nil && pciAddress == "" {
+				p.progressUpdater(fmt.Sprintf("configured %d/%d PFs", configured, total))
+			}
+		}
+	}
+	if p.progressUpdater != nil {
+		defer p.progressUpdater("")
+	}
+	if p.pfInProgressUpdater != nil {
+		defer p.pfInProgressUpdater("")
+	}
+
 	if err := p.helpers.ConfigSriovInterfaces(p.helpers, p.DesireState.Spec.Interfaces,
-		p.DesireState.Status.Interfaces, p.skipVFConfiguration); err != nil {
+		p.DesireState.Status.Interfaces, p.skipVFConfiguration, progress); err != nil {
 		// Catch the "cannot allocate memory" error and try to use PCI realloc
 		if errors.Is(err, syscall.ENOMEM) {
 			p.enableDesiredKernelArgs(consts.KernelArgPciRealloc)
@@ -243,6 +342,22 @@ func (p *GenericPlugin) Apply() error {
 		return err
 	}
 
+	if err := p.applyLinkSettings(); err != nil {
+		return err
+	}
+
+	if err := p.applyVlanFiltering(); err != nil {
+		return err
+	}
+
+	if err := p.applyVfConfig(); err != nil {
+		return err
+	}
+
+	if err := p.applyVfNetdevSettings(); err != nil {
+		return err
+	}
+
 	if p.shouldConfigureBridges() {
 		if err := p.helpers.ConfigureBridges(p.DesireState.Spec.Bridges, p.DesireState.Status.Bridges); err != nil {
 			return err
@@ -252,6 +367,519 @@ func (p *GenericPlugin) Apply() error {
 	return nil
 }
 
+// checkPrimaryInterface refuses to change the number of VFs of the PFs carrying the default route of the node,
+// unless explicitly allowed, as the node is likely to lose its connectivity while the VFs are created
+func (p *GenericPlugin) checkPrimaryInterface(state *sriovnetworkv1.SriovNetworkNodeState) error {
+	var primaryIfaces []string
+	for _, iface := range state.Spec.Interfaces {
+		if iface.ExternallyManaged || iface.AllowPrimaryInterface {
+			continue
+		}
+		for _, ifaceStatus := range state.Status.Interfaces {
+			if ifaceStatus.PciAddress != iface.PciAddress || ifaceStatus.NumVfs == iface.NumVfs {
+				continue
+			}
+			if primaryIfaces == nil {
+				var err error
+				primaryIfaces, err = p.helpers.GetDefaultRouteInterfaces()
+				if err != nil {
+					return fmt.Errorf("failed to get the interfaces carrying the default route: %v", err)
+				}
+			}
+			if slices.Contains(primaryIfaces, ifaceStatus.Name) {
+				return &plugin.DegradedError{
+					Reason: sriovnetworkv1.ReasonPrimaryInterfaceProtected,
+					Message: fmt.Sprintf("PF %s (%s) carries the default route of the node, refusing to change its number of VFs "+
+						"from %d to %d, set allowPrimaryInterface in the policy to override",
+						ifaceStatus.Name, iface.PciAddress, ifaceStatus.NumVfs, iface.NumVfs),
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// checkVfTxRates makes sure the TX rates of the VFs of the externally managed PFs don't exceed the link speed
+// of the PF, the NIC rejects the rates higher than the link speed
+func checkVfTxRates(state *sriovnetworkv1.SriovNetworkNodeState) error {
+	for _, iface := range state.Spec.Interfaces {
+		if !iface.ExternallyManaged {
+			continue
+		}
+		linkSpeedMbps := iface.LinkSpeedMbps
+		if linkSpeedMbps == 0 {
+			idx := slices.IndexFunc(state.Status.Interfaces, func(ifaceStatus sriovnetworkv1.InterfaceExt) bool {
+				return ifaceStatus.PciAddress == iface.PciAddress
+			})
+			if idx >= 0 {
+				linkSpeedMbps = sriovnetworkv1.GetLinkSpeedMbps(&state.Status.Interfaces[idx])
+			}
+		}
+		for _, group := range iface.VfGroups {
+			if err := sriovnetworkv1.ValidateVfTxRates(group.VfAttributes, linkSpeedMbps); err != nil {
+				return &plugin.DegradedError{
+					Reason:  sriovnetworkv1.ReasonVfTxRateInvalid,
+					Message: fmt.Sprintf("invalid TX rates for the VFs %s of PF %s: %v", group.VfRange, iface.PciAddress, err),
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// checkVfioHugepages makes sure hugepages are allocated on the node when VFs are bound to vfio-pci,
+// the DPDK applications using these VFs fail to start without hugepages
+func (p *GenericPlugin) checkVfioHugepages(state *sriovnetworkv1.SriovNetworkNodeState) error {
+	if !slices.ContainsFunc(state.Spec.Interfaces, func(iface sriovnetworkv1.Interface) bool {
+		return slices.ContainsFunc(iface.VfGroups, func(group sriovnetworkv1.VfGroup) bool {
+			return group.DeviceType == consts.DeviceTypeVfioPci
+		})
+	}) {
+		return nil
+	}
+	hasHugepages, err := p.helpers.HasHugepages()
+	if err != nil {
+		return fmt.Errorf("failed to check if hugepages are allocated: %v", err)
+	}
+	if hasHugepages {
+		return nil
+	}
+	msg := "VFs are bound to vfio-pci but no hugepages are allocated on the node, DPDK applications using them will fail to start, " +
+		"allocate hugepages e.g. with the hugepagesz and hugepages kernel arguments"
+	if vars.VfioHugepagesAdvisory {
+		log.Log.Info("checkVfioHugepages(): WARNING " + msg)
+		return nil
+	}
+	return &plugin.DegradedError{Reason: sriovnetworkv1.ReasonHugepagesMissing, Message: msg}
+}
+
+// checkSwitchdevKernelVersion refuses to configure NICs in switchdev mode on kernels older than vars.SwitchdevMinKernelVersion,
+// the devlink and representor handling fails in obscure ways on these kernels
+func (p *GenericPlugin) checkSwitchdevKernelVersion(state *sriovnetworkv1.SriovNetworkNodeState) error {
+	if vars.SwitchdevMinKernelVersion == "" || !slices.ContainsFunc(state.Spec.Interfaces, func(iface sriovnetworkv1.Interface) bool {
+		return iface.EswitchMode == sriovnetworkv1.ESwithModeSwitchDev
+	}) {
+		return nil
+	}
+	minVersion, err := utilversion.ParseGeneric(vars.SwitchdevMinKernelVersion)
+	if err != nil {
+		return fmt.Errorf("invalid minimum kernel version %q for switchdev: %v", vars.SwitchdevMinKernelVersion, err)
+	}
+	kernelVersion, err := p.helpers.GetKernelVersion()
+	if err != nil {
+		return fmt.Errorf("failed to get the kernel version: %v", err)
+	}
+	currentVersion, err := utilversion.ParseGeneric(kernelVersion)
+	if err != nil {
+		return fmt.Errorf("failed to parse the kernel version %q: %v", kernelVersion, err)
+	}
+	if currentVersion.AtLeast(minVersion) {
+		return nil
+	}
+	return &plugin.DegradedError{
+		Reason: sriovnetworkv1.ReasonKernelTooOld,
+		Message: fmt.Sprintf("switchdev mode requires kernel %s or newer, the node is running kernel %s",
+			vars.SwitchdevMinKernelVersion, kernelVersion),
+	}
+}
+
+// checkVfDrivers makes sure the drivers explicitly requested for the VF groups exist on the host
+func (p *GenericPlugin) checkVfDrivers() error {
+	for _, iface := range p.DesireState.Spec.Interfaces {
+		for _, group := range iface.VfGroups {
+			if group.VfDriver == "" {
+				continue
+			}
+			available, err := p.helpers.IsDriverAvailable(consts.BusPci, group.VfDriver)
+			if err != nil {
+				return fmt.Errorf("failed to check if driver %s requested for VFs of %s is available: %v",
+					group.VfDriver, iface.PciAddress, err)
+			}
+			if !available {
+				return fmt.Errorf("driver %s requested for VFs of %s doesn't exist on the host, make sure the kernel module is loaded",
+					group.VfDriver, iface.PciAddress)
+			}
+		}
+	}
+	return nil
+}
+
+// applyLinkSettings sets the auto-negotiation and the forced speed of the PF links
+func (p *GenericPlugin) applyLinkSettings() error {
+	for _, iface := range p.DesireState.Spec.Interfaces {
+		if iface.LinkAutoNeg == nil && iface.LinkSpeedMbps == 0 {
+			continue
+		}
+		if err := p.helpers.SetNetDevLinkSettings(iface.Name, iface.LinkAutoNeg, iface.LinkSpeedMbps); err != nil {
+			return fmt.Errorf("failed to configure the link of %s: %v", iface.Name, err)
+		}
+	}
+	return nil
+}
+
+// applyVlanFiltering enables or disables the hardware VLAN filtering of the PFs
+func (p *GenericPlugin) applyVlanFiltering() error {
+	for _, iface := range p.DesireState.Spec.Interfaces {
+		if iface.VlanFiltering == nil {
+			continue
+		}
+		if err := p.helpers.SetNetDevVlanFiltering(iface.Name, *iface.VlanFiltering); err != nil {
+			return fmt.Errorf("failed to configure the VLAN filtering of %s: %v", iface.Name, err)
+		}
+	}
+	return nil
+}
+
+// applyVfConfig applies the attributes of the VFs configured through the PF, the VLAN, QoS,
+// trust, spoof checking and TX rates of externally managed PFs and the trust mode of individual VFs.
+// The desired attributes of a VF are merged and applied together so the VF never goes
+// through an intermediate state, e.g. trusted but still spoof checked or the group trust
+// mode before the per VF one.
+func (p *GenericPlugin) applyVfConfig() error {
+	// the VFs are configured in the post phase
+	if p.skipVFConfiguration {
+		return nil
+	}
+	for _, iface := range p.DesireState.Spec.Interfaces {
+		for vfID := 0; vfID < iface.NumVfs; vfID++ {
+			idx := slices.IndexFunc(iface.VfGroups, func(group sriovnetworkv1.VfGroup) bool {
+				return sriovnetworkv1.IndexInRange(vfID, group.VfRange)
+			})
+			if idx < 0 {
+				continue
+			}
+			config := desiredVfConfig(&iface, &iface.VfGroups[idx], vfID)
+			// the TX rates are applied on their own so the rates rejected by the NIC are reported as such
+			rates := hostTypes.VfConfig{MinTxRate: config.MinTxRate, MaxTxRate: config.MaxTxRate}
+			config.MinTxRate, config.MaxTxRate = nil, nil
+			if config != (hostTypes.VfConfig{}) {
+				if err := p.helpers.SetVfConfig(iface.Name, vfID, config); err != nil {
+					return fmt.Errorf("failed to configure VF %d of %s: %v", vfID, iface.Name, err)
+				}
+			}
+			if rates != (hostTypes.VfConfig{}) {
+				if err := p.helpers.SetVfConfig(iface.Name, vfID, rates); err != nil {
+					return &plugin.DegradedError{
+						Reason:  sriovnetworkv1.ReasonVfTxRateInvalid,
+						Message: fmt.Sprintf("the NIC rejected the TX rates of VF %d of %s: %v", vfID, iface.Name, err),
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// desiredVfConfig returns the attributes requested for the VF by its group,
+// the per VF trust mode takes precedence over the trust mode of the VfAttributes
+func desiredVfConfig(iface *sriovnetworkv1.Interface, group *sriovnetworkv1.VfGroup, vfID int) hostTypes.VfConfig {
+	config := hostTypes.VfConfig{}
+	if iface.ExternallyManaged && group.VfAttributes != nil {
+		vlan, trust := group.VfAttributes.Vlan, group.VfAttributes.Trust
+		config.Vlan = &vlan
+		config.VlanQoS = group.VfAttributes.VlanQoS
+		config.VlanProto = strings.ToLower(group.VfAttributes.VlanProto)
+		config.Trust = &trust
+		config.SpoofChk = group.VfAttributes.SpoofChk
+		config.MinTxRate = group.VfAttributes.MinTxRate
+		config.MaxTxRate = group.VfAttributes.MaxTxRate
+	}
+	if trust, ok := group.VfTrust[strconv.Itoa(vfID)]; ok {
+		config.Trust = &trust
+	}
+	return config
+}
+
+// vfNetdevSettingFunc applies a setting of the VF group on the netdev of one of its VFs
+type vfNetdevSettingFunc func(iface *sriovnetworkv1.Interface, group *sriovnetworkv1.VfGroup,
+	pf *sriovnetworkv1.InterfaceExt, vf *sriovnetworkv1.VirtualFunction) error
+
+// applyVfNetdevSettings applies the settings of the VF groups on the netdevs of the VFs, the VFs may have been
+// created by the configuration so the VF netdevs are discovered once for all the settings. The promiscuous mode
+// is applied after the trust mode of the VFs.
+func (p *GenericPlugin) applyVfNetdevSettings() error {
+	if !slices.ContainsFunc(p.DesireState.Spec.Interfaces, func(iface sriovnetworkv1.Interface) bool {
+		return slices.ContainsFunc(iface.VfGroups, hasVfNetdevSettings)
+	}) {
+		return nil
+	}
+	ifaceStatuses, err := p.helpers.DiscoverSriovDevices(p.helpers)
+	if err != nil {
+		return err
+	}
+	for _, apply := range []vfNetdevSettingFunc{
+		p.applyVfMtu, p.applyVfSysctls, p.applyVfRss, p.applyVfPromisc, p.applyVfFilters, p.applyVfMacsec,
+	} {
+		if err := forEachVfNetdev(p.DesireState.Spec.Interfaces, ifaceStatuses, apply); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// hasVfNetdevSettings returns true if the VF group has settings applied on the netdevs of its VFs
+func hasVfNetdevSettings(group sriovnetworkv1.VfGroup) bool {
+	return group.VfMtu > 0 || len(group.VfSysctls) > 0 || group.VfRss != nil ||
+		group.Promisc != nil || group.VfFilters != nil || group.Macsec != nil
+}
+
+// forEachVfNetdev calls apply for each VF netdev of the VF groups of the interfaces,
+// the VFs bound to userspace drivers have no netdev and are skipped
+func forEachVfNetdev(interfaces sriovnetworkv1.Interfaces, ifaceStatuses []sriovnetworkv1.InterfaceExt,
+	apply vfNetdevSettingFunc) error {
+	for i := range interfaces {
+		idx := slices.IndexFunc(ifaceStatuses, func(status sriovnetworkv1.InterfaceExt) bool {
+			return status.PciAddress == interfaces[i].PciAddress
+		})
+		if idx < 0 {
+			continue
+		}
+		pf := &ifaceStatuses[idx]
+		for j := range interfaces[i].VfGroups {
+			group := &interfaces[i].VfGroups[j]
+			for k := range pf.VFs {
+				vf := &pf.VFs[k]
+				if vf.Name == "" || !sriovnetworkv1.IndexInRange(vf.VfID, group.VfRange) {
+					continue
+				}
+				if err := apply(&interfaces[i], group, pf, vf); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// applyVfMtu sets the MTU requested for the VF group on the VF netdev, the VF MTU can't exceed the MTU of the PF
+func (p *GenericPlugin) applyVfMtu(iface *sriovnetworkv1.Interface, group *sriovnetworkv1.VfGroup,
+	pf *sriovnetworkv1.InterfaceExt, vf *sriovnetworkv1.VirtualFunction) error {
+	if group.VfMtu == 0 {
+		return nil
+	}
+	if group.VfMtu > pf.Mtu {
+		return fmt.Errorf("VF MTU %d for VF group %s is higher than the MTU %d of the PF %s",
+			group.VfMtu, group.ResourceName, pf.Mtu, iface.PciAddress)
+	}
+	if vf.Mtu == group.VfMtu {
+		return nil
+	}
+	if err := p.helpers.SetNetdevMTU(vf.PciAddress, group.VfMtu); err != nil {
+		return fmt.Errorf("failed to set MTU %d on VF %s: %v", group.VfMtu, vf.Name, err)
+	}
+	return nil
+}
+
+// applyVfSysctls sets the sysctls requested for the VF group on the VF netdev
+func (p *GenericPlugin) applyVfSysctls(_ *sriovnetworkv1.Interface, group *sriovnetworkv1.VfGroup,
+	_ *sriovnetworkv1.InterfaceExt, vf *sriovnetworkv1.VirtualFunction) error {
+	names := make([]string, 0, len(group.VfSysctls))
+	for name := range group.VfSysctls {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		if err := p.helpers.SetNetDevSysctl(vf.Name, name, group.VfSysctls[name]); err != nil {
+			return fmt.Errorf("failed to set sysctl %s on VF %s: %v", name, vf.Name, err)
+		}
+	}
+	return nil
+}
+
+// applyVfRss sets the RSS configuration requested for the VF group on the VF netdev,
+// the VFs whose driver doesn't support it are skipped by the host helper with a warning
+func (p *GenericPlugin) applyVfRss(_ *sriovnetworkv1.Interface, group *sriovnetworkv1.VfGroup,
+	_ *sriovnetworkv1.InterfaceExt, vf *sriovnetworkv1.VirtualFunction) error {
+	if group.VfRss == nil {
+		return nil
+	}
+	if err := p.helpers.SetNetDevRss(vf.Name, group.VfRss.HashKey, group.VfRss.IndirectionSize); err != nil {
+		return fmt.Errorf("failed to set RSS on VF %s: %v", vf.Name, err)
+	}
+	return nil
+}
+
+// applyVfPromisc sets the promiscuous mode requested for the VF group on the VF netdev,
+// the promiscuous mode is only enabled on the VFs in trust mode as the PF ignores it for the other VFs
+func (p *GenericPlugin) applyVfPromisc(iface *sriovnetworkv1.Interface, group *sriovnetworkv1.VfGroup,
+	_ *sriovnetworkv1.InterfaceExt, vf *sriovnetworkv1.VirtualFunction) error {
+	// the trust mode of the VFs is configured in the post phase
+	if p.skipVFConfiguration || group.Promisc == nil {
+		return nil
+	}
+	trust := desiredVfConfig(iface, group, vf.VfID).Trust
+	if *group.Promisc && (trust == nil || !*trust) {
+		log.Log.Info("generic plugin applyVfPromisc(): VF not in trust mode, skip enabling promiscuous mode",
+			"pf", iface.Name, "vf", vf.VfID)
+		return nil
+	}
+	if err := p.helpers.SetNetDevPromisc(vf.Name, *group.Promisc); err != nil {
+		return fmt.Errorf("failed to set promiscuous mode on VF %s: %v", vf.Name, err)
+	}
+	return nil
+}
+
+// applyVfFilters sets the broadcast and multicast filters requested for the VF group on the VF netdev
+func (p *GenericPlugin) applyVfFilters(_ *sriovnetworkv1.Interface, group *sriovnetworkv1.VfGroup,
+	_ *sriovnetworkv1.InterfaceExt, vf *sriovnetworkv1.VirtualFunction) error {
+	if group.VfFilters == nil {
+		return nil
+	}
+	if group.VfFilters.Multicast != nil {
+		if err := p.helpers.SetNetDevMulticast(vf.Name, *group.VfFilters.Multicast); err != nil {
+			return fmt.Errorf("failed to set multicast mode on VF %s: %v", vf.Name, err)
+		}
+	}
+	if group.VfFilters.Broadcast != nil {
+		if err := p.helpers.SetNetDevBroadcastFilter(vf.Name, !*group.VfFilters.Broadcast); err != nil {
+			return fmt.Errorf("failed to set broadcast filter on VF %s: %v", vf.Name, err)
+		}
+	}
+	return nil
+}
+
+// macsecConfig returns the key identifier, the key and the encryption of the MACsec configuration of the VF group,
+// the keys are read from the Secret referenced by the VF group
+func (p *GenericPlugin) macsecConfig(macsec *sriovnetworkv1.VfMacsec) (string, string, bool, error) {
+	secret, err := p.secretGetter(macsec.KeySecretName)
+	if err != nil {
+		return "", "", false, fmt.Errorf("failed to get the MACsec key Secret %s: %v", macsec.KeySecretName, err)
+	}
+	key := strings.TrimSpace(string(secret[consts.MacsecSecretKey]))
+	if key == "" {
+		return "", "", false, fmt.Errorf("MACsec key Secret %s has no %s entry", macsec.KeySecretName, consts.MacsecSecretKey)
+	}
+	keyID := strings.TrimSpace(string(secret[consts.MacsecSecretKeyID]))
+	if keyID == "" {
+		keyID = consts.MacsecDefaultKeyID
+	}
+	return keyID, key, macsec.EncryptionMode != consts.MacsecEncryptionModeIntegrity, nil
+}
+
+// applyVfMacsec configures MACsec on the VF netdev with the keys read from the Secret referenced by the VF group
+func (p *GenericPlugin) applyVfMacsec(_ *sriovnetworkv1.Interface, group *sriovnetworkv1.VfGroup,
+	_ *sriovnetworkv1.InterfaceExt, vf *sriovnetworkv1.VirtualFunction) error {
+	if group.Macsec == nil {
+		return nil
+	}
+	if p.secretGetter == nil {
+		log.Log.Info("generic plugin applyVfMacsec(): Secrets can't be read, skip MACsec configuration", "vf", vf.Name)
+		return nil
+	}
+	keyID, key, encrypt, err := p.macsecConfig(group.Macsec)
+	if err != nil {
+		return err
+	}
+	if err := p.helpers.SetNetDevMacsec(vf.Name, keyID, key, encrypt); err != nil {
+		return fmt.Errorf("failed to configure MACsec on VF %s: %v", vf.Name, err)
+	}
+	return nil
+}
+
+// needToUpdateMacsec returns true if the MACsec configuration of the VF groups changed since the previous
+// desired state or if the keys of the Secrets differ from the ones configured on the VFs, the MACsec interfaces
+// are recreated which disrupts the traffic of the VFs
+func (p *GenericPlugin) needToUpdateMacsec(previous, desired *sriovnetworkv1.SriovNetworkNodeState) bool {
+	if previous == nil {
+		return false
+	}
+	macsecConfigs := func(state *sriovnetworkv1.SriovNetworkNodeState) map[string]sriovnetworkv1.VfMacsec {
+		configs := map[string]sriovnetworkv1.VfMacsec{}
+		for _, iface := range state.Spec.Interfaces {
+			for _, group := range iface.VfGroups {
+				if group.Macsec != nil {
+					configs[iface.PciAddress+"/"+group.VfRange] = *group.Macsec
+				}
+			}
+		}
+		return configs
+	}
+	if !maps.Equal(macsecConfigs(previous), macsecConfigs(desired)) {
+		log.Log.V(2).Info("generic plugin needToUpdateMacsec(): MACsec configuration of the VFs changed")
+		return true
+	}
+	if p.secretGetter == nil {
+		return false
+	}
+	outdated := false
+	_ = forEachVfNetdev(desired.Spec.Interfaces, desired.Status.Interfaces, func(_ *sriovnetworkv1.Interface,
+		group *sriovnetworkv1.VfGroup, _ *sriovnetworkv1.InterfaceExt, vf *sriovnetworkv1.VirtualFunction) error {
+		if outdated || group.Macsec == nil {
+			return nil
+		}
+		keyID, key, encrypt, err := p.macsecConfig(group.Macsec)
+		if err != nil {
+			// the error is reported when the configuration is applied
+			return nil
+		}
+		outdated, err = p.helpers.IsNetDevMacsecOutdated(vf.Name, keyID, key, encrypt)
+		if err != nil {
+			log.Log.Error(err, "generic plugin needToUpdateMacsec(): failed to check the MACsec configuration", "vf", vf.Name)
+		}
+		return nil
+	})
+	if outdated {
+		log.Log.V(2).Info("generic plugin needToUpdateMacsec(): MACsec keys of the VFs changed")
+	}
+	return outdated
+}
+
+// needToUpdateLinkSettings returns true if the auto-negotiation or the forced speed of a PF link changed since
+// the previous desired state or if the current link speed drifted from the forced one, the link renegotiation
+// disrupts the traffic of the VFs
+func needToUpdateLinkSettings(previous, desired *sriovnetworkv1.SriovNetworkNodeState) bool {
+	type linkSettings struct {
+		autoNegSet bool
+		autoNeg    bool
+		speedMbps  int
+	}
+	if previous != nil {
+		settings := func(state *sriovnetworkv1.SriovNetworkNodeState) map[string]linkSettings {
+			configs := map[string]linkSettings{}
+			for _, iface := range state.Spec.Interfaces {
+				if iface.LinkAutoNeg == nil && iface.LinkSpeedMbps == 0 {
+					continue
+				}
+				config := linkSettings{speedMbps: iface.LinkSpeedMbps}
+				if iface.LinkAutoNeg != nil {
+					config.autoNegSet, config.autoNeg = true, *iface.LinkAutoNeg
+				}
+				configs[iface.PciAddress] = config
+			}
+			return configs
+		}
+		if !maps.Equal(settings(previous), settings(desired)) {
+			log.Log.V(2).Info("generic plugin needToUpdateLinkSettings(): link settings of the PFs changed")
+			return true
+		}
+	}
+	for _, iface := range desired.Spec.Interfaces {
+		for _, ifaceStatus := range desired.Status.Interfaces {
+			if iface.PciAddress == ifaceStatus.PciAddress && sriovnetworkv1.NeedToUpdateLinkSpeed(&iface, &ifaceStatus) {
+				log.Log.V(2).Info("generic plugin needToUpdateLinkSettings(): link speed needs to be updated",
+					"address", iface.PciAddress, "current", ifaceStatus.LinkSpeed, "desired", iface.LinkSpeedMbps)
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// needToUpdateVlanFiltering returns true if the hardware VLAN filtering of a PF doesn't match the desired one,
+// toggling the filtering drops the traffic of the VFs while the NIC reprograms its filters
+func needToUpdateVlanFiltering(desired *sriovnetworkv1.SriovNetworkNodeState) bool {
+	for _, iface := range desired.Spec.Interfaces {
+		for _, ifaceStatus := range desired.Status.Interfaces {
+			if iface.PciAddress == ifaceStatus.PciAddress && sriovnetworkv1.NeedToUpdateVlanFiltering(&iface, &ifaceStatus) {
+				log.Log.V(2).Info("generic plugin needToUpdateVlanFiltering(): VLAN filtering needs to be updated",
+					"address", iface.PciAddress, "desired", *iface.VlanFiltering)
+				return true
+			}
+		}
+	}
+	return false
+}
+
 func needDriverCheckDeviceType(state *sriovnetworkv1.SriovNetworkNodeState, driverState *DriverState) bool {
 	for _, iface := range state.Spec.Interfaces {
 		for i := range iface.VfGroups {
@@ -330,6 +958,7 @@ func (p *GenericPlugin) syncDesiredKernelArgs() (bool, error) {
 	}
 
 	needReboot := false
+	pendingKernelArgs := []string{}
 	for karg, kargState := range p.DesiredKernelArgs {
 		if kargState {
 			err = editKernelArg(p.helpers, "add", karg)
@@ -340,6 +969,7 @@ func (p *GenericPlugin) syncDesiredKernelArgs() (bool, error) {
 
 			if !p.helpers.IsKernelArgsSet(kargs, karg) {
 				needReboot = true
+				pendingKernelArgs = append(pendingKernelArgs, karg)
 			}
 		} else {
 			err = editKernelArg(p.helpers, "remove", karg)
@@ -350,16 +980,26 @@ func (p *GenericPlugin) syncDesiredKernelArgs() (bool, error) {
 
 			if p.helpers.IsKernelArgsSet(kargs, karg) {
 				needReboot = true
+				pendingKernelArgs = append(pendingKernelArgs, "-"+karg)
 			}
 		}
 	}
+	slices.Sort(pendingKernelArgs)
+	p.pendingKernelArgs = pendingKernelArgs
 	return needReboot, nil
 }
 
-func (p *GenericPlugin) needDrainNode(desired sriovnetworkv1.SriovNetworkNodeStateSpec, current sriovnetworkv1.SriovNetworkNodeStateStatus) bool {
+// PendingKernelArgs returns the kernel arguments computed by the last OnNodeStateChange which differ from
+// the running kernel, the arguments to remove are prefixed with "-"
+func (p *GenericPlugin) PendingKernelArgs() []string {
+	return p.pendingKernelArgs
+}
+
+func (p *GenericPlugin) needDrainNode(previous *sriovnetworkv1.SriovNetworkNodeState,
+	desired sriovnetworkv1.SriovNetworkNodeStateSpec, current sriovnetworkv1.SriovNetworkNodeStateStatus) bool {
 	log.Log.V(2).Info("generic plugin needDrainNode()", "current", current, "desired", desired)
 
-	if p.needToUpdateVFs(desired, current) {
+	if p.needToUpdateVFs(previous, desired, current) {
 		return true
 	}
 
@@ -372,7 +1012,71 @@ func (p *GenericPlugin) needDrainNode(desired sriovnetworkv1.SriovNetworkNodeSta
 	return false
 }
 
-func (p *GenericPlugin) needToUpdateVFs(desired sriovnetworkv1.SriovNetworkNodeStateSpec, current sriovnetworkv1.SriovNetworkNodeStateStatus) bool {
+// needDrainForPfLinkState returns false if the only pending change on the interface
+// is bringing an explicitly requested PF link up, which doesn't disrupt workloads.
+// The auto state brings the PF link up too but keeps draining: without an explicit
+// request a PF found down is handled as an unexpected state of the device, e.g. after
+// a reset of the NIC, which is reconfigured with the workloads drained.
+func needDrainForPfLinkState(iface *sriovnetworkv1.Interface, ifaceStatus sriovnetworkv1.InterfaceExt) bool {
+	if sriovnetworkv1.GetPfLinkStateFromSpec(iface) != sriovnetworkv1.PfLinkStateUp ||
+		!sriovnetworkv1.NeedToUpdatePfLinkState(iface, &ifaceStatus) {
+		return true
+	}
+	ifaceStatus.LinkAdminState = consts.LinkAdminStateUp
+	return sriovnetworkv1.NeedToUpdateSriov(iface, &ifaceStatus)
+}
+
+// needDrainForVfAttributes returns false if the interface differs from its previous desired configuration only by
+// attributes applied in place on the existing VFs, e.g. VLAN, trust, spoofchk or VF MTU, and is otherwise configured
+func needDrainForVfAttributes(previous *sriovnetworkv1.SriovNetworkNodeState, iface *sriovnetworkv1.Interface,
+	ifaceStatus sriovnetworkv1.InterfaceExt) bool {
+	if previous == nil {
+		return true
+	}
+	idx := slices.IndexFunc(previous.Spec.Interfaces, func(prev sriovnetworkv1.Interface) bool {
+		return prev.PciAddress == iface.PciAddress
+	})
+	if idx < 0 {
+		return true
+	}
+	prevIface, desiredIface := previous.Spec.Interfaces[idx].DeepCopy(), iface.DeepCopy()
+	if len(prevIface.VfGroups) != len(desiredIface.VfGroups) {
+		return true
+	}
+	// the traffic of the VFs is interrupted while the VLAN protocol changes
+	for j := range desiredIface.VfGroups {
+		if vfVlanProto(&prevIface.VfGroups[j]) != vfVlanProto(&desiredIface.VfGroups[j]) {
+			return true
+		}
+	}
+	for _, i := range []*sriovnetworkv1.Interface{prevIface, desiredIface} {
+		for j := range i.VfGroups {
+			i.VfGroups[j].VfAttributes = nil
+			i.VfGroups[j].VfTrust = nil
+			i.VfGroups[j].VfMtu = 0
+			i.VfGroups[j].VfSysctls = nil
+			i.VfGroups[j].Promisc = nil
+			i.VfGroups[j].VfRss = nil
+		}
+	}
+	if !reflect.DeepEqual(prevIface, desiredIface) {
+		return true
+	}
+	// the VF attributes of the externally managed PFs are applied in place, the rest must be already configured
+	desiredIface.ExternallyManaged = false
+	return sriovnetworkv1.NeedToUpdateSriovExceptInPlaceVfConfig(desiredIface, &ifaceStatus)
+}
+
+// vfVlanProto returns the lower case VLAN protocol requested for the VFs of the group, 802.1q if not set
+func vfVlanProto(group *sriovnetworkv1.VfGroup) string {
+	if group.VfAttributes == nil || group.VfAttributes.VlanProto == "" {
+		return "802.1q"
+	}
+	return strings.ToLower(group.VfAttributes.VlanProto)
+}
+
+func (p *GenericPlugin) needToUpdateVFs(previous *sriovnetworkv1.SriovNetworkNodeState,
+	desired sriovnetworkv1.SriovNetworkNodeStateSpec, current sriovnetworkv1.SriovNetworkNodeStateStatus) bool {
 	for _, ifaceStatus := range current.Interfaces {
 		configured := false
 		for _, iface := range desired.Interfaces {
@@ -383,7 +1087,17 @@ func (p *GenericPlugin) needToUpdateVFs(desired sriovnetworkv1.SriovNetworkNodeS
 						"address", iface.PciAddress)
 					break
 				}
-				if sriovnetworkv1.NeedToUpdateSriov(&iface, &ifaceStatus) {
+				if sriovnetworkv1.NeedToUpdateSriovExceptInPlaceVfConfig(&iface, &ifaceStatus) {
+					if !needDrainForPfLinkState(&iface, ifaceStatus) {
+						log.Log.V(2).Info("generic plugin needToUpdateVFs(): no need drain, PF link only needs to be set up",
+							"address", iface.PciAddress)
+						continue
+					}
+					if !needDrainForVfAttributes(previous, &iface, ifaceStatus) {
+						log.Log.V(2).Info("generic plugin needToUpdateVFs(): no need drain, only VF attributes need to be updated",
+							"address", iface.PciAddress)
+						continue
+					}
 					log.Log.V(2).Info("generic plugin needToUpdateVFs(): need drain, for PCI address request update",
 						"address", iface.PciAddress)
 					return true
@@ -458,22 +1172,31 @@ func (p *GenericPlugin) addVfioDesiredKernelArg(state *sriovnetworkv1.SriovNetwo
 }
 
 func (p *GenericPlugin) configRdmaKernelArg(state *sriovnetworkv1.SriovNetworkNodeState) error {
-	if state.Spec.System.RdmaMode == "" {
+	if vars.SkipRdma {
+		log.Log.V(2).Info("generic-plugin configRdmaKernelArg(): RDMA checks are skipped")
+		return nil
+	}
+	rdmaMode := sriovnetworkv1.ResolveRdmaMode(state.Spec.System.RdmaMode, state.Status.Interfaces)
+	if rdmaMode != state.Spec.System.RdmaMode {
+		log.Log.V(2).Info("generic-plugin configRdmaKernelArg(): resolved rdma mode",
+			"mode", state.Spec.System.RdmaMode, "resolved", rdmaMode)
+	}
+	if rdmaMode == "" {
 		p.disableDesiredKernelArgs(consts.KernelArgRdmaExclusive)
 		p.disableDesiredKernelArgs(consts.KernelArgRdmaShared)
-	} else if state.Spec.System.RdmaMode == "shared" {
+	} else if rdmaMode == "shared" {
 		p.enableDesiredKernelArgs(consts.KernelArgRdmaShared)
 		p.disableDesiredKernelArgs(consts.KernelArgRdmaExclusive)
-	} else if state.Spec.System.RdmaMode == "exclusive" {
+	} else if rdmaMode == "exclusive" {
 		p.enableDesiredKernelArgs(consts.KernelArgRdmaExclusive)
 		p.disableDesiredKernelArgs(consts.KernelArgRdmaShared)
 	} else {
-		err := fmt.Errorf("unexpected rdma mode: %s", state.Spec.System.RdmaMode)
+		err := fmt.Errorf("unexpected rdma mode: %s", rdmaMode)
 		log.Log.Error(err, "generic-plugin configRdmaKernelArg(): failed to configure kernel arguments for rdma")
 		return err
 	}
 
-	return p.helpers.SetRDMASubsystem(state.Spec.System.RdmaMode)
+	return p.helpers.SetRDMASubsystem(rdmaMode)
 }
 
 func (p *GenericPlugin) needRebootNode(state *sriovnetworkv1.SriovNetworkNodeState) (bool, error) {
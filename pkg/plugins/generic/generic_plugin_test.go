@@ -1,6 +1,7 @@
 package generic
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/golang/mock/gomock"
@@ -27,6 +28,7 @@ var _ = Describe("Generic plugin", func() {
 		err           error
 		ctrl          *gomock.Controller
 		hostHelper    *mock_helper.MockHostHelpersInterface
+		kernelVersion string
 	)
 
 	BeforeEach(func() {
@@ -44,6 +46,8 @@ var _ = Describe("Generic plugin", func() {
 		hostHelper.EXPECT().IsKernelArgsSet("", consts.KernelArgIommuPassthrough).Return(false).AnyTimes()
 
 		hostHelper.EXPECT().RunCommand(gomock.Any(), gomock.Any()).Return("", "", nil).AnyTimes()
+		kernelVersion = "6.1.0"
+		hostHelper.EXPECT().GetKernelVersion().DoAndReturn(func() (string, error) { return kernelVersion, nil }).AnyTimes()
 
 		genericPlugin, err = NewGenericPlugin(hostHelper)
 		Expect(err).ToNot(HaveOccurred())
@@ -101,6 +105,132 @@ var _ = Describe("Generic plugin", func() {
 			Expect(needDrain).To(BeFalse())
 		})
 
+		Context("VF attributes", func() {
+			var networkNodeState *sriovnetworkv1.SriovNetworkNodeState
+
+			BeforeEach(func() {
+				networkNodeState = &sriovnetworkv1.SriovNetworkNodeState{
+					Spec: sriovnetworkv1.SriovNetworkNodeStateSpec{
+						Interfaces: sriovnetworkv1.Interfaces{{
+							PciAddress:        "0000:00:00.0",
+							NumVfs:            1,
+							ExternallyManaged: true,
+							VfGroups: []sriovnetworkv1.VfGroup{{
+								DeviceType:   "netdevice",
+								PolicyName:   "policy-1",
+								ResourceName: "resource-1",
+								VfRange:      "0-0",
+								VfAttributes: &sriovnetworkv1.VfAttributes{Vlan: 10},
+							}}}},
+					},
+					Status: sriovnetworkv1.SriovNetworkNodeStateStatus{
+						Interfaces: sriovnetworkv1.InterfaceExts{{
+							PciAddress:     "0000:00:00.0",
+							NumVfs:         1,
+							TotalVfs:       1,
+							DeviceID:       "1015",
+							Vendor:         "15b3",
+							Name:           "sriovif1",
+							Mtu:            1500,
+							Mac:            "0c:42:a1:55:ee:46",
+							Driver:         "mlx5_core",
+							EswitchMode:    "legacy",
+							LinkSpeed:      "25000 Mb/s",
+							LinkType:       "ETH",
+							LinkAdminState: "up",
+							VFs: []sriovnetworkv1.VirtualFunction{{
+								PciAddress: "0000:00:00.1",
+								DeviceID:   "1016",
+								Vendor:     "15b3",
+								VfID:       0,
+								Name:       "sriovif1v0",
+								Mtu:        1500,
+								Mac:        "8e:d6:2c:62:87:1b",
+								Driver:     "mlx5_core",
+							}},
+						}},
+					},
+				}
+				_, _, err := genericPlugin.OnNodeStateChange(networkNodeState)
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("should not drain when only the VLAN of the VFs changes", func() {
+				networkNodeState = networkNodeState.DeepCopy()
+				networkNodeState.Spec.Interfaces[0].VfGroups[0].VfAttributes.Vlan = 20
+
+				needDrain, needReboot, err := genericPlugin.OnNodeStateChange(networkNodeState)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(needReboot).To(BeFalse())
+				Expect(needDrain).To(BeFalse())
+			})
+
+			It("should accept TX rates within the link speed of the PF", func() {
+				networkNodeState = networkNodeState.DeepCopy()
+				minTxRate, maxTxRate := 1000, 25000
+				networkNodeState.Spec.Interfaces[0].VfGroups[0].VfAttributes.MinTxRate = &minTxRate
+				networkNodeState.Spec.Interfaces[0].VfGroups[0].VfAttributes.MaxTxRate = &maxTxRate
+
+				_, _, err := genericPlugin.OnNodeStateChange(networkNodeState)
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("should report the node as degraded when the TX rate is higher than the link speed of the PF", func() {
+				networkNodeState = networkNodeState.DeepCopy()
+				maxTxRate := 40000
+				networkNodeState.Spec.Interfaces[0].VfGroups[0].VfAttributes.MaxTxRate = &maxTxRate
+
+				_, _, err := genericPlugin.OnNodeStateChange(networkNodeState)
+				var degradedErr *plugin.DegradedError
+				Expect(errors.As(err, &degradedErr)).To(BeTrue())
+				Expect(degradedErr.Reason).To(Equal(sriovnetworkv1.ReasonVfTxRateInvalid))
+				Expect(degradedErr.Message).To(ContainSubstring("maxTxRate(40000) is higher than the link speed of the PF(25000 Mb/s)"))
+			})
+
+			It("should report the node as degraded when the minimum TX rate is higher than the maximum one", func() {
+				networkNodeState = networkNodeState.DeepCopy()
+				minTxRate, maxTxRate := 2000, 1000
+				networkNodeState.Spec.Interfaces[0].VfGroups[0].VfAttributes.MinTxRate = &minTxRate
+				networkNodeState.Spec.Interfaces[0].VfGroups[0].VfAttributes.MaxTxRate = &maxTxRate
+
+				_, _, err := genericPlugin.OnNodeStateChange(networkNodeState)
+				Expect(err).To(MatchError(ContainSubstring("minTxRate(2000) is higher than maxTxRate(1000)")))
+			})
+
+			It("should drain when the VLAN protocol of the VFs changes", func() {
+				networkNodeState = networkNodeState.DeepCopy()
+				networkNodeState.Spec.Interfaces[0].VfGroups[0].VfAttributes.VlanProto = "802.1ad"
+
+				needDrain, needReboot, err := genericPlugin.OnNodeStateChange(networkNodeState)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(needReboot).To(BeFalse())
+				Expect(needDrain).To(BeTrue())
+			})
+
+			It("should not drain when the VLAN protocol is set to the default one", func() {
+				networkNodeState = networkNodeState.DeepCopy()
+				networkNodeState.Spec.Interfaces[0].VfGroups[0].VfAttributes.VlanProto = "802.1Q"
+
+				needDrain, needReboot, err := genericPlugin.OnNodeStateChange(networkNodeState)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(needReboot).To(BeFalse())
+				Expect(needDrain).To(BeFalse())
+			})
+
+			It("should drain when the VLAN changes together with the number of VFs", func() {
+				networkNodeState = networkNodeState.DeepCopy()
+				networkNodeState.Spec.Interfaces[0].NumVfs = 2
+				networkNodeState.Spec.Interfaces[0].VfGroups[0].VfRange = "0-1"
+				networkNodeState.Spec.Interfaces[0].VfGroups[0].VfAttributes.Vlan = 20
+
+				hostHelper.EXPECT().GetDefaultRouteInterfaces().Return([]string{"eno1"}, nil).AnyTimes()
+				needDrain, needReboot, err := genericPlugin.OnNodeStateChange(networkNodeState)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(needReboot).To(BeFalse())
+				Expect(needDrain).To(BeTrue())
+			})
+		})
+
 		It("should drain because MTU value has changed on PF", func() {
 			networkNodeState := &sriovnetworkv1.SriovNetworkNodeState{
 				Spec: sriovnetworkv1.SriovNetworkNodeStateSpec{
@@ -195,12 +325,199 @@ var _ = Describe("Generic plugin", func() {
 				},
 			}
 
+			hostHelper.EXPECT().GetDefaultRouteInterfaces().Return([]string{"eno1"}, nil)
 			needDrain, needReboot, err := genericPlugin.OnNodeStateChange(networkNodeState)
 			Expect(err).ToNot(HaveOccurred())
 			Expect(needReboot).To(BeFalse())
 			Expect(needDrain).To(BeTrue())
 		})
 
+		Context("primary interface", func() {
+			var networkNodeState *sriovnetworkv1.SriovNetworkNodeState
+
+			BeforeEach(func() {
+				networkNodeState = &sriovnetworkv1.SriovNetworkNodeState{
+					Spec: sriovnetworkv1.SriovNetworkNodeStateSpec{
+						Interfaces: sriovnetworkv1.Interfaces{{
+							PciAddress: "0000:00:00.0",
+							NumVfs:     2,
+							VfGroups: []sriovnetworkv1.VfGroup{{
+								DeviceType:   "netdevice",
+								PolicyName:   "policy-1",
+								ResourceName: "resource-1",
+								VfRange:      "0-1",
+							}}}},
+					},
+					Status: sriovnetworkv1.SriovNetworkNodeStateStatus{
+						Interfaces: sriovnetworkv1.InterfaceExts{{
+							PciAddress:     "0000:00:00.0",
+							NumVfs:         1,
+							TotalVfs:       8,
+							DeviceID:       "1015",
+							Vendor:         "15b3",
+							Name:           "eno1",
+							Mtu:            1500,
+							Driver:         "mlx5_core",
+							LinkType:       "ETH",
+							LinkAdminState: "up",
+						}},
+					},
+				}
+				hostHelper.EXPECT().GetDefaultRouteInterfaces().Return([]string{"eno1", "bond0"}, nil).AnyTimes()
+			})
+
+			It("should refuse to change the number of VFs of the PF carrying the default route", func() {
+				_, _, err := genericPlugin.OnNodeStateChange(networkNodeState)
+				var degradedErr *plugin.DegradedError
+				Expect(errors.As(err, &degradedErr)).To(BeTrue())
+				Expect(degradedErr.Reason).To(Equal(sriovnetworkv1.ReasonPrimaryInterfaceProtected))
+				Expect(err.Error()).To(ContainSubstring("eno1"))
+			})
+
+			It("should change the number of VFs of the PF carrying the default route when allowed", func() {
+				networkNodeState.Spec.Interfaces[0].AllowPrimaryInterface = true
+				needDrain, _, err := genericPlugin.OnNodeStateChange(networkNodeState)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(needDrain).To(BeTrue())
+			})
+
+			It("should not check the PF carrying the default route when the number of VFs doesn't change", func() {
+				networkNodeState.Status.Interfaces[0].NumVfs = 2
+				_, _, err := genericPlugin.OnNodeStateChange(networkNodeState)
+				Expect(err).ToNot(HaveOccurred())
+			})
+		})
+
+		Context("vfio-pci hugepages", func() {
+			var networkNodeState *sriovnetworkv1.SriovNetworkNodeState
+
+			BeforeEach(func() {
+				networkNodeState = &sriovnetworkv1.SriovNetworkNodeState{
+					Spec: sriovnetworkv1.SriovNetworkNodeStateSpec{
+						Interfaces: sriovnetworkv1.Interfaces{{
+							PciAddress: "0000:00:00.0",
+							NumVfs:     2,
+							VfGroups: []sriovnetworkv1.VfGroup{{
+								DeviceType:   consts.DeviceTypeVfioPci,
+								PolicyName:   "policy-1",
+								ResourceName: "resource-1",
+								VfRange:      "0-1",
+							}}}},
+					},
+					Status: sriovnetworkv1.SriovNetworkNodeStateStatus{
+						Interfaces: sriovnetworkv1.InterfaceExts{{
+							PciAddress: "0000:00:00.0",
+							NumVfs:     2,
+							TotalVfs:   8,
+							DeviceID:   "158b",
+							Vendor:     "8086",
+							Name:       "ens1",
+							Driver:     "i40e",
+						}},
+					},
+				}
+				hostHelper.EXPECT().GetCPUVendor().Return(hostTypes.CPUVendorIntel, nil).AnyTimes()
+			})
+
+			It("should configure the VFs when hugepages are allocated", func() {
+				hostHelper.EXPECT().HasHugepages().Return(true, nil)
+				_, _, err := genericPlugin.OnNodeStateChange(networkNodeState)
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("should report the node as degraded when no hugepages are allocated", func() {
+				hostHelper.EXPECT().HasHugepages().Return(false, nil)
+				_, _, err := genericPlugin.OnNodeStateChange(networkNodeState)
+				var degradedErr *plugin.DegradedError
+				Expect(errors.As(err, &degradedErr)).To(BeTrue())
+				Expect(degradedErr.Reason).To(Equal(sriovnetworkv1.ReasonHugepagesMissing))
+			})
+
+			It("should only warn when no hugepages are allocated and the check is advisory", func() {
+				advisory := vars.VfioHugepagesAdvisory
+				vars.VfioHugepagesAdvisory = true
+				DeferCleanup(func() { vars.VfioHugepagesAdvisory = advisory })
+
+				hostHelper.EXPECT().HasHugepages().Return(false, nil)
+				_, _, err := genericPlugin.OnNodeStateChange(networkNodeState)
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("should not check hugepages when no VFs are bound to vfio-pci", func() {
+				networkNodeState.Spec.Interfaces[0].VfGroups[0].DeviceType = consts.DeviceTypeNetDevice
+				hostHelper.EXPECT().HasHugepages().Times(0)
+				_, _, err := genericPlugin.OnNodeStateChange(networkNodeState)
+				Expect(err).ToNot(HaveOccurred())
+			})
+		})
+
+		Context("switchdev kernel version", func() {
+			var networkNodeState *sriovnetworkv1.SriovNetworkNodeState
+
+			BeforeEach(func() {
+				networkNodeState = &sriovnetworkv1.SriovNetworkNodeState{
+					Spec: sriovnetworkv1.SriovNetworkNodeStateSpec{
+						Interfaces: sriovnetworkv1.Interfaces{{
+							PciAddress:  "0000:00:00.0",
+							NumVfs:      2,
+							EswitchMode: sriovnetworkv1.ESwithModeSwitchDev,
+							VfGroups: []sriovnetworkv1.VfGroup{{
+								DeviceType:   consts.DeviceTypeNetDevice,
+								PolicyName:   "policy-1",
+								ResourceName: "resource-1",
+								VfRange:      "0-1",
+							}}}},
+					},
+					Status: sriovnetworkv1.SriovNetworkNodeStateStatus{
+						Interfaces: sriovnetworkv1.InterfaceExts{{
+							PciAddress:  "0000:00:00.0",
+							NumVfs:      2,
+							TotalVfs:    8,
+							DeviceID:    "1015",
+							Vendor:      "15b3",
+							Name:        "ens1",
+							Driver:      "mlx5_core",
+							EswitchMode: sriovnetworkv1.ESwithModeSwitchDev,
+						}},
+					},
+				}
+				minKernelVersion := vars.SwitchdevMinKernelVersion
+				vars.SwitchdevMinKernelVersion = "5.8"
+				DeferCleanup(func() { vars.SwitchdevMinKernelVersion = minKernelVersion })
+			})
+
+			It("should configure switchdev on a recent kernel", func() {
+				kernelVersion = "5.14.0-427.el9.x86_64"
+				_, _, err := genericPlugin.OnNodeStateChange(networkNodeState)
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("should report the node as degraded when the kernel is too old for switchdev", func() {
+				kernelVersion = "4.18.0-553.el8.x86_64"
+				_, _, err := genericPlugin.OnNodeStateChange(networkNodeState)
+				var degradedErr *plugin.DegradedError
+				Expect(errors.As(err, &degradedErr)).To(BeTrue())
+				Expect(degradedErr.Reason).To(Equal(sriovnetworkv1.ReasonKernelTooOld))
+				Expect(degradedErr.Message).To(ContainSubstring("requires kernel 5.8 or newer"))
+				Expect(degradedErr.Message).To(ContainSubstring("4.18.0-553.el8.x86_64"))
+			})
+
+			It("should not check the kernel version when the check is disabled", func() {
+				kernelVersion = "4.18.0-553.el8.x86_64"
+				vars.SwitchdevMinKernelVersion = ""
+				_, _, err := genericPlugin.OnNodeStateChange(networkNodeState)
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("should not check the kernel version for NICs in legacy mode", func() {
+				kernelVersion = "4.18.0-553.el8.x86_64"
+				networkNodeState.Spec.Interfaces[0].EswitchMode = sriovnetworkv1.ESwithModeLegacy
+				networkNodeState.Status.Interfaces[0].EswitchMode = sriovnetworkv1.ESwithModeLegacy
+				_, _, err := genericPlugin.OnNodeStateChange(networkNodeState)
+				Expect(err).ToNot(HaveOccurred())
+			})
+		})
+
 		It("should drain because PF link is down", func() {
 			networkNodeState := &sriovnetworkv1.SriovNetworkNodeState{
 				Spec: sriovnetworkv1.SriovNetworkNodeStateSpec{
@@ -299,6 +616,427 @@ var _ = Describe("Generic plugin", func() {
 			Expect(needDrain).To(BeFalse())
 		})
 
+		Context("PF link state", func() {
+			newLinkState := func(desired, current string) *sriovnetworkv1.SriovNetworkNodeState {
+				return &sriovnetworkv1.SriovNetworkNodeState{
+					Spec: sriovnetworkv1.SriovNetworkNodeStateSpec{
+						Interfaces: sriovnetworkv1.Interfaces{{
+							PciAddress:  "0000:00:00.0",
+							NumVfs:      1,
+							Mtu:         1500,
+							PfLinkState: desired,
+							VfGroups: []sriovnetworkv1.VfGroup{{
+								DeviceType:   "netdevice",
+								PolicyName:   "policy-1",
+								ResourceName: "resource-1",
+								VfRange:      "0-1",
+								Mtu:          1500,
+							}}}},
+					},
+					Status: sriovnetworkv1.SriovNetworkNodeStateStatus{
+						Interfaces: sriovnetworkv1.InterfaceExts{{
+							PciAddress:     "0000:00:00.0",
+							NumVfs:         1,
+							TotalVfs:       1,
+							DeviceID:       "1015",
+							Vendor:         "15b3",
+							Name:           "sriovif1",
+							Mtu:            1500,
+							Mac:            "0c:42:a1:55:ee:46",
+							Driver:         "mlx5_core",
+							EswitchMode:    "legacy",
+							LinkSpeed:      "25000 Mb/s",
+							LinkType:       "ETH",
+							LinkAdminState: current,
+							VFs: []sriovnetworkv1.VirtualFunction{{
+								PciAddress: "0000:00:00.1",
+								DeviceID:   "1016",
+								Vendor:     "15b3",
+								VfID:       0,
+								Name:       "sriovif1v0",
+								Mtu:        1500,
+								Driver:     "mlx5_core",
+							}},
+						}},
+					},
+				}
+			}
+
+			It("should drain when the PF link is set from up to down", func() {
+				networkNodeState := newLinkState(sriovnetworkv1.PfLinkStateDown, consts.LinkAdminStateUp)
+				needDrain, needReboot, err := genericPlugin.OnNodeStateChange(networkNodeState)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(needReboot).To(BeFalse())
+				Expect(needDrain).To(BeTrue())
+
+				changed, err := genericPlugin.CheckStatusChanges(networkNodeState)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(changed).To(BeTrue())
+			})
+
+			It("should not drain when the PF link is set from down to up", func() {
+				networkNodeState := newLinkState(sriovnetworkv1.PfLinkStateUp, consts.LinkAdminStateDown)
+				needDrain, needReboot, err := genericPlugin.OnNodeStateChange(networkNodeState)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(needReboot).To(BeFalse())
+				Expect(needDrain).To(BeFalse())
+
+				changed, err := genericPlugin.CheckStatusChanges(networkNodeState)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(changed).To(BeTrue())
+			})
+
+			It("should drain when the PF link is down and the state is auto", func() {
+				networkNodeState := newLinkState(sriovnetworkv1.PfLinkStateAuto, consts.LinkAdminStateDown)
+				needDrain, needReboot, err := genericPlugin.OnNodeStateChange(networkNodeState)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(needReboot).To(BeFalse())
+				Expect(needDrain).To(BeTrue())
+			})
+
+			It("should not detect changes when the PF link is already down", func() {
+				networkNodeState := newLinkState(sriovnetworkv1.PfLinkStateDown, consts.LinkAdminStateDown)
+				needDrain, needReboot, err := genericPlugin.OnNodeStateChange(networkNodeState)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(needReboot).To(BeFalse())
+				Expect(needDrain).To(BeFalse())
+
+				changed, err := genericPlugin.CheckStatusChanges(networkNodeState)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(changed).To(BeFalse())
+			})
+
+			It("should drain when the PF link is set up together with other changes", func() {
+				networkNodeState := newLinkState(sriovnetworkv1.PfLinkStateUp, consts.LinkAdminStateDown)
+				networkNodeState.Spec.Interfaces[0].Mtu = 9000
+				needDrain, _, err := genericPlugin.OnNodeStateChange(networkNodeState)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(needDrain).To(BeTrue())
+			})
+		})
+
+		Context("VF attributes of externally managed PFs", func() {
+			newExternallyManagedState := func(vlan int) *sriovnetworkv1.SriovNetworkNodeState {
+				return &sriovnetworkv1.SriovNetworkNodeState{
+					Spec: sriovnetworkv1.SriovNetworkNodeStateSpec{
+						Interfaces: sriovnetworkv1.Interfaces{{
+							PciAddress:        "0000:00:00.0",
+							Name:              "sriovif1",
+							NumVfs:            1,
+							ExternallyManaged: true,
+							VfGroups: []sriovnetworkv1.VfGroup{{
+								VfRange:      "0-0",
+								ResourceName: "resource",
+								DeviceType:   consts.DeviceTypeNetDevice,
+								VfAttributes: &sriovnetworkv1.VfAttributes{Vlan: 100, Trust: true},
+							}},
+						}},
+					},
+					Status: sriovnetworkv1.SriovNetworkNodeStateStatus{
+						Interfaces: sriovnetworkv1.InterfaceExts{{
+							PciAddress: "0000:00:00.0",
+							Name:       "sriovif1",
+							NumVfs:     1,
+							TotalVfs:   1,
+							VFs: []sriovnetworkv1.VirtualFunction{{
+								PciAddress: "0000:00:00.1",
+								VfID:       0,
+								Name:       "sriovif1v0",
+								Driver:     "mlx5_core",
+								Vlan:       vlan,
+								Trust:      true,
+							}},
+						}},
+					},
+				}
+			}
+
+			It("should detect the VLAN of a VF changed on the host", func() {
+				changed, err := genericPlugin.CheckStatusChanges(newExternallyManagedState(200))
+				Expect(err).ToNot(HaveOccurred())
+				Expect(changed).To(BeTrue())
+			})
+
+			It("should not detect changes when the VF attributes are applied", func() {
+				changed, err := genericPlugin.CheckStatusChanges(newExternallyManagedState(100))
+				Expect(err).ToNot(HaveOccurred())
+				Expect(changed).To(BeFalse())
+			})
+		})
+
+		Context("PF link settings", func() {
+			newLinkSettings := func(autoNeg *bool, speedMbps int, currentSpeed string) *sriovnetworkv1.SriovNetworkNodeState {
+				return &sriovnetworkv1.SriovNetworkNodeState{
+					Spec: sriovnetworkv1.SriovNetworkNodeStateSpec{
+						Interfaces: sriovnetworkv1.Interfaces{{
+							PciAddress:    "0000:00:00.0",
+							NumVfs:        1,
+							Mtu:           1500,
+							LinkAutoNeg:   autoNeg,
+							LinkSpeedMbps: speedMbps,
+							VfGroups: []sriovnetworkv1.VfGroup{{
+								DeviceType:   "netdevice",
+								PolicyName:   "policy-1",
+								ResourceName: "resource-1",
+								VfRange:      "0-1",
+								Mtu:          1500,
+							}}}},
+					},
+					Status: sriovnetworkv1.SriovNetworkNodeStateStatus{
+						Interfaces: sriovnetworkv1.InterfaceExts{{
+							PciAddress:  "0000:00:00.0",
+							NumVfs:      1,
+							TotalVfs:    1,
+							DeviceID:    "1015",
+							Vendor:      "15b3",
+							Name:        "sriovif1",
+							Mtu:         1500,
+							Mac:         "0c:42:a1:55:ee:46",
+							Driver:      "mlx5_core",
+							EswitchMode: "legacy",
+							LinkSpeed:   currentSpeed,
+							LinkType:    "ETH",
+							VFs: []sriovnetworkv1.VirtualFunction{{
+								PciAddress: "0000:00:00.1",
+								DeviceID:   "1016",
+								Vendor:     "15b3",
+								VfID:       0,
+								Name:       "sriovif1v0",
+								Mtu:        1500,
+								Driver:     "mlx5_core",
+							}},
+						}},
+					},
+				}
+			}
+
+			It("should drain when the link speed differs from the forced one", func() {
+				networkNodeState := newLinkSettings(nil, 10000, "25000 Mb/s")
+				needDrain, needReboot, err := genericPlugin.OnNodeStateChange(networkNodeState)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(needReboot).To(BeFalse())
+				Expect(needDrain).To(BeTrue())
+
+				changed, err := genericPlugin.CheckStatusChanges(networkNodeState)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(changed).To(BeTrue())
+			})
+
+			It("should not detect changes when the link already runs at the forced speed", func() {
+				networkNodeState := newLinkSettings(nil, 25000, "25000 Mb/s")
+				needDrain, _, err := genericPlugin.OnNodeStateChange(networkNodeState)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(needDrain).To(BeFalse())
+
+				changed, err := genericPlugin.CheckStatusChanges(networkNodeState)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(changed).To(BeFalse())
+			})
+
+			It("should not detect changes when the link speed is unknown", func() {
+				networkNodeState := newLinkSettings(nil, 10000, "-1 Mb/s")
+				needDrain, _, err := genericPlugin.OnNodeStateChange(networkNodeState)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(needDrain).To(BeFalse())
+
+				changed, err := genericPlugin.CheckStatusChanges(networkNodeState)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(changed).To(BeFalse())
+			})
+
+			It("should drain when the auto-negotiation changes", func() {
+				disabled, enabled := false, true
+				_, _, err := genericPlugin.OnNodeStateChange(newLinkSettings(&disabled, 0, "25000 Mb/s"))
+				Expect(err).ToNot(HaveOccurred())
+
+				needDrain, _, err := genericPlugin.OnNodeStateChange(newLinkSettings(&enabled, 0, "25000 Mb/s"))
+				Expect(err).ToNot(HaveOccurred())
+				Expect(needDrain).To(BeTrue())
+
+				needDrain, _, err = genericPlugin.OnNodeStateChange(newLinkSettings(&enabled, 0, "25000 Mb/s"))
+				Expect(err).ToNot(HaveOccurred())
+				Expect(needDrain).To(BeFalse())
+			})
+		})
+
+		Context("VLAN filtering", func() {
+			newVlanFiltering := func(desired, current *bool) *sriovnetworkv1.SriovNetworkNodeState {
+				return &sriovnetworkv1.SriovNetworkNodeState{
+					Spec: sriovnetworkv1.SriovNetworkNodeStateSpec{
+						Interfaces: sriovnetworkv1.Interfaces{{
+							PciAddress:    "0000:00:00.0",
+							NumVfs:        1,
+							Mtu:           1500,
+							VlanFiltering: desired,
+							VfGroups: []sriovnetworkv1.VfGroup{{
+								DeviceType:   "netdevice",
+								PolicyName:   "policy-1",
+								ResourceName: "resource-1",
+								VfRange:      "0-1",
+								Mtu:          1500,
+							}}}},
+					},
+					Status: sriovnetworkv1.SriovNetworkNodeStateStatus{
+						Interfaces: sriovnetworkv1.InterfaceExts{{
+							PciAddress:    "0000:00:00.0",
+							NumVfs:        1,
+							TotalVfs:      1,
+							DeviceID:      "1015",
+							Vendor:        "15b3",
+							Name:          "sriovif1",
+							Mtu:           1500,
+							Mac:           "0c:42:a1:55:ee:46",
+							Driver:        "mlx5_core",
+							EswitchMode:   "legacy",
+							LinkSpeed:     "25000 Mb/s",
+							LinkType:      "ETH",
+							VlanFiltering: current,
+							VFs: []sriovnetworkv1.VirtualFunction{{
+								PciAddress: "0000:00:00.1",
+								DeviceID:   "1016",
+								Vendor:     "15b3",
+								VfID:       0,
+								Name:       "sriovif1v0",
+								Mtu:        1500,
+								Driver:     "mlx5_core",
+							}},
+						}},
+					},
+				}
+			}
+
+			It("should drain when the VLAN filtering needs to be enabled", func() {
+				enabled, disabled := true, false
+				networkNodeState := newVlanFiltering(&enabled, &disabled)
+				needDrain, needReboot, err := genericPlugin.OnNodeStateChange(networkNodeState)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(needReboot).To(BeFalse())
+				Expect(needDrain).To(BeTrue())
+
+				changed, err := genericPlugin.CheckStatusChanges(networkNodeState)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(changed).To(BeTrue())
+			})
+
+			It("should drain when the VLAN filtering needs to be disabled", func() {
+				enabled, disabled := true, false
+				networkNodeState := newVlanFiltering(&disabled, &enabled)
+				needDrain, _, err := genericPlugin.OnNodeStateChange(networkNodeState)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(needDrain).To(BeTrue())
+			})
+
+			It("should not detect changes when the VLAN filtering is already set", func() {
+				enabled := true
+				networkNodeState := newVlanFiltering(&enabled, &enabled)
+				needDrain, _, err := genericPlugin.OnNodeStateChange(networkNodeState)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(needDrain).To(BeFalse())
+
+				changed, err := genericPlugin.CheckStatusChanges(networkNodeState)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(changed).To(BeFalse())
+			})
+
+			It("should not detect changes when the NIC doesn't report the VLAN filtering", func() {
+				enabled := true
+				needDrain, _, err := genericPlugin.OnNodeStateChange(newVlanFiltering(&enabled, nil))
+				Expect(err).ToNot(HaveOccurred())
+				Expect(needDrain).To(BeFalse())
+			})
+		})
+
+		Context("eSwitch inline mode", func() {
+			newInlineModeState := func(desired, current string) *sriovnetworkv1.SriovNetworkNodeState {
+				return &sriovnetworkv1.SriovNetworkNodeState{
+					Spec: sriovnetworkv1.SriovNetworkNodeStateSpec{
+						Interfaces: sriovnetworkv1.Interfaces{{
+							PciAddress:        "0000:00:00.0",
+							NumVfs:            1,
+							Mtu:               1500,
+							EswitchMode:       "switchdev",
+							EswitchInlineMode: desired,
+							VfGroups: []sriovnetworkv1.VfGroup{{
+								DeviceType:   "netdevice",
+								PolicyName:   "policy-1",
+								ResourceName: "resource-1",
+								VfRange:      "0-1",
+								Mtu:          1500,
+							}}}},
+					},
+					Status: sriovnetworkv1.SriovNetworkNodeStateStatus{
+						Interfaces: sriovnetworkv1.InterfaceExts{{
+							PciAddress:        "0000:00:00.0",
+							NumVfs:            1,
+							TotalVfs:          1,
+							DeviceID:          "1015",
+							Vendor:            "15b3",
+							Name:              "sriovif1",
+							Mtu:               1500,
+							Mac:               "0c:42:a1:55:ee:46",
+							Driver:            "mlx5_core",
+							EswitchMode:       "switchdev",
+							EswitchInlineMode: current,
+							LinkSpeed:         "25000 Mb/s",
+							LinkType:          "ETH",
+							LinkAdminState:    consts.LinkAdminStateUp,
+							VFs: []sriovnetworkv1.VirtualFunction{{
+								PciAddress: "0000:00:00.1",
+								DeviceID:   "1016",
+								Vendor:     "15b3",
+								VfID:       0,
+								Name:       "sriovif1v0",
+								Mtu:        1500,
+								Driver:     "mlx5_core",
+							}},
+						}},
+					},
+				}
+			}
+
+			DescribeTable("should drain when the inline mode changes",
+				func(desired, current string) {
+					networkNodeState := newInlineModeState(desired, current)
+					needDrain, needReboot, err := genericPlugin.OnNodeStateChange(networkNodeState)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(needReboot).To(BeFalse())
+					Expect(needDrain).To(BeTrue())
+
+					changed, err := genericPlugin.CheckStatusChanges(networkNodeState)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(changed).To(BeTrue())
+				},
+				Entry("none", "none", "link"),
+				Entry("link", "link", "none"),
+				Entry("network", "network", "link"),
+				Entry("transport", "transport", "network"),
+			)
+
+			DescribeTable("should not drain when the inline mode is already set",
+				func(mode string) {
+					networkNodeState := newInlineModeState(mode, mode)
+					needDrain, _, err := genericPlugin.OnNodeStateChange(networkNodeState)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(needDrain).To(BeFalse())
+
+					changed, err := genericPlugin.CheckStatusChanges(networkNodeState)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(changed).To(BeFalse())
+				},
+				Entry("none", "none"),
+				Entry("link", "link"),
+				Entry("network", "network"),
+				Entry("transport", "transport"),
+			)
+
+			It("should not drain when the inline mode is not requested", func() {
+				networkNodeState := newInlineModeState("", "network")
+				needDrain, _, err := genericPlugin.OnNodeStateChange(networkNodeState)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(needDrain).To(BeFalse())
+			})
+		})
+
 		It("should drain because driver has changed on VF of type netdevice", func() {
 			networkNodeState := &sriovnetworkv1.SriovNetworkNodeState{
 				Spec: sriovnetworkv1.SriovNetworkNodeStateSpec{
@@ -929,6 +1667,18 @@ var _ = Describe("Generic plugin", func() {
 				Expect(changed).To(BeTrue())
 			})
 
+			It("should report the kernel args applied by the next reboot", func() {
+				hostHelper.EXPECT().GetCPUVendor().Return(hostTypes.CPUVendorIntel, nil)
+				hostHelper.EXPECT().RunCommand("/bin/sh", gomock.Any(), gomock.Any(), gomock.Any()).Return("", "", nil).AnyTimes()
+
+				genericPlugin.(*GenericPlugin).addVfioDesiredKernelArg(vfioNetworkNodeState)
+				needReboot, err := genericPlugin.(*GenericPlugin).syncDesiredKernelArgs()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(needReboot).To(BeTrue())
+				Expect(genericPlugin.(*GenericPlugin).PendingKernelArgs()).To(Equal(
+					[]string{consts.KernelArgIntelIommu, consts.KernelArgIommuPt}))
+			})
+
 			It("should set the correct kernel args on AMD CPUs", func() {
 				hostHelper.EXPECT().GetCPUVendor().Return(hostTypes.CPUVendorAMD, nil)
 				genericPlugin.(*GenericPlugin).addVfioDesiredKernelArg(vfioNetworkNodeState)
@@ -966,6 +1716,41 @@ var _ = Describe("Generic plugin", func() {
 				Expect(err).ToNot(HaveOccurred())
 				Expect(changed).To(BeTrue())
 			})
+			It("should resolve rdma auto mode to exclusive on nodes with Mellanox NICs", func() {
+				hostHelper.EXPECT().SetRDMASubsystem(consts.RdmaSubsystemModeExclusive).Return(nil)
+				autoState := &sriovnetworkv1.SriovNetworkNodeState{
+					Spec: sriovnetworkv1.SriovNetworkNodeStateSpec{System: sriovnetworkv1.System{
+						RdmaMode: consts.RdmaSubsystemModeAuto,
+					}},
+					Status: sriovnetworkv1.SriovNetworkNodeStateStatus{
+						Interfaces: sriovnetworkv1.InterfaceExts{
+							{PciAddress: "0000:00:00.0", Vendor: "8086"},
+							{PciAddress: "0000:d8:00.0", Vendor: "15b3"},
+						},
+					},
+				}
+				err := genericPlugin.(*GenericPlugin).configRdmaKernelArg(autoState)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(genericPlugin.(*GenericPlugin).DesiredKernelArgs[consts.KernelArgRdmaShared]).To(BeFalse())
+				Expect(genericPlugin.(*GenericPlugin).DesiredKernelArgs[consts.KernelArgRdmaExclusive]).To(BeTrue())
+			})
+			It("should resolve rdma auto mode to shared on nodes without Mellanox NICs", func() {
+				hostHelper.EXPECT().SetRDMASubsystem(consts.RdmaSubsystemModeShared).Return(nil)
+				autoState := &sriovnetworkv1.SriovNetworkNodeState{
+					Spec: sriovnetworkv1.SriovNetworkNodeStateSpec{System: sriovnetworkv1.System{
+						RdmaMode: consts.RdmaSubsystemModeAuto,
+					}},
+					Status: sriovnetworkv1.SriovNetworkNodeStateStatus{
+						Interfaces: sriovnetworkv1.InterfaceExts{{PciAddress: "0000:00:00.0", Vendor: "8086"}},
+					},
+				}
+				err := genericPlugin.(*GenericPlugin).configRdmaKernelArg(autoState)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(genericPlugin.(*GenericPlugin).DesiredKernelArgs[consts.KernelArgRdmaShared]).To(BeTrue())
+				Expect(genericPlugin.(*GenericPlugin).DesiredKernelArgs[consts.KernelArgRdmaExclusive]).To(BeFalse())
+			})
 			It("should not configure RDMA kernel args", func() {
 				hostHelper.EXPECT().SetRDMASubsystem("").Return(nil)
 				rdmaState.Spec.System = sriovnetworkv1.System{}
@@ -1354,4 +2139,857 @@ var _ = Describe("Generic plugin", func() {
 		Expect(err).ToNot(HaveOccurred())
 		Expect(updated).To(BeTrue())
 	})
+
+	Context("Apply", func() {
+		It("should report the configuration progress", func() {
+			messages := []string{}
+			genericPlugin, err = NewGenericPlugin(hostHelper, WithProgressUpdater(func(message string) {
+				messages = append(messages, message)
+			}))
+			Expect(err).ToNot(HaveOccurred())
+
+			genericPlugin.(*GenericPlugin).DesireState = &sriovnetworkv1.SriovNetworkNodeState{
+				Spec: sriovnetworkv1.SriovNetworkNodeStateSpec{
+					Interfaces: sriovnetworkv1.Interfaces{
+						{PciAddress: "0000:00:00.0", NumVfs: 1},
+						{PciAddress: "0000:00:00.1", NumVfs: 1},
+					},
+				},
+			}
+
+			hostHelper.EXPECT().Chroot(consts.Host).Return(func() error { return nil }, nil)
+			hostHelper.EXPECT().ConfigSriovInterfaces(gomock.Any(), gomock.Any(), gomock.Any(), false, gomock.Any()).
+				DoAndReturn(func(_, _, _, _ interface{}, progress hostTypes.ConfigProgressFunc) error {
+					progress(0, 2, "0000:00:00.0")
+					progress(1, 2, "")
+					Expect(messages).To(Equal([]string{"configured 1/2 PFs"}))
+					progress(1, 2, "0000:00:00.1")
+					progress(2, 2, "")
+					return nil
+				})
+			hostHelper.EXPECT().ConfigureBridges(gomock.Any(), gomock.Any()).Return(nil)
+
+			Expect(genericPlugin.Apply()).To(Succeed())
+			Expect(messages).To(Equal([]string{"configured 1/2 PFs", "configured 2/2 PFs", ""}))
+		})
+
+		It("should fail when the requested VF driver doesn't exist on the host", func() {
+			genericPlugin.(*GenericPlugin).DesireState = &sriovnetworkv1.SriovNetworkNodeState{
+				Spec: sriovnetworkv1.SriovNetworkNodeStateSpec{
+					Interfaces: sriovnetworkv1.Interfaces{{
+						PciAddress: "0000:00:00.0",
+						NumVfs:     1,
+						VfGroups: []sriovnetworkv1.VfGroup{{
+							VfRange:      "0-0",
+							ResourceName: "resource",
+							DeviceType:   consts.DeviceTypeNetDevice,
+							VfDriver:     "custom_driver",
+						}},
+					}},
+				},
+			}
+
+			hostHelper.EXPECT().Chroot(consts.Host).Return(func() error { return nil }, nil)
+			hostHelper.EXPECT().IsDriverAvailable(consts.BusPci, "custom_driver").Return(false, nil)
+
+			err := genericPlugin.Apply()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("driver custom_driver requested for VFs of 0000:00:00.0 doesn't exist on the host"))
+		})
+
+		It("should configure VFs with the requested VF driver", func() {
+			genericPlugin.(*GenericPlugin).DesireState = &sriovnetworkv1.SriovNetworkNodeState{
+				Spec: sriovnetworkv1.SriovNetworkNodeStateSpec{
+					Interfaces: sriovnetworkv1.Interfaces{{
+						PciAddress: "0000:00:00.0",
+						NumVfs:     1,
+						VfGroups: []sriovnetworkv1.VfGroup{{
+							VfRange:      "0-0",
+							ResourceName: "resource",
+							DeviceType:   consts.DeviceTypeNetDevice,
+							VfDriver:     "custom_driver",
+						}},
+					}},
+				},
+			}
+
+			hostHelper.EXPECT().Chroot(consts.Host).Return(func() error { return nil }, nil)
+			hostHelper.EXPECT().IsDriverAvailable(consts.BusPci, "custom_driver").Return(true, nil)
+			hostHelper.EXPECT().ConfigSriovInterfaces(gomock.Any(), gomock.Any(), gomock.Any(), false, gomock.Any()).
+				DoAndReturn(func(_ interface{}, interfaces []sriovnetworkv1.Interface, _, _, _ interface{}) error {
+					Expect(interfaces[0].VfGroups[0].VfDriver).To(Equal("custom_driver"))
+					return nil
+				})
+			hostHelper.EXPECT().ConfigureBridges(gomock.Any(), gomock.Any()).Return(nil)
+
+			Expect(genericPlugin.Apply()).To(Succeed())
+		})
+
+		It("should set the requested VF MTU on each VF netdev", func() {
+			genericPlugin.(*GenericPlugin).DesireState = &sriovnetworkv1.SriovNetworkNodeState{
+				Spec: sriovnetworkv1.SriovNetworkNodeStateSpec{
+					Interfaces: sriovnetworkv1.Interfaces{{
+						PciAddress: "0000:00:00.0",
+						NumVfs:     3,
+						Mtu:        9000,
+						VfGroups: []sriovnetworkv1.VfGroup{
+							{
+								VfRange:      "0-1",
+								ResourceName: "resource",
+								DeviceType:   consts.DeviceTypeNetDevice,
+								Mtu:          9000,
+								VfMtu:        1500,
+							},
+							{
+								VfRange:      "2-2",
+								ResourceName: "resource_default",
+								DeviceType:   consts.DeviceTypeNetDevice,
+								Mtu:          9000,
+							},
+						},
+					}},
+				},
+			}
+
+			hostHelper.EXPECT().Chroot(consts.Host).Return(func() error { return nil }, nil)
+			hostHelper.EXPECT().ConfigSriovInterfaces(gomock.Any(), gomock.Any(), gomock.Any(), false, gomock.Any()).Return(nil)
+			hostHelper.EXPECT().DiscoverSriovDevices(hostHelper).Return([]sriovnetworkv1.InterfaceExt{{
+				PciAddress: "0000:00:00.0",
+				NumVfs:     3,
+				Mtu:        9000,
+				VFs: []sriovnetworkv1.VirtualFunction{
+					{PciAddress: "0000:00:00.1", VfID: 0, Name: "eth0v0", Mtu: 9000},
+					// the MTU is already set
+					{PciAddress: "0000:00:00.2", VfID: 1, Name: "eth0v1", Mtu: 1500},
+					{PciAddress: "0000:00:00.3", VfID: 2, Name: "eth0v2", Mtu: 9000},
+				},
+			}}, nil)
+			hostHelper.EXPECT().SetNetdevMTU("0000:00:00.1", 1500).Return(nil)
+			hostHelper.EXPECT().ConfigureBridges(gomock.Any(), gomock.Any()).Return(nil)
+
+			Expect(genericPlugin.Apply()).To(Succeed())
+		})
+
+		It("should fail when the VF MTU is higher than the MTU of the PF", func() {
+			genericPlugin.(*GenericPlugin).DesireState = &sriovnetworkv1.SriovNetworkNodeState{
+				Spec: sriovnetworkv1.SriovNetworkNodeStateSpec{
+					Interfaces: sriovnetworkv1.Interfaces{{
+						PciAddress: "0000:00:00.0",
+						NumVfs:     1,
+						VfGroups: []sriovnetworkv1.VfGroup{{
+							VfRange:      "0-0",
+							ResourceName: "resource",
+							DeviceType:   consts.DeviceTypeNetDevice,
+							VfMtu:        9000,
+						}},
+					}},
+				},
+			}
+
+			hostHelper.EXPECT().Chroot(consts.Host).Return(func() error { return nil }, nil)
+			hostHelper.EXPECT().ConfigSriovInterfaces(gomock.Any(), gomock.Any(), gomock.Any(), false, gomock.Any()).Return(nil)
+			hostHelper.EXPECT().DiscoverSriovDevices(hostHelper).Return([]sriovnetworkv1.InterfaceExt{{
+				PciAddress: "0000:00:00.0",
+				NumVfs:     1,
+				Mtu:        1500,
+				VFs: []sriovnetworkv1.VirtualFunction{
+					{PciAddress: "0000:00:00.1", VfID: 0, Name: "eth0v0", Mtu: 1500},
+				},
+			}}, nil)
+
+			Expect(genericPlugin.Apply()).To(MatchError(ContainSubstring("is higher than the MTU 1500 of the PF")))
+		})
+
+		It("should set the requested sysctls on each VF netdev", func() {
+			genericPlugin.(*GenericPlugin).DesireState = &sriovnetworkv1.SriovNetworkNodeState{
+				Spec: sriovnetworkv1.SriovNetworkNodeStateSpec{
+					Interfaces: sriovnetworkv1.Interfaces{{
+						PciAddress: "0000:00:00.0",
+						NumVfs:     3,
+						VfGroups: []sriovnetworkv1.VfGroup{
+							{
+								VfRange:      "0-1",
+								ResourceName: "resource",
+								DeviceType:   consts.DeviceTypeNetDevice,
+								VfSysctls: map[string]string{
+									"net.ipv4.conf.<if>.rp_filter":    "2",
+									"net.ipv6.conf.<if>.disable_ipv6": "1",
+								},
+							},
+							{
+								VfRange:      "2-2",
+								ResourceName: "resource_default",
+								DeviceType:   consts.DeviceTypeNetDevice,
+							},
+						},
+					}},
+				},
+			}
+
+			hostHelper.EXPECT().Chroot(consts.Host).Return(func() error { return nil }, nil)
+			hostHelper.EXPECT().ConfigSriovInterfaces(gomock.Any(), gomock.Any(), gomock.Any(), false, gomock.Any()).Return(nil)
+			hostHelper.EXPECT().DiscoverSriovDevices(hostHelper).Return([]sriovnetworkv1.InterfaceExt{{
+				PciAddress: "0000:00:00.0",
+				NumVfs:     3,
+				VFs: []sriovnetworkv1.VirtualFunction{
+					{PciAddress: "0000:00:00.1", VfID: 0, Name: "eth0v0"},
+					{PciAddress: "0000:00:00.2", VfID: 1, Name: "eth0v1"},
+					{PciAddress: "0000:00:00.3", VfID: 2, Name: "eth0v2"},
+				},
+			}}, nil)
+			for _, vf := range []string{"eth0v0", "eth0v1"} {
+				hostHelper.EXPECT().SetNetDevSysctl(vf, "net.ipv4.conf.<if>.rp_filter", "2").Return(nil)
+				hostHelper.EXPECT().SetNetDevSysctl(vf, "net.ipv6.conf.<if>.disable_ipv6", "1").Return(nil)
+			}
+			hostHelper.EXPECT().ConfigureBridges(gomock.Any(), gomock.Any()).Return(nil)
+
+			Expect(genericPlugin.Apply()).To(Succeed())
+		})
+
+		It("should set the requested RSS configuration on each VF netdev", func() {
+			genericPlugin.(*GenericPlugin).DesireState = &sriovnetworkv1.SriovNetworkNodeState{
+				Spec: sriovnetworkv1.SriovNetworkNodeStateSpec{
+					Interfaces: sriovnetworkv1.Interfaces{{
+						PciAddress: "0000:00:00.0",
+						NumVfs:     3,
+						VfGroups: []sriovnetworkv1.VfGroup{
+							{
+								VfRange:      "0-1",
+								ResourceName: "resource",
+								DeviceType:   consts.DeviceTypeNetDevice,
+								NumVfQueues:  4,
+								VfRss:        &sriovnetworkv1.VfRss{HashKey: "6d:5a:56:da", IndirectionSize: 4},
+							},
+							{
+								VfRange:      "2-2",
+								ResourceName: "resource_default",
+								DeviceType:   consts.DeviceTypeNetDevice,
+							},
+						},
+					}},
+				},
+			}
+
+			hostHelper.EXPECT().Chroot(consts.Host).Return(func() error { return nil }, nil)
+			hostHelper.EXPECT().ConfigSriovInterfaces(gomock.Any(), gomock.Any(), gomock.Any(), false, gomock.Any()).Return(nil)
+			hostHelper.EXPECT().DiscoverSriovDevices(hostHelper).Return([]sriovnetworkv1.InterfaceExt{{
+				PciAddress: "0000:00:00.0",
+				NumVfs:     3,
+				VFs: []sriovnetworkv1.VirtualFunction{
+					{PciAddress: "0000:00:00.1", VfID: 0, Name: "eth0v0"},
+					// VF without netdev, e.g. bound to a userspace driver
+					{PciAddress: "0000:00:00.2", VfID: 1},
+					{PciAddress: "0000:00:00.3", VfID: 2, Name: "eth0v2"},
+				},
+			}}, nil)
+			hostHelper.EXPECT().SetNetDevRss("eth0v0", "6d:5a:56:da", 4).Return(nil)
+			hostHelper.EXPECT().ConfigureBridges(gomock.Any(), gomock.Any()).Return(nil)
+
+			Expect(genericPlugin.Apply()).To(Succeed())
+		})
+
+		It("should fail when the RSS configuration of a VF netdev can't be set", func() {
+			genericPlugin.(*GenericPlugin).DesireState = &sriovnetworkv1.SriovNetworkNodeState{
+				Spec: sriovnetworkv1.SriovNetworkNodeStateSpec{
+					Interfaces: sriovnetworkv1.Interfaces{{
+						PciAddress: "0000:00:00.0",
+						NumVfs:     1,
+						VfGroups: []sriovnetworkv1.VfGroup{{
+							VfRange:      "0-0",
+							ResourceName: "resource",
+							DeviceType:   consts.DeviceTypeNetDevice,
+							VfRss:        &sriovnetworkv1.VfRss{IndirectionSize: 2},
+						}},
+					}},
+				},
+			}
+
+			hostHelper.EXPECT().Chroot(consts.Host).Return(func() error { return nil }, nil)
+			hostHelper.EXPECT().ConfigSriovInterfaces(gomock.Any(), gomock.Any(), gomock.Any(), false, gomock.Any()).Return(nil)
+			hostHelper.EXPECT().DiscoverSriovDevices(hostHelper).Return([]sriovnetworkv1.InterfaceExt{{
+				PciAddress: "0000:00:00.0",
+				NumVfs:     1,
+				VFs:        []sriovnetworkv1.VirtualFunction{{PciAddress: "0000:00:00.1", VfID: 0, Name: "eth0v0"}},
+			}}, nil)
+			hostHelper.EXPECT().SetNetDevRss("eth0v0", "", 2).Return(errors.New("invalid indirection table"))
+
+			Expect(genericPlugin.Apply()).To(MatchError(ContainSubstring("failed to set RSS on VF eth0v0")))
+		})
+
+		It("should set the trust mode of individual VFs", func() {
+			genericPlugin.(*GenericPlugin).DesireState = &sriovnetworkv1.SriovNetworkNodeState{
+				Spec: sriovnetworkv1.SriovNetworkNodeStateSpec{
+					Interfaces: sriovnetworkv1.Interfaces{{
+						PciAddress: "0000:00:00.0",
+						Name:       "eth0",
+						NumVfs:     6,
+						VfGroups: []sriovnetworkv1.VfGroup{
+							{
+								VfRange:      "0-3",
+								ResourceName: "resource",
+								DeviceType:   consts.DeviceTypeNetDevice,
+								VfTrust:      map[string]bool{"1": true, "3": false},
+							},
+							{
+								VfRange:      "4-5",
+								ResourceName: "resource_trusted",
+								DeviceType:   consts.DeviceTypeNetDevice,
+								// out of the range of the group
+								VfTrust: map[string]bool{"5": true, "0": true},
+							},
+						},
+					}},
+				},
+			}
+
+			hostHelper.EXPECT().Chroot(consts.Host).Return(func() error { return nil }, nil)
+			hostHelper.EXPECT().ConfigSriovInterfaces(gomock.Any(), gomock.Any(), gomock.Any(), false, gomock.Any()).Return(nil)
+			trusted, untrusted := true, false
+			hostHelper.EXPECT().SetVfConfig("eth0", 1, hostTypes.VfConfig{Trust: &trusted}).Return(nil)
+			hostHelper.EXPECT().SetVfConfig("eth0", 3, hostTypes.VfConfig{Trust: &untrusted}).Return(nil)
+			hostHelper.EXPECT().SetVfConfig("eth0", 5, hostTypes.VfConfig{Trust: &trusted}).Return(nil)
+			hostHelper.EXPECT().ConfigureBridges(gomock.Any(), gomock.Any()).Return(nil)
+
+			Expect(genericPlugin.Apply()).To(Succeed())
+		})
+
+		It("should enable the promiscuous mode of the trusted VFs", func() {
+			promisc := true
+			genericPlugin.(*GenericPlugin).DesireState = &sriovnetworkv1.SriovNetworkNodeState{
+				Spec: sriovnetworkv1.SriovNetworkNodeStateSpec{
+					Interfaces: sriovnetworkv1.Interfaces{{
+						PciAddress: "0000:00:00.0",
+						Name:       "eth0",
+						NumVfs:     2,
+						VfGroups: []sriovnetworkv1.VfGroup{{
+							VfRange:      "0-1",
+							ResourceName: "resource",
+							DeviceType:   consts.DeviceTypeNetDevice,
+							VfTrust:      map[string]bool{"0": true, "1": true},
+							Promisc:      &promisc,
+						}},
+					}},
+				},
+			}
+
+			hostHelper.EXPECT().Chroot(consts.Host).Return(func() error { return nil }, nil)
+			hostHelper.EXPECT().ConfigSriovInterfaces(gomock.Any(), gomock.Any(), gomock.Any(), false, gomock.Any()).Return(nil)
+			trusted := true
+			setTrust0 := hostHelper.EXPECT().SetVfConfig("eth0", 0, hostTypes.VfConfig{Trust: &trusted}).Return(nil)
+			setTrust1 := hostHelper.EXPECT().SetVfConfig("eth0", 1, hostTypes.VfConfig{Trust: &trusted}).Return(nil)
+			hostHelper.EXPECT().DiscoverSriovDevices(hostHelper).Return([]sriovnetworkv1.InterfaceExt{{
+				PciAddress: "0000:00:00.0",
+				NumVfs:     2,
+				VFs: []sriovnetworkv1.VirtualFunction{
+					{PciAddress: "0000:00:00.1", VfID: 0, Name: "eth0v0"},
+					{PciAddress: "0000:00:00.2", VfID: 1, Name: "eth0v1"},
+				},
+			}}, nil)
+			// the promiscuous mode is set once the VFs are trusted
+			hostHelper.EXPECT().SetNetDevPromisc("eth0v0", true).Return(nil).After(setTrust0)
+			hostHelper.EXPECT().SetNetDevPromisc("eth0v1", true).Return(nil).After(setTrust1)
+			hostHelper.EXPECT().ConfigureBridges(gomock.Any(), gomock.Any()).Return(nil)
+
+			Expect(genericPlugin.Apply()).To(Succeed())
+		})
+
+		It("should not enable the promiscuous mode of the VFs which are not trusted", func() {
+			promisc := true
+			genericPlugin.(*GenericPlugin).DesireState = &sriovnetworkv1.SriovNetworkNodeState{
+				Spec: sriovnetworkv1.SriovNetworkNodeStateSpec{
+					Interfaces: sriovnetworkv1.Interfaces{{
+						PciAddress: "0000:00:00.0",
+						Name:       "eth0",
+						NumVfs:     2,
+						VfGroups: []sriovnetworkv1.VfGroup{{
+							VfRange:      "0-1",
+							ResourceName: "resource",
+							DeviceType:   consts.DeviceTypeNetDevice,
+							VfTrust:      map[string]bool{"0": true, "1": false},
+							Promisc:      &promisc,
+						}},
+					}},
+				},
+			}
+
+			hostHelper.EXPECT().Chroot(consts.Host).Return(func() error { return nil }, nil)
+			hostHelper.EXPECT().ConfigSriovInterfaces(gomock.Any(), gomock.Any(), gomock.Any(), false, gomock.Any()).Return(nil)
+			trusted, untrusted := true, false
+			hostHelper.EXPECT().SetVfConfig("eth0", 0, hostTypes.VfConfig{Trust: &trusted}).Return(nil)
+			hostHelper.EXPECT().SetVfConfig("eth0", 1, hostTypes.VfConfig{Trust: &untrusted}).Return(nil)
+			hostHelper.EXPECT().DiscoverSriovDevices(hostHelper).Return([]sriovnetworkv1.InterfaceExt{{
+				PciAddress: "0000:00:00.0",
+				NumVfs:     2,
+				VFs: []sriovnetworkv1.VirtualFunction{
+					{PciAddress: "0000:00:00.1", VfID: 0, Name: "eth0v0"},
+					{PciAddress: "0000:00:00.2", VfID: 1, Name: "eth0v1"},
+				},
+			}}, nil)
+			hostHelper.EXPECT().SetNetDevPromisc("eth0v0", true).Return(nil)
+			hostHelper.EXPECT().ConfigureBridges(gomock.Any(), gomock.Any()).Return(nil)
+
+			Expect(genericPlugin.Apply()).To(Succeed())
+		})
+
+		It("should apply the broadcast and multicast filters on the VFs of the groups", func() {
+			enabled, disabled := true, false
+			genericPlugin.(*GenericPlugin).DesireState = &sriovnetworkv1.SriovNetworkNodeState{
+				Spec: sriovnetworkv1.SriovNetworkNodeStateSpec{
+					Interfaces: sriovnetworkv1.Interfaces{{
+						PciAddress: "0000:00:00.0",
+						Name:       "eth0",
+						NumVfs:     4,
+						VfGroups: []sriovnetworkv1.VfGroup{{
+							VfRange:      "0-1",
+							ResourceName: "secure",
+							DeviceType:   consts.DeviceTypeNetDevice,
+							VfFilters:    &sriovnetworkv1.VfFilters{Broadcast: &disabled, Multicast: &disabled},
+						}, {
+							VfRange:      "2-2",
+							ResourceName: "multicast",
+							DeviceType:   consts.DeviceTypeNetDevice,
+							VfFilters:    &sriovnetworkv1.VfFilters{Multicast: &enabled},
+						}, {
+							VfRange:      "3-3",
+							ResourceName: "default",
+							DeviceType:   consts.DeviceTypeNetDevice,
+						}},
+					}},
+				},
+			}
+
+			hostHelper.EXPECT().Chroot(consts.Host).Return(func() error { return nil }, nil)
+			hostHelper.EXPECT().ConfigSriovInterfaces(gomock.Any(), gomock.Any(), gomock.Any(), false, gomock.Any()).Return(nil)
+			hostHelper.EXPECT().DiscoverSriovDevices(hostHelper).Return([]sriovnetworkv1.InterfaceExt{{
+				PciAddress: "0000:00:00.0",
+				NumVfs:     4,
+				VFs: []sriovnetworkv1.VirtualFunction{
+					{PciAddress: "0000:00:00.1", VfID: 0, Name: "eth0v0"},
+					{PciAddress: "0000:00:00.2", VfID: 1, Name: "eth0v1"},
+					{PciAddress: "0000:00:00.3", VfID: 2, Name: "eth0v2"},
+					{PciAddress: "0000:00:00.4", VfID: 3, Name: "eth0v3"},
+				},
+			}}, nil)
+			hostHelper.EXPECT().SetNetDevMulticast("eth0v0", false).Return(nil)
+			hostHelper.EXPECT().SetNetDevBroadcastFilter("eth0v0", true).Return(nil)
+			hostHelper.EXPECT().SetNetDevMulticast("eth0v1", false).Return(nil)
+			hostHelper.EXPECT().SetNetDevBroadcastFilter("eth0v1", true).Return(nil)
+			hostHelper.EXPECT().SetNetDevMulticast("eth0v2", true).Return(nil)
+			hostHelper.EXPECT().ConfigureBridges(gomock.Any(), gomock.Any()).Return(nil)
+
+			Expect(genericPlugin.Apply()).To(Succeed())
+		})
+
+		It("should fail when a filter can't be applied on a VF", func() {
+			disabled := false
+			genericPlugin.(*GenericPlugin).DesireState = &sriovnetworkv1.SriovNetworkNodeState{
+				Spec: sriovnetworkv1.SriovNetworkNodeStateSpec{
+					Interfaces: sriovnetworkv1.Interfaces{{
+						PciAddress: "0000:00:00.0",
+						Name:       "eth0",
+						NumVfs:     1,
+						VfGroups: []sriovnetworkv1.VfGroup{{
+							VfRange:      "0-0",
+							ResourceName: "secure",
+							DeviceType:   consts.DeviceTypeNetDevice,
+							VfFilters:    &sriovnetworkv1.VfFilters{Broadcast: &disabled},
+						}},
+					}},
+				},
+			}
+
+			hostHelper.EXPECT().Chroot(consts.Host).Return(func() error { return nil }, nil)
+			hostHelper.EXPECT().ConfigSriovInterfaces(gomock.Any(), gomock.Any(), gomock.Any(), false, gomock.Any()).Return(nil)
+			hostHelper.EXPECT().DiscoverSriovDevices(hostHelper).Return([]sriovnetworkv1.InterfaceExt{{
+				PciAddress: "0000:00:00.0",
+				NumVfs:     1,
+				VFs:        []sriovnetworkv1.VirtualFunction{{PciAddress: "0000:00:00.1", VfID: 0, Name: "eth0v0"}},
+			}}, nil)
+			hostHelper.EXPECT().SetNetDevBroadcastFilter("eth0v0", true).Return(errors.New("operation not supported"))
+
+			Expect(genericPlugin.Apply()).To(MatchError(ContainSubstring("failed to set broadcast filter on VF eth0v0")))
+		})
+
+		It("should discover the VF netdevs once for all the VF netdev settings", func() {
+			disabled := false
+			genericPlugin.(*GenericPlugin).DesireState = &sriovnetworkv1.SriovNetworkNodeState{
+				Spec: sriovnetworkv1.SriovNetworkNodeStateSpec{
+					Interfaces: sriovnetworkv1.Interfaces{{
+						PciAddress: "0000:00:00.0",
+						Name:       "eth0",
+						NumVfs:     1,
+						VfGroups: []sriovnetworkv1.VfGroup{{
+							VfRange:      "0-0",
+							ResourceName: "resource",
+							DeviceType:   consts.DeviceTypeNetDevice,
+							VfMtu:        1400,
+							VfSysctls:    map[string]string{"net.ipv4.conf.<if>.rp_filter": "2"},
+							VfRss:        &sriovnetworkv1.VfRss{IndirectionSize: 2},
+							VfFilters:    &sriovnetworkv1.VfFilters{Multicast: &disabled},
+						}},
+					}},
+				},
+			}
+
+			hostHelper.EXPECT().Chroot(consts.Host).Return(func() error { return nil }, nil)
+			hostHelper.EXPECT().ConfigSriovInterfaces(gomock.Any(), gomock.Any(), gomock.Any(), false, gomock.Any()).Return(nil)
+			hostHelper.EXPECT().DiscoverSriovDevices(hostHelper).Return([]sriovnetworkv1.InterfaceExt{{
+				PciAddress: "0000:00:00.0",
+				NumVfs:     1,
+				Mtu:        1500,
+				VFs:        []sriovnetworkv1.VirtualFunction{{PciAddress: "0000:00:00.1", VfID: 0, Name: "eth0v0", Mtu: 1500}},
+			}}, nil).Times(1)
+			hostHelper.EXPECT().SetNetdevMTU("0000:00:00.1", 1400).Return(nil)
+			hostHelper.EXPECT().SetNetDevSysctl("eth0v0", "net.ipv4.conf.<if>.rp_filter", "2").Return(nil)
+			hostHelper.EXPECT().SetNetDevRss("eth0v0", "", 2).Return(nil)
+			hostHelper.EXPECT().SetNetDevMulticast("eth0v0", false).Return(nil)
+			hostHelper.EXPECT().ConfigureBridges(gomock.Any(), gomock.Any()).Return(nil)
+
+			Expect(genericPlugin.Apply()).To(Succeed())
+		})
+
+		It("should apply all the attributes of a VF of an externally managed PF in a single operation", func() {
+			spoofChk := false
+			genericPlugin.(*GenericPlugin).DesireState = &sriovnetworkv1.SriovNetworkNodeState{
+				Spec: sriovnetworkv1.SriovNetworkNodeStateSpec{
+					Interfaces: sriovnetworkv1.Interfaces{{
+						PciAddress:        "0000:00:00.0",
+						Name:              "eth0",
+						NumVfs:            3,
+						ExternallyManaged: true,
+						VfGroups: []sriovnetworkv1.VfGroup{
+							{
+								VfRange:      "0-1",
+								ResourceName: "resource",
+								DeviceType:   consts.DeviceTypeNetDevice,
+								VfAttributes: &sriovnetworkv1.VfAttributes{
+									Vlan:     100,
+									VlanQoS:  3,
+									Trust:    true,
+									SpoofChk: &spoofChk,
+								},
+								VfTrust: map[string]bool{"1": false},
+							},
+							{
+								VfRange:      "2-2",
+								ResourceName: "resource_default",
+								DeviceType:   consts.DeviceTypeNetDevice,
+							},
+						},
+					}},
+				},
+			}
+
+			hostHelper.EXPECT().Chroot(consts.Host).Return(func() error { return nil }, nil)
+			hostHelper.EXPECT().ConfigSriovInterfaces(gomock.Any(), gomock.Any(), gomock.Any(), false, gomock.Any()).Return(nil)
+			vlan, trusted, untrusted := 100, true, false
+			gomock.InOrder(
+				hostHelper.EXPECT().SetVfConfig("eth0", 0, hostTypes.VfConfig{
+					Vlan: &vlan, VlanQoS: 3, Trust: &trusted, SpoofChk: &spoofChk}).Return(nil),
+				// the per VF trust mode overrides the one of the group without being applied separately
+				hostHelper.EXPECT().SetVfConfig("eth0", 1, hostTypes.VfConfig{
+					Vlan: &vlan, VlanQoS: 3, Trust: &untrusted, SpoofChk: &spoofChk}).Return(nil),
+			)
+			hostHelper.EXPECT().ConfigureBridges(gomock.Any(), gomock.Any()).Return(nil)
+
+			Expect(genericPlugin.Apply()).To(Succeed())
+		})
+
+		DescribeTable("should apply the VLAN protocol of the VFs of an externally managed PF",
+			func(vlanProto, expectedVlanProto string) {
+				genericPlugin.(*GenericPlugin).DesireState = &sriovnetworkv1.SriovNetworkNodeState{
+					Spec: sriovnetworkv1.SriovNetworkNodeStateSpec{
+						Interfaces: sriovnetworkv1.Interfaces{{
+							PciAddress:        "0000:00:00.0",
+							Name:              "eth0",
+							NumVfs:            1,
+							ExternallyManaged: true,
+							VfGroups: []sriovnetworkv1.VfGroup{{
+								VfRange:      "0-0",
+								ResourceName: "resource",
+								DeviceType:   consts.DeviceTypeNetDevice,
+								VfAttributes: &sriovnetworkv1.VfAttributes{Vlan: 100, VlanProto: vlanProto},
+							}},
+						}},
+					},
+				}
+
+				hostHelper.EXPECT().Chroot(consts.Host).Return(func() error { return nil }, nil)
+				hostHelper.EXPECT().ConfigSriovInterfaces(gomock.Any(), gomock.Any(), gomock.Any(), false, gomock.Any()).Return(nil)
+				vlan, untrusted := 100, false
+				hostHelper.EXPECT().SetVfConfig("eth0", 0, hostTypes.VfConfig{
+					Vlan: &vlan, VlanProto: expectedVlanProto, Trust: &untrusted}).Return(nil)
+				hostHelper.EXPECT().ConfigureBridges(gomock.Any(), gomock.Any()).Return(nil)
+
+				Expect(genericPlugin.Apply()).To(Succeed())
+			},
+			Entry("802.1q", "802.1q", "802.1q"),
+			Entry("802.1Q", "802.1Q", "802.1q"),
+			Entry("802.1ad", "802.1ad", "802.1ad"),
+			Entry("802.1AD", "802.1AD", "802.1ad"),
+		)
+
+		It("should apply the TX rates of the VFs of an externally managed PF after the other attributes", func() {
+			minTxRate, maxTxRate := 100, 1000
+			genericPlugin.(*GenericPlugin).DesireState = &sriovnetworkv1.SriovNetworkNodeState{
+				Spec: sriovnetworkv1.SriovNetworkNodeStateSpec{
+					Interfaces: sriovnetworkv1.Interfaces{{
+						PciAddress:        "0000:00:00.0",
+						Name:              "eth0",
+						NumVfs:            1,
+						ExternallyManaged: true,
+						VfGroups: []sriovnetworkv1.VfGroup{{
+							VfRange:      "0-0",
+							ResourceName: "resource",
+							DeviceType:   consts.DeviceTypeNetDevice,
+							VfAttributes: &sriovnetworkv1.VfAttributes{
+								Vlan:      100,
+								MinTxRate: &minTxRate,
+								MaxTxRate: &maxTxRate,
+							},
+						}},
+					}},
+				},
+			}
+
+			hostHelper.EXPECT().Chroot(consts.Host).Return(func() error { return nil }, nil)
+			hostHelper.EXPECT().ConfigSriovInterfaces(gomock.Any(), gomock.Any(), gomock.Any(), false, gomock.Any()).Return(nil)
+			vlan, untrusted := 100, false
+			gomock.InOrder(
+				hostHelper.EXPECT().SetVfConfig("eth0", 0, hostTypes.VfConfig{Vlan: &vlan, Trust: &untrusted}).Return(nil),
+				hostHelper.EXPECT().SetVfConfig("eth0", 0, hostTypes.VfConfig{MinTxRate: &minTxRate, MaxTxRate: &maxTxRate}).Return(nil),
+			)
+			hostHelper.EXPECT().ConfigureBridges(gomock.Any(), gomock.Any()).Return(nil)
+
+			Expect(genericPlugin.Apply()).To(Succeed())
+		})
+
+		It("should report the node as degraded when the NIC rejects the TX rates of a VF", func() {
+			maxTxRate := 1000
+			genericPlugin.(*GenericPlugin).DesireState = &sriovnetworkv1.SriovNetworkNodeState{
+				Spec: sriovnetworkv1.SriovNetworkNodeStateSpec{
+					Interfaces: sriovnetworkv1.Interfaces{{
+						PciAddress:        "0000:00:00.0",
+						Name:              "eth0",
+						NumVfs:            1,
+						ExternallyManaged: true,
+						VfGroups: []sriovnetworkv1.VfGroup{{
+							VfRange:      "0-0",
+							ResourceName: "resource",
+							DeviceType:   consts.DeviceTypeNetDevice,
+							VfAttributes: &sriovnetworkv1.VfAttributes{MaxTxRate: &maxTxRate},
+						}},
+					}},
+				},
+			}
+
+			hostHelper.EXPECT().Chroot(consts.Host).Return(func() error { return nil }, nil)
+			hostHelper.EXPECT().ConfigSriovInterfaces(gomock.Any(), gomock.Any(), gomock.Any(), false, gomock.Any()).Return(nil)
+			vlan, untrusted := 0, false
+			hostHelper.EXPECT().SetVfConfig("eth0", 0, hostTypes.VfConfig{Vlan: &vlan, Trust: &untrusted}).Return(nil)
+			hostHelper.EXPECT().SetVfConfig("eth0", 0, hostTypes.VfConfig{MaxTxRate: &maxTxRate}).Return(errors.New("invalid argument"))
+
+			err := genericPlugin.Apply()
+			var degradedErr *plugin.DegradedError
+			Expect(errors.As(err, &degradedErr)).To(BeTrue())
+			Expect(degradedErr.Reason).To(Equal(sriovnetworkv1.ReasonVfTxRateInvalid))
+			Expect(degradedErr.Message).To(ContainSubstring("the NIC rejected the TX rates of VF 0 of eth0"))
+		})
+
+		It("should force the speed of the PF link", func() {
+			genericPlugin.(*GenericPlugin).DesireState = &sriovnetworkv1.SriovNetworkNodeState{
+				Spec: sriovnetworkv1.SriovNetworkNodeStateSpec{
+					Interfaces: sriovnetworkv1.Interfaces{
+						{PciAddress: "0000:00:00.0", Name: "eth0", NumVfs: 1, LinkSpeedMbps: 10000},
+						{PciAddress: "0000:00:01.0", Name: "eth1", NumVfs: 1},
+					},
+				},
+			}
+
+			hostHelper.EXPECT().Chroot(consts.Host).Return(func() error { return nil }, nil)
+			hostHelper.EXPECT().ConfigSriovInterfaces(gomock.Any(), gomock.Any(), gomock.Any(), false, gomock.Any()).Return(nil)
+			hostHelper.EXPECT().SetNetDevLinkSettings("eth0", nil, 10000).Return(nil)
+			hostHelper.EXPECT().ConfigureBridges(gomock.Any(), gomock.Any()).Return(nil)
+
+			Expect(genericPlugin.Apply()).To(Succeed())
+		})
+
+		It("should set the auto-negotiation of the PF link", func() {
+			autoNeg := true
+			genericPlugin.(*GenericPlugin).DesireState = &sriovnetworkv1.SriovNetworkNodeState{
+				Spec: sriovnetworkv1.SriovNetworkNodeStateSpec{
+					Interfaces: sriovnetworkv1.Interfaces{
+						{PciAddress: "0000:00:00.0", Name: "eth0", NumVfs: 1, LinkAutoNeg: &autoNeg},
+					},
+				},
+			}
+
+			hostHelper.EXPECT().Chroot(consts.Host).Return(func() error { return nil }, nil)
+			hostHelper.EXPECT().ConfigSriovInterfaces(gomock.Any(), gomock.Any(), gomock.Any(), false, gomock.Any()).Return(nil)
+			hostHelper.EXPECT().SetNetDevLinkSettings("eth0", &autoNeg, 0).Return(errors.New("operation not supported"))
+
+			Expect(genericPlugin.Apply()).To(MatchError(ContainSubstring("failed to configure the link of eth0")))
+		})
+
+		It("should enable the VLAN filtering of the PF", func() {
+			enabled := true
+			genericPlugin.(*GenericPlugin).DesireState = &sriovnetworkv1.SriovNetworkNodeState{
+				Spec: sriovnetworkv1.SriovNetworkNodeStateSpec{
+					Interfaces: sriovnetworkv1.Interfaces{
+						{PciAddress: "0000:00:00.0", Name: "eth0", NumVfs: 1, VlanFiltering: &enabled},
+						{PciAddress: "0000:00:01.0", Name: "eth1", NumVfs: 1},
+					},
+				},
+			}
+
+			hostHelper.EXPECT().Chroot(consts.Host).Return(func() error { return nil }, nil)
+			hostHelper.EXPECT().ConfigSriovInterfaces(gomock.Any(), gomock.Any(), gomock.Any(), false, gomock.Any()).Return(nil)
+			hostHelper.EXPECT().SetNetDevVlanFiltering("eth0", true).Return(nil)
+			hostHelper.EXPECT().ConfigureBridges(gomock.Any(), gomock.Any()).Return(nil)
+
+			Expect(genericPlugin.Apply()).To(Succeed())
+		})
+
+		It("should disable the VLAN filtering of the PF", func() {
+			disabled := false
+			genericPlugin.(*GenericPlugin).DesireState = &sriovnetworkv1.SriovNetworkNodeState{
+				Spec: sriovnetworkv1.SriovNetworkNodeStateSpec{
+					Interfaces: sriovnetworkv1.Interfaces{
+						{PciAddress: "0000:00:00.0", Name: "eth0", NumVfs: 1, VlanFiltering: &disabled},
+					},
+				},
+			}
+
+			hostHelper.EXPECT().Chroot(consts.Host).Return(func() error { return nil }, nil)
+			hostHelper.EXPECT().ConfigSriovInterfaces(gomock.Any(), gomock.Any(), gomock.Any(), false, gomock.Any()).Return(nil)
+			hostHelper.EXPECT().SetNetDevVlanFiltering("eth0", false).Return(errors.New("operation not supported"))
+
+			Expect(genericPlugin.Apply()).To(MatchError(ContainSubstring("failed to configure the VLAN filtering of eth0")))
+		})
+
+		Context("MACsec", func() {
+			var networkNodeState *sriovnetworkv1.SriovNetworkNodeState
+
+			BeforeEach(func() {
+				networkNodeState = &sriovnetworkv1.SriovNetworkNodeState{
+					Spec: sriovnetworkv1.SriovNetworkNodeStateSpec{
+						Interfaces: sriovnetworkv1.Interfaces{{
+							PciAddress: "0000:00:00.0",
+							NumVfs:     2,
+							VfGroups: []sriovnetworkv1.VfGroup{{
+								VfRange:      "0-1",
+								ResourceName: "resource",
+								DeviceType:   consts.DeviceTypeNetDevice,
+								Macsec: &sriovnetworkv1.VfMacsec{
+									KeySecretName:  "macsec-key",
+									EncryptionMode: consts.MacsecEncryptionModeIntegrity,
+								},
+							}},
+						}},
+					},
+				}
+			})
+
+			It("should configure MACsec on each VF netdev with the key of the referenced Secret", func() {
+				genericPlugin, err = NewGenericPlugin(hostHelper, WithSecretGetter(func(name string) (map[string][]byte, error) {
+					Expect(name).To(Equal("macsec-key"))
+					return map[string][]byte{
+						consts.MacsecSecretKey:   []byte("81818181818181818181818181818181\n"),
+						consts.MacsecSecretKeyID: []byte("01"),
+					}, nil
+				}))
+				Expect(err).ToNot(HaveOccurred())
+				genericPlugin.(*GenericPlugin).DesireState = networkNodeState
+
+				hostHelper.EXPECT().Chroot(consts.Host).Return(func() error { return nil }, nil)
+				hostHelper.EXPECT().ConfigSriovInterfaces(gomock.Any(), gomock.Any(), gomock.Any(), false, gomock.Any()).Return(nil)
+				hostHelper.EXPECT().DiscoverSriovDevices(hostHelper).Return([]sriovnetworkv1.InterfaceExt{{
+					PciAddress: "0000:00:00.0",
+					NumVfs:     2,
+					VFs: []sriovnetworkv1.VirtualFunction{
+						{PciAddress: "0000:00:00.1", VfID: 0, Name: "eth0v0"},
+						{PciAddress: "0000:00:00.2", VfID: 1, Name: ""},
+					},
+				}}, nil)
+				hostHelper.EXPECT().SetNetDevMacsec("eth0v0", "01", "81818181818181818181818181818181", false).Return(nil)
+				hostHelper.EXPECT().ConfigureBridges(gomock.Any(), gomock.Any()).Return(nil)
+
+				Expect(genericPlugin.Apply()).To(Succeed())
+			})
+
+			It("should fail when the referenced Secret has no key", func() {
+				genericPlugin, err = NewGenericPlugin(hostHelper, WithSecretGetter(func(name string) (map[string][]byte, error) {
+					return map[string][]byte{}, nil
+				}))
+				Expect(err).ToNot(HaveOccurred())
+				genericPlugin.(*GenericPlugin).DesireState = networkNodeState
+
+				hostHelper.EXPECT().Chroot(consts.Host).Return(func() error { return nil }, nil)
+				hostHelper.EXPECT().ConfigSriovInterfaces(gomock.Any(), gomock.Any(), gomock.Any(), false, gomock.Any()).Return(nil)
+				hostHelper.EXPECT().DiscoverSriovDevices(hostHelper).Return([]sriovnetworkv1.InterfaceExt{{
+					PciAddress: "0000:00:00.0",
+					NumVfs:     2,
+					VFs: []sriovnetworkv1.VirtualFunction{
+						{PciAddress: "0000:00:00.1", VfID: 0, Name: "eth0v0"},
+					},
+				}}, nil)
+
+				Expect(genericPlugin.Apply()).To(MatchError(ContainSubstring("MACsec key Secret macsec-key has no key entry")))
+			})
+
+			It("should drain when the MACsec configuration changes", func() {
+				networkNodeState.Status.Interfaces = sriovnetworkv1.InterfaceExts{{
+					PciAddress: "0000:00:00.0",
+					NumVfs:     2,
+					TotalVfs:   2,
+					VFs: []sriovnetworkv1.VirtualFunction{
+						{PciAddress: "0000:00:00.1", VfID: 0, Name: "eth0v0", Driver: "mlx5_core"},
+						{PciAddress: "0000:00:00.2", VfID: 1, Name: "eth0v1", Driver: "mlx5_core"},
+					},
+				}}
+				needDrain, _, err := genericPlugin.OnNodeStateChange(networkNodeState)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(needDrain).To(BeFalse())
+
+				updated := networkNodeState.DeepCopy()
+				updated.Spec.Interfaces[0].VfGroups[0].Macsec.EncryptionMode = consts.MacsecEncryptionModeEncrypt
+				needDrain, _, err = genericPlugin.OnNodeStateChange(updated)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(needDrain).To(BeTrue())
+			})
+
+			It("should drain when the key of the referenced Secret changes", func() {
+				genericPlugin, err = NewGenericPlugin(hostHelper, WithSecretGetter(func(name string) (map[string][]byte, error) {
+					return map[string][]byte{consts.MacsecSecretKey: []byte("82828282828282828282828282828282")}, nil
+				}))
+				Expect(err).ToNot(HaveOccurred())
+				networkNodeState.Status.Interfaces = sriovnetworkv1.InterfaceExts{{
+					PciAddress: "0000:00:00.0",
+					NumVfs:     2,
+					TotalVfs:   2,
+					VFs: []sriovnetworkv1.VirtualFunction{
+						{PciAddress: "0000:00:00.1", VfID: 0, Name: "eth0v0", Driver: "mlx5_core"},
+						{PciAddress: "0000:00:00.2", VfID: 1, Name: "eth0v1", Driver: "mlx5_core"},
+					},
+				}}
+				needDrain, _, err := genericPlugin.OnNodeStateChange(networkNodeState)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(needDrain).To(BeFalse())
+
+				hostHelper.EXPECT().IsNetDevMacsecOutdated("eth0v0", consts.MacsecDefaultKeyID,
+					"82828282828282828282828282828282", false).Return(false, nil)
+				hostHelper.EXPECT().IsNetDevMacsecOutdated("eth0v1", consts.MacsecDefaultKeyID,
+					"82828282828282828282828282828282", false).Return(false, nil)
+				needDrain, _, err = genericPlugin.OnNodeStateChange(networkNodeState.DeepCopy())
+				Expect(err).ToNot(HaveOccurred())
+				Expect(needDrain).To(BeFalse())
+
+				hostHelper.EXPECT().IsNetDevMacsecOutdated("eth0v0", consts.MacsecDefaultKeyID,
+					"82828282828282828282828282828282", false).Return(true, nil)
+				needDrain, _, err = genericPlugin.OnNodeStateChange(networkNodeState.DeepCopy())
+				Expect(err).ToNot(HaveOccurred())
+				Expect(needDrain).To(BeTrue())
+			})
+		})
+	})
 })
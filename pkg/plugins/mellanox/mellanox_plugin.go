@@ -2,6 +2,7 @@ package mellanox
 
 import (
 	"fmt"
+	"sort"
 
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
@@ -92,6 +93,10 @@ func (p *MellanoxPlugin) OnNodeStateChange(new *sriovnetworkv1.SriovNetworkNodeS
 		return
 	}
 
+	if err = p.checkFirmwareSupport(); err != nil {
+		return false, false, err
+	}
+
 	for _, ifaceSpec := range mellanoxNicsSpec {
 		pciPrefix := mlx.GetPciAddressPrefix(ifaceSpec.PciAddress)
 		// skip processed nics, help not running the same logic 2 times for dual port NICs
@@ -218,6 +223,31 @@ func (p *MellanoxPlugin) Apply() error {
 	return nil
 }
 
+// checkFirmwareSupport makes sure the driver and the firmware of the NICs to configure support SR-IOV
+// before touching their firmware configuration
+func (p *MellanoxPlugin) checkFirmwareSupport() error {
+	pciAddresses := make([]string, 0, len(mellanoxNicsSpec))
+	for pciAddress := range mellanoxNicsSpec {
+		pciAddresses = append(pciAddresses, pciAddress)
+	}
+	sort.Strings(pciAddresses)
+
+	for _, pciAddress := range pciAddresses {
+		ifaceStatus := mellanoxNicsStatus[mlx.GetPciAddressPrefix(pciAddress)][pciAddress]
+		fwVersion := ""
+		if ifaceStatus.Name != "" {
+			fwVersion = p.helpers.GetNetDevFirmwareVersion(ifaceStatus.Name)
+		}
+		if err := mlx.CheckSriovFirmwareSupport(ifaceStatus.DeviceID, ifaceStatus.Driver, fwVersion); err != nil {
+			return &plugin.DegradedError{
+				Reason:  sriovnetworkv1.ReasonUnsupportedFirmware,
+				Message: fmt.Sprintf("PF %s (%s): %v", ifaceStatus.Name, pciAddress, err),
+			}
+		}
+	}
+	return nil
+}
+
 // nicHasExternallyManagedPFs returns true if one of the ports(interface) of the NIC is marked as externally managed
 // in StoreManagerInterface.
 func (p *MellanoxPlugin) nicHasExternallyManagedPFs(nicPortsMap map[string]sriovnetworkv1.InterfaceExt) (bool, error) {
@@ -0,0 +1,99 @@
+package mellanox
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	sriovnetworkv1 "github.com/k8snetworkplumbingwg/sriov-network-operator/api/v1"
+	mock_helper "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/helper/mock"
+	plugin "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/plugins"
+	mlx "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/vendors/mellanox"
+)
+
+func TestMellanoxPlugin(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Test Mellanox Plugin")
+}
+
+var _ = Describe("Mellanox plugin", func() {
+	var (
+		mellanoxPlugin plugin.VendorPlugin
+		err            error
+		ctrl           *gomock.Controller
+		hostHelper     *mock_helper.MockHostHelpersInterface
+		nodeState      *sriovnetworkv1.SriovNetworkNodeState
+	)
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		hostHelper = mock_helper.NewMockHostHelpersInterface(ctrl)
+		hostHelper.EXPECT().IsKernelLockdownMode().Return(false).AnyTimes()
+
+		mellanoxPlugin, err = NewMellanoxPlugin(hostHelper)
+		Expect(err).ToNot(HaveOccurred())
+
+		nodeState = &sriovnetworkv1.SriovNetworkNodeState{
+			Spec: sriovnetworkv1.SriovNetworkNodeStateSpec{
+				Interfaces: sriovnetworkv1.Interfaces{{
+					PciAddress: "0000:d8:00.0",
+					NumVfs:     4,
+				}},
+			},
+			Status: sriovnetworkv1.SriovNetworkNodeStateStatus{
+				Interfaces: sriovnetworkv1.InterfaceExts{{
+					Name:       "enp216s0f0np0",
+					PciAddress: "0000:d8:00.0",
+					Vendor:     "15b3",
+					DeviceID:   "1015",
+					Driver:     "mlx5_core",
+					LinkType:   "ETH",
+					TotalVfs:   8,
+				}},
+			},
+		}
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+	})
+
+	Context("OnNodeStateChange", func() {
+		It("should report the NIC as degraded when its firmware is below the supported minimum", func() {
+			hostHelper.EXPECT().GetNetDevFirmwareVersion("enp216s0f0np0").Return("14.27.1016")
+
+			_, _, err := mellanoxPlugin.OnNodeStateChange(nodeState)
+			Expect(err).To(HaveOccurred())
+			var degradedErr *plugin.DegradedError
+			Expect(errors.As(err, &degradedErr)).To(BeTrue())
+			Expect(degradedErr.Reason).To(Equal(sriovnetworkv1.ReasonUnsupportedFirmware))
+			Expect(degradedErr.Message).To(ContainSubstring("require firmware 14.28.2006 or newer"))
+			Expect(degradedErr.Message).To(ContainSubstring("runs firmware 14.27.1016"))
+		})
+
+		It("should report the NIC as degraded when it isn't bound to the supported driver", func() {
+			nodeState.Status.Interfaces[0].Driver = "vfio-pci"
+			hostHelper.EXPECT().GetNetDevFirmwareVersion("enp216s0f0np0").Return("")
+
+			_, _, err := mellanoxPlugin.OnNodeStateChange(nodeState)
+			var degradedErr *plugin.DegradedError
+			Expect(errors.As(err, &degradedErr)).To(BeTrue())
+			Expect(degradedErr.Reason).To(Equal(sriovnetworkv1.ReasonUnsupportedFirmware))
+			Expect(degradedErr.Message).To(ContainSubstring("bind the NIC to mlx5_core"))
+		})
+
+		It("should configure the NIC when its firmware is supported", func() {
+			hostHelper.EXPECT().GetNetDevFirmwareVersion("enp216s0f0np0").Return("14.32.1010")
+			fwData := &mlx.MlxNic{EnableSriov: true, TotalVfs: 4, LinkTypeP1: "ETH"}
+			hostHelper.EXPECT().GetMlxNicFwData("0000:d8:00.0").Return(fwData, fwData, nil)
+
+			needDrain, needReboot, err := mellanoxPlugin.OnNodeStateChange(nodeState)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(needDrain).To(BeFalse())
+			Expect(needReboot).To(BeFalse())
+		})
+	})
+})
@@ -1,6 +1,8 @@
 package plugin
 
 import (
+	"fmt"
+
 	sriovnetworkv1 "github.com/k8snetworkplumbingwg/sriov-network-operator/api/v1"
 )
 
@@ -17,3 +19,22 @@ type VendorPlugin interface {
 	// CheckStatusChanges checks status changes on the SriovNetworkNodeState CR for configured VFs.
 	CheckStatusChanges(*sriovnetworkv1.SriovNetworkNodeState) (bool, error)
 }
+
+// KernelArgsReporter is implemented by the plugins which configure kernel arguments
+type KernelArgsReporter interface {
+	// PendingKernelArgs returns the kernel arguments computed by the last OnNodeStateChange which differ from
+	// the running kernel, the arguments to remove are prefixed with "-"
+	PendingKernelArgs() []string
+}
+
+// DegradedError is returned by the plugins when the configuration can't be applied until it's changed,
+// the daemon reports it with the Degraded condition of the node state
+type DegradedError struct {
+	// Reason of the Degraded condition
+	Reason  string
+	Message string
+}
+
+func (e *DegradedError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Reason, e.Message)
+}
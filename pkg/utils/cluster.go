@@ -188,6 +188,29 @@ func labelObject(ctx context.Context, obj client.Object, key, value string, c cl
 	return nil
 }
 
+// RemoveAnnotationFromObject removes an annotation from a kubernetes object
+func RemoveAnnotationFromObject(ctx context.Context, obj client.Object, key string, c client.Client) error {
+	if _, exist := obj.GetAnnotations()[key]; !exist {
+		return nil
+	}
+
+	log.Log.V(2).Info("RemoveAnnotationFromObject(): remove annotation from object",
+		"objectName", obj.GetName(),
+		"objectKind", obj.GetObjectKind(),
+		"annotationKey", key)
+	newObj := obj.DeepCopyObject().(client.Object)
+	delete(newObj.GetAnnotations(), key)
+	patch := client.MergeFrom(obj)
+	err := c.Patch(ctx,
+		newObj, patch)
+	if err != nil {
+		log.Log.Error(err, "RemoveAnnotationFromObject(): Failed to patch object")
+		return err
+	}
+
+	return nil
+}
+
 // removeLabelObject remove a label from a kubernetes object
 func removeLabelObject(ctx context.Context, obj client.Object, key string, c client.Client) error {
 	newObj := obj.DeepCopyObject().(client.Object)
@@ -234,3 +257,14 @@ func RemoveLabelFromNode(ctx context.Context, nodeName string, key string, c cli
 
 	return removeLabelObject(ctx, node, key, c)
 }
+
+// RemoveAnnotationFromNode removes an annotation from a node
+func RemoveAnnotationFromNode(ctx context.Context, nodeName string, key string, c client.Client) error {
+	node := &corev1.Node{}
+	err := c.Get(context.TODO(), client.ObjectKey{Name: nodeName}, node)
+	if err != nil {
+		return err
+	}
+
+	return RemoveAnnotationFromObject(ctx, node, key, c)
+}
@@ -3,6 +3,7 @@ package vars
 import (
 	"os"
 	"regexp"
+	"time"
 
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/rest"
@@ -57,12 +58,35 @@ var (
 	// MlxPluginFwReset global variable enables mstfwreset before rebooting a node on VF changes
 	MlxPluginFwReset = false
 
+	// VfioHugepagesAdvisory global variable makes the missing hugepages for vfio-pci VFs a warning instead of an error
+	VfioHugepagesAdvisory = false
+
+	// ReportVfConsumerPods global variable enables the reporting of the pods the VFs are allocated to in the status
+	ReportVfConsumerPods = false
+
+	// SkipRdma global variable bypasses the RDMA checks and configuration on clusters without RDMA hardware
+	SkipRdma = false
+
 	// FilesystemRoot used by test to mock interactions with filesystem
 	FilesystemRoot = ""
 
 	// OVSDBSocketPath path to OVSDB socket
 	OVSDBSocketPath = "unix:///var/run/openvswitch/db.sock"
 
+	// DaemonResyncPeriod is the interval at which the config-daemon re-processes its SriovNetworkNodeState
+	// even if the object didn't change, this is the steady-state check for drift on the host
+	DaemonResyncPeriod = 15 * time.Second
+
+	// DevicePluginRestartInterval is the minimum interval between two restarts of the device plugin by the
+	// config-daemon when the VF configuration didn't change, it prevents restart storms during reconcile bursts.
+	// Without the blockDevicePluginUntilConfigured feature gate the device plugin is only restarted when the VF
+	// configuration changed, the interval has then no effect as these restarts are never skipped
+	DevicePluginRestartInterval = 30 * time.Second
+
+	// SwitchdevMinKernelVersion is the minimum kernel version required to configure NICs in switchdev mode,
+	// the check is disabled if empty
+	SwitchdevMinKernelVersion = "5.8"
+
 	//Cluster variables
 	Config *rest.Config    = nil
 	Scheme *runtime.Scheme = nil
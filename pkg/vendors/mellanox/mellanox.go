@@ -8,6 +8,7 @@ import (
 
 	"github.com/pkg/errors"
 	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	utilversion "k8s.io/apimachinery/pkg/util/version"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	sriovnetworkv1 "github.com/k8snetworkplumbingwg/sriov-network-operator/api/v1"
@@ -268,6 +269,47 @@ func IsDualPort(pciAddress string, mellanoxNicsStatus map[string]map[string]srio
 	return len(mellanoxNicsStatus[pciAddressPrefix]) > 1
 }
 
+// firmwareRequirement is the driver and the minimal firmware version a NIC needs to support SR-IOV
+type firmwareRequirement struct {
+	name        string
+	driver      string
+	minFirmware string
+}
+
+// sriovFirmwareRequirements are the known requirements of the older ConnectX NICs keyed by device ID,
+// the NICs missing from the table support SR-IOV with all their firmware versions
+var sriovFirmwareRequirements = map[string]firmwareRequirement{
+	"1013": {name: "ConnectX-4", driver: "mlx5_core", minFirmware: "12.28.2006"},
+	"1015": {name: "ConnectX-4 Lx", driver: "mlx5_core", minFirmware: "14.28.2006"},
+	"1017": {name: "ConnectX-5", driver: "mlx5_core", minFirmware: "16.28.2006"},
+}
+
+// CheckSriovFirmwareSupport returns an error explaining how to fix the NIC when its driver or firmware
+// doesn't support SR-IOV, the driver and the firmware version are not checked when they are unknown
+func CheckSriovFirmwareSupport(deviceID, driver, fwVersion string) error {
+	req, ok := sriovFirmwareRequirements[deviceID]
+	if !ok {
+		return nil
+	}
+	if driver != "" && driver != req.driver {
+		return fmt.Errorf("%s NICs require the %s driver for SR-IOV but the NIC is bound to %s, bind the NIC to %s",
+			req.name, req.driver, driver, req.driver)
+	}
+	if fwVersion == "" {
+		return nil
+	}
+	current, err := utilversion.ParseGeneric(fwVersion)
+	if err != nil {
+		log.Log.V(2).Info("CheckSriovFirmwareSupport(): can't parse firmware version", "version", fwVersion, "error", err)
+		return nil
+	}
+	if current.LessThan(utilversion.MustParseGeneric(req.minFirmware)) {
+		return fmt.Errorf("%s NICs require firmware %s or newer for SR-IOV but the NIC runs firmware %s, "+
+			"update the firmware of the NIC (e.g. with mlxup) and reboot the node", req.name, req.minFirmware, fwVersion)
+	}
+	return nil
+}
+
 // handleTotalVfs return required total VFs or max (required VFs for dual port NIC) and needReboot if totalVfs will change
 func HandleTotalVfs(fwCurrent, fwNext, attrs *MlxNic, ifaceSpec sriovnetworkv1.Interface, isDualPort bool, mellanoxNicsSpec map[string]sriovnetworkv1.Interface) (
 	totalVfs int, needReboot, changeWithoutReboot bool) {
@@ -3,16 +3,32 @@ package webhook
 import (
 	"os"
 
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes"
+	corelisters "k8s.io/client-go/listers/core/v1"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	snclientset "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/client/clientset/versioned"
+	sninformers "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/client/informers/externalversions"
+	snlisters "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/client/listers/sriovnetwork/v1"
 )
 
 var snclient snclientset.Interface
-var kubeclient *kubernetes.Clientset
+var kubeclient kubernetes.Interface
+
+// nodeLister reads the nodes from the informer cache
+var nodeLister corelisters.NodeLister
+
+// operatorConfigLister reads the SriovOperatorConfigs from the informer cache
+var operatorConfigLister snlisters.SriovOperatorConfigLister
+
+// the networks are read from the informer cache to find the net-att-def conflicts
+var sriovNetworkLister snlisters.SriovNetworkLister
+var sriovIBNetworkLister snlisters.SriovIBNetworkLister
+var ovsNetworkLister snlisters.OVSNetworkLister
 
 func SetupInClusterClient() error {
 	var err error
@@ -34,5 +50,18 @@ func SetupInClusterClient() error {
 	snclient = snclientset.NewForConfigOrDie(config)
 	kubeclient = kubernetes.NewForConfigOrDie(config)
 
+	informerFactory := informers.NewSharedInformerFactory(kubeclient, 0)
+	nodeLister = informerFactory.Core().V1().Nodes().Lister()
+	informerFactory.Start(wait.NeverStop)
+	informerFactory.WaitForCacheSync(wait.NeverStop)
+
+	snInformerFactory := sninformers.NewSharedInformerFactory(snclient, 0)
+	operatorConfigLister = snInformerFactory.Sriovnetwork().V1().SriovOperatorConfigs().Lister()
+	sriovNetworkLister = snInformerFactory.Sriovnetwork().V1().SriovNetworks().Lister()
+	sriovIBNetworkLister = snInformerFactory.Sriovnetwork().V1().SriovIBNetworks().Lister()
+	ovsNetworkLister = snInformerFactory.Sriovnetwork().V1().OVSNetworks().Lister()
+	snInformerFactory.Start(wait.NeverStop)
+	snInformerFactory.WaitForCacheSync(wait.NeverStop)
+
 	return nil
 }
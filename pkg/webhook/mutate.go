@@ -2,18 +2,24 @@ package webhook
 
 import (
 	"encoding/json"
+	"fmt"
 	"strings"
 
 	v1 "k8s.io/api/admission/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
+	sriovnetworkv1 "github.com/k8snetworkplumbingwg/sriov-network-operator/api/v1"
 	constants "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/consts"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/vars"
 )
 
 var (
 	defaultPriorityPatch  = map[string]interface{}{"op": "add", "path": "/spec/priority", "value": 99}
 	defaultIsRdmaPatch    = map[string]interface{}{"op": "add", "path": "/spec/isRdma", "value": false}
 	InfiniBandIsRdmaPatch = map[string]interface{}{"op": "add", "path": "/spec/isRdma", "value": true}
+	defaultTrustPatch     = map[string]interface{}{"op": "add", "path": "/spec/trust", "value": sriovnetworkv1.SriovCniStateOff}
+	defaultSpoofChkPatch  = map[string]interface{}{"op": "add", "path": "/spec/spoofChk", "value": sriovnetworkv1.SriovCniStateOff}
 )
 
 func mutateSriovNetworkNodePolicy(cr map[string]interface{}) (*v1.AdmissionResponse, error) {
@@ -54,3 +60,61 @@ func mutateSriovNetworkNodePolicy(cr map[string]interface{}) (*v1.AdmissionRespo
 	reviewResponse.PatchType = &pt
 	return &reviewResponse, nil
 }
+
+func mutateSriovNetwork(cr map[string]interface{}, operation v1.Operation) (*v1.AdmissionResponse, error) {
+	log.Log.V(2).Info("mutateSriovNetwork(): set default value")
+	reviewResponse := v1.AdmissionResponse{}
+	reviewResponse.Allowed = true
+
+	if operation != v1.Create {
+		return &reviewResponse, nil
+	}
+
+	enabled, err := isFeatureGateEnabled(constants.SriovNetworkTrustSpoofChkOffByDefaultFeatureGate)
+	if err != nil {
+		return nil, err
+	}
+	if !enabled {
+		return &reviewResponse, nil
+	}
+
+	metadata, ok := cr["metadata"].(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("invalid SriovNetwork, metadata is not an object")
+	}
+	// the name is only logged, it's not set yet for the networks created with a generateName
+	name, _ := metadata["name"].(string)
+	spec, ok := cr["spec"].(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("invalid SriovNetwork %q, spec is not an object", name)
+	}
+	patchs := []map[string]interface{}{}
+	if _, ok := spec["trust"]; !ok {
+		log.Log.V(2).Info("mutateSriovNetwork(): set default trust to off for", "network-name", name)
+		patchs = append(patchs, defaultTrustPatch)
+	}
+	if _, ok := spec["spoofChk"]; !ok {
+		log.Log.V(2).Info("mutateSriovNetwork(): set default spoofChk to off for", "network-name", name)
+		patchs = append(patchs, defaultSpoofChkPatch)
+	}
+	reviewResponse.Patch, err = json.Marshal(patchs)
+	if err != nil {
+		return nil, err
+	}
+
+	pt := v1.PatchTypeJSONPatch
+	reviewResponse.PatchType = &pt
+	return &reviewResponse, nil
+}
+
+// isFeatureGateEnabled returns the state of the feature gate in the default SriovOperatorConfig
+func isFeatureGateEnabled(featureGate string) (bool, error) {
+	config, err := operatorConfigLister.SriovOperatorConfigs(vars.Namespace).Get(constants.DefaultConfigName)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to get default SriovOperatorConfig: %v", err)
+	}
+	return config.Spec.FeatureGates[featureGate], nil
+}
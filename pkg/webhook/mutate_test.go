@@ -0,0 +1,104 @@
+package webhook
+
+import (
+	"encoding/json"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	v1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	constants "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/consts"
+)
+
+func newSriovNetworkMap(spec map[string]interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"metadata": map[string]interface{}{"name": "test-network"},
+		"spec":     spec,
+	}
+}
+
+func getPatches(g *WithT, resp *v1.AdmissionResponse) []map[string]interface{} {
+	patches := []map[string]interface{}{}
+	if resp.Patch != nil {
+		g.Expect(json.Unmarshal(resp.Patch, &patches)).To(Succeed())
+	}
+	return patches
+}
+
+func TestMutateSriovNetworkTrustSpoofChkDefaultsGateDisabled(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	setFakeClients(newDefaultOperatorConfig())
+
+	resp, err := mutateSriovNetwork(newSriovNetworkMap(map[string]interface{}{"resourceName": "resource_1"}), v1.Create)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(resp.Allowed).To(BeTrue())
+	g.Expect(getPatches(g, resp)).To(BeEmpty())
+}
+
+func TestMutateSriovNetworkTrustSpoofChkDefaultsGateEnabled(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	config := newDefaultOperatorConfig()
+	config.Spec.FeatureGates = map[string]bool{constants.SriovNetworkTrustSpoofChkOffByDefaultFeatureGate: true}
+	setFakeClients(config)
+
+	resp, err := mutateSriovNetwork(newSriovNetworkMap(map[string]interface{}{"resourceName": "resource_1"}), v1.Create)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(resp.Allowed).To(BeTrue())
+	g.Expect(getPatches(g, resp)).To(ConsistOf(
+		map[string]interface{}{"op": "add", "path": "/spec/trust", "value": "off"},
+		map[string]interface{}{"op": "add", "path": "/spec/spoofChk", "value": "off"},
+	))
+
+	// explicitly configured values are kept
+	resp, err = mutateSriovNetwork(newSriovNetworkMap(map[string]interface{}{
+		"resourceName": "resource_1",
+		"trust":        "on",
+	}), v1.Create)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(getPatches(g, resp)).To(ConsistOf(
+		map[string]interface{}{"op": "add", "path": "/spec/spoofChk", "value": "off"},
+	))
+
+	// defaults are applied only on creation
+	resp, err = mutateSriovNetwork(newSriovNetworkMap(map[string]interface{}{"resourceName": "resource_1"}), v1.Update)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(resp.Allowed).To(BeTrue())
+	g.Expect(getPatches(g, resp)).To(BeEmpty())
+}
+
+func TestMutateSriovNetworkInvalidObject(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	config := newDefaultOperatorConfig()
+	config.Spec.FeatureGates = map[string]bool{constants.SriovNetworkTrustSpoofChkOffByDefaultFeatureGate: true}
+	setFakeClients(config)
+
+	_, err := mutateSriovNetwork(map[string]interface{}{"metadata": "test-network"}, v1.Create)
+	g.Expect(err).To(MatchError(ContainSubstring("metadata is not an object")))
+
+	_, err = mutateSriovNetwork(map[string]interface{}{"metadata": map[string]interface{}{"name": "test-network"}}, v1.Create)
+	g.Expect(err).To(MatchError(ContainSubstring("spec is not an object")))
+
+	// the admission request is rejected instead of panicking
+	raw, err := json.Marshal(map[string]interface{}{"metadata": []string{"test-network"}, "spec": map[string]interface{}{}})
+	g.Expect(err).NotTo(HaveOccurred())
+	resp := MutateCustomResource(v1.AdmissionReview{Request: &v1.AdmissionRequest{
+		Kind:      metav1.GroupVersionKind{Kind: "SriovNetwork"},
+		Operation: v1.Create,
+		Object:    runtime.RawExtension{Raw: raw},
+	}})
+	g.Expect(resp.Allowed).To(BeFalse())
+	g.Expect(resp.Result.Message).To(ContainSubstring("metadata is not an object"))
+
+	// networks created with a generateName don't have a name yet
+	resp, err = mutateSriovNetwork(map[string]interface{}{
+		"metadata": map[string]interface{}{"generateName": "test-network-"},
+		"spec":     map[string]interface{}{"resourceName": "resource_1"},
+	}, v1.Create)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(getPatches(g, resp)).To(HaveLen(2))
+}
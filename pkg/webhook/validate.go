@@ -2,9 +2,15 @@ package webhook
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"net"
 	"os"
+	"path/filepath"
 	"regexp"
+	"slices"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -27,15 +33,54 @@ const (
 )
 
 var (
+	// AllowOverlappingPools reports the SriovNetworkPoolConfigs selecting nodes of other pools
+	// with a warning instead of rejecting them
+	AllowOverlappingPools bool
+
 	nodesSelected     bool
 	interfaceSelected bool
+	cniTypeRegex      = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9._-]*$`)
+	vfDriverName      = regexp.MustCompile(`^[a-zA-Z0-9_-]+$`)
+	// allowedVfSysctls are the per-interface sysctls which can be set on the VFs
+	allowedVfSysctls = []string{
+		"net.ipv4.conf.<if>.accept_local",
+		"net.ipv4.conf.<if>.arp_announce",
+		"net.ipv4.conf.<if>.arp_ignore",
+		"net.ipv4.conf.<if>.arp_notify",
+		"net.ipv4.conf.<if>.rp_filter",
+		"net.ipv6.conf.<if>.accept_dad",
+		"net.ipv6.conf.<if>.accept_ra",
+		"net.ipv6.conf.<if>.disable_ipv6",
+	}
 )
 
+// nonCriticalError is a validation failure which is reported as an admission warning instead of
+// rejecting the object when the webhook validation mode is warn
+type nonCriticalError struct {
+	error
+}
+
+// isWarnValidationMode returns true if the default SriovOperatorConfig requests the non-critical
+// validation failures to be reported as warnings, the validation is enforced if it can't be read
+func isWarnValidationMode() bool {
+	config, err := operatorConfigLister.SriovOperatorConfigs(vars.Namespace).Get(consts.DefaultConfigName)
+	if err != nil {
+		if !k8serrors.IsNotFound(err) {
+			log.Log.Error(err, "failed to get default SriovOperatorConfig, enforce the validation")
+		}
+		return false
+	}
+	return config.Spec.WebhookValidationMode == sriovnetworkv1.WebhookValidationModeWarn
+}
+
 func validateSriovOperatorConfig(cr *sriovnetworkv1.SriovOperatorConfig, operation v1.Operation) (bool, []string, error) {
 	log.Log.V(2).Info("validateSriovOperatorConfig", "object", cr)
 	var warnings []string
 
 	if operation == v1.Delete {
+		if err := validateSriovOperatorConfigDelete(cr); err != nil {
+			return false, warnings, err
+		}
 		return true, warnings, nil
 	}
 
@@ -52,9 +97,52 @@ func validateSriovOperatorConfig(cr *sriovnetworkv1.SriovOperatorConfig, operati
 		return false, warnings, err
 	}
 
+	if cr.Spec.DrainPodSelector != nil {
+		if _, err := metav1.LabelSelectorAsSelector(cr.Spec.DrainPodSelector); err != nil {
+			return false, warnings, fmt.Errorf("invalid drainPodSelector: %v", err)
+		}
+	}
+
+	if cr.Spec.MaxUnavailable != nil {
+		pool := &sriovnetworkv1.SriovNetworkPoolConfig{Spec: sriovnetworkv1.SriovNetworkPoolConfigSpec{MaxUnavailable: cr.Spec.MaxUnavailable}}
+		if _, err := pool.MaxUnavailable(0); err != nil {
+			return false, warnings, fmt.Errorf("invalid maxUnavailable: %v", err)
+		}
+	}
+
 	return true, warnings, nil
 }
 
+// validateSriovOperatorConfigDelete prevents the deletion of the default SriovOperatorConfig while SriovNetworkNodePolicies exist,
+// removing it tears down the webhooks and the daemonsets managing the nodes. The check can be bypassed with the force delete annotation.
+func validateSriovOperatorConfigDelete(cr *sriovnetworkv1.SriovOperatorConfig) error {
+	if cr.GetName() != consts.DefaultConfigName || cr.GetNamespace() != vars.Namespace {
+		return nil
+	}
+	if cr.GetAnnotations()[consts.ForceDeleteAnnotation] == "true" {
+		log.Log.Info("force deletion of the default SriovOperatorConfig requested", "annotation", consts.ForceDeleteAnnotation)
+		return nil
+	}
+
+	policies, err := snclient.SriovnetworkV1().SriovNetworkNodePolicies(cr.Namespace).List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("can't validate SriovOperatorConfig[%s] deletion: %q", cr.Name, err)
+	}
+	var names []string
+	for _, policy := range policies.Items {
+		if policy.Name == consts.DefaultPolicyName {
+			continue
+		}
+		names = append(names, policy.Name)
+	}
+	if len(names) > 0 {
+		return fmt.Errorf("can't delete SriovOperatorConfig[%s] while SriovNetworkNodePolicies exist [%s], "+
+			"remove the policies first or set the %s=\"true\" annotation to force the deletion",
+			cr.Name, strings.Join(names, ", "), consts.ForceDeleteAnnotation)
+	}
+	return nil
+}
+
 // validateSriovOperatorConfigDisableDrain checks if the user is setting `.Spec.DisableDrain` from false to true while
 // operator is updating one or more nodes. Disabling the drain at this stage would prevent the operator to uncordon a node at
 // the end of the update operation, keeping nodes un-schedulable until manual intervention.
@@ -107,9 +195,388 @@ func validateSriovNetworkPoolConfig(cr *sriovnetworkv1.SriovNetworkPoolConfig, o
 		}
 	}
 
+	if operation != v1.Delete {
+		overlap, err := validateSriovNetworkPoolConfigOverlap(cr)
+		if err != nil {
+			return false, warnings, err
+		}
+		if overlap != "" {
+			if !AllowOverlappingPools {
+				return false, warnings, fmt.Errorf("SriovNetworkPoolConfig[%s] %s", cr.Name, overlap)
+			}
+			warnings = append(warnings, fmt.Sprintf("SriovNetworkPoolConfig[%s] %s, "+
+				"the nodes are only configured by one of the pools", cr.Name, overlap))
+		}
+	}
+
 	return true, warnings, nil
 }
 
+// validateSriovNetworkPoolConfigOverlap returns a description of the overlap if the nodes selected by the pool
+// are also selected by another pool, the maxUnavailable and rdmaMode of these nodes would be ambiguous.
+// As in the drain controller a pool without nodeSelector selects all the nodes and the pools
+// of an OvsHardwareOffloadConfig are ignored.
+func validateSriovNetworkPoolConfigOverlap(cr *sriovnetworkv1.SriovNetworkPoolConfig) (string, error) {
+	if cr.Spec.OvsHardwareOffloadConfig.Name != "" {
+		return "", nil
+	}
+	selector, err := poolNodeSelector(cr)
+	if err != nil {
+		return "", fmt.Errorf("SriovNetworkPoolConfig[%s] invalid nodeSelector: %v", cr.Name, err)
+	}
+
+	pools, err := snclient.SriovnetworkV1().SriovNetworkPoolConfigs(cr.Namespace).List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		return "", fmt.Errorf("can't validate SriovNetworkPoolConfig[%s] nodeSelector: %v", cr.Name, err)
+	}
+	type poolSelector struct {
+		name     string
+		selector labels.Selector
+	}
+	others := []poolSelector{}
+	for _, pool := range pools.Items {
+		if pool.Name == cr.Name || pool.Spec.OvsHardwareOffloadConfig.Name != "" {
+			continue
+		}
+		otherSelector, err := poolNodeSelector(&pool)
+		if err != nil {
+			log.Log.Error(err, "validateSriovNetworkPoolConfigOverlap(): invalid nodeSelector, skipping", "pool", pool.Name)
+			continue
+		}
+		others = append(others, poolSelector{pool.Name, otherSelector})
+	}
+	if len(others) == 0 {
+		return "", nil
+	}
+
+	nodes, err := nodeLister.List(selector)
+	if err != nil {
+		return "", fmt.Errorf("can't validate SriovNetworkPoolConfig[%s] nodeSelector: %v", cr.Name, err)
+	}
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].Name < nodes[j].Name })
+	for _, node := range nodes {
+		for _, other := range others {
+			if other.selector.Matches(labels.Set(node.Labels)) {
+				return fmt.Sprintf("selects node %s which is already selected by SriovNetworkPoolConfig[%s]", node.Name, other.name), nil
+			}
+		}
+	}
+	return "", nil
+}
+
+// poolNodeSelector returns the selector of the nodes of the pool, a pool without nodeSelector selects all the nodes
+func poolNodeSelector(pool *sriovnetworkv1.SriovNetworkPoolConfig) (labels.Selector, error) {
+	if pool.Spec.NodeSelector == nil {
+		return labels.Everything(), nil
+	}
+	return metav1.LabelSelectorAsSelector(pool.Spec.NodeSelector)
+}
+
+// validateSriovNetwork checks that the namespace targeted by the network is allowed by the default SriovOperatorConfig
+func validateSriovNetwork(cr *sriovnetworkv1.SriovNetwork, operation v1.Operation) (bool, []string, error) {
+	log.Log.V(2).Info("validateSriovNetwork", "object", cr)
+	var warnings []string
+
+	if operation == v1.Delete {
+		return true, warnings, nil
+	}
+
+	if err := validateIPAM(cr.Spec.IPAM); err != nil {
+		return false, warnings, fmt.Errorf("SriovNetwork[%s] invalid IPAM configuration: %v", cr.Name, err)
+	}
+
+	if err := validateCniType(cr.Spec.CniType); err != nil {
+		return false, warnings, fmt.Errorf("SriovNetwork[%s] %v", cr.Name, err)
+	}
+
+	if cr.Spec.ClearVlan && cr.Spec.Vlan != 0 {
+		return false, warnings, fmt.Errorf("SriovNetwork[%s] clearVlan can't be used together with vlan %d", cr.Name, cr.Spec.Vlan)
+	}
+
+	if err := validateNetAttDefConflict("SriovNetwork", cr); err != nil {
+		return false, warnings, err
+	}
+
+	if cr.GetNamespace() != vars.Namespace {
+		return true, warnings, nil
+	}
+
+	if cr.Spec.PreferredPf != "" {
+		warning, err := validatePreferredPf(cr)
+		if err != nil {
+			return false, warnings, err
+		}
+		if warning != "" {
+			warnings = append(warnings, warning)
+		}
+	}
+
+	targetNamespace := cr.NetworkNamespace()
+	if targetNamespace == "" {
+		targetNamespace = cr.GetNamespace()
+	}
+
+	config, err := operatorConfigLister.SriovOperatorConfigs(vars.Namespace).Get(consts.DefaultConfigName)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return true, warnings, nil
+		}
+		return false, warnings, fmt.Errorf("can't validate SriovNetwork[%s] network namespace: %q", cr.Name, err)
+	}
+
+	if len(config.Spec.AllowedNetworkNamespaces) > 0 &&
+		!sriovnetworkv1.StringInArray(targetNamespace, config.Spec.AllowedNetworkNamespaces) {
+		return false, warnings, fmt.Errorf("SriovNetwork[%s] can't target namespace %s, allowed namespaces are %v",
+			cr.Name, targetNamespace, config.Spec.AllowedNetworkNamespaces)
+	}
+
+	return true, warnings, nil
+}
+
+// validatePreferredPf checks the preferred PF of the network is one of the PFs providing its resource,
+// a warning is returned when the resource isn't provided by any node yet
+func validatePreferredPf(cr *sriovnetworkv1.SriovNetwork) (string, error) {
+	nodeStates, err := snclient.SriovnetworkV1().SriovNetworkNodeStates(namespace).List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		return "", fmt.Errorf("can't validate SriovNetwork[%s] preferred PF: %q", cr.Name, err)
+	}
+
+	pfNames := []string{}
+	for _, nodeState := range nodeStates.Items {
+		for _, iface := range nodeState.Spec.Interfaces {
+			if !slices.ContainsFunc(iface.VfGroups, func(group sriovnetworkv1.VfGroup) bool {
+				return group.ResourceName == cr.Spec.ResourceName
+			}) {
+				continue
+			}
+			for _, ifaceStatus := range nodeState.Status.Interfaces {
+				if ifaceStatus.PciAddress == iface.PciAddress && !slices.Contains(pfNames, ifaceStatus.Name) {
+					pfNames = append(pfNames, ifaceStatus.Name)
+				}
+			}
+		}
+	}
+
+	if len(pfNames) == 0 {
+		return fmt.Sprintf("SriovNetwork[%s] preferred PF %s can't be validated, no node provides the resource %s",
+			cr.Name, cr.Spec.PreferredPf, cr.Spec.ResourceName), nil
+	}
+	if !slices.Contains(pfNames, cr.Spec.PreferredPf) {
+		sort.Strings(pfNames)
+		return "", fmt.Errorf("SriovNetwork[%s] preferred PF %s doesn't provide the resource %s, the PFs providing it are %v",
+			cr.Name, cr.Spec.PreferredPf, cr.Spec.ResourceName, pfNames)
+	}
+	return "", nil
+}
+
+// validateOVSNetwork checks the OVSNetwork link state is one of the supported values and the IPAM configuration is consistent
+func validateOVSNetwork(cr *sriovnetworkv1.OVSNetwork, operation v1.Operation) (bool, []string, error) {
+	log.Log.V(2).Info("validateOVSNetwork", "object", cr)
+	var warnings []string
+
+	if operation == v1.Delete {
+		return true, warnings, nil
+	}
+
+	allowedLinkStates := []string{sriovnetworkv1.SriovCniStateAuto, sriovnetworkv1.SriovCniStateEnable, sriovnetworkv1.SriovCniStateDisable}
+	if cr.Spec.LinkState != "" && !sriovnetworkv1.StringInArray(cr.Spec.LinkState, allowedLinkStates) {
+		return false, warnings, fmt.Errorf("OVSNetwork[%s] invalid linkState %q, allowed values are %v",
+			cr.Name, cr.Spec.LinkState, allowedLinkStates)
+	}
+
+	if err := validateIPAM(cr.Spec.IPAM); err != nil {
+		return false, warnings, fmt.Errorf("OVSNetwork[%s] invalid IPAM configuration: %v", cr.Name, err)
+	}
+
+	if err := validateCniType(cr.Spec.CniType); err != nil {
+		return false, warnings, fmt.Errorf("OVSNetwork[%s] %v", cr.Name, err)
+	}
+
+	if err := validateNetAttDefConflict("OVSNetwork", cr); err != nil {
+		return false, warnings, err
+	}
+
+	return true, warnings, nil
+}
+
+// validateSriovIBNetwork checks the net-att-def generated for the SriovIBNetwork doesn't conflict with another network
+func validateSriovIBNetwork(cr *sriovnetworkv1.SriovIBNetwork, operation v1.Operation) (bool, []string, error) {
+	log.Log.V(2).Info("validateSriovIBNetwork", "object", cr)
+	var warnings []string
+
+	if operation == v1.Delete {
+		return true, warnings, nil
+	}
+
+	if err := validateIPAM(cr.Spec.IPAM); err != nil {
+		return false, warnings, fmt.Errorf("SriovIBNetwork[%s] invalid IPAM configuration: %v", cr.Name, err)
+	}
+
+	if err := validateNetAttDefConflict("SriovIBNetwork", cr); err != nil {
+		return false, warnings, err
+	}
+
+	return true, warnings, nil
+}
+
+// netAttDefNetwork is implemented by the network CRs generating a net-att-def
+type netAttDefNetwork interface {
+	metav1.Object
+	NetworkNamespace() string
+	NetAttDefName() string
+}
+
+// netAttDefKey returns the namespace and the name of the net-att-def generated for the network,
+// the net-att-def is generated in the namespace of the network when no network namespace is set
+func netAttDefKey(cr netAttDefNetwork) string {
+	namespace := cr.NetworkNamespace()
+	if namespace == "" {
+		namespace = cr.GetNamespace()
+	}
+	return namespace + "/" + cr.NetAttDefName()
+}
+
+// validateNetAttDefConflict checks no other network, of any type, generates a net-att-def with the same name
+// in the same namespace as the network, the controller would never take over the net-att-def of the other network
+func validateNetAttDefConflict(kind string, cr netAttDefNetwork) error {
+	key := netAttDefKey(cr)
+	networks := map[string][]netAttDefNetwork{}
+
+	sriovNetworks, err := sriovNetworkLister.List(labels.Everything())
+	if err != nil {
+		return fmt.Errorf("can't validate %s[%s] NetworkAttachmentDefinition: %q", kind, cr.GetName(), err)
+	}
+	for _, network := range sriovNetworks {
+		networks["SriovNetwork"] = append(networks["SriovNetwork"], network)
+	}
+	ibNetworks, err := sriovIBNetworkLister.List(labels.Everything())
+	if err != nil {
+		return fmt.Errorf("can't validate %s[%s] NetworkAttachmentDefinition: %q", kind, cr.GetName(), err)
+	}
+	for _, network := range ibNetworks {
+		networks["SriovIBNetwork"] = append(networks["SriovIBNetwork"], network)
+	}
+	ovsNetworks, err := ovsNetworkLister.List(labels.Everything())
+	if err != nil {
+		return fmt.Errorf("can't validate %s[%s] NetworkAttachmentDefinition: %q", kind, cr.GetName(), err)
+	}
+	for _, network := range ovsNetworks {
+		networks["OVSNetwork"] = append(networks["OVSNetwork"], network)
+	}
+
+	for _, otherKind := range []string{"SriovNetwork", "SriovIBNetwork", "OVSNetwork"} {
+		for _, other := range networks[otherKind] {
+			// the network itself on update
+			if otherKind == kind && other.GetNamespace() == cr.GetNamespace() && other.GetName() == cr.GetName() {
+				continue
+			}
+			if netAttDefKey(other) == key {
+				return fmt.Errorf("%s[%s] NetworkAttachmentDefinition %s is already generated by %s %s/%s",
+					kind, cr.GetName(), key, otherKind, other.GetNamespace(), other.GetName())
+			}
+		}
+	}
+	return nil
+}
+
+// validateCniType checks the CNI type override is a plausible name of a CNI plugin binary
+func validateCniType(cniType string) error {
+	if cniType == "" {
+		return nil
+	}
+	if len(cniType) > 255 || !cniTypeRegex.MatchString(cniType) {
+		return fmt.Errorf("invalid cniType %q, it must be the name of a CNI plugin binary", cniType)
+	}
+	return nil
+}
+
+// hostLocalIPAMRange is an address range of the host-local IPAM plugin
+type hostLocalIPAMRange struct {
+	Subnet     string `json:"subnet"`
+	RangeStart string `json:"rangeStart,omitempty"`
+	RangeEnd   string `json:"rangeEnd,omitempty"`
+	Gateway    string `json:"gateway,omitempty"`
+}
+
+// hostLocalIPAM is the subset of the host-local IPAM plugin configuration which is validated
+type hostLocalIPAM struct {
+	hostLocalIPAMRange
+	Ranges [][]hostLocalIPAMRange `json:"ranges,omitempty"`
+}
+
+// validateIPAM checks the subnets and ranges of host-local IPAM configurations.
+// Each range set must contain subnets of a single IP family, a dual-stack configuration
+// uses a range set per family. Configurations which are not of a known IPAM type, including
+// the ones whose type can't be read, are not validated.
+func validateIPAM(ipam string) error {
+	if strings.TrimSpace(ipam) == "" {
+		return nil
+	}
+	ipamType := struct {
+		Type string `json:"type"`
+	}{}
+	if err := json.Unmarshal([]byte(ipam), &ipamType); err != nil || ipamType.Type != "host-local" {
+		return nil
+	}
+
+	conf := hostLocalIPAM{}
+	if err := json.Unmarshal([]byte(ipam), &conf); err != nil {
+		return fmt.Errorf("failed to parse host-local IPAM configuration: %v", err)
+	}
+	if conf.Subnet != "" {
+		if _, err := validateHostLocalIPAMRange(&conf.hostLocalIPAMRange); err != nil {
+			return err
+		}
+	}
+	for i, rangeSet := range conf.Ranges {
+		if len(rangeSet) == 0 {
+			return fmt.Errorf("range set %d is empty", i)
+		}
+		var setIsIPv4 bool
+		for j := range rangeSet {
+			isIPv4, err := validateHostLocalIPAMRange(&rangeSet[j])
+			if err != nil {
+				return fmt.Errorf("range set %d: %v", i, err)
+			}
+			if j == 0 {
+				setIsIPv4 = isIPv4
+			} else if isIPv4 != setIsIPv4 {
+				return fmt.Errorf("range set %d mixes IPv4 and IPv6 subnets, use a separate range set for each IP family", i)
+			}
+		}
+	}
+	return nil
+}
+
+// validateHostLocalIPAMRange checks the range addresses belong to the range subnet
+// and returns true if the subnet is an IPv4 one
+func validateHostLocalIPAMRange(r *hostLocalIPAMRange) (bool, error) {
+	_, subnet, err := net.ParseCIDR(r.Subnet)
+	if err != nil {
+		return false, fmt.Errorf("invalid subnet %q: %v", r.Subnet, err)
+	}
+	isIPv4 := subnet.IP.To4() != nil
+	for _, addr := range []struct{ field, value string }{
+		{"rangeStart", r.RangeStart}, {"rangeEnd", r.RangeEnd}, {"gateway", r.Gateway},
+	} {
+		field, value := addr.field, addr.value
+		if value == "" {
+			continue
+		}
+		ip := net.ParseIP(value)
+		if ip == nil {
+			return false, fmt.Errorf("invalid %s %q", field, value)
+		}
+		if (ip.To4() != nil) != isIPv4 {
+			return false, fmt.Errorf("%s %s and subnet %s are of different IP families", field, value, r.Subnet)
+		}
+		if !subnet.Contains(ip) {
+			return false, fmt.Errorf("%s %s is not in subnet %s", field, value, r.Subnet)
+		}
+	}
+	return isIPv4, nil
+}
+
 func validateSriovNetworkNodePolicy(cr *sriovnetworkv1.SriovNetworkNodePolicy, operation v1.Operation) (bool, []string, error) {
 	log.Log.V(2).Info("validateSriovNetworkNodePolicy", "object", cr)
 	var warnings []string
@@ -135,6 +602,9 @@ func validateSriovNetworkNodePolicy(cr *sriovnetworkv1.SriovNetworkNodePolicy, o
 
 	admit, err = dynamicValidateSriovNetworkNodePolicy(cr)
 	if err != nil {
+		if errors.As(err, &nonCriticalError{}) && isWarnValidationMode() {
+			return true, append(warnings, err.Error()), nil
+		}
 		return admit, warnings, err
 	}
 
@@ -151,6 +621,10 @@ func staticValidateSriovNetworkNodePolicy(cr *sriovnetworkv1.SriovNetworkNodePol
 		return false, fmt.Errorf("at least one of these parameters (vendor, deviceID, pfNames, rootDevices or netFilter) has to be defined in nicSelector in CR %s", cr.GetName())
 	}
 
+	if cr.Spec.NumVfs > 0 && cr.Spec.MaxNumVfs > 0 {
+		return false, fmt.Errorf("numVfs and maxNumVfs can't be used together in CR %s", cr.GetName())
+	}
+
 	devMode := false
 	if os.Getenv("DEV_MODE") == "TRUE" {
 		devMode = true
@@ -174,32 +648,16 @@ func staticValidateSriovNetworkNodePolicy(cr *sriovnetworkv1.SriovNetworkNodePol
 		}
 	}
 
-	if len(cr.Spec.NicSelector.PfNames) > 0 {
-		for _, pf := range cr.Spec.NicSelector.PfNames {
-			if strings.Contains(pf, "#") {
-				fields := strings.Split(pf, "#")
-				if len(fields) != 2 {
-					return false, fmt.Errorf("failed to parse %s PF name in nicSelector, probably incorrect separator character usage", pf)
-				}
-				rng := strings.Split(fields[1], "-")
-				if len(rng) != 2 {
-					return false, fmt.Errorf("failed to parse %s PF name nicSelector, probably incorrect range character usage", pf)
-				}
-				rngSt, err := strconv.Atoi(rng[0])
-				if err != nil {
-					return false, fmt.Errorf("failed to parse %s PF name nicSelector, start range is incorrect", pf)
-				}
-				rngEnd, err := strconv.Atoi(rng[1])
-				if err != nil {
-					return false, fmt.Errorf("failed to parse %s PF name nicSelector, end range is incorrect", pf)
-				}
-				if rngEnd < rngSt {
-					return false, fmt.Errorf("failed to parse %s PF name nicSelector, end range shall not be smaller than start range", pf)
-				}
-				if !(rngEnd < cr.Spec.NumVfs) {
-					return false, fmt.Errorf("failed to parse %s PF name nicSelector, end range exceeds the maximum VF index ", pf)
-				}
-			}
+	maxVfs := max(cr.Spec.NumVfs, cr.Spec.MaxNumVfs)
+	for _, pf := range cr.Spec.NicSelector.PfNames {
+		if err := validateVfRange(pf, maxVfs); err != nil {
+			return false, fmt.Errorf("invalid PF name %s in nicSelector: %v", pf, err)
+		}
+	}
+	// the VFs of the rootDevices selectors are not partitioned, a range would never select the PF
+	for _, rootDevice := range cr.Spec.NicSelector.RootDevices {
+		if strings.Contains(rootDevice, "#") {
+			return false, fmt.Errorf("invalid root device %s in nicSelector: VF ranges are only supported in pfNames", rootDevice)
 		}
 	}
 
@@ -223,17 +681,199 @@ func staticValidateSriovNetworkNodePolicy(cr *sriovnetworkv1.SriovNetworkNodePol
 	if (cr.Spec.VdpaType == consts.VdpaTypeVirtio || cr.Spec.VdpaType == consts.VdpaTypeVhost) && cr.Spec.EswitchMode != sriovnetworkv1.ESwithModeSwitchDev {
 		return false, fmt.Errorf("vdpa requires the device to be configured in switchdev mode")
 	}
+	// vdpa devices are created on top of the default driver of the VFs
+	if cr.Spec.VfDriver != "" {
+		if !vfDriverName.MatchString(cr.Spec.VfDriver) {
+			return false, fmt.Errorf("vfDriver %q is not a valid kernel driver name", cr.Spec.VfDriver)
+		}
+		if cr.Spec.VdpaType != "" {
+			return false, fmt.Errorf("vfDriver can't be used together with vdpaType")
+		}
+	}
 	// software bridge management: device must be configured in switchdev mode
 	if !cr.Spec.Bridge.IsEmpty() && cr.Spec.EswitchMode != sriovnetworkv1.ESwithModeSwitchDev {
 		return false, fmt.Errorf("software bridge management requires the device to be configured in switchdev mode")
 	}
+	// VF representors only exist in switchdev mode
+	if cr.Spec.KeepDefaultRepresentorNames && cr.Spec.EswitchMode != sriovnetworkv1.ESwithModeSwitchDev {
+		return false, fmt.Errorf("keepDefaultRepresentorNames requires the device to be configured in switchdev mode")
+	}
 	// software bridge management: device can't be externally managed
 	if !cr.Spec.Bridge.IsEmpty() && cr.Spec.ExternallyManaged {
 		return false, fmt.Errorf("software bridge management can't be used when the device externally managed")
 	}
+	// software bridge management: trunks are valid VLAN IDs and can't be set on an access port
+	if !cr.Spec.Bridge.IsEmpty() {
+		port := cr.Spec.Bridge.OVS.Uplink.Port
+		for _, vlan := range port.Trunks {
+			if vlan < 0 || vlan > 4095 {
+				return false, fmt.Errorf("invalid OVS uplink trunk VLAN %d, must be between 0 and 4095", vlan)
+			}
+		}
+		if len(port.Trunks) > 0 && port.VlanMode == "access" {
+			return false, fmt.Errorf("OVS uplink trunks can't be used with 'vlanMode: access'")
+		}
+	}
+	// number of VF queues is configured with ethtool on the VF netdev
+	if cr.Spec.NumVfQueues > 0 && cr.Spec.DeviceType != "" && cr.Spec.DeviceType != consts.DeviceTypeNetDevice {
+		return false, fmt.Errorf("numVfQueues can only be used with 'deviceType: %s'", consts.DeviceTypeNetDevice)
+	}
+	// the VF MTU is set on the VF netdev and can't exceed the MTU of the PF
+	if cr.Spec.VfMtu > 0 {
+		if cr.Spec.DeviceType != "" && cr.Spec.DeviceType != consts.DeviceTypeNetDevice {
+			return false, fmt.Errorf("vfMtu can only be used with 'deviceType: %s'", consts.DeviceTypeNetDevice)
+		}
+		if cr.Spec.Mtu > 0 && cr.Spec.VfMtu > cr.Spec.Mtu {
+			return false, fmt.Errorf("vfMtu(%d) is higher than the mtu(%d) of the PF", cr.Spec.VfMtu, cr.Spec.Mtu)
+		}
+	}
+	// a forced link speed disables the auto-negotiation
+	if cr.Spec.LinkSpeedMbps > 0 && cr.Spec.LinkAutoNeg != nil && *cr.Spec.LinkAutoNeg {
+		return false, fmt.Errorf("linkSpeedMbps can't be used when linkAutoNeg is enabled")
+	}
+	// VF attributes are only configured for VFs of externally managed devices
+	if cr.Spec.VfAttributes != nil && !cr.Spec.ExternallyManaged {
+		return false, fmt.Errorf("vfAttributes can only be used when the device is externally managed")
+	}
+	// the VLAN protocol is applied together with the VLAN of the VF
+	if cr.Spec.VfAttributes != nil && cr.Spec.VfAttributes.VlanProto != "" && cr.Spec.VfAttributes.Vlan == 0 {
+		return false, fmt.Errorf("vfAttributes.vlanProto can only be used together with vfAttributes.vlan")
+	}
+	if err := sriovnetworkv1.ValidateVfTxRates(cr.Spec.VfAttributes, cr.Spec.LinkSpeedMbps); err != nil {
+		return false, err
+	}
+	// sysctls are set on the VF netdev
+	if len(cr.Spec.VfSysctls) > 0 {
+		if cr.Spec.DeviceType != "" && cr.Spec.DeviceType != consts.DeviceTypeNetDevice {
+			return false, fmt.Errorf("vfSysctls can only be used with 'deviceType: %s'", consts.DeviceTypeNetDevice)
+		}
+		for name, value := range cr.Spec.VfSysctls {
+			if !sriovnetworkv1.StringInArray(name, allowedVfSysctls) {
+				return false, fmt.Errorf("sysctl %s in vfSysctls is not allowed, allowed sysctls are %v", name, allowedVfSysctls)
+			}
+			if value == "" || strings.ContainsAny(value, "\n\r") {
+				return false, fmt.Errorf("invalid value %q for sysctl %s in vfSysctls", value, name)
+			}
+		}
+	}
+	// the per VF trust mode only applies to the VFs of the policy
+	if len(cr.Spec.VfTrust) > 0 {
+		if err := validateVfTrustIndexes(cr); err != nil {
+			return false, err
+		}
+	}
+	// the promiscuous mode is set on the VF netdev and only takes effect on trusted VFs
+	if cr.Spec.Promisc != nil && *cr.Spec.Promisc {
+		if cr.Spec.DeviceType != "" && cr.Spec.DeviceType != consts.DeviceTypeNetDevice {
+			return false, fmt.Errorf("promisc can only be used with 'deviceType: %s'", consts.DeviceTypeNetDevice)
+		}
+		trusted := cr.Spec.VfAttributes != nil && cr.Spec.VfAttributes.Trust
+		for _, trust := range cr.Spec.VfTrust {
+			trusted = trusted || trust
+		}
+		if !trusted {
+			return false, fmt.Errorf("promisc can only be enabled on trusted VFs, enable the trust mode with vfAttributes or vfTrust")
+		}
+	}
+	// the filters are set on the VF netdev, dropping frames contradicts the promiscuous mode
+	if cr.Spec.VfFilters != nil {
+		if cr.Spec.DeviceType != "" && cr.Spec.DeviceType != consts.DeviceTypeNetDevice {
+			return false, fmt.Errorf("vfFilters can only be used with 'deviceType: %s'", consts.DeviceTypeNetDevice)
+		}
+		if cr.Spec.VfFilters.Broadcast == nil && cr.Spec.VfFilters.Multicast == nil {
+			return false, fmt.Errorf("vfFilters must configure broadcast or multicast")
+		}
+		dropBroadcast := cr.Spec.VfFilters.Broadcast != nil && !*cr.Spec.VfFilters.Broadcast
+		dropMulticast := cr.Spec.VfFilters.Multicast != nil && !*cr.Spec.VfFilters.Multicast
+		if (dropBroadcast || dropMulticast) && cr.Spec.Promisc != nil && *cr.Spec.Promisc {
+			return false, fmt.Errorf("vfFilters dropping broadcast or multicast frames can't be used together with promisc")
+		}
+	}
+	// MACsec is configured on the VF netdev
+	if cr.Spec.Macsec != nil {
+		if cr.Spec.DeviceType != "" && cr.Spec.DeviceType != consts.DeviceTypeNetDevice {
+			return false, fmt.Errorf("macsec can only be used with 'deviceType: %s'", consts.DeviceTypeNetDevice)
+		}
+		if cr.Spec.Macsec.KeySecretName == "" {
+			return false, fmt.Errorf("macsec requires the keySecretName of the Secret holding the key")
+		}
+	}
+
+	if cr.Spec.VfRss != nil {
+		if cr.Spec.DeviceType != "" && cr.Spec.DeviceType != consts.DeviceTypeNetDevice {
+			return false, fmt.Errorf("vfRss can only be used with 'deviceType: %s'", consts.DeviceTypeNetDevice)
+		}
+		if cr.Spec.VfRss.HashKey == "" && cr.Spec.VfRss.IndirectionSize == 0 {
+			return false, fmt.Errorf("vfRss requires the hashKey or the indirectionSize")
+		}
+		if cr.Spec.NumVfQueues > 0 && cr.Spec.VfRss.IndirectionSize > cr.Spec.NumVfQueues {
+			return false, fmt.Errorf("vfRss indirectionSize %d is greater than numVfQueues %d",
+				cr.Spec.VfRss.IndirectionSize, cr.Spec.NumVfQueues)
+		}
+	}
 	return true, nil
 }
 
+// validateVfRange checks that the optional VF range of a pfNames selector, e.g. "eno1#0-3",
+// is well-formed and that its last VF index is lower than the number of VFs of the policy
+func validateVfRange(device string, numVfs int) error {
+	if !strings.Contains(device, "#") {
+		return nil
+	}
+	fields := strings.Split(device, "#")
+	if len(fields) != 2 || fields[0] == "" {
+		return fmt.Errorf("expected <device>#<first VF>-<last VF>, probably incorrect separator character usage")
+	}
+	rng := strings.Split(fields[1], "-")
+	if len(rng) != 2 {
+		return fmt.Errorf("VF range %q must be <first VF>-<last VF>", fields[1])
+	}
+	rngSt, err := strconv.Atoi(rng[0])
+	if err != nil {
+		return fmt.Errorf("first VF index %q of the range is not a non negative integer", rng[0])
+	}
+	rngEnd, err := strconv.Atoi(rng[1])
+	if err != nil {
+		return fmt.Errorf("last VF index %q of the range is not a non negative integer", rng[1])
+	}
+	if rngEnd < rngSt {
+		return fmt.Errorf("last VF index %d is smaller than the first VF index %d", rngEnd, rngSt)
+	}
+	if rngEnd >= numVfs {
+		return fmt.Errorf("last VF index %d is out of range, numVfs is %d", rngEnd, numVfs)
+	}
+	return nil
+}
+
+// validateVfTrustIndexes checks that the VF indexes of vfTrust are lower than numVfs and belong to
+// the VF range of at least one of the pfNames selectors, when the selectors restrict the VF range
+func validateVfTrustIndexes(cr *sriovnetworkv1.SriovNetworkNodePolicy) error {
+	type vfRange struct{ start, end int }
+	ranges := []vfRange{}
+	for _, pfName := range cr.Spec.NicSelector.PfNames {
+		_, rngStart, rngEnd, err := sriovnetworkv1.ParseVfRange(pfName)
+		if err != nil {
+			return fmt.Errorf("invalid PF name %s: %v", pfName, err)
+		}
+		if rngStart >= 0 && rngEnd >= 0 {
+			ranges = append(ranges, vfRange{rngStart, rngEnd})
+		}
+	}
+	for index := range cr.Spec.VfTrust {
+		vfID, err := strconv.Atoi(index)
+		if err != nil || vfID < 0 {
+			return fmt.Errorf("invalid VF index %q in vfTrust, must be a non negative integer", index)
+		}
+		if vfID >= cr.Spec.NumVfs {
+			return fmt.Errorf("VF index %d in vfTrust is out of range, numVfs is %d", vfID, cr.Spec.NumVfs)
+		}
+		if len(ranges) > 0 && !slices.ContainsFunc(ranges, func(r vfRange) bool { return vfID >= r.start && vfID <= r.end }) {
+			return fmt.Errorf("VF index %d in vfTrust is not in the VF range of the pfNames %v", vfID, cr.Spec.NicSelector.PfNames)
+		}
+	}
+	return nil
+}
+
 func dynamicValidateSriovNetworkNodePolicy(cr *sriovnetworkv1.SriovNetworkNodePolicy) (bool, error) {
 	nodesSelected = false
 	interfaceSelected = false
@@ -264,7 +904,7 @@ func dynamicValidateSriovNetworkNodePolicy(cr *sriovnetworkv1.SriovNetworkNodePo
 	}
 
 	if !nodesSelected {
-		return false, fmt.Errorf("no matched node is selected by the nodeSelector in CR %s", cr.GetName())
+		return false, nonCriticalError{fmt.Errorf("no matched node is selected by the nodeSelector in CR %s", cr.GetName())}
 	}
 	if !interfaceSelected {
 		for nodeName, messages := range nodeInterfaceErrorList {
@@ -272,7 +912,7 @@ func dynamicValidateSriovNetworkNodePolicy(cr *sriovnetworkv1.SriovNetworkNodePo
 				log.Log.V(2).Info("interface selection errors", "nodeName", nodeName, "message", message)
 			}
 		}
-		return false, fmt.Errorf("no supported NIC is selected by the nicSelector in CR %s", cr.GetName())
+		return false, nonCriticalError{fmt.Errorf("no supported NIC is selected by the nicSelector in CR %s", cr.GetName())}
 	}
 
 	return true, nil
@@ -288,6 +928,9 @@ func validatePolicyForNodeStateAndPolicy(nsList *sriovnetworkv1.SriovNetworkNode
 			if interfaceAndErrorList != nil {
 				nodeInterfaceErrorList[ns.GetName()] = interfaceAndErrorList
 			}
+			if err := validateVfNamePrefix(cr, npList, &ns, node); err != nil {
+				return err
+			}
 			break
 		}
 	}
@@ -313,7 +956,7 @@ func validatePolicyForNodeState(policy *sriovnetworkv1.SriovNetworkNodePolicy, s
 		if err == nil {
 			interfaceSelected = true
 			interfaceSelectedForNode = true
-			if policy.GetName() != consts.DefaultPolicyName && policy.Spec.NumVfs == 0 {
+			if policy.GetName() != consts.DefaultPolicyName && policy.GetNumVfs(&iface) == 0 {
 				return nil, fmt.Errorf("numVfs(%d) in CR %s is not allowed", policy.Spec.NumVfs, policy.GetName())
 			}
 			if policy.Spec.NumVfs > iface.TotalVfs && iface.Vendor == IntelID {
@@ -323,10 +966,24 @@ func validatePolicyForNodeState(policy *sriovnetworkv1.SriovNetworkNodePolicy, s
 				return nil, fmt.Errorf("numVfs(%d) in CR %s exceed the maximum allowed value(%d) interface(%s)", policy.Spec.NumVfs, policy.GetName(), MlxMaxVFs, iface.Name)
 			}
 
+			// the MTU of the PF is only raised up to the mtu of the policy
+			if iface.Mtu > 0 && policy.Spec.VfMtu > max(policy.Spec.Mtu, iface.Mtu) {
+				return nil, fmt.Errorf("vfMtu(%d) in CR %s is higher than the MTU of the PF %s(%d)", policy.Spec.VfMtu, policy.GetName(), iface.Name, iface.Mtu)
+			}
+
+			// the TX rates of the VFs are limited by the link speed of the PF, or by the speed forced by the policy
+			linkSpeedMbps := sriovnetworkv1.GetLinkSpeedMbps(&iface)
+			if policy.Spec.LinkSpeedMbps > 0 {
+				linkSpeedMbps = policy.Spec.LinkSpeedMbps
+			}
+			if err := sriovnetworkv1.ValidateVfTxRates(policy.Spec.VfAttributes, linkSpeedMbps); err != nil {
+				return nil, fmt.Errorf("%v in CR %s for the PF %s", err, policy.GetName(), iface.Name)
+			}
+
 			// Externally create validations
 			if policy.Spec.ExternallyManaged {
-				if policy.Spec.NumVfs > iface.NumVfs {
-					return nil, fmt.Errorf("numVfs(%d) in CR %s is higher than the virtual functions allocated for the PF externally value(%d)", policy.Spec.NumVfs, policy.GetName(), iface.NumVfs)
+				if policy.GetNumVfs(&iface) > iface.NumVfs {
+					return nil, fmt.Errorf("numVfs(%d) in CR %s is higher than the virtual functions allocated for the PF externally value(%d)", policy.GetNumVfs(&iface), policy.GetName(), iface.NumVfs)
 				}
 
 				if policy.Spec.Mtu != 0 && policy.Spec.Mtu > iface.Mtu {
@@ -353,6 +1010,43 @@ func validatePolicyForNodeState(policy *sriovnetworkv1.SriovNetworkNodePolicy, s
 	return nil, nil
 }
 
+// validateVfNamePrefix rejects a VF name prefix which would give the same names to the VFs of different PFs
+// of the node, i.e. a prefix used for several PFs by the policy or used by another policy for another PF
+func validateVfNamePrefix(policy *sriovnetworkv1.SriovNetworkNodePolicy, npList *sriovnetworkv1.SriovNetworkNodePolicyList,
+	state *sriovnetworkv1.SriovNetworkNodeState, node *corev1.Node) error {
+	if policy.Spec.VfNamePrefix == "" {
+		return nil
+	}
+	pfs := selectedPfNames(&policy.Spec.NicSelector, state, node)
+	if len(pfs) > 1 {
+		return fmt.Errorf("vfNamePrefix(%s) in CR %s is used for several PFs of node %s: %s",
+			policy.Spec.VfNamePrefix, policy.GetName(), node.GetName(), strings.Join(pfs, ", "))
+	}
+	for _, np := range npList.Items {
+		if np.GetName() == policy.GetName() || np.Spec.VfNamePrefix != policy.Spec.VfNamePrefix || !np.Selected(node) {
+			continue
+		}
+		for _, pf := range selectedPfNames(&np.Spec.NicSelector, state, node) {
+			if !slices.Contains(pfs, pf) {
+				return fmt.Errorf("vfNamePrefix(%s) in CR %s is already used by policy %s for the PF %s of node %s",
+					policy.Spec.VfNamePrefix, policy.GetName(), np.GetName(), pf, node.GetName())
+			}
+		}
+	}
+	return nil
+}
+
+// selectedPfNames returns the names of the PFs of the node state selected by the NIC selector
+func selectedPfNames(selector *sriovnetworkv1.SriovNetworkNicSelector, state *sriovnetworkv1.SriovNetworkNodeState, node *corev1.Node) []string {
+	pfs := []string{}
+	for i := range state.Status.Interfaces {
+		if validateNicModel(selector, &state.Status.Interfaces[i], node) == nil {
+			pfs = append(pfs, state.Status.Interfaces[i].Name)
+		}
+	}
+	return pfs
+}
+
 func validatePolicyForNodePolicy(current *sriovnetworkv1.SriovNetworkNodePolicy, previous *sriovnetworkv1.SriovNetworkNodePolicy) error {
 	log.Log.V(2).Info("validateConflictPolicy(): validate policy against policy",
 		"source", current.GetName(), "target", previous.GetName())
@@ -389,7 +1083,7 @@ func validatePfNames(current *sriovnetworkv1.SriovNetworkNodePolicy, previous *s
 			// Not validate return err for previous PF
 			// since it should already be evaluated in previous run.
 			preName, preRngSt, preRngEnd, _ := sriovnetworkv1.ParseVfRange(prePf)
-			if curName == preName {
+			if selectorsOverlap(curName, preName) {
 				err = validateExternallyManage(current, previous)
 				if err != nil {
 					return err
@@ -411,7 +1105,7 @@ func validateRootDevices(current *sriovnetworkv1.SriovNetworkNodePolicy, previou
 	for _, curRootDevice := range current.Spec.NicSelector.RootDevices {
 		for _, preRootDevice := range previous.Spec.NicSelector.RootDevices {
 			// TODO: (SchSeba) implement range for root devices
-			if curRootDevice == preRootDevice {
+			if selectorsOverlap(curRootDevice, preRootDevice) {
 				return fmt.Errorf("root device %s is overlapped with existing policy %s", curRootDevice, previous.GetName())
 			}
 		}
@@ -419,6 +1113,19 @@ func validateRootDevices(current *sriovnetworkv1.SriovNetworkNodePolicy, previou
 	return nil
 }
 
+// selectorsOverlap returns true if the pfNames or rootDevices selectors are equal or if one of them
+// is a glob pattern matching the other
+func selectorsOverlap(a, b string) bool {
+	if a == b {
+		return true
+	}
+	if matched, err := filepath.Match(a, b); err == nil && matched {
+		return true
+	}
+	matched, err := filepath.Match(b, a)
+	return err == nil && matched
+}
+
 func validateExternallyManage(current, previous *sriovnetworkv1.SriovNetworkNodePolicy) error {
 	// reject policy with externallyManage if there is a policy on the same PF without it
 	if current.Spec.ExternallyManaged != previous.Spec.ExternallyManaged {
@@ -448,7 +1155,7 @@ func validateNicModel(selector *sriovnetworkv1.SriovNetworkNicSelector, iface *s
 	if selector.DeviceID != "" && selector.DeviceID != iface.DeviceID {
 		return fmt.Errorf("selector device ID: %s is not equal to the interface device ID: %s", selector.Vendor, iface.Vendor)
 	}
-	if len(selector.RootDevices) > 0 && !sriovnetworkv1.StringInArray(iface.PciAddress, selector.RootDevices) {
+	if len(selector.RootDevices) > 0 && !sriovnetworkv1.StringMatchInArray(iface.PciAddress, selector.RootDevices) {
 		return fmt.Errorf("interface PCI address: %s not found in root devices", iface.PciAddress)
 	}
 	if len(selector.PfNames) > 0 {
@@ -461,7 +1168,7 @@ func validateNicModel(selector *sriovnetworkv1.SriovNetworkNicSelector, iface *s
 				pfNames = append(pfNames, p)
 			}
 		}
-		if !sriovnetworkv1.StringInArray(iface.Name, pfNames) {
+		if !sriovnetworkv1.StringMatchInArray(iface.Name, pfNames) {
 			return fmt.Errorf("interface name: %s not found in physical function names", iface.PciAddress)
 		}
 	}
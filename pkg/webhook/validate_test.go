@@ -10,13 +10,18 @@ import (
 
 	. "github.com/onsi/gomega"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	fakek8s "k8s.io/client-go/kubernetes/fake"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
 
 	. "github.com/k8snetworkplumbingwg/sriov-network-operator/api/v1"
 	constants "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/consts"
 	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/vars"
 
 	fakesnclientset "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/client/clientset/versioned/fake"
+	snlisters "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/client/listers/sriovnetwork/v1"
 )
 
 func TestMain(m *testing.M) {
@@ -39,6 +44,7 @@ func TestMain(m *testing.M) {
 		"14e4 16d7 16dc", // BCM57414 2x25G
 		"14e4 1750 1806", // BCM75508 2x100G
 	}
+	vars.Namespace = "openshift-sriov-network-operator"
 	os.Exit(m.Run())
 }
 
@@ -168,7 +174,7 @@ func TestValidateSriovOperatorConfigWithDefaultOperatorConfig(t *testing.T) {
 	g := NewGomegaWithT(t)
 
 	config := newDefaultOperatorConfig()
-	snclient = fakesnclientset.NewSimpleClientset()
+	setFakeClients()
 
 	ok, w, err := validateSriovOperatorConfig(config, "DELETE")
 	g.Expect(err).NotTo(HaveOccurred())
@@ -189,6 +195,65 @@ func TestValidateSriovOperatorConfigWithDefaultOperatorConfig(t *testing.T) {
 	g.Expect(ok).To(Equal(true))
 }
 
+func TestValidateSriovOperatorConfigDeleteWithPolicies(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	config := newDefaultOperatorConfig()
+	policy := &SriovNetworkNodePolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "p1", Namespace: vars.Namespace},
+	}
+	setFakeClients(config, policy)
+
+	ok, _, err := validateSriovOperatorConfig(config, "DELETE")
+	g.Expect(err).To(MatchError(ContainSubstring("remove the policies first")))
+	g.Expect(err).To(MatchError(ContainSubstring("[p1]")))
+	g.Expect(ok).To(Equal(false))
+
+	config.Annotations = map[string]string{constants.ForceDeleteAnnotation: "true"}
+	ok, _, err = validateSriovOperatorConfig(config, "DELETE")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(ok).To(Equal(true))
+}
+
+func TestValidateSriovOperatorConfigDrainPodSelector(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	config := newDefaultOperatorConfig()
+	setFakeClients()
+
+	config.Spec.DrainPodSelector = &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{{
+		Key: "drain.example.com/skip", Operator: metav1.LabelSelectorOpDoesNotExist,
+	}}}
+	ok, _, err := validateSriovOperatorConfig(config, "UPDATE")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(ok).To(Equal(true))
+
+	config.Spec.DrainPodSelector = &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{{
+		Key: "drain.example.com/skip", Operator: metav1.LabelSelectorOpIn,
+	}}}
+	ok, _, err = validateSriovOperatorConfig(config, "UPDATE")
+	g.Expect(err).To(MatchError(ContainSubstring("invalid drainPodSelector")))
+	g.Expect(ok).To(Equal(false))
+}
+
+func TestValidateSriovOperatorConfigMaxUnavailable(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	config := newDefaultOperatorConfig()
+	setFakeClients()
+
+	maxUnavailable := intstr.Parse("50%")
+	config.Spec.MaxUnavailable = &maxUnavailable
+	ok, _, err := validateSriovOperatorConfig(config, "UPDATE")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(ok).To(Equal(true))
+
+	maxUnavailable = intstr.Parse("two")
+	ok, _, err = validateSriovOperatorConfig(config, "UPDATE")
+	g.Expect(err).To(MatchError(ContainSubstring("invalid maxUnavailable")))
+	g.Expect(ok).To(Equal(false))
+}
+
 func TestValidateSriovOperatorConfigDisableDrain(t *testing.T) {
 	g := NewGomegaWithT(t)
 
@@ -202,7 +267,7 @@ func TestValidateSriovOperatorConfigDisableDrain(t *testing.T) {
 		},
 	}
 
-	snclient = fakesnclientset.NewSimpleClientset(
+	setFakeClients(
 		config,
 		nodeState,
 	)
@@ -226,7 +291,7 @@ func TestValidateSriovNetworkPoolConfigWithDefault(t *testing.T) {
 	g := NewGomegaWithT(t)
 
 	config := newDefaultNetworkPoolConfig()
-	snclient = fakesnclientset.NewSimpleClientset()
+	setFakeClients()
 
 	ok, _, err := validateSriovNetworkPoolConfig(config, "DELETE")
 	g.Expect(err).ToNot(HaveOccurred())
@@ -246,7 +311,7 @@ func TestValidateSriovNetworkPoolConfigWithParallelAndHWOffload(t *testing.T) {
 
 	config := newDefaultNetworkPoolConfig()
 	config.Spec.OvsHardwareOffloadConfig.Name = "test"
-	snclient = fakesnclientset.NewSimpleClientset()
+	setFakeClients()
 
 	ok, _, err := validateSriovNetworkPoolConfig(config, "UPDATE")
 	g.Expect(err).To(HaveOccurred())
@@ -257,6 +322,440 @@ func TestValidateSriovNetworkPoolConfigWithParallelAndHWOffload(t *testing.T) {
 	g.Expect(ok).To(BeFalse())
 }
 
+func newPoolConfigWithNodeSelector(name string, matchLabels map[string]string) *SriovNetworkPoolConfig {
+	config := newDefaultNetworkPoolConfig()
+	config.Name = name
+	config.Namespace = vars.Namespace
+	config.Spec.NodeSelector = &metav1.LabelSelector{MatchLabels: matchLabels}
+	return config
+}
+
+func newNodeListerWithNodes(nodes ...*corev1.Node) corelisters.NodeLister {
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	for _, node := range nodes {
+		_ = indexer.Add(node)
+	}
+	return corelisters.NewNodeLister(indexer)
+}
+
+// setFakeClients sets the sriov clientset and fills the listers of the informer cache with the given objects
+func setFakeClients(objects ...runtime.Object) {
+	snclient = fakesnclientset.NewSimpleClientset(objects...)
+
+	newIndexer := func() cache.Indexer {
+		return cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	}
+	configs, sriovNetworks, ibNetworks, ovsNetworks := newIndexer(), newIndexer(), newIndexer(), newIndexer()
+	for _, obj := range objects {
+		switch obj.(type) {
+		case *SriovOperatorConfig:
+			_ = configs.Add(obj)
+		case *SriovNetwork:
+			_ = sriovNetworks.Add(obj)
+		case *SriovIBNetwork:
+			_ = ibNetworks.Add(obj)
+		case *OVSNetwork:
+			_ = ovsNetworks.Add(obj)
+		}
+	}
+	operatorConfigLister = snlisters.NewSriovOperatorConfigLister(configs)
+	sriovNetworkLister = snlisters.NewSriovNetworkLister(sriovNetworks)
+	sriovIBNetworkLister = snlisters.NewSriovIBNetworkLister(ibNetworks)
+	ovsNetworkLister = snlisters.NewOVSNetworkLister(ovsNetworks)
+}
+
+func TestValidateSriovNetworkPoolConfigOverlappingNodeSelector(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	existing := newPoolConfigWithNodeSelector("pool-a", map[string]string{"pool": "a"})
+	setFakeClients(existing)
+	nodeLister = newNodeListerWithNodes(
+		&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "worker-0", Labels: map[string]string{"pool": "a", "zone": "1"}}},
+		&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "worker-1", Labels: map[string]string{"pool": "b", "zone": "1"}}},
+	)
+	defer func() { nodeLister = nil }()
+
+	config := newPoolConfigWithNodeSelector("pool-zone", map[string]string{"zone": "1"})
+
+	ok, _, err := validateSriovNetworkPoolConfig(config, "CREATE")
+	g.Expect(err).To(MatchError(ContainSubstring("selects node worker-0 which is already selected by SriovNetworkPoolConfig[pool-a]")))
+	g.Expect(ok).To(BeFalse())
+
+	ok, _, err = validateSriovNetworkPoolConfig(config, "UPDATE")
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(ok).To(BeFalse())
+
+	ok, _, err = validateSriovNetworkPoolConfig(config, "DELETE")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(ok).To(BeTrue())
+
+	AllowOverlappingPools = true
+	defer func() { AllowOverlappingPools = false }()
+
+	ok, w, err := validateSriovNetworkPoolConfig(config, "CREATE")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(ok).To(BeTrue())
+	g.Expect(w).To(ContainElement(ContainSubstring("selects node worker-0 which is already selected by SriovNetworkPoolConfig[pool-a]")))
+}
+
+func TestValidateSriovNetworkPoolConfigDisjointNodeSelector(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	existing := newPoolConfigWithNodeSelector("pool-a", map[string]string{"pool": "a"})
+	setFakeClients(existing)
+	nodeLister = newNodeListerWithNodes(
+		&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "worker-0", Labels: map[string]string{"pool": "a"}}},
+		&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "worker-1", Labels: map[string]string{"pool": "b"}}},
+	)
+	defer func() { nodeLister = nil }()
+
+	config := newPoolConfigWithNodeSelector("pool-b", map[string]string{"pool": "b"})
+	ok, w, err := validateSriovNetworkPoolConfig(config, "CREATE")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(ok).To(BeTrue())
+	g.Expect(w).To(BeEmpty())
+
+	// updating the existing pool must not report an overlap with itself
+	ok, w, err = validateSriovNetworkPoolConfig(existing, "UPDATE")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(ok).To(BeTrue())
+	g.Expect(w).To(BeEmpty())
+}
+
+func TestValidateSriovNetworkPoolConfigWithoutNodeSelector(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	existing := newPoolConfigWithNodeSelector("pool-a", map[string]string{"pool": "a"})
+	hwOffload := newPoolConfigWithNodeSelector("hw-offload", nil)
+	hwOffload.Spec.NodeSelector = nil
+	hwOffload.Spec.MaxUnavailable = nil
+	hwOffload.Spec.OvsHardwareOffloadConfig.Name = "worker"
+	setFakeClients(existing, hwOffload)
+	nodeLister = newNodeListerWithNodes(
+		&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "worker-0", Labels: map[string]string{"pool": "a"}}},
+		&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "worker-1", Labels: map[string]string{"pool": "b"}}},
+	)
+	defer func() { nodeLister = nil }()
+
+	// a pool without nodeSelector selects all the nodes
+	config := newPoolConfigWithNodeSelector("pool-all", nil)
+	config.Spec.NodeSelector = nil
+	ok, _, err := validateSriovNetworkPoolConfig(config, "CREATE")
+	g.Expect(err).To(MatchError(ContainSubstring("selects node worker-0 which is already selected by SriovNetworkPoolConfig[pool-a]")))
+	g.Expect(ok).To(BeFalse())
+
+	// the pools of an OvsHardwareOffloadConfig don't select nodes for the drain
+	config = newPoolConfigWithNodeSelector("pool-b", map[string]string{"pool": "b"})
+	ok, _, err = validateSriovNetworkPoolConfig(config, "CREATE")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(ok).To(BeTrue())
+
+	ok, _, err = validateSriovNetworkPoolConfig(hwOffload, "UPDATE")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(ok).To(BeTrue())
+}
+
+func TestValidateOVSNetworkLinkState(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	setFakeClients()
+
+	network := &OVSNetwork{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-network",
+			Namespace: vars.Namespace,
+		},
+		Spec: OVSNetworkSpec{
+			ResourceName: "resource_1",
+		},
+	}
+
+	for _, linkState := range []string{"", "auto", "enable", "disable"} {
+		network.Spec.LinkState = linkState
+		ok, _, err := validateOVSNetwork(network, "CREATE")
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(ok).To(BeTrue())
+	}
+
+	network.Spec.LinkState = "up"
+	ok, _, err := validateOVSNetwork(network, "UPDATE")
+	g.Expect(err).To(MatchError(ContainSubstring("invalid linkState \"up\"")))
+	g.Expect(ok).To(BeFalse())
+
+	ok, _, err = validateOVSNetwork(network, "DELETE")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(ok).To(BeTrue())
+}
+
+func newSriovNetwork(networkNamespace string) *SriovNetwork {
+	return &SriovNetwork{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-network",
+			Namespace: vars.Namespace,
+		},
+		Spec: SriovNetworkSpec{
+			ResourceName:     "resource_1",
+			NetworkNamespace: networkNamespace,
+		},
+	}
+}
+
+func TestValidateSriovNetworkWithoutAllowedNamespaces(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	setFakeClients(newDefaultOperatorConfig())
+
+	ok, _, err := validateSriovNetwork(newSriovNetwork("any-namespace"), "CREATE")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(ok).To(BeTrue())
+}
+
+func TestValidateSriovNetworkWithAllowedNamespaces(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	config := newDefaultOperatorConfig()
+	config.Spec.AllowedNetworkNamespaces = []string{"allowed-namespace"}
+	setFakeClients(config)
+
+	ok, _, err := validateSriovNetwork(newSriovNetwork("allowed-namespace"), "CREATE")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(ok).To(BeTrue())
+
+	ok, _, err = validateSriovNetwork(newSriovNetwork("other-namespace"), "UPDATE")
+	g.Expect(err).To(MatchError(ContainSubstring("can't target namespace other-namespace")))
+	g.Expect(ok).To(BeFalse())
+
+	// the network namespace defaults to the namespace of the SriovNetwork
+	ok, _, err = validateSriovNetwork(newSriovNetwork(""), "CREATE")
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(ok).To(BeFalse())
+
+	ok, _, err = validateSriovNetwork(newSriovNetwork("other-namespace"), "DELETE")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(ok).To(BeTrue())
+}
+
+func TestValidateSriovNetworkDualStackIPAM(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	setFakeClients(newDefaultOperatorConfig())
+
+	network := newSriovNetwork("")
+	network.Spec.IPAM = `{"type":"host-local","ranges":[` +
+		`[{"subnet":"10.56.217.0/24","rangeStart":"10.56.217.171","rangeEnd":"10.56.217.181","gateway":"10.56.217.1"}],` +
+		`[{"subnet":"fd00:10:56::/64","rangeStart":"fd00:10:56::10","rangeEnd":"fd00:10:56::20","gateway":"fd00:10:56::1"}]]}`
+	ok, _, err := validateSriovNetwork(network, "CREATE")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(ok).To(BeTrue())
+
+	network.Spec.IPAM = `{"type":"host-local","subnet":"10.56.217.0/24","rangeStart":"10.56.217.171","gateway":"10.56.217.1"}`
+	ok, _, err = validateSriovNetwork(network, "CREATE")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(ok).To(BeTrue())
+
+	// other IPAM plugins are not validated
+	network.Spec.IPAM = `{"type":"whereabouts","range":"fd00:10:56::/64","gateway":"10.56.217.1"}`
+	ok, _, err = validateSriovNetwork(network, "CREATE")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(ok).To(BeTrue())
+
+	network.Spec.IPAM = `{"type":{"name":"custom-ipam"},"range":"fd00:10:56::/64"}`
+	ok, _, err = validateSriovNetwork(network, "CREATE")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(ok).To(BeTrue())
+}
+
+func TestValidateSriovIBNetworkInvalidDualStackIPAM(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	setFakeClients(newDefaultOperatorConfig())
+
+	ibNetwork := &SriovIBNetwork{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-ib-network", Namespace: vars.Namespace},
+		Spec: SriovIBNetworkSpec{
+			ResourceName: "resource_ib",
+			IPAM: `{"type":"host-local","ranges":[` +
+				`[{"subnet":"10.56.217.0/24"},{"subnet":"fd00:10:56::/64"}]]}`,
+		},
+	}
+	ok, _, err := validateSriovIBNetwork(ibNetwork, "CREATE")
+	g.Expect(err).To(MatchError(ContainSubstring("SriovIBNetwork[test-ib-network] invalid IPAM configuration: range set 0 mixes IPv4 and IPv6 subnets")))
+	g.Expect(ok).To(BeFalse())
+
+	ibNetwork.Spec.IPAM = `{"type":"host-local","ranges":[[{"subnet":"10.56.217.0/24"}],[{"subnet":"fd00:10:56::/64"}]]}`
+	ok, _, err = validateSriovIBNetwork(ibNetwork, "CREATE")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(ok).To(BeTrue())
+}
+
+func TestValidateSriovNetworkClearVlan(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	setFakeClients(newDefaultOperatorConfig())
+
+	network := newSriovNetwork("")
+	network.Spec.ClearVlan = true
+	ok, _, err := validateSriovNetwork(network, "CREATE")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(ok).To(BeTrue())
+
+	network.Spec.Vlan = 100
+	ok, _, err = validateSriovNetwork(network, "UPDATE")
+	g.Expect(err).To(MatchError(ContainSubstring("clearVlan can't be used together with vlan 100")))
+	g.Expect(ok).To(BeFalse())
+}
+
+func TestValidateSriovNetworkCniType(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	setFakeClients(newDefaultOperatorConfig())
+
+	network := newSriovNetwork("")
+	network.Spec.CniType = "sriov-custom_v2.1"
+	ok, _, err := validateSriovNetwork(network, "CREATE")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(ok).To(BeTrue())
+
+	for _, cniType := range []string{"/opt/cni/bin/sriov", "../sriov", "sriov cni", "-sriov"} {
+		network.Spec.CniType = cniType
+		ok, _, err = validateSriovNetwork(network, "CREATE")
+		g.Expect(err).To(MatchError(ContainSubstring("invalid cniType %q", cniType)))
+		g.Expect(ok).To(BeFalse())
+	}
+}
+
+func TestValidateSriovNetworkPreferredPf(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	nodeState := &SriovNetworkNodeState{
+		ObjectMeta: metav1.ObjectMeta{Name: "worker-1", Namespace: namespace},
+		Spec: SriovNetworkNodeStateSpec{
+			Interfaces: Interfaces{
+				{PciAddress: "0000:86:00.0", VfGroups: []VfGroup{{ResourceName: "resource_1", VfRange: "0-3"}}},
+				{PciAddress: "0000:86:00.1", VfGroups: []VfGroup{{ResourceName: "resource_2", VfRange: "0-3"}}},
+			},
+		},
+		Status: SriovNetworkNodeStateStatus{
+			Interfaces: InterfaceExts{
+				{Name: "ens1f0", PciAddress: "0000:86:00.0"},
+				{Name: "ens1f1", PciAddress: "0000:86:00.1"},
+			},
+		},
+	}
+	setFakeClients(newDefaultOperatorConfig(), nodeState)
+
+	network := newSriovNetwork("")
+	network.Spec.PreferredPf = "ens1f0"
+	ok, warnings, err := validateSriovNetwork(network, "CREATE")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(ok).To(BeTrue())
+	g.Expect(warnings).To(BeEmpty())
+
+	network.Spec.PreferredPf = "ens1f1"
+	ok, _, err = validateSriovNetwork(network, "CREATE")
+	g.Expect(err).To(MatchError(ContainSubstring("preferred PF ens1f1 doesn't provide the resource resource_1, the PFs providing it are [ens1f0]")))
+	g.Expect(ok).To(BeFalse())
+
+	network.Spec.ResourceName = "resource_3"
+	ok, warnings, err = validateSriovNetwork(network, "CREATE")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(ok).To(BeTrue())
+	g.Expect(warnings).To(ConsistOf(ContainSubstring("no node provides the resource resource_3")))
+}
+
+func TestValidateSriovNetworkNetAttDefConflict(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	existing := newSriovNetwork("target-namespace")
+	setFakeClients(newDefaultOperatorConfig(), existing)
+
+	// the network itself on update
+	ok, _, err := validateSriovNetwork(newSriovNetwork("target-namespace"), "UPDATE")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(ok).To(BeTrue())
+
+	network := newSriovNetwork("target-namespace")
+	network.Namespace = "other-namespace"
+	network.Spec.NetworkNamespace = ""
+	ok, _, err = validateSriovNetwork(network, "CREATE")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(ok).To(BeTrue())
+
+	network.Spec.NetworkNamespace = "target-namespace"
+	network.Namespace = "target-namespace"
+	ok, _, err = validateSriovNetwork(network, "CREATE")
+	g.Expect(err).To(MatchError(ContainSubstring(
+		"NetworkAttachmentDefinition target-namespace/test-network is already generated by SriovNetwork %s/test-network", vars.Namespace)))
+	g.Expect(ok).To(BeFalse())
+
+	// the net-att-def name can be overridden
+	network.Name = "other-network"
+	network.Spec.NetworkName = "test-network"
+	ok, _, err = validateSriovNetwork(network, "CREATE")
+	g.Expect(err).To(MatchError(ContainSubstring("is already generated by SriovNetwork")))
+	g.Expect(ok).To(BeFalse())
+}
+
+func TestValidateNetworksNetAttDefConflictAcrossTypes(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	ibNetwork := &SriovIBNetwork{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-network", Namespace: vars.Namespace},
+		Spec:       SriovIBNetworkSpec{ResourceName: "resource_ib", NetworkNamespace: "target-namespace"},
+	}
+	setFakeClients(newDefaultOperatorConfig(), ibNetwork)
+
+	ok, _, err := validateSriovIBNetwork(ibNetwork, "UPDATE")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(ok).To(BeTrue())
+
+	ok, _, err = validateSriovNetwork(newSriovNetwork("target-namespace"), "CREATE")
+	g.Expect(err).To(MatchError(ContainSubstring(
+		"SriovNetwork[test-network] NetworkAttachmentDefinition target-namespace/test-network is already generated by SriovIBNetwork %s/test-network", vars.Namespace)))
+	g.Expect(ok).To(BeFalse())
+
+	ovsNetwork := &OVSNetwork{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-network", Namespace: vars.Namespace},
+		Spec:       OVSNetworkSpec{ResourceName: "resource_ovs", NetworkNamespace: "target-namespace"},
+	}
+	ok, _, err = validateOVSNetwork(ovsNetwork, "CREATE")
+	g.Expect(err).To(MatchError(ContainSubstring("is already generated by SriovIBNetwork")))
+	g.Expect(ok).To(BeFalse())
+
+	ovsNetwork.Spec.NetworkNamespace = "other-namespace"
+	ok, _, err = validateOVSNetwork(ovsNetwork, "CREATE")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(ok).To(BeTrue())
+}
+
+func TestValidateSriovNetworkInvalidDualStackIPAM(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	setFakeClients(newDefaultOperatorConfig())
+
+	network := newSriovNetwork("")
+	network.Spec.IPAM = `{"type":"host-local","ranges":[` +
+		`[{"subnet":"10.56.217.0/24"},{"subnet":"fd00:10:56::/64"}]]}`
+	ok, _, err := validateSriovNetwork(network, "CREATE")
+	g.Expect(err).To(MatchError(ContainSubstring("range set 0 mixes IPv4 and IPv6 subnets")))
+	g.Expect(ok).To(BeFalse())
+
+	network.Spec.IPAM = `{"type":"host-local","ranges":[` +
+		`[{"subnet":"10.56.217.0/24"}],[{"subnet":"fd00:10:56::/64","gateway":"10.56.217.1"}]]}`
+	ok, _, err = validateSriovNetwork(network, "UPDATE")
+	g.Expect(err).To(MatchError(ContainSubstring("gateway 10.56.217.1 and subnet fd00:10:56::/64 are of different IP families")))
+	g.Expect(ok).To(BeFalse())
+
+	network.Spec.IPAM = `{"type":"host-local","subnet":"10.56.217.0/24","rangeEnd":"10.56.218.10"}`
+	ok, _, err = validateSriovNetwork(network, "CREATE")
+	g.Expect(err).To(MatchError(ContainSubstring("rangeEnd 10.56.218.10 is not in subnet 10.56.217.0/24")))
+	g.Expect(ok).To(BeFalse())
+
+	ok, _, err = validateSriovNetwork(network, "DELETE")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(ok).To(BeTrue())
+}
+
 func TestValidateSriovNetworkNodePolicyWithDefaultPolicy(t *testing.T) {
 	var err error
 	var ok bool
@@ -338,6 +837,32 @@ func TestValidatePolicyForNodeStateWithInvalidNumVfsPolicy(t *testing.T) {
 	g.Expect(err).To(MatchError("numVfs(65) in CR p1 exceed the maximum allowed value(64) interface(ens803f0)"))
 }
 
+func TestValidatePolicyForNodeStateWithMaxNumVfsPolicy(t *testing.T) {
+	state := newNodeState()
+	policy := &SriovNetworkNodePolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "p1",
+		},
+		Spec: SriovNetworkNodePolicySpec{
+			DeviceType: "netdevice",
+			NicSelector: SriovNetworkNicSelector{
+				PfNames:     []string{"ens803f0"},
+				RootDevices: []string{"0000:86:00.0"},
+				Vendor:      "8086",
+			},
+			NodeSelector: map[string]string{
+				"feature.node.kubernetes.io/network-sriov.capable": "true",
+			},
+			MaxNumVfs:    128,
+			Priority:     99,
+			ResourceName: "p0",
+		},
+	}
+	g := NewGomegaWithT(t)
+	_, err := validatePolicyForNodeState(policy, state, NewNode())
+	g.Expect(err).NotTo(HaveOccurred())
+}
+
 func TestValidatePolicyForNodeStateWithInvalidNumVfsExternallyCreated(t *testing.T) {
 	state := newNodeState()
 	policy := &SriovNetworkNodePolicy{
@@ -500,8 +1025,10 @@ func TestValidatePolicyForNodeStateWithExternallyManageAndMTU(t *testing.T) {
 	g.Expect(err).ToNot(HaveOccurred())
 }
 
-func TestValidatePolicyForNodeStateWithExternallyManageAndDifferentMTU(t *testing.T) {
+func TestValidatePolicyForNodeStateWithVfTxRates(t *testing.T) {
 	state := newNodeState()
+	state.Status.Interfaces[0].LinkSpeed = "25000 Mb/s"
+	maxTxRate := 10000
 	policy := &SriovNetworkNodePolicy{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: "p1",
@@ -520,15 +1047,52 @@ func TestValidatePolicyForNodeStateWithExternallyManageAndDifferentMTU(t *testin
 			Priority:          99,
 			ResourceName:      "p0",
 			ExternallyManaged: true,
-			Mtu:               9000,
+			VfAttributes:      &VfAttributes{MaxTxRate: &maxTxRate},
 		},
 	}
 	g := NewGomegaWithT(t)
 	_, err := validatePolicyForNodeState(policy, state, NewNode())
-	g.Expect(err).To(HaveOccurred())
-}
+	g.Expect(err).ToNot(HaveOccurred())
 
-func TestValidatePolicyForNodeStateWithExternallyManageAndLinkType(t *testing.T) {
+	maxTxRate = 40000
+	_, err = validatePolicyForNodeState(policy, state, NewNode())
+	g.Expect(err).To(MatchError("maxTxRate(40000) is higher than the link speed of the PF(25000 Mb/s) in CR p1 for the PF ens803f0"))
+
+	// the rates are checked against the speed forced by the policy
+	policy.Spec.LinkSpeedMbps = 50000
+	_, err = validatePolicyForNodeState(policy, state, NewNode())
+	g.Expect(err).ToNot(HaveOccurred())
+}
+
+func TestValidatePolicyForNodeStateWithExternallyManageAndDifferentMTU(t *testing.T) {
+	state := newNodeState()
+	policy := &SriovNetworkNodePolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "p1",
+		},
+		Spec: SriovNetworkNodePolicySpec{
+			DeviceType: "netdevice",
+			NicSelector: SriovNetworkNicSelector{
+				PfNames:     []string{"ens803f0"},
+				RootDevices: []string{"0000:86:00.0"},
+				Vendor:      "8086",
+			},
+			NodeSelector: map[string]string{
+				"feature.node.kubernetes.io/network-sriov.capable": "true",
+			},
+			NumVfs:            4,
+			Priority:          99,
+			ResourceName:      "p0",
+			ExternallyManaged: true,
+			Mtu:               9000,
+		},
+	}
+	g := NewGomegaWithT(t)
+	_, err := validatePolicyForNodeState(policy, state, NewNode())
+	g.Expect(err).To(HaveOccurred())
+}
+
+func TestValidatePolicyForNodeStateWithExternallyManageAndLinkType(t *testing.T) {
 	state := newNodeState()
 	policy := &SriovNetworkNodePolicy{
 		ObjectMeta: metav1.ObjectMeta{
@@ -594,156 +1158,664 @@ func TestValidatePolicyForNodeStateWithExternallyManageAndDifferentLinkType(t *t
 	g.Expect(err).To(HaveOccurred())
 }
 
-func TestValidatePolicyForNodePolicyWithOverlappedVfRange(t *testing.T) {
-	appliedPolicy := newNodePolicy()
+func TestValidatePolicyForNodePolicyWithOverlappedVfRange(t *testing.T) {
+	appliedPolicy := newNodePolicy()
+	policy := &SriovNetworkNodePolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "p0",
+		},
+		Spec: SriovNetworkNodePolicySpec{
+			DeviceType: "netdevice",
+			NicSelector: SriovNetworkNicSelector{
+				PfNames: []string{"ens803f1#2-2"},
+				Vendor:  "8086",
+			},
+			NodeSelector: map[string]string{
+				"feature.node.kubernetes.io/network-sriov.capable": "true",
+			},
+			NumVfs:       63,
+			Priority:     99,
+			ResourceName: "p0",
+		},
+	}
+	g := NewGomegaWithT(t)
+	err := validatePolicyForNodePolicy(policy, appliedPolicy)
+	g.Expect(err).To(MatchError(ContainSubstring(fmt.Sprintf("VF index range in %s is overlapped with existing policy %s", policy.Spec.NicSelector.PfNames[0], appliedPolicy.ObjectMeta.Name))))
+}
+
+func TestValidatePolicyForNodePolicyWithOverlappedPatterns(t *testing.T) {
+	appliedPolicy := newNodePolicy()
+	policy := &SriovNetworkNodePolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "p0",
+		},
+		Spec: SriovNetworkNodePolicySpec{
+			DeviceType: "netdevice",
+			NicSelector: SriovNetworkNicSelector{
+				PfNames: []string{"ens803*#1-2"},
+				Vendor:  "8086",
+			},
+			NodeSelector: map[string]string{
+				"feature.node.kubernetes.io/network-sriov.capable": "true",
+			},
+			NumVfs:       63,
+			Priority:     99,
+			ResourceName: "p0",
+		},
+	}
+	g := NewGomegaWithT(t)
+	err := validatePolicyForNodePolicy(policy, appliedPolicy)
+	g.Expect(err).To(MatchError(ContainSubstring("VF index range in ens803*#1-2 is overlapped with existing policy p1")))
+
+	policy.Spec.NicSelector.PfNames = []string{"ens803*#3-5"}
+	err = validatePolicyForNodePolicy(policy, appliedPolicy)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	policy.Spec.NicSelector.PfNames = nil
+	policy.Spec.NicSelector.RootDevices = []string{"0000:86:00.*"}
+	err = validatePolicyForNodePolicy(policy, appliedPolicy)
+	g.Expect(err).To(MatchError(ContainSubstring("root device 0000:86:00.* is overlapped with existing policy p1")))
+
+	// the applied policy is the one using the pattern
+	appliedPolicy.Spec.NicSelector.RootDevices = []string{"0000:86:*"}
+	policy.Spec.NicSelector.RootDevices = []string{"0000:86:00.0"}
+	err = validatePolicyForNodePolicy(policy, appliedPolicy)
+	g.Expect(err).To(MatchError(ContainSubstring("root device 0000:86:00.0 is overlapped with existing policy p1")))
+
+	policy.Spec.NicSelector.RootDevices = []string{"0000:3b:00.0"}
+	err = validatePolicyForNodePolicy(policy, appliedPolicy)
+	g.Expect(err).NotTo(HaveOccurred())
+}
+
+func TestValidatePolicyForNodeStateWithUpdatedExistingVfRange(t *testing.T) {
+	appliedPolicy := newNodePolicy()
+	policy := &SriovNetworkNodePolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "p1",
+		},
+		Spec: SriovNetworkNodePolicySpec{
+			DeviceType: "netdevice",
+			NicSelector: SriovNetworkNicSelector{
+				PfNames:     []string{"ens803f1#1-2"},
+				RootDevices: []string{"0000:86:00.1"},
+				Vendor:      "8086",
+			},
+			NodeSelector: map[string]string{
+				"feature.node.kubernetes.io/network-sriov.capable": "true",
+			},
+			NumVfs:       63,
+			Priority:     99,
+			ResourceName: "p1",
+		},
+	}
+	g := NewGomegaWithT(t)
+	err := validatePolicyForNodePolicy(policy, appliedPolicy)
+	g.Expect(err).NotTo(HaveOccurred())
+}
+
+func TestValidatePoliciesWithDifferentExcludeTopologyForTheSameResource(t *testing.T) {
+	current := &SriovNetworkNodePolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "currentPolicy"},
+		Spec: SriovNetworkNodePolicySpec{
+			ResourceName:    "resourceX",
+			ExcludeTopology: true,
+		},
+	}
+
+	previous := &SriovNetworkNodePolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "previousPolicy"},
+		Spec: SriovNetworkNodePolicySpec{
+			ResourceName:    "resourceX",
+			ExcludeTopology: false,
+		},
+	}
+
+	err := validatePolicyForNodePolicy(current, previous)
+
+	g := NewGomegaWithT(t)
+	g.Expect(err).To(MatchError("excludeTopology[true] field conflicts with policy [previousPolicy].ExcludeTopology[false] as they target the same resource[resourceX]"))
+}
+
+func TestValidatePoliciesWithDifferentExcludeTopologyForTheSameResourceAndTheSamePF(t *testing.T) {
+	current := &SriovNetworkNodePolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "currentPolicy"},
+		Spec: SriovNetworkNodePolicySpec{
+			ResourceName:    "resourceX",
+			NumVfs:          10,
+			NicSelector:     SriovNetworkNicSelector{PfNames: []string{"eno1#0-4"}},
+			ExcludeTopology: true,
+		},
+	}
+
+	previous := &SriovNetworkNodePolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "previousPolicy"},
+		Spec: SriovNetworkNodePolicySpec{
+			ResourceName:    "resourceX",
+			NumVfs:          10,
+			NicSelector:     SriovNetworkNicSelector{PfNames: []string{"eno1#5-9"}},
+			ExcludeTopology: false,
+		},
+	}
+
+	err := validatePolicyForNodePolicy(current, previous)
+
+	g := NewGomegaWithT(t)
+	g.Expect(err).To(MatchError("excludeTopology[true] field conflicts with policy [previousPolicy].ExcludeTopology[false] as they target the same resource[resourceX]"))
+}
+
+func TestValidatePoliciesWithSameExcludeTopologyForTheSameResource(t *testing.T) {
+	current := &SriovNetworkNodePolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "currentPolicy"},
+		Spec: SriovNetworkNodePolicySpec{
+			ResourceName:    "resourceX",
+			ExcludeTopology: true,
+		},
+	}
+
+	previous := &SriovNetworkNodePolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "previousPolicy"},
+		Spec: SriovNetworkNodePolicySpec{
+			ResourceName:    "resourceX",
+			ExcludeTopology: true,
+		},
+	}
+
+	err := validatePolicyForNodePolicy(current, previous)
+
+	g := NewGomegaWithT(t)
+	g.Expect(err).NotTo(HaveOccurred())
+}
+
+func TestValidatePoliciesWithDifferentNumVfForTheSameResourceAndTheSameRootDevice(t *testing.T) {
+	current := &SriovNetworkNodePolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "currentPolicy"},
+		Spec: SriovNetworkNodePolicySpec{
+			ResourceName: "resourceX",
+			NumVfs:       10,
+			NicSelector:  SriovNetworkNicSelector{RootDevices: []string{"0000:86:00.1"}},
+		},
+	}
+
+	previous := &SriovNetworkNodePolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "previousPolicy"},
+		Spec: SriovNetworkNodePolicySpec{
+			ResourceName: "resourceX",
+			NumVfs:       5,
+			NicSelector:  SriovNetworkNicSelector{RootDevices: []string{"0000:86:00.1"}},
+		},
+	}
+
+	err := validatePolicyForNodePolicy(current, previous)
+
+	g := NewGomegaWithT(t)
+	g.Expect(err).To(MatchError("root device 0000:86:00.1 is overlapped with existing policy previousPolicy"))
+}
+
+func TestStaticValidateSriovNetworkNodePolicyWithValidVendorDevice(t *testing.T) {
+	policy := &SriovNetworkNodePolicy{
+		Spec: SriovNetworkNodePolicySpec{
+			DeviceType: "netdevice",
+			NicSelector: SriovNetworkNicSelector{
+				Vendor:   "8086",
+				DeviceID: "158b",
+			},
+			NodeSelector: map[string]string{
+				"feature.node.kubernetes.io/network-sriov.capable": "true",
+			},
+			NumVfs:       63,
+			Priority:     99,
+			ResourceName: "p0",
+		},
+	}
+	g := NewGomegaWithT(t)
+	ok, err := staticValidateSriovNetworkNodePolicy(policy)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(ok).To(Equal(true))
+}
+
+func TestStaticValidateSriovNetworkNodePolicyWithNumVfsAndMaxNumVfs(t *testing.T) {
+	policy := &SriovNetworkNodePolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "p1",
+		},
+		Spec: SriovNetworkNodePolicySpec{
+			DeviceType: "netdevice",
+			NicSelector: SriovNetworkNicSelector{
+				Vendor:   "8086",
+				DeviceID: "158b",
+			},
+			NodeSelector: map[string]string{
+				"feature.node.kubernetes.io/network-sriov.capable": "true",
+			},
+			NumVfs:       63,
+			MaxNumVfs:    64,
+			Priority:     99,
+			ResourceName: "p0",
+		},
+	}
+	g := NewGomegaWithT(t)
+	ok, err := staticValidateSriovNetworkNodePolicy(policy)
+	g.Expect(err).To(MatchError("numVfs and maxNumVfs can't be used together in CR p1"))
+	g.Expect(ok).To(Equal(false))
+}
+
+func TestStaticValidateSriovNetworkNodePolicyWithVfSysctls(t *testing.T) {
+	policy := &SriovNetworkNodePolicy{
+		Spec: SriovNetworkNodePolicySpec{
+			DeviceType: "netdevice",
+			NicSelector: SriovNetworkNicSelector{
+				Vendor:   "8086",
+				DeviceID: "158b",
+			},
+			NodeSelector: map[string]string{
+				"feature.node.kubernetes.io/network-sriov.capable": "true",
+			},
+			NumVfs:       63,
+			Priority:     99,
+			ResourceName: "p0",
+			VfSysctls:    map[string]string{"net.ipv4.conf.<if>.rp_filter": "2"},
+		},
+	}
+	g := NewGomegaWithT(t)
+	ok, err := staticValidateSriovNetworkNodePolicy(policy)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(ok).To(BeTrue())
+
+	policy.Spec.VfSysctls = map[string]string{"kernel.panic": "1"}
+	ok, err = staticValidateSriovNetworkNodePolicy(policy)
+	g.Expect(err).To(MatchError(ContainSubstring("sysctl kernel.panic in vfSysctls is not allowed")))
+	g.Expect(ok).To(BeFalse())
+
+	policy.Spec.VfSysctls = map[string]string{"net.ipv4.conf.<if>.rp_filter": "2\n1"}
+	ok, err = staticValidateSriovNetworkNodePolicy(policy)
+	g.Expect(err).To(MatchError(ContainSubstring("invalid value")))
+	g.Expect(ok).To(BeFalse())
+
+	policy.Spec.VfSysctls = map[string]string{"net.ipv4.conf.<if>.rp_filter": "2"}
+	policy.Spec.DeviceType = constants.DeviceTypeVfioPci
+	ok, err = staticValidateSriovNetworkNodePolicy(policy)
+	g.Expect(err).To(MatchError(ContainSubstring("vfSysctls can only be used with 'deviceType: netdevice'")))
+	g.Expect(ok).To(BeFalse())
+}
+
+func TestStaticValidateSriovNetworkNodePolicyWithVfTrust(t *testing.T) {
+	policy := &SriovNetworkNodePolicy{
+		Spec: SriovNetworkNodePolicySpec{
+			DeviceType: "netdevice",
+			NicSelector: SriovNetworkNicSelector{
+				Vendor:   "8086",
+				DeviceID: "158b",
+				PfNames:  []string{"ens803f0#2-5"},
+			},
+			NodeSelector: map[string]string{
+				"feature.node.kubernetes.io/network-sriov.capable": "true",
+			},
+			NumVfs:       8,
+			Priority:     99,
+			ResourceName: "p0",
+			VfTrust:      map[string]bool{"2": true, "5": false},
+		},
+	}
+	g := NewGomegaWithT(t)
+	ok, err := staticValidateSriovNetworkNodePolicy(policy)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(ok).To(BeTrue())
+
+	policy.Spec.VfTrust = map[string]bool{"6": true}
+	ok, err = staticValidateSriovNetworkNodePolicy(policy)
+	g.Expect(err).To(MatchError(ContainSubstring("VF index 6 in vfTrust is not in the VF range")))
+	g.Expect(ok).To(BeFalse())
+
+	policy.Spec.NicSelector.PfNames = nil
+	ok, err = staticValidateSriovNetworkNodePolicy(policy)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(ok).To(BeTrue())
+
+	policy.Spec.VfTrust = map[string]bool{"8": true}
+	ok, err = staticValidateSriovNetworkNodePolicy(policy)
+	g.Expect(err).To(MatchError(ContainSubstring("VF index 8 in vfTrust is out of range, numVfs is 8")))
+	g.Expect(ok).To(BeFalse())
+
+	policy.Spec.VfTrust = map[string]bool{"vf1": true}
+	ok, err = staticValidateSriovNetworkNodePolicy(policy)
+	g.Expect(err).To(MatchError(ContainSubstring("invalid VF index \"vf1\" in vfTrust")))
+	g.Expect(ok).To(BeFalse())
+}
+
+func TestStaticValidateSriovNetworkNodePolicyWithVfRange(t *testing.T) {
+	testCases := []struct {
+		name        string
+		pfNames     []string
+		rootDevices []string
+		expectedErr string
+	}{
+		{name: "valid PF name range", pfNames: []string{"ens803f0#0-7"}},
+		{name: "valid single VF range", pfNames: []string{"ens803f0#3-3"}},
+		{name: "root device range", rootDevices: []string{"0000:86:00.0#2-5"},
+			expectedErr: "invalid root device 0000:86:00.0#2-5 in nicSelector: VF ranges are only supported in pfNames"},
+		{name: "PF name without range", pfNames: []string{"ens803f0"}},
+		{name: "missing range", pfNames: []string{"ens803f0#"}, expectedErr: "VF range \"\" must be <first VF>-<last VF>"},
+		{name: "single index", pfNames: []string{"ens803f0#3"}, expectedErr: "VF range \"3\" must be <first VF>-<last VF>"},
+		{name: "multiple separators", pfNames: []string{"ens803f0#0-1#2-3"}, expectedErr: "probably incorrect separator character usage"},
+		{name: "missing device", pfNames: []string{"#0-1"}, expectedErr: "probably incorrect separator character usage"},
+		{name: "negative index", pfNames: []string{"ens803f0#-1-2"}, expectedErr: "must be <first VF>-<last VF>"},
+		{name: "non numeric index", pfNames: []string{"ens803f0#a-2"}, expectedErr: "first VF index \"a\" of the range is not a non negative integer"},
+		{name: "reversed range", pfNames: []string{"ens803f0#5-2"}, expectedErr: "last VF index 2 is smaller than the first VF index 5"},
+		{name: "out of range", pfNames: []string{"ens803f0#4-8"}, expectedErr: "invalid PF name ens803f0#4-8 in nicSelector: last VF index 8 is out of range, numVfs is 8"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			policy := &SriovNetworkNodePolicy{
+				Spec: SriovNetworkNodePolicySpec{
+					DeviceType: "netdevice",
+					NicSelector: SriovNetworkNicSelector{
+						Vendor:      "8086",
+						DeviceID:    "158b",
+						PfNames:     tc.pfNames,
+						RootDevices: tc.rootDevices,
+					},
+					NodeSelector: map[string]string{
+						"feature.node.kubernetes.io/network-sriov.capable": "true",
+					},
+					NumVfs:       8,
+					Priority:     99,
+					ResourceName: "p0",
+				},
+			}
+			g := NewGomegaWithT(t)
+			ok, err := staticValidateSriovNetworkNodePolicy(policy)
+			if tc.expectedErr == "" {
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(ok).To(BeTrue())
+				return
+			}
+			g.Expect(err).To(MatchError(ContainSubstring(tc.expectedErr)))
+			g.Expect(ok).To(BeFalse())
+		})
+	}
+}
+
+func TestStaticValidateSriovNetworkNodePolicyWithMacsec(t *testing.T) {
+	policy := &SriovNetworkNodePolicy{
+		Spec: SriovNetworkNodePolicySpec{
+			DeviceType: "netdevice",
+			NicSelector: SriovNetworkNicSelector{
+				Vendor:   "8086",
+				DeviceID: "158b",
+			},
+			NodeSelector: map[string]string{
+				"feature.node.kubernetes.io/network-sriov.capable": "true",
+			},
+			NumVfs:       63,
+			Priority:     99,
+			ResourceName: "p0",
+			Macsec:       &VfMacsec{KeySecretName: "macsec-key"},
+		},
+	}
+	g := NewGomegaWithT(t)
+	ok, err := staticValidateSriovNetworkNodePolicy(policy)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(ok).To(BeTrue())
+
+	policy.Spec.Macsec = &VfMacsec{}
+	ok, err = staticValidateSriovNetworkNodePolicy(policy)
+	g.Expect(err).To(MatchError(ContainSubstring("macsec requires the keySecretName")))
+	g.Expect(ok).To(BeFalse())
+
+	policy.Spec.Macsec = &VfMacsec{KeySecretName: "macsec-key"}
+	policy.Spec.DeviceType = constants.DeviceTypeVfioPci
+	ok, err = staticValidateSriovNetworkNodePolicy(policy)
+	g.Expect(err).To(MatchError(ContainSubstring("macsec can only be used with 'deviceType: netdevice'")))
+	g.Expect(ok).To(BeFalse())
+}
+
+func TestStaticValidateSriovNetworkNodePolicyWithVfDriver(t *testing.T) {
+	policy := &SriovNetworkNodePolicy{
+		Spec: SriovNetworkNodePolicySpec{
+			DeviceType: constants.DeviceTypeVfioPci,
+			NicSelector: SriovNetworkNicSelector{
+				Vendor:   "8086",
+				DeviceID: "158b",
+			},
+			NodeSelector: map[string]string{
+				"feature.node.kubernetes.io/network-sriov.capable": "true",
+			},
+			NumVfs:       63,
+			Priority:     99,
+			ResourceName: "p0",
+			VfDriver:     "igb_uio",
+		},
+	}
+	g := NewGomegaWithT(t)
+	ok, err := staticValidateSriovNetworkNodePolicy(policy)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(ok).To(BeTrue())
+
+	policy.Spec.VfDriver = "igb_uio; rm"
+	ok, err = staticValidateSriovNetworkNodePolicy(policy)
+	g.Expect(err).To(MatchError(ContainSubstring("is not a valid kernel driver name")))
+	g.Expect(ok).To(BeFalse())
+
+	policy.Spec.VfDriver = "mlx5_core"
+	policy.Spec.DeviceType = constants.DeviceTypeNetDevice
+	policy.Spec.EswitchMode = "switchdev"
+	policy.Spec.VdpaType = constants.VdpaTypeVirtio
+	ok, err = staticValidateSriovNetworkNodePolicy(policy)
+	g.Expect(err).To(MatchError(ContainSubstring("vfDriver can't be used together with vdpaType")))
+	g.Expect(ok).To(BeFalse())
+}
+
+func TestStaticValidateSriovNetworkNodePolicyWithVfMtu(t *testing.T) {
+	policy := &SriovNetworkNodePolicy{
+		Spec: SriovNetworkNodePolicySpec{
+			DeviceType: "netdevice",
+			NicSelector: SriovNetworkNicSelector{
+				Vendor:   "8086",
+				DeviceID: "158b",
+			},
+			NodeSelector: map[string]string{
+				"feature.node.kubernetes.io/network-sriov.capable": "true",
+			},
+			NumVfs:       63,
+			Priority:     99,
+			ResourceName: "p0",
+			Mtu:          9000,
+			VfMtu:        1500,
+		},
+	}
+	g := NewGomegaWithT(t)
+	ok, err := staticValidateSriovNetworkNodePolicy(policy)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(ok).To(BeTrue())
+
+	policy.Spec.VfMtu = 9216
+	ok, err = staticValidateSriovNetworkNodePolicy(policy)
+	g.Expect(err).To(MatchError(ContainSubstring("vfMtu(9216) is higher than the mtu(9000) of the PF")))
+	g.Expect(ok).To(BeFalse())
+
+	policy.Spec.VfMtu = 1500
+	policy.Spec.DeviceType = constants.DeviceTypeVfioPci
+	ok, err = staticValidateSriovNetworkNodePolicy(policy)
+	g.Expect(err).To(MatchError(ContainSubstring("vfMtu can only be used with 'deviceType: netdevice'")))
+	g.Expect(ok).To(BeFalse())
+}
+
+func TestStaticValidateSriovNetworkNodePolicyWithVfTxRates(t *testing.T) {
+	minTxRate, maxTxRate := 100, 1000
+	policy := &SriovNetworkNodePolicy{
+		Spec: SriovNetworkNodePolicySpec{
+			DeviceType: "netdevice",
+			NicSelector: SriovNetworkNicSelector{
+				Vendor:   "8086",
+				DeviceID: "158b",
+			},
+			NodeSelector: map[string]string{
+				"feature.node.kubernetes.io/network-sriov.capable": "true",
+			},
+			NumVfs:            63,
+			Priority:          99,
+			ResourceName:      "p0",
+			ExternallyManaged: true,
+			VfAttributes:      &VfAttributes{MinTxRate: &minTxRate, MaxTxRate: &maxTxRate},
+		},
+	}
+	g := NewGomegaWithT(t)
+	ok, err := staticValidateSriovNetworkNodePolicy(policy)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(ok).To(BeTrue())
+
+	minTxRate = 2000
+	ok, err = staticValidateSriovNetworkNodePolicy(policy)
+	g.Expect(err).To(MatchError(ContainSubstring("minTxRate(2000) is higher than maxTxRate(1000)")))
+	g.Expect(ok).To(BeFalse())
+
+	// a maximum rate of 0 disables the rate limit
+	maxTxRate = 0
+	ok, err = staticValidateSriovNetworkNodePolicy(policy)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(ok).To(BeTrue())
+
+	maxTxRate = 20000
+	policy.Spec.LinkSpeedMbps = 10000
+	ok, err = staticValidateSriovNetworkNodePolicy(policy)
+	g.Expect(err).To(MatchError(ContainSubstring("maxTxRate(20000) is higher than the link speed of the PF(10000 Mb/s)")))
+	g.Expect(ok).To(BeFalse())
+}
+
+func TestStaticValidateSriovNetworkNodePolicyWithKeepDefaultRepresentorNames(t *testing.T) {
 	policy := &SriovNetworkNodePolicy{
-		ObjectMeta: metav1.ObjectMeta{
-			Name: "p0",
-		},
 		Spec: SriovNetworkNodePolicySpec{
 			DeviceType: "netdevice",
 			NicSelector: SriovNetworkNicSelector{
-				PfNames: []string{"ens803f1#2-2"},
-				Vendor:  "8086",
+				Vendor:   "15b3",
+				DeviceID: "101d",
 			},
 			NodeSelector: map[string]string{
 				"feature.node.kubernetes.io/network-sriov.capable": "true",
 			},
-			NumVfs:       63,
-			Priority:     99,
-			ResourceName: "p0",
+			NumVfs:                      63,
+			Priority:                    99,
+			ResourceName:                "p0",
+			EswitchMode:                 "switchdev",
+			KeepDefaultRepresentorNames: true,
 		},
 	}
 	g := NewGomegaWithT(t)
-	err := validatePolicyForNodePolicy(policy, appliedPolicy)
-	g.Expect(err).To(MatchError(ContainSubstring(fmt.Sprintf("VF index range in %s is overlapped with existing policy %s", policy.Spec.NicSelector.PfNames[0], appliedPolicy.ObjectMeta.Name))))
+	ok, err := staticValidateSriovNetworkNodePolicy(policy)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(ok).To(BeTrue())
+
+	policy.Spec.EswitchMode = "legacy"
+	ok, err = staticValidateSriovNetworkNodePolicy(policy)
+	g.Expect(err).To(MatchError(ContainSubstring("keepDefaultRepresentorNames requires the device to be configured in switchdev mode")))
+	g.Expect(ok).To(BeFalse())
 }
 
-func TestValidatePolicyForNodeStateWithUpdatedExistingVfRange(t *testing.T) {
-	appliedPolicy := newNodePolicy()
+func TestStaticValidateSriovNetworkNodePolicyWithPromisc(t *testing.T) {
+	promisc := true
 	policy := &SriovNetworkNodePolicy{
-		ObjectMeta: metav1.ObjectMeta{
-			Name: "p1",
-		},
 		Spec: SriovNetworkNodePolicySpec{
 			DeviceType: "netdevice",
 			NicSelector: SriovNetworkNicSelector{
-				PfNames:     []string{"ens803f1#1-2"},
-				RootDevices: []string{"0000:86:00.1"},
-				Vendor:      "8086",
+				Vendor:   "8086",
+				DeviceID: "158b",
 			},
 			NodeSelector: map[string]string{
 				"feature.node.kubernetes.io/network-sriov.capable": "true",
 			},
 			NumVfs:       63,
 			Priority:     99,
-			ResourceName: "p1",
+			ResourceName: "p0",
+			VfTrust:      map[string]bool{"0": true},
+			Promisc:      &promisc,
 		},
 	}
 	g := NewGomegaWithT(t)
-	err := validatePolicyForNodePolicy(policy, appliedPolicy)
+	ok, err := staticValidateSriovNetworkNodePolicy(policy)
 	g.Expect(err).NotTo(HaveOccurred())
-}
-
-func TestValidatePoliciesWithDifferentExcludeTopologyForTheSameResource(t *testing.T) {
-	current := &SriovNetworkNodePolicy{
-		ObjectMeta: metav1.ObjectMeta{Name: "currentPolicy"},
-		Spec: SriovNetworkNodePolicySpec{
-			ResourceName:    "resourceX",
-			ExcludeTopology: true,
-		},
-	}
-
-	previous := &SriovNetworkNodePolicy{
-		ObjectMeta: metav1.ObjectMeta{Name: "previousPolicy"},
-		Spec: SriovNetworkNodePolicySpec{
-			ResourceName:    "resourceX",
-			ExcludeTopology: false,
-		},
-	}
-
-	err := validatePolicyForNodePolicy(current, previous)
+	g.Expect(ok).To(BeTrue())
 
-	g := NewGomegaWithT(t)
-	g.Expect(err).To(MatchError("excludeTopology[true] field conflicts with policy [previousPolicy].ExcludeTopology[false] as they target the same resource[resourceX]"))
-}
+	policy.Spec.VfTrust = nil
+	policy.Spec.ExternallyManaged = true
+	policy.Spec.VfAttributes = &VfAttributes{Trust: true}
+	ok, err = staticValidateSriovNetworkNodePolicy(policy)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(ok).To(BeTrue())
 
-func TestValidatePoliciesWithDifferentExcludeTopologyForTheSameResourceAndTheSamePF(t *testing.T) {
-	current := &SriovNetworkNodePolicy{
-		ObjectMeta: metav1.ObjectMeta{Name: "currentPolicy"},
-		Spec: SriovNetworkNodePolicySpec{
-			ResourceName:    "resourceX",
-			NumVfs:          10,
-			NicSelector:     SriovNetworkNicSelector{PfNames: []string{"eno1#0-4"}},
-			ExcludeTopology: true,
-		},
-	}
+	policy.Spec.VfAttributes = &VfAttributes{Trust: false}
+	ok, err = staticValidateSriovNetworkNodePolicy(policy)
+	g.Expect(err).To(MatchError(ContainSubstring("promisc can only be enabled on trusted VFs")))
+	g.Expect(ok).To(BeFalse())
 
-	previous := &SriovNetworkNodePolicy{
-		ObjectMeta: metav1.ObjectMeta{Name: "previousPolicy"},
-		Spec: SriovNetworkNodePolicySpec{
-			ResourceName:    "resourceX",
-			NumVfs:          10,
-			NicSelector:     SriovNetworkNicSelector{PfNames: []string{"eno1#5-9"}},
-			ExcludeTopology: false,
-		},
-	}
+	policy.Spec.VfAttributes = nil
+	policy.Spec.VfTrust = map[string]bool{"0": false}
+	ok, err = staticValidateSriovNetworkNodePolicy(policy)
+	g.Expect(err).To(MatchError(ContainSubstring("promisc can only be enabled on trusted VFs")))
+	g.Expect(ok).To(BeFalse())
 
-	err := validatePolicyForNodePolicy(current, previous)
+	// disabling the promiscuous mode doesn't require trust
+	promisc = false
+	ok, err = staticValidateSriovNetworkNodePolicy(policy)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(ok).To(BeTrue())
 
-	g := NewGomegaWithT(t)
-	g.Expect(err).To(MatchError("excludeTopology[true] field conflicts with policy [previousPolicy].ExcludeTopology[false] as they target the same resource[resourceX]"))
+	promisc = true
+	policy.Spec.VfTrust = map[string]bool{"0": true}
+	policy.Spec.DeviceType = constants.DeviceTypeVfioPci
+	ok, err = staticValidateSriovNetworkNodePolicy(policy)
+	g.Expect(err).To(MatchError(ContainSubstring("promisc can only be used with 'deviceType: netdevice'")))
+	g.Expect(ok).To(BeFalse())
 }
 
-func TestValidatePoliciesWithSameExcludeTopologyForTheSameResource(t *testing.T) {
-	current := &SriovNetworkNodePolicy{
-		ObjectMeta: metav1.ObjectMeta{Name: "currentPolicy"},
-		Spec: SriovNetworkNodePolicySpec{
-			ResourceName:    "resourceX",
-			ExcludeTopology: true,
-		},
-	}
-
-	previous := &SriovNetworkNodePolicy{
-		ObjectMeta: metav1.ObjectMeta{Name: "previousPolicy"},
+func TestStaticValidateSriovNetworkNodePolicyWithVfFilters(t *testing.T) {
+	enabled, disabled := true, false
+	policy := &SriovNetworkNodePolicy{
 		Spec: SriovNetworkNodePolicySpec{
-			ResourceName:    "resourceX",
-			ExcludeTopology: true,
+			DeviceType: "netdevice",
+			NicSelector: SriovNetworkNicSelector{
+				Vendor:   "8086",
+				DeviceID: "158b",
+			},
+			NodeSelector: map[string]string{
+				"feature.node.kubernetes.io/network-sriov.capable": "true",
+			},
+			NumVfs:       63,
+			Priority:     99,
+			ResourceName: "p0",
+			VfFilters:    &VfFilters{Broadcast: &disabled, Multicast: &disabled},
 		},
 	}
-
-	err := validatePolicyForNodePolicy(current, previous)
-
 	g := NewGomegaWithT(t)
+	ok, err := staticValidateSriovNetworkNodePolicy(policy)
 	g.Expect(err).NotTo(HaveOccurred())
-}
+	g.Expect(ok).To(BeTrue())
 
-func TestValidatePoliciesWithDifferentNumVfForTheSameResourceAndTheSameRootDevice(t *testing.T) {
-	current := &SriovNetworkNodePolicy{
-		ObjectMeta: metav1.ObjectMeta{Name: "currentPolicy"},
-		Spec: SriovNetworkNodePolicySpec{
-			ResourceName: "resourceX",
-			NumVfs:       10,
-			NicSelector:  SriovNetworkNicSelector{RootDevices: []string{"0000:86:00.1"}},
-		},
-	}
+	// dropping frames contradicts the promiscuous mode
+	policy.Spec.VfTrust = map[string]bool{"0": true}
+	policy.Spec.Promisc = &enabled
+	ok, err = staticValidateSriovNetworkNodePolicy(policy)
+	g.Expect(err).To(MatchError(ContainSubstring("vfFilters dropping broadcast or multicast frames can't be used together with promisc")))
+	g.Expect(ok).To(BeFalse())
 
-	previous := &SriovNetworkNodePolicy{
-		ObjectMeta: metav1.ObjectMeta{Name: "previousPolicy"},
-		Spec: SriovNetworkNodePolicySpec{
-			ResourceName: "resourceX",
-			NumVfs:       5,
-			NicSelector:  SriovNetworkNicSelector{RootDevices: []string{"0000:86:00.1"}},
-		},
-	}
+	policy.Spec.VfFilters = &VfFilters{Broadcast: &enabled, Multicast: &enabled}
+	ok, err = staticValidateSriovNetworkNodePolicy(policy)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(ok).To(BeTrue())
 
-	err := validatePolicyForNodePolicy(current, previous)
+	policy.Spec.VfFilters = &VfFilters{}
+	ok, err = staticValidateSriovNetworkNodePolicy(policy)
+	g.Expect(err).To(MatchError(ContainSubstring("vfFilters must configure broadcast or multicast")))
+	g.Expect(ok).To(BeFalse())
 
-	g := NewGomegaWithT(t)
-	g.Expect(err).To(MatchError("root device 0000:86:00.1 is overlapped with existing policy previousPolicy"))
+	policy.Spec.Promisc = nil
+	policy.Spec.VfFilters = &VfFilters{Multicast: &disabled}
+	policy.Spec.DeviceType = constants.DeviceTypeVfioPci
+	ok, err = staticValidateSriovNetworkNodePolicy(policy)
+	g.Expect(err).To(MatchError(ContainSubstring("vfFilters can only be used with 'deviceType: netdevice'")))
+	g.Expect(ok).To(BeFalse())
 }
 
-func TestStaticValidateSriovNetworkNodePolicyWithValidVendorDevice(t *testing.T) {
+func TestStaticValidateSriovNetworkNodePolicyWithVfRss(t *testing.T) {
 	policy := &SriovNetworkNodePolicy{
 		Spec: SriovNetworkNodePolicySpec{
 			DeviceType: "netdevice",
@@ -757,12 +1829,31 @@ func TestStaticValidateSriovNetworkNodePolicyWithValidVendorDevice(t *testing.T)
 			NumVfs:       63,
 			Priority:     99,
 			ResourceName: "p0",
+			NumVfQueues:  4,
+			VfRss:        &VfRss{HashKey: "6d:5a:56:da", IndirectionSize: 4},
 		},
 	}
 	g := NewGomegaWithT(t)
 	ok, err := staticValidateSriovNetworkNodePolicy(policy)
 	g.Expect(err).NotTo(HaveOccurred())
-	g.Expect(ok).To(Equal(true))
+	g.Expect(ok).To(BeTrue())
+
+	policy.Spec.VfRss = &VfRss{}
+	ok, err = staticValidateSriovNetworkNodePolicy(policy)
+	g.Expect(err).To(MatchError(ContainSubstring("vfRss requires the hashKey or the indirectionSize")))
+	g.Expect(ok).To(BeFalse())
+
+	policy.Spec.VfRss = &VfRss{IndirectionSize: 8}
+	ok, err = staticValidateSriovNetworkNodePolicy(policy)
+	g.Expect(err).To(MatchError(ContainSubstring("vfRss indirectionSize 8 is greater than numVfQueues 4")))
+	g.Expect(ok).To(BeFalse())
+
+	policy.Spec.VfRss = &VfRss{IndirectionSize: 4}
+	policy.Spec.NumVfQueues = 0
+	policy.Spec.DeviceType = constants.DeviceTypeVfioPci
+	ok, err = staticValidateSriovNetworkNodePolicy(policy)
+	g.Expect(err).To(MatchError(ContainSubstring("vfRss can only be used with 'deviceType: netdevice'")))
+	g.Expect(ok).To(BeFalse())
 }
 
 func TestStaticValidateSriovNetworkNodePolicyWithInvalidVendor(t *testing.T) {
@@ -1171,6 +2262,40 @@ func TestStaticValidateSriovNetworkNodePolicyWithBridgeConfigWithExternallyManag
 	g.Expect(ok).To(Equal(false))
 }
 
+func TestStaticValidateSriovNetworkNodePolicyWithBridgeTrunksOnAccessPort(t *testing.T) {
+	policy := &SriovNetworkNodePolicy{
+		Spec: SriovNetworkNodePolicySpec{
+			DeviceType: "netdevice",
+			Bridge: Bridge{OVS: &OVSConfig{Uplink: OVSUplinkConfig{Port: OVSPortConfig{
+				VlanMode: "access",
+				Trunks:   []int{100, 200},
+			}}}},
+			EswitchMode: "switchdev",
+			NicSelector: SriovNetworkNicSelector{
+				PfNames: []string{"ens803f1"},
+			},
+			NodeSelector: map[string]string{
+				"feature.node.kubernetes.io/network-sriov.capable": "true",
+			},
+			ResourceName: "p0",
+		},
+	}
+	g := NewGomegaWithT(t)
+	ok, err := staticValidateSriovNetworkNodePolicy(policy)
+	g.Expect(err).To(MatchError(ContainSubstring("vlanMode: access")))
+	g.Expect(ok).To(Equal(false))
+
+	policy.Spec.Bridge.OVS.Uplink.Port = OVSPortConfig{VlanMode: "trunk", Trunks: []int{100, 4096}}
+	ok, err = staticValidateSriovNetworkNodePolicy(policy)
+	g.Expect(err).To(MatchError(ContainSubstring("invalid OVS uplink trunk VLAN 4096")))
+	g.Expect(ok).To(Equal(false))
+
+	policy.Spec.Bridge.OVS.Uplink.Port = OVSPortConfig{VlanMode: "trunk", Trunks: []int{100, 200}}
+	ok, err = staticValidateSriovNetworkNodePolicy(policy)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(ok).To(Equal(true))
+}
+
 func TestValidatePolicyForNodeStateWithValidNetFilter(t *testing.T) {
 	interfaceSelected = false
 	state := newNodeState()
@@ -1334,3 +2459,156 @@ func TestValidatePolicyForNodePolicyAllowSwitchdevWithExternallyManage(t *testin
 	err := validatePolicyForNodePolicy(policy, appliedPolicy)
 	g.Expect(err).NotTo(HaveOccurred())
 }
+
+func TestValidateVfNamePrefix(t *testing.T) {
+	g := NewGomegaWithT(t)
+	state := newNodeState()
+	node := NewNode()
+	node.SetName("worker-0")
+	node.SetLabels(map[string]string{"feature.node.kubernetes.io/network-sriov.capable": "true"})
+	policy := newNodePolicy()
+	policy.Spec.VfNamePrefix = "sriov"
+	npList := &SriovNetworkNodePolicyList{Items: []SriovNetworkNodePolicy{*policy}}
+	g.Expect(validateVfNamePrefix(policy, npList, state, node)).To(Succeed())
+
+	// the policies of the same PF can share the prefix
+	other := newNodePolicy()
+	other.SetName("p2")
+	other.Spec.NicSelector.PfNames = []string{"ens803f1#3-5"}
+	other.Spec.VfNamePrefix = "sriov"
+	npList.Items = append(npList.Items, *other)
+	g.Expect(validateVfNamePrefix(policy, npList, state, node)).To(Succeed())
+
+	// the prefix can't be used by another policy for another PF
+	npList.Items[1].Spec.NicSelector = SriovNetworkNicSelector{PfNames: []string{"ens803f0"}, Vendor: "8086"}
+	g.Expect(validateVfNamePrefix(policy, npList, state, node)).To(
+		MatchError("vfNamePrefix(sriov) in CR p1 is already used by policy p2 for the PF ens803f0 of node worker-0"))
+
+	// the prefix can't be used for several PFs
+	policy.Spec.NicSelector = SriovNetworkNicSelector{Vendor: "8086", DeviceID: "158b"}
+	g.Expect(validateVfNamePrefix(policy, &SriovNetworkNodePolicyList{}, state, node)).To(
+		MatchError("vfNamePrefix(sriov) in CR p1 is used for several PFs of node worker-0: ens803f0, ens803f1"))
+}
+
+func TestStaticValidateSriovNetworkNodePolicyVfVlanProtoRequiresVlan(t *testing.T) {
+	policy := &SriovNetworkNodePolicy{
+		Spec: SriovNetworkNodePolicySpec{
+			DeviceType: "netdevice",
+			NicSelector: SriovNetworkNicSelector{
+				Vendor:   "15b3",
+				DeviceID: "101d",
+			},
+			NodeSelector: map[string]string{
+				"feature.node.kubernetes.io/network-sriov.capable": "true",
+			},
+			NumVfs:            1,
+			Priority:          99,
+			ResourceName:      "p0",
+			ExternallyManaged: true,
+			VfAttributes:      &VfAttributes{VlanProto: "802.1ad"},
+		},
+	}
+	g := NewGomegaWithT(t)
+	ok, err := staticValidateSriovNetworkNodePolicy(policy)
+	g.Expect(err).To(MatchError(ContainSubstring("vfAttributes.vlanProto can only be used together with vfAttributes.vlan")))
+	g.Expect(ok).To(Equal(false))
+
+	policy.Spec.VfAttributes.Vlan = 100
+	ok, err = staticValidateSriovNetworkNodePolicy(policy)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(ok).To(Equal(true))
+}
+
+func TestStaticValidateSriovNetworkNodePolicyVfAttributesRequireExternallyManaged(t *testing.T) {
+	policy := &SriovNetworkNodePolicy{
+		Spec: SriovNetworkNodePolicySpec{
+			DeviceType: "netdevice",
+			NicSelector: SriovNetworkNicSelector{
+				Vendor:   "15b3",
+				DeviceID: "101d",
+			},
+			NodeSelector: map[string]string{
+				"feature.node.kubernetes.io/network-sriov.capable": "true",
+			},
+			NumVfs:       1,
+			Priority:     99,
+			ResourceName: "p0",
+			VfAttributes: &VfAttributes{Vlan: 100},
+		},
+	}
+	g := NewGomegaWithT(t)
+	ok, err := staticValidateSriovNetworkNodePolicy(policy)
+	g.Expect(err).To(MatchError(ContainSubstring("vfAttributes can only be used when the device is externally managed")))
+	g.Expect(ok).To(Equal(false))
+
+	policy.Spec.ExternallyManaged = true
+	ok, err = staticValidateSriovNetworkNodePolicy(policy)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(ok).To(Equal(true))
+}
+
+func TestStaticValidateSriovNetworkNodePolicyLinkSpeedWithAutoNeg(t *testing.T) {
+	autoNeg := true
+	policy := &SriovNetworkNodePolicy{
+		Spec: SriovNetworkNodePolicySpec{
+			DeviceType: "netdevice",
+			NicSelector: SriovNetworkNicSelector{
+				Vendor:   "15b3",
+				DeviceID: "101d",
+			},
+			NodeSelector: map[string]string{
+				"feature.node.kubernetes.io/network-sriov.capable": "true",
+			},
+			NumVfs:        1,
+			Priority:      99,
+			ResourceName:  "p0",
+			LinkAutoNeg:   &autoNeg,
+			LinkSpeedMbps: 10000,
+		},
+	}
+	g := NewGomegaWithT(t)
+	ok, err := staticValidateSriovNetworkNodePolicy(policy)
+	g.Expect(err).To(MatchError(ContainSubstring("linkSpeedMbps can't be used when linkAutoNeg is enabled")))
+	g.Expect(ok).To(Equal(false))
+
+	autoNeg = false
+	ok, err = staticValidateSriovNetworkNodePolicy(policy)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(ok).To(Equal(true))
+}
+
+func TestValidateSriovNetworkNodePolicyWarnValidationMode(t *testing.T) {
+	vars.Namespace = "openshift-sriov-network-operator"
+	config := newDefaultOperatorConfig()
+	config.Spec.WebhookValidationMode = WebhookValidationModeWarn
+	setFakeClients(config)
+	kubeclient = fakek8s.NewSimpleClientset()
+	policy := newNodePolicy()
+	policy.Namespace = vars.Namespace
+
+	g := NewGomegaWithT(t)
+	ok, w, err := validateSriovNetworkNodePolicy(policy, "CREATE")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(ok).To(Equal(true))
+	g.Expect(w).To(ConsistOf(ContainSubstring("no matched node is selected by the nodeSelector in CR p1")))
+
+	// invalid specs are still rejected
+	policy.Spec.ResourceName = "p-1"
+	ok, _, err = validateSriovNetworkNodePolicy(policy, "CREATE")
+	g.Expect(err).To(MatchError(ContainSubstring("contains invalid characters")))
+	g.Expect(ok).To(Equal(false))
+}
+
+func TestValidateSriovNetworkNodePolicyEnforceValidationMode(t *testing.T) {
+	vars.Namespace = "openshift-sriov-network-operator"
+	setFakeClients(newDefaultOperatorConfig())
+	kubeclient = fakek8s.NewSimpleClientset()
+	policy := newNodePolicy()
+	policy.Namespace = vars.Namespace
+
+	g := NewGomegaWithT(t)
+	ok, w, err := validateSriovNetworkNodePolicy(policy, "CREATE")
+	g.Expect(err).To(MatchError(ContainSubstring("no matched node is selected by the nodeSelector in CR p1")))
+	g.Expect(ok).To(Equal(false))
+	g.Expect(w).To(BeEmpty())
+}
@@ -32,7 +32,13 @@ func MutateCustomResource(ar v1.AdmissionReview) *v1.AdmissionResponse {
 		return toV1AdmissionResponse(err)
 	}
 	var reviewResp *v1.AdmissionResponse
-	if reviewResp, err = mutateSriovNetworkNodePolicy(cr); err != nil {
+	switch ar.Request.Kind.Kind {
+	case "SriovNetwork":
+		reviewResp, err = mutateSriovNetwork(cr, ar.Request.Operation)
+	default:
+		reviewResp, err = mutateSriovNetworkNodePolicy(cr)
+	}
+	if err != nil {
 		log.Log.Error(err, "failed to mutate object")
 		return toV1AdmissionResponse(err)
 	}
@@ -83,6 +89,51 @@ func ValidateCustomResource(ar v1.AdmissionReview) *v1.AdmissionResponse {
 			}
 		}
 
+	case "SriovNetwork":
+		network := sriovnetworkv1.SriovNetwork{}
+
+		err = json.Unmarshal(raw, &network)
+		if err != nil {
+			log.Log.Error(err, "failed to unmarshal object")
+			return toV1AdmissionResponse(err)
+		}
+
+		if reviewResponse.Allowed, reviewResponse.Warnings, err = validateSriovNetwork(&network, ar.Request.Operation); err != nil {
+			reviewResponse.Result = &metav1.Status{
+				Reason: metav1.StatusReason(err.Error()),
+			}
+		}
+
+	case "OVSNetwork":
+		network := sriovnetworkv1.OVSNetwork{}
+
+		err = json.Unmarshal(raw, &network)
+		if err != nil {
+			log.Log.Error(err, "failed to unmarshal object")
+			return toV1AdmissionResponse(err)
+		}
+
+		if reviewResponse.Allowed, reviewResponse.Warnings, err = validateOVSNetwork(&network, ar.Request.Operation); err != nil {
+			reviewResponse.Result = &metav1.Status{
+				Reason: metav1.StatusReason(err.Error()),
+			}
+		}
+
+	case "SriovIBNetwork":
+		network := sriovnetworkv1.SriovIBNetwork{}
+
+		err = json.Unmarshal(raw, &network)
+		if err != nil {
+			log.Log.Error(err, "failed to unmarshal object")
+			return toV1AdmissionResponse(err)
+		}
+
+		if reviewResponse.Allowed, reviewResponse.Warnings, err = validateSriovIBNetwork(&network, ar.Request.Operation); err != nil {
+			reviewResponse.Result = &metav1.Status{
+				Reason: metav1.StatusReason(err.Error()),
+			}
+		}
+
 	case "SriovNetworkPoolConfig":
 		config := sriovnetworkv1.SriovNetworkPoolConfig{}
 